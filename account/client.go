@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -74,6 +75,24 @@ type Client interface {
 	ChangeSigScheme(address string, sigScheme s.SignatureScheme) error
 	//Get the underlying wallet data
 	GetWalletData() *WalletData
+	//CreateHDWallet store the encrypted mnemonic to wallet. Generate a new mnemonic if mnemonic is empty
+	CreateHDWallet(mnemonic, path string, passwd []byte) (string, error)
+	//IsHDWallet return whether wallet holds a mnemonic
+	IsHDWallet() bool
+	//GetMnemonic return the decrypted mnemonic of HD wallet
+	GetMnemonic(passwd []byte) (string, error)
+	//DeriveAccount derive the next account from the mnemonic of HD wallet
+	DeriveAccount(label string, typeCode keypair.KeyType, curveCode byte, sigScheme s.SignatureScheme, passwd []byte) (*Account, error)
+	//AddMultiSigAccount add a M-of-N multi signature account to wallet
+	AddMultiSigAccount(label string, m int, pubKeys []keypair.PublicKey, labels []string) (*MultiSigAccountData, error)
+	//GetMultiSigAccount return multi signature account by address or label
+	GetMultiSigAccount(address string) *MultiSigAccountData
+	//GetMultiSigAccounts return all multi signature accounts
+	GetMultiSigAccounts() []*MultiSigAccountData
+	//DeleteMultiSigAccount delete multi signature account
+	DeleteMultiSigAccount(address string) error
+	//RestoreHDWallet restore HD wallet from mnemonic, and derive the first num accounts
+	RestoreHDWallet(mnemonic, path string, num int, typeCode keypair.KeyType, curveCode byte, sigScheme s.SignatureScheme, passwd []byte) ([]*Account, error)
 }
 
 func Open(path string) (Client, error) {
@@ -179,7 +198,7 @@ func (this *ClientImpl) addAccountData(accData *AccountData) error {
 	label := accData.Label
 	if label != "" {
 		_, ok := this.accLabels[label]
-		if ok {
+		if ok || this.walletData.GetMultiSigAccount(label) != nil {
 			return fmt.Errorf("duplicate label")
 		}
 	}
@@ -341,6 +360,7 @@ func (this *ClientImpl) getAccountMetadata(accData *AccountData) *AccountMetadat
 	accMeta.Hash = accData.Hash
 	accMeta.Curve = accData.Param["curve"]
 	accMeta.Salt = accData.Salt
+	accMeta.HDPath = accData.HDPath
 	return accMeta
 }
 
@@ -567,3 +587,232 @@ func (this *ClientImpl) checkSigScheme(keyType, sigScheme string) bool {
 func (this *ClientImpl) GetWalletData() *WalletData {
 	return this.walletData
 }
+
+func (this *ClientImpl) CreateHDWallet(mnemonic, path string, passwd []byte) (string, error) {
+	if len(passwd) == 0 {
+		return "", fmt.Errorf("password cannot empty")
+	}
+	var err error
+	if mnemonic == "" {
+		mnemonic, err = NewMnemonic()
+		if err != nil {
+			return "", fmt.Errorf("generate mnemonic error: %s", err)
+		}
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.walletData.HDWallet != nil {
+		return "", fmt.Errorf("wallet already has a mnemonic")
+	}
+	hdWallet, err := NewHDWalletData(mnemonic, path, passwd, this.walletData.Scrypt)
+	if err != nil {
+		return "", err
+	}
+	this.walletData.HDWallet = hdWallet
+	err = this.save()
+	if err != nil {
+		this.walletData.HDWallet = nil
+		return "", fmt.Errorf("save error: %s", err)
+	}
+	return normalizeMnemonic(mnemonic), nil
+}
+
+func (this *ClientImpl) IsHDWallet() bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.walletData.HDWallet != nil
+}
+
+func (this *ClientImpl) GetMnemonic(passwd []byte) (string, error) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	if this.walletData.HDWallet == nil {
+		return "", fmt.Errorf("wallet is not a HD wallet")
+	}
+	return this.walletData.HDWallet.GetMnemonic(passwd, this.walletData.Scrypt)
+}
+
+func (this *ClientImpl) DeriveAccount(label string, typeCode keypair.KeyType, curveCode byte, sigScheme s.SignatureScheme, passwd []byte) (*Account, error) {
+	seed, index, err := this.nextDeriveIndex(typeCode, curveCode, passwd)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		acc, err := this.deriveAccount(label, seed, index, typeCode, curveCode, sigScheme, passwd)
+		if err != nil {
+			return nil, err
+		}
+		if acc != nil {
+			return acc, nil
+		}
+		index++
+	}
+}
+
+func (this *ClientImpl) RestoreHDWallet(mnemonic, path string, num int, typeCode keypair.KeyType, curveCode byte, sigScheme s.SignatureScheme, passwd []byte) ([]*Account, error) {
+	_, err := this.CreateHDWallet(mnemonic, path, passwd)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := MnemonicToSeed(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	accs := make([]*Account, 0, num)
+	for i := 0; i < num; i++ {
+		acc, err := this.deriveAccount("", seed, uint32(i), typeCode, curveCode, sigScheme, passwd)
+		if err != nil {
+			return accs, err
+		}
+		if acc != nil {
+			accs = append(accs, acc)
+		}
+	}
+	return accs, nil
+}
+
+//nextDeriveIndex return the seed and the index after the last account derived with the same key type
+func (this *ClientImpl) nextDeriveIndex(typeCode keypair.KeyType, curveCode byte, passwd []byte) ([]byte, uint32, error) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	hdWallet := this.walletData.HDWallet
+	if hdWallet == nil {
+		return nil, 0, fmt.Errorf("wallet is not a HD wallet")
+	}
+	mnemonic, err := hdWallet.GetMnemonic(passwd, this.walletData.Scrypt)
+	if err != nil {
+		return nil, 0, err
+	}
+	seed, err := MnemonicToSeed(mnemonic)
+	if err != nil {
+		return nil, 0, err
+	}
+	curve, err := keypair.GetCurve(curveCode)
+	if err != nil {
+		return nil, 0, err
+	}
+	alg := "ECDSA"
+	if typeCode == keypair.PK_SM2 {
+		alg = "SM2"
+	}
+	next := uint32(0)
+	prefix := hdWallet.Path + "/"
+	for _, accData := range this.walletData.Accounts {
+		if accData.Alg != alg || accData.Param["curve"] != curve.Params().Name || !strings.HasPrefix(accData.HDPath, prefix) {
+			continue
+		}
+		index, err := strconv.ParseUint(strings.TrimPrefix(accData.HDPath, prefix), 10, 32)
+		if err != nil {
+			continue
+		}
+		if uint32(index) >= next {
+			next = uint32(index) + 1
+		}
+	}
+	return seed, next, nil
+}
+
+//deriveAccount add the account at index to wallet, return nil if it's already in wallet
+func (this *ClientImpl) deriveAccount(label string, seed []byte, index uint32, typeCode keypair.KeyType, curveCode byte, sigScheme s.SignatureScheme, passwd []byte) (*Account, error) {
+	this.lock.RLock()
+	path := this.walletData.HDWallet.DerivePath(index)
+	this.lock.RUnlock()
+	prvkey, err := DeriveKey(seed, path, typeCode, curveCode)
+	if err != nil {
+		return nil, err
+	}
+	pubkey := prvkey.Public()
+	address := types.AddressFromPubKey(pubkey)
+	addressBase58 := address.ToBase58()
+	if this.GetAccountMetadataByAddress(addressBase58) != nil {
+		return nil, nil
+	}
+	prvSecret, err := keypair.EncryptWithCustomScrypt(prvkey, addressBase58, passwd, this.walletData.Scrypt)
+	if err != nil {
+		return nil, fmt.Errorf("encryptPrivateKey error: %s", err)
+	}
+	accData := &AccountData{}
+	accData.Label = label
+	accData.SetKeyPair(prvSecret)
+	accData.SigSch = sigScheme.Name()
+	accData.PubKey = hex.EncodeToString(keypair.SerializePublicKey(pubkey))
+	accData.HDPath = path
+
+	err = this.addAccountData(accData)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		PrivateKey: prvkey,
+		PublicKey:  pubkey,
+		Address:    address,
+		SigScheme:  sigScheme,
+	}, nil
+}
+
+func (this *ClientImpl) AddMultiSigAccount(label string, m int, pubKeys []keypair.PublicKey, labels []string) (*MultiSigAccountData, error) {
+	labels = append([]string{}, labels...)
+	for i, pubKey := range pubKeys {
+		if i < len(labels) && labels[i] != "" {
+			continue
+		}
+		//use label of the member account in wallet
+		accMeta := this.GetAccountMetadataByAddress(types.AddressFromPubKey(pubKey).ToBase58())
+		for len(labels) <= i {
+			labels = append(labels, "")
+		}
+		if accMeta != nil {
+			labels[i] = accMeta.Label
+		}
+	}
+	msAcc, err := NewMultiSigAccountData(label, m, pubKeys, labels)
+	if err != nil {
+		return nil, err
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.walletData.GetMultiSigAccount(msAcc.Address) != nil {
+		return nil, fmt.Errorf("multi signature account %s already exists", msAcc.Address)
+	}
+	if label != "" {
+		_, ok := this.accLabels[label]
+		if ok || this.walletData.GetMultiSigAccount(label) != nil {
+			return nil, fmt.Errorf("duplicate label")
+		}
+	}
+	this.walletData.AddMultiSigAccount(msAcc)
+	err = this.save()
+	if err != nil {
+		this.walletData.DelMultiSigAccount(msAcc.Address)
+		return nil, fmt.Errorf("save error: %s", err)
+	}
+	return msAcc, nil
+}
+
+func (this *ClientImpl) GetMultiSigAccount(address string) *MultiSigAccountData {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.walletData.GetMultiSigAccount(address)
+}
+
+func (this *ClientImpl) GetMultiSigAccounts() []*MultiSigAccountData {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return append([]*MultiSigAccountData{}, this.walletData.MultiSigs...)
+}
+
+func (this *ClientImpl) DeleteMultiSigAccount(address string) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	msAcc := this.walletData.GetMultiSigAccount(address)
+	if msAcc == nil {
+		return fmt.Errorf("cannot find multi signature account: %s", address)
+	}
+	this.walletData.DelMultiSigAccount(msAcc.Address)
+	err := this.save()
+	if err != nil {
+		this.walletData.AddMultiSigAccount(msAcc)
+		return fmt.Errorf("save error: %s", err)
+	}
+	return nil
+}
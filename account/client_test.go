@@ -330,3 +330,35 @@ func TestCheckSigScheme(t *testing.T) {
 	assert.Equal(t, testClient.checkSigScheme("Ed25519", "SHA512withEdDSA"), true)
 	assert.Equal(t, testClient.checkSigScheme("Ed25519", "SHA224withECDSA"), false)
 }
+
+func TestMultiSigAccount(t *testing.T) {
+	walletPath := "multisig_test.dat"
+	wallet, err := NewClientImpl(walletPath)
+	assert.Nil(t, err)
+	defer os.Remove(walletPath)
+
+	acc1, err := wallet.NewAccount("member1", keypair.PK_ECDSA, keypair.P256, s.SHA256withECDSA, testPasswd)
+	assert.Nil(t, err)
+	acc2 := NewAccount("")
+	pubKeys := []keypair.PublicKey{acc1.PublicKey, acc2.PublicKey}
+	msAcc, err := wallet.AddMultiSigAccount("team", 2, pubKeys, []string{"", "member2"})
+	assert.Nil(t, err)
+	assert.Equal(t, "member1", msAcc.Members[0].Label)
+	assert.Equal(t, "member2", msAcc.Members[1].Label)
+	_, err = wallet.AddMultiSigAccount("team2", 2, pubKeys, nil)
+	assert.NotNil(t, err)
+	_, err = wallet.NewAccount("team", keypair.PK_ECDSA, keypair.P256, s.SHA256withECDSA, testPasswd)
+	assert.NotNil(t, err)
+
+	wallet, err = NewClientImpl(walletPath)
+	assert.Nil(t, err)
+	msAcc = wallet.GetMultiSigAccount("team")
+	assert.NotNil(t, msAcc)
+	assert.Equal(t, 1, msAcc.GetMember(acc2.PublicKey))
+	keys, err := msAcc.GetPubKeys()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(keys))
+
+	assert.Nil(t, wallet.DeleteMultiSigAccount(msAcc.Address))
+	assert.Nil(t, wallet.GetMultiSigAccount(msAcc.Address))
+}
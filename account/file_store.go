@@ -19,6 +19,7 @@
 package account
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/ontio/dad-go-crypto/keypair"
 	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/core/types"
 )
 
 /** AccountData - for wallet read and save, no crypto object included **/
@@ -38,6 +40,7 @@ type AccountData struct {
 	SigSch    string `json:"signatureScheme"`
 	IsDefault bool   `json:"isDefault"`
 	Lock      bool   `json:"lock"`
+	HDPath    string `json:"hdPath,omitempty"`
 }
 
 func (this *AccountData) SetKeyPair(keyinfo *keypair.ProtectedKey) {
@@ -66,13 +69,76 @@ func (this *AccountData) SetLabel(label string) {
 	this.Label = label
 }
 
+/** MultiSigAccountData - M-of-N multi signature account, only public keys of members included **/
+type MultiSigAccountData struct {
+	Label   string            `json:"label"`
+	Address string            `json:"address"`
+	M       int               `json:"m"`
+	Members []*MultiSigMember `json:"members"`
+}
+
+type MultiSigMember struct {
+	Label  string `json:"label"`
+	PubKey string `json:"publicKey"`
+}
+
+func NewMultiSigAccountData(label string, m int, pubKeys []keypair.PublicKey, labels []string) (*MultiSigAccountData, error) {
+	addr, err := types.AddressFromMultiPubKeys(pubKeys, m)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*MultiSigMember, 0, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		member := &MultiSigMember{PubKey: hex.EncodeToString(keypair.SerializePublicKey(pubKey))}
+		if i < len(labels) {
+			member.Label = labels[i]
+		}
+		members = append(members, member)
+	}
+	return &MultiSigAccountData{
+		Label:   label,
+		Address: addr.ToBase58(),
+		M:       m,
+		Members: members,
+	}, nil
+}
+
+func (this *MultiSigAccountData) GetPubKeys() ([]keypair.PublicKey, error) {
+	pubKeys := make([]keypair.PublicKey, 0, len(this.Members))
+	for _, member := range this.Members {
+		data, err := hex.DecodeString(member.PubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid member public key %s: %s", member.PubKey, err)
+		}
+		pubKey, err := keypair.DeserializePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid member public key %s: %s", member.PubKey, err)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys, nil
+}
+
+//GetMember return the index of member with the public key, -1 if not found
+func (this *MultiSigAccountData) GetMember(pubKey keypair.PublicKey) int {
+	pk := hex.EncodeToString(keypair.SerializePublicKey(pubKey))
+	for i, member := range this.Members {
+		if member.PubKey == pk {
+			return i
+		}
+	}
+	return -1
+}
+
 type WalletData struct {
-	Name       string               `json:"name"`
-	Version    string               `json:"version"`
-	Scrypt     *keypair.ScryptParam `json:"scrypt"`
-	Identities []Identity           `json:"identities,omitempty"`
-	Accounts   []*AccountData       `json:"accounts,omitempty"`
-	Extra      string               `json:"extra,omitempty"`
+	Name       string                 `json:"name"`
+	Version    string                 `json:"version"`
+	Scrypt     *keypair.ScryptParam   `json:"scrypt"`
+	Identities []Identity             `json:"identities,omitempty"`
+	Accounts   []*AccountData         `json:"accounts,omitempty"`
+	MultiSigs  []*MultiSigAccountData `json:"multiSigAccounts,omitempty"`
+	HDWallet   *HDWalletData          `json:"hdWallet,omitempty"`
+	Extra      string                 `json:"extra,omitempty"`
 }
 
 func NewWalletData() *WalletData {
@@ -99,6 +165,20 @@ func (this *WalletData) Clone() *WalletData {
 		w.Accounts[i] = &ac
 	}
 	w.Identities = this.Identities
+	w.MultiSigs = make([]*MultiSigAccountData, len(this.MultiSigs))
+	for i, v := range this.MultiSigs {
+		ms := *v
+		ms.Members = make([]*MultiSigMember, len(v.Members))
+		for j, member := range v.Members {
+			m := *member
+			ms.Members[j] = &m
+		}
+		w.MultiSigs[i] = &ms
+	}
+	if this.HDWallet != nil {
+		hd := *this.HDWallet
+		w.HDWallet = &hd
+	}
 	w.Extra = this.Extra
 	return &w
 }
@@ -115,6 +195,29 @@ func (this *WalletData) DelAccount(address string) {
 	this.Accounts = append(this.Accounts[:index], this.Accounts[index+1:]...)
 }
 
+func (this *WalletData) AddMultiSigAccount(acc *MultiSigAccountData) {
+	this.MultiSigs = append(this.MultiSigs, acc)
+}
+
+func (this *WalletData) DelMultiSigAccount(address string) {
+	for i, acc := range this.MultiSigs {
+		if acc.Address == address {
+			this.MultiSigs = append(this.MultiSigs[:i], this.MultiSigs[i+1:]...)
+			return
+		}
+	}
+}
+
+//GetMultiSigAccount return multi signature account by address or label
+func (this *WalletData) GetMultiSigAccount(address string) *MultiSigAccountData {
+	for _, acc := range this.MultiSigs {
+		if acc.Address == address || (acc.Label != "" && acc.Label == address) {
+			return acc
+		}
+	}
+	return nil
+}
+
 func (this *WalletData) GetAccountByIndex(index int) *AccountData {
 	if index < 0 || index >= len(this.Accounts) {
 		return nil
@@ -191,9 +294,27 @@ func (this *WalletData) reencrypt(passwords [][]byte, param *keypair.ScryptParam
 		keys[i] = prot
 	}
 
+	var hdWallet *HDWalletData
+	if this.HDWallet != nil {
+		// the mnemonic shares its password with the accounts derived from it
+		for _, passwd := range passwords {
+			hd, err := this.HDWallet.Reencrypt(passwd, passwd, this.Scrypt, param)
+			if err == nil {
+				hdWallet = hd
+				break
+			}
+		}
+		if hdWallet == nil {
+			return errors.New("re-encrypt mnemonic failed, no password matches")
+		}
+	}
+
 	for i, v := range keys {
 		this.Accounts[i].SetKeyPair(v)
 	}
+	if hdWallet != nil {
+		this.HDWallet = hdWallet
+	}
 	if param != nil {
 		this.Scrypt = param
 	} else {
@@ -0,0 +1,286 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package account
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ontio/dad-go-crypto/ec"
+	"github.com/ontio/dad-go-crypto/keypair"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	//DEFAULT_HD_PATH is the BIP44 chain of ONT (coin type 1024), account index is appended to it
+	DEFAULT_HD_PATH = "m/44'/1024'/0'/0"
+	//HARDENED_KEY_START is the first index of hardened child keys
+	HARDENED_KEY_START = uint32(0x80000000)
+	//MNEMONIC_ENTROPY_BITS is the entropy of new mnemonics, 128 bits gives 12 words
+	MNEMONIC_ENTROPY_BITS = 128
+)
+
+//HDWalletData is the encrypted mnemonic of a hierarchical deterministic wallet
+type HDWalletData struct {
+	EncAlg   string `json:"enc-alg"`
+	Mnemonic []byte `json:"mnemonic"`
+	Salt     []byte `json:"salt"`
+	Path     string `json:"path"`
+}
+
+//NewMnemonic generate a new random BIP39 mnemonic
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MNEMONIC_ENTROPY_BITS)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+//NewHDWalletData encrypt mnemonic by passwd. The path is used as additional data,
+//so that the derive path cannot be changed without the password
+func NewHDWalletData(mnemonic, path string, passwd []byte, param *keypair.ScryptParam) (*HDWalletData, error) {
+	mnemonic = normalizeMnemonic(mnemonic)
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+	if path == "" {
+		path = DEFAULT_HD_PATH
+	}
+	if _, err := ParseDerivePath(path); err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	data := &HDWalletData{
+		EncAlg: "aes-256-gcm",
+		Salt:   salt,
+		Path:   path,
+	}
+	gcm, nonce, err := mnemonicCipher(passwd, salt, param)
+	if err != nil {
+		return nil, err
+	}
+	data.Mnemonic = gcm.Seal(nil, nonce, []byte(mnemonic), []byte(path))
+	return data, nil
+}
+
+//GetMnemonic decrypt the mnemonic
+func (this *HDWalletData) GetMnemonic(passwd []byte, param *keypair.ScryptParam) (string, error) {
+	if this.EncAlg != "aes-256-gcm" {
+		return "", fmt.Errorf("unsupported encryption algorithm: %s", this.EncAlg)
+	}
+	gcm, nonce, err := mnemonicCipher(passwd, this.Salt, param)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := gcm.Open(nil, nonce, this.Mnemonic, []byte(this.Path))
+	if err != nil {
+		return "", fmt.Errorf("decrypt mnemonic error: %s", err)
+	}
+	return string(plaintext), nil
+}
+
+//Reencrypt encrypt the mnemonic with new password and scrypt param
+func (this *HDWalletData) Reencrypt(oldPasswd, newPasswd []byte, oldParam, newParam *keypair.ScryptParam) (*HDWalletData, error) {
+	mnemonic, err := this.GetMnemonic(oldPasswd, oldParam)
+	if err != nil {
+		return nil, err
+	}
+	return NewHDWalletData(mnemonic, this.Path, newPasswd, newParam)
+}
+
+//DerivePath return the derive path of the account at index
+func (this *HDWalletData) DerivePath(index uint32) string {
+	return fmt.Sprintf("%s/%d", this.Path, index)
+}
+
+func mnemonicCipher(passwd, salt []byte, param *keypair.ScryptParam) (gcm cipher.AEAD, nonce []byte, err error) {
+	if len(passwd) == 0 {
+		return nil, nil, fmt.Errorf("password cannot empty")
+	}
+	if param == nil {
+		param = keypair.GetScryptParameters()
+	}
+	if param.DKLen < 32 {
+		return nil, nil, errors.New("derived key length too short")
+	}
+	dkey, err := scrypt.Key(passwd, salt, param.N, param.R, param.P, param.DKLen)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := aes.NewCipher(dkey[len(dkey)-32:])
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err = cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gcm, dkey[:12], nil
+}
+
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(mnemonic), " ")
+}
+
+//MnemonicToSeed return the BIP39 seed of mnemonic with empty passphrase
+func MnemonicToSeed(mnemonic string) ([]byte, error) {
+	return bip39.NewSeedWithErrorChecking(normalizeMnemonic(mnemonic), "")
+}
+
+//ParseDerivePath parse path like m/44'/1024'/0'/0/0 to child indexes
+func ParseDerivePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derive path: %s, should start with m", path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HARDENED_KEY_START {
+			return nil, fmt.Errorf("invalid derive path: %s", path)
+		}
+		if hardened {
+			index += uint64(HARDENED_KEY_START)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+//extendedKey is a private key with chain code, derived as SLIP-0010 does for
+//curves other than secp256k1
+type extendedKey struct {
+	curve     elliptic.Curve
+	key       []byte
+	chainCode []byte
+}
+
+func curveSeedKey(keyType keypair.KeyType, curveCode byte) ([]byte, error) {
+	switch {
+	case keyType == keypair.PK_ECDSA && curveCode == keypair.P256:
+		return []byte("Nist256p1 seed"), nil
+	case keyType == keypair.PK_SM2 && curveCode == keypair.SM2P256V1:
+		return []byte("Sm2p256v1 seed"), nil
+	}
+	return nil, fmt.Errorf("key type %d with curve %d does not support derivation, only ECDSA P-256 and SM2 are supported", keyType, curveCode)
+}
+
+func newMasterKey(seed []byte, keyType keypair.KeyType, curveCode byte) (*extendedKey, error) {
+	seedKey, err := curveSeedKey(keyType, curveCode)
+	if err != nil {
+		return nil, err
+	}
+	curve, err := keypair.GetCurve(curveCode)
+	if err != nil {
+		return nil, err
+	}
+	n := curve.Params().N
+	data := seed
+	for {
+		mac := hmac.New(sha512.New, seedKey)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		k := new(big.Int).SetBytes(sum[:32])
+		if k.Sign() != 0 && k.Cmp(n) < 0 {
+			return &extendedKey{curve: curve, key: sum[:32], chainCode: sum[32:]}, nil
+		}
+		data = sum
+	}
+}
+
+func (this *extendedKey) child(index uint32) *extendedKey {
+	var data []byte
+	if index >= HARDENED_KEY_START {
+		data = append([]byte{0}, this.key...)
+	} else {
+		data = compressPoint(this.curve, this.key)
+	}
+	var idx [4]byte
+	binary.BigEndian.PutUint32(idx[:], index)
+	n := this.curve.Params().N
+	for {
+		mac := hmac.New(sha512.New, this.chainCode)
+		mac.Write(data)
+		mac.Write(idx[:])
+		sum := mac.Sum(nil)
+		il := new(big.Int).SetBytes(sum[:32])
+		if il.Cmp(n) < 0 {
+			k := il.Add(il, new(big.Int).SetBytes(this.key))
+			k.Mod(k, n)
+			if k.Sign() != 0 {
+				key := make([]byte, 32)
+				kb := k.Bytes()
+				copy(key[32-len(kb):], kb)
+				return &extendedKey{curve: this.curve, key: key, chainCode: sum[32:]}
+			}
+		}
+		data = append([]byte{1}, sum[32:]...)
+	}
+}
+
+func compressPoint(curve elliptic.Curve, key []byte) []byte {
+	x, y := curve.ScalarBaseMult(key)
+	buf := make([]byte, 33)
+	buf[0] = 2 + byte(y.Bit(0))
+	xb := x.Bytes()
+	copy(buf[33-len(xb):], xb)
+	return buf
+}
+
+//DeriveKey derive the private key at path from seed. Only ECDSA P-256 and SM2 are supported
+func DeriveKey(seed []byte, path string, keyType keypair.KeyType, curveCode byte) (keypair.PrivateKey, error) {
+	indexes, err := ParseDerivePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := newMasterKey(seed, keyType, curveCode)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		key = key.child(index)
+	}
+	alg := ec.ECDSA
+	if keyType == keypair.PK_SM2 {
+		alg = ec.SM2
+	}
+	return &ec.PrivateKey{
+		Algorithm:  alg,
+		PrivateKey: ec.ConstructPrivateKey(key.key, key.curve),
+	}, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package account
+
+import (
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/ontio/dad-go-crypto/ec"
+	"github.com/ontio/dad-go-crypto/keypair"
+	s "github.com/ontio/dad-go-crypto/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDerivePath(t *testing.T) {
+	indexes, err := ParseDerivePath("m/44'/1024'/0'/0/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{44 + HARDENED_KEY_START, 1024 + HARDENED_KEY_START, HARDENED_KEY_START, 0, 1}, indexes)
+
+	for _, path := range []string{"", "44'/0", "m/a", "m/2147483648"} {
+		_, err = ParseDerivePath(path)
+		assert.NotNil(t, err, path)
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	//test vector 1 for nist256p1 of SLIP-0010
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := newMasterKey(seed, keypair.PK_ECDSA, keypair.P256)
+	assert.Nil(t, err)
+	assert.Equal(t, "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2", hex.EncodeToString(master.key))
+	assert.Equal(t, "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea", hex.EncodeToString(master.chainCode))
+
+	prvkey, err := DeriveKey(seed, "m/0'/1", keypair.PK_ECDSA, keypair.P256)
+	assert.Nil(t, err)
+	assert.Equal(t, "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129", hex.EncodeToString(prvkey.(*ec.PrivateKey).D.Bytes()))
+
+	sm2Key, err := DeriveKey(seed, "m/0'/1", keypair.PK_SM2, keypair.SM2P256V1)
+	assert.Nil(t, err)
+	assert.Equal(t, ec.SM2, sm2Key.(*ec.PrivateKey).Algorithm)
+
+	_, err = DeriveKey(seed, "m/0'/1", keypair.PK_EDDSA, keypair.ED25519)
+	assert.NotNil(t, err)
+}
+
+func TestHDWallet(t *testing.T) {
+	path1, path2 := "./hd_wallet_test1.dat", "./hd_wallet_test2.dat"
+	defer os.Remove(path1)
+	defer os.Remove(path2)
+
+	wallet, err := NewClientImpl(path1)
+	assert.Nil(t, err)
+	_, err = wallet.DeriveAccount("", keypair.PK_ECDSA, keypair.P256, s.SHA256withECDSA, testPasswd)
+	assert.NotNil(t, err)
+
+	mnemonic, err := wallet.CreateHDWallet("", "", testPasswd)
+	assert.Nil(t, err)
+	assert.True(t, wallet.IsHDWallet())
+	acc1, err := wallet.DeriveAccount("a1", keypair.PK_ECDSA, keypair.P256, s.SHA256withECDSA, testPasswd)
+	assert.Nil(t, err)
+	acc2, err := wallet.DeriveAccount("a2", keypair.PK_ECDSA, keypair.P256, s.SHA256withECDSA, testPasswd)
+	assert.Nil(t, err)
+	assert.NotEqual(t, acc1.Address, acc2.Address)
+	assert.Equal(t, DEFAULT_HD_PATH+"/1", wallet.GetAccountMetadataByLabel("a2").HDPath)
+
+	wallet, err = NewClientImpl(path1)
+	assert.Nil(t, err)
+	m, err := wallet.GetMnemonic(testPasswd)
+	assert.Nil(t, err)
+	assert.Equal(t, mnemonic, m)
+	_, err = wallet.GetMnemonic([]byte("wrong"))
+	assert.NotNil(t, err)
+
+	restored, err := NewClientImpl(path2)
+	assert.Nil(t, err)
+	accs, err := restored.RestoreHDWallet(mnemonic, "", 2, keypair.PK_ECDSA, keypair.P256, s.SHA256withECDSA, testPasswd)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(accs))
+	assert.Equal(t, acc1.Address, accs[0].Address)
+	assert.Equal(t, acc2.Address, accs[1].Address)
+}
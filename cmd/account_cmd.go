@@ -31,6 +31,7 @@ import (
 	"github.com/ontio/dad-go/core/types"
 	"github.com/urfave/cli"
 	"os"
+	"strings"
 )
 
 var (
@@ -55,9 +56,11 @@ You can use ./dad-go account --help command to view help information of wallet m
 					utils.AccountDefaultFlag,
 					utils.AccountLabelFlag,
 					utils.IdentityFlag,
+					utils.AccountDeriveFlag,
 					utils.WalletFileFlag,
 				},
 				Description: ` Add a new account to wallet.
+   With --derive option, accounts are derived from the mnemonic of HD wallet which created by 'wallet create --mnemonic', and the password of the mnemonic is required.
    dad-go support three type of key: ecdsa, sm2 and ed25519, and support 224、256、384、521 bits length of key in ecdsa, but only support 256 bits length of key in sm2 and ed25519.
    dad-go support multiple signature scheme.
    For ECDSA support SHA224withECDSA、SHA256withECDSA、SHA384withECDSA、SHA512withEdDSA、SHA3-224withECDSA、SHA3-256withECDSA、SHA3-384withECDSA、SHA3-512withECDSA、RIPEMD160withECDSA;
@@ -135,6 +138,21 @@ You can use ./dad-go account --help command to view help information of wallet m
 				},
 				Description: "Import accounts of wallet to another. If not specific accounts in args, all account in source will be import",
 			},
+			{
+				Action:    accountAddMultiSig,
+				Name:      "addmultisig",
+				Usage:     "Add a multi signature account",
+				ArgsUsage: "[sub-command options]",
+				Flags: []cli.Flag{
+					utils.WalletFileFlag,
+					utils.AccountMultiMFlag,
+					utils.AccountMultiPubKeyFlag,
+					utils.AccountMultiLabelsFlag,
+					utils.AccountLabelFlag,
+				},
+				Description: `Add a M-of-N multi signature account to wallet, no private key is stored for it.
+   'asset transfer' and 'contract invoke' from a multi signature account create a partial signed transaction file, which can be signed by other members with 'psbt sign' command.`,
+			},
 			{
 				Action:    accountExport,
 				Name:      "export",
@@ -169,7 +187,13 @@ func accountCreate(ctx *cli.Context) error {
 	optionFile := checkFileName(ctx)
 	optionNumber := checkNumber(ctx)
 	optionLabel := checkLabel(ctx)
-	pass, _ := password.GetConfirmedPassword()
+	optionDerive := ctx.Bool(utils.GetFlagName(utils.AccountDeriveFlag))
+	var pass []byte
+	if optionDerive {
+		pass, _ = password.GetAccountPassword()
+	} else {
+		pass, _ = password.GetConfirmedPassword()
+	}
 	keyType := keyTypeMap[optionType].code
 	curve := curveMap[optionCurve].code
 	scheme := schemeMap[optionScheme].code
@@ -178,6 +202,9 @@ func accountCreate(ctx *cli.Context) error {
 		return fmt.Errorf("error opening wallet: %s", err)
 	}
 	defer common.ClearPasswd(pass)
+	if optionDerive && !wallet.IsHDWallet() {
+		return fmt.Errorf("wallet %s is not a HD wallet, please create it by 'wallet create --mnemonic'", optionFile)
+	}
 	if ctx.Bool(utils.IdentityFlag.Name) {
 		// create ONT ID
 		wd := wallet.GetWalletData()
@@ -199,7 +226,12 @@ func accountCreate(ctx *cli.Context) error {
 		if label != "" && optionNumber > 1 {
 			label = fmt.Sprintf("%s%d", label, i+1)
 		}
-		acc, err := wallet.NewAccount(label, keyType, curve, scheme, pass)
+		var acc *account.Account
+		if optionDerive {
+			acc, err = wallet.DeriveAccount(label, keyType, curve, scheme, pass)
+		} else {
+			acc, err = wallet.NewAccount(label, keyType, curve, scheme, pass)
+		}
 		if err != nil {
 			return fmt.Errorf("error creating new account: %s", err)
 		}
@@ -208,6 +240,9 @@ func accountCreate(ctx *cli.Context) error {
 		PrintInfoMsg("Address:%s", acc.Address.ToBase58())
 		PrintInfoMsg("Public key:%s", hex.EncodeToString(keypair.SerializePublicKey(acc.PublicKey)))
 		PrintInfoMsg("Signature scheme:%s", acc.SigScheme.Name())
+		if optionDerive {
+			PrintInfoMsg("HD path:%s", wallet.GetAccountMetadataByAddress(acc.Address.ToBase58()).HDPath)
+		}
 	}
 
 	PrintInfoMsg("Create account successfully.")
@@ -221,10 +256,12 @@ func accountList(ctx *cli.Context) error {
 		return fmt.Errorf("error opening wallet %s: %s", optionFile, err)
 	}
 	accNum := wallet.GetAccountNum()
-	if accNum == 0 {
+	msAccs := wallet.GetMultiSigAccounts()
+	if accNum == 0 && len(msAccs) == 0 {
 		PrintInfoMsg("No account.")
 		return nil
 	}
+	defer printMultiSigAccounts(msAccs, ctx.Bool(utils.GetFlagName(utils.AccountVerboseFlag)))
 	accList := make(map[string]string, ctx.NArg())
 	for i := 0; i < ctx.NArg(); i++ {
 		addr := ctx.Args().Get(i)
@@ -264,6 +301,9 @@ func accountList(ctx *cli.Context) error {
 		PrintInfoMsg("	Curve: %v", accMeta.Curve)
 		PrintInfoMsg("	Key length: %v bits", len(accMeta.Key)*8)
 		PrintInfoMsg("	Public key: %v", accMeta.PubKey)
+		if accMeta.HDPath != "" {
+			PrintInfoMsg("	HD path: %v", accMeta.HDPath)
+		}
 		PrintInfoMsg("	Signature scheme: %v\n", accMeta.SigSch)
 	}
 	return nil
@@ -374,7 +414,17 @@ func accountDelete(ctx *cli.Context) error {
 	}
 	accMeta := common.GetAccountMetadataMulti(wallet, address)
 	if accMeta == nil {
-		return fmt.Errorf("cannot get account by: %s", address)
+		msAcc := wallet.GetMultiSigAccount(address)
+		if msAcc == nil {
+			return fmt.Errorf("cannot get account by: %s", address)
+		}
+		err = wallet.DeleteMultiSigAccount(msAcc.Address)
+		if err != nil {
+			PrintErrorMsg("Delete multi signature account label:%s address:%s failed, %s", msAcc.Label, msAcc.Address, err)
+		} else {
+			PrintInfoMsg("Delete multi signature account label:%s address:%s successfully.", msAcc.Label, msAcc.Address)
+		}
+		return nil
 	}
 	passwd, err := common.GetPasswd(ctx)
 	if err != nil {
@@ -390,6 +440,66 @@ func accountDelete(ctx *cli.Context) error {
 	return nil
 }
 
+func accountAddMultiSig(ctx *cli.Context) error {
+	pkstr := strings.TrimSpace(strings.Trim(ctx.String(utils.GetFlagName(utils.AccountMultiPubKeyFlag)), ","))
+	m := ctx.Uint(utils.GetFlagName(utils.AccountMultiMFlag))
+	if pkstr == "" || m == 0 {
+		PrintErrorMsg("Missing argument. %s or %s expected.",
+			utils.GetFlagName(utils.AccountMultiMFlag),
+			utils.GetFlagName(utils.AccountMultiPubKeyFlag))
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	pubKeys := make([]keypair.PublicKey, 0)
+	for _, pk := range strings.Split(pkstr, ",") {
+		pk = strings.TrimSpace(pk)
+		if pk == "" {
+			continue
+		}
+		data, err := hex.DecodeString(pk)
+		if err != nil {
+			return fmt.Errorf("invalid pub key:%s", pk)
+		}
+		pubKey, err := keypair.DeserializePublicKey(data)
+		if err != nil {
+			return fmt.Errorf("invalid pub key:%s", pk)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	var labels []string
+	if labelstr := ctx.String(utils.GetFlagName(utils.AccountMultiLabelsFlag)); labelstr != "" {
+		labels = strings.Split(labelstr, ",")
+		for i := range labels {
+			labels[i] = strings.TrimSpace(labels[i])
+		}
+	}
+
+	optionFile := checkFileName(ctx)
+	wallet, err := account.Open(optionFile)
+	if err != nil {
+		return fmt.Errorf("error opening wallet: %s", err)
+	}
+	msAcc, err := wallet.AddMultiSigAccount(checkLabel(ctx), int(m), pubKeys, labels)
+	if err != nil {
+		return fmt.Errorf("error adding multi signature account: %s", err)
+	}
+	printMultiSigAccounts([]*account.MultiSigAccountData{msAcc}, true)
+	PrintInfoMsg("Add multi signature account successfully.")
+	return nil
+}
+
+func printMultiSigAccounts(msAccs []*account.MultiSigAccountData, verbose bool) {
+	for _, msAcc := range msAccs {
+		PrintInfoMsg("MultiSig Address:%s  Label:%s  M:%d N:%d", msAcc.Address, msAcc.Label, msAcc.M, len(msAcc.Members))
+		if !verbose {
+			continue
+		}
+		for i, member := range msAcc.Members {
+			PrintInfoMsg("	Member %d Label:%s PubKey:%s", i+1, member.Label, member.PubKey)
+		}
+	}
+}
+
 func accountImport(ctx *cli.Context) error {
 	source := ctx.String(utils.GetFlagName(utils.AccountSourceFileFlag))
 	if source == "" {
@@ -51,6 +51,8 @@ var AssetCommand = cli.Command{
 				utils.TransactionAmountFlag,
 				utils.ForceSendTxFlag,
 				utils.WalletFileFlag,
+				utils.MultiSigSignerFlag,
+				utils.PartialSignedTxFileFlag,
 			},
 		},
 		{
@@ -202,14 +204,29 @@ func transfer(ctx *cli.Context) error {
 		gasPrice = 0
 	}
 
-	var signer *account.Account
-	signer, err = cmdcom.GetAccount(ctx, fromAddr)
-	if err != nil {
-		return err
-	}
-	txHash, err := utils.Transfer(gasPrice, gasLimit, signer, asset, fromAddr, toAddr, amount)
-	if err != nil {
-		return fmt.Errorf("transfer error:%s", err)
+	var txHash string
+	if msAcc := getMultiSigAccount(ctx, fromAddr); msAcc != nil {
+		mutTx, err := utils.TransferTx(gasPrice, gasLimit, asset, fromAddr, toAddr, amount)
+		if err != nil {
+			return err
+		}
+		txHash, err = signMultiSigTx(ctx, msAcc, mutTx)
+		if err != nil {
+			return fmt.Errorf("transfer error:%s", err)
+		}
+		if txHash == "" {
+			return nil
+		}
+	} else {
+		var signer *account.Account
+		signer, err = cmdcom.GetAccount(ctx, fromAddr)
+		if err != nil {
+			return err
+		}
+		txHash, err = utils.Transfer(gasPrice, gasLimit, signer, asset, fromAddr, toAddr, amount)
+		if err != nil {
+			return fmt.Errorf("transfer error:%s", err)
+		}
 	}
 	PrintInfoMsg("Transfer %s", strings.ToUpper(asset))
 	PrintInfoMsg("  From:%s", fromAddr)
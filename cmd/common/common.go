@@ -156,6 +156,10 @@ func ParseAddress(address string, ctx *cli.Context) (string, error) {
 	if acc != nil {
 		return acc.Address, nil
 	}
+	msAcc := wallet.GetMultiSigAccount(address)
+	if msAcc != nil {
+		return msAcc.Address, nil
+	}
 	index, err := strconv.ParseInt(address, 10, 32)
 	if err != nil {
 		return "", fmt.Errorf("cannot get account by address: %s", address)
@@ -35,7 +35,16 @@ func Setdad-goConfig(ctx *cli.Context) (*config.dad-goConfig, error) {
 		return nil, fmt.Errorf("setGenesis error:%s", err)
 	}
 	setCommonConfig(ctx, cfg.Common)
+	if cfg.Common.SnapshotHeight != 0 {
+		if _, err := common.Uint256FromHexString(cfg.Common.SnapshotHash); err != nil {
+			return nil, fmt.Errorf("invalid --%s:%s", utils.SnapshotHashFlag.Name, err)
+		}
+	}
+	if cfg.Common.PruneBlocks != 0 && cfg.Common.PruneBlocks < config.MIN_PRUNE_BLOCKS {
+		return nil, fmt.Errorf("--%s must be at least %d", utils.PruneBlocksFlag.Name, config.MIN_PRUNE_BLOCKS)
+	}
 	setConsensusConfig(ctx, cfg.Consensus)
+	setTxPoolConfig(ctx, cfg.TxPool)
 	setP2PNodeConfig(ctx, cfg.P2PNode)
 	setRpcConfig(ctx, cfg.Rpc)
 	setRestfulConfig(ctx, cfg.Restful)
@@ -125,9 +134,15 @@ func setGenesis(ctx *cli.Context, cfg *config.dad-goConfig) error {
 func setCommonConfig(ctx *cli.Context, cfg *config.CommonConfig) {
 	cfg.LogLevel = ctx.Uint(utils.GetFlagName(utils.LogLevelFlag))
 	cfg.EnableEventLog = !ctx.Bool(utils.GetFlagName(utils.DisableEventLogFlag))
+	cfg.EnableAddressIndex = ctx.Bool(utils.GetFlagName(utils.EnableAddressIndexFlag))
+	cfg.MetricsPort = ctx.Uint(utils.GetFlagName(utils.MetricsPortFlag))
 	cfg.GasLimit = ctx.Uint64(utils.GetFlagName(utils.GasLimitFlag))
 	cfg.GasPrice = ctx.Uint64(utils.GetFlagName(utils.GasPriceFlag))
 	cfg.DataDir = ctx.String(utils.GetFlagName(utils.DataDirFlag))
+	cfg.SnapshotInterval = uint32(ctx.Uint(utils.GetFlagName(utils.SnapshotIntervalFlag)))
+	cfg.SnapshotHeight = uint32(ctx.Uint(utils.GetFlagName(utils.SnapshotHeightFlag)))
+	cfg.SnapshotHash = ctx.String(utils.GetFlagName(utils.SnapshotHashFlag))
+	cfg.PruneBlocks = uint32(ctx.Uint(utils.GetFlagName(utils.PruneBlocksFlag)))
 }
 
 func setConsensusConfig(ctx *cli.Context, cfg *config.ConsensusConfig) {
@@ -135,6 +150,13 @@ func setConsensusConfig(ctx *cli.Context, cfg *config.ConsensusConfig) {
 	cfg.MaxTxInBlock = ctx.Uint(utils.GetFlagName(utils.MaxTxInBlockFlag))
 }
 
+func setTxPoolConfig(ctx *cli.Context, cfg *config.TxPoolConfig) {
+	cfg.PriceBump = ctx.Uint64(utils.GetFlagName(utils.TxpoolPriceBumpFlag))
+	cfg.AccountSlots = ctx.Uint(utils.GetFlagName(utils.TxpoolAccountSlotsFlag))
+	cfg.AccountQueue = ctx.Uint(utils.GetFlagName(utils.TxpoolAccountQueueFlag))
+	cfg.GlobalSlots = ctx.Uint(utils.GetFlagName(utils.TxpoolGlobalSlotsFlag))
+}
+
 func setP2PNodeConfig(ctx *cli.Context, cfg *config.P2PNodeConfig) {
 	cfg.NetworkId = uint32(ctx.Uint(utils.GetFlagName(utils.NetworkIdFlag)))
 	cfg.NetworkMagic = config.GetNetworkMagic(cfg.NetworkId)
@@ -171,6 +193,7 @@ func setRpcConfig(ctx *cli.Context, cfg *config.RpcConfig) {
 	cfg.EnableHttpJsonRpc = !ctx.Bool(utils.GetFlagName(utils.RPCDisabledFlag))
 	cfg.HttpJsonPort = ctx.Uint(utils.GetFlagName(utils.RPCPortFlag))
 	cfg.HttpLocalPort = ctx.Uint(utils.GetFlagName(utils.RPCLocalProtFlag))
+	cfg.EnableTrace = ctx.Bool(utils.GetFlagName(utils.RPCTraceEnableFlag))
 }
 
 func setRestfulConfig(ctx *cli.Context, cfg *config.RestfulConfig) {
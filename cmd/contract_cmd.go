@@ -26,6 +26,8 @@ import (
 	"github.com/ontio/dad-go/common"
 	"github.com/ontio/dad-go/common/config"
 	"github.com/ontio/dad-go/core/payload"
+	"github.com/ontio/dad-go/core/types"
+	cutils "github.com/ontio/dad-go/core/utils"
 	httpcom "github.com/ontio/dad-go/http/base/common"
 	"github.com/ontio/dad-go/smartcontract/states"
 	"github.com/urfave/cli"
@@ -95,6 +97,8 @@ var (
 					utils.ContractReturnTypeFlag,
 					utils.WalletFileFlag,
 					utils.AccountAddressFlag,
+					utils.MultiSigSignerFlag,
+					utils.PartialSignedTxFileFlag,
 				},
 			},
 			{
@@ -347,10 +351,6 @@ func invokeContract(ctx *cli.Context) error {
 		}
 		return nil
 	}
-	signer, err := cmdcom.GetAccount(ctx)
-	if err != nil {
-		return fmt.Errorf("get signer account error:%s", err)
-	}
 	gasPrice := ctx.Uint64(utils.GetFlagName(utils.TransactionGasPriceFlag))
 	gasLimit := ctx.Uint64(utils.GetFlagName(utils.TransactionGasLimitFlag))
 	networkId, err := utils.GetNetworkId()
@@ -361,6 +361,32 @@ func invokeContract(ctx *cli.Context) error {
 		gasPrice = 0
 	}
 
+	if msAcc := getMultiSigAccount(ctx, ctx.String(utils.GetFlagName(utils.AccountAddressFlag))); msAcc != nil {
+		var mutTx *types.MutableTransaction
+		if vmtype == payload.NEOVM_TYPE {
+			mutTx, err = httpcom.NewNeovmInvokeTransaction(gasPrice, gasLimit, contractAddr, params)
+		} else {
+			mutTx, err = cutils.NewWasmVMInvokeTransaction(gasPrice, gasLimit, contractAddr, params)
+		}
+		if err != nil {
+			return fmt.Errorf("build invoke transaction error:%s", err)
+		}
+		txHash, err := signMultiSigTx(ctx, msAcc, mutTx)
+		if err != nil {
+			return fmt.Errorf("invoke contract error:%s", err)
+		}
+		if txHash != "" {
+			PrintInfoMsg("  TxHash:%s", txHash)
+			PrintInfoMsg("\nTips:")
+			PrintInfoMsg("  Using './dad-go info status %s' to query transaction status.", txHash)
+		}
+		return nil
+	}
+	signer, err := cmdcom.GetAccount(ctx)
+	if err != nil {
+		return fmt.Errorf("get signer account error:%s", err)
+	}
+
 	var txHash string
 	if vmtype == payload.NEOVM_TYPE {
 		txHash, err = utils.InvokeNeoVMContract(gasPrice, gasLimit, signer, contractAddr, params)
@@ -0,0 +1,155 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cmdcom "github.com/ontio/dad-go/cmd/common"
+	"github.com/ontio/dad-go/cmd/utils"
+	"github.com/ontio/dad-go/common/config"
+	httpcom "github.com/ontio/dad-go/http/base/common"
+	"github.com/urfave/cli"
+)
+
+var DataCommand = cli.Command{
+	Name:        "data",
+	Usage:       "Upload file to ipfs cluster and register it on chain",
+	Description: "Data commands can upload file through ipfs cluster, register its cid in content registry contract, and query the on-chain record of cid.",
+	Subcommands: []cli.Command{
+		{
+			Action:    dataUpload,
+			Name:      "upload",
+			Usage:     "Upload file and register its cid",
+			ArgsUsage: "<file>",
+			Description: `Upload file to ipfs, pin it by ipfs cluster unless --nopin is set, and register the cid, size and uploader of file
+in content registry contract. If account does not specified, using default account`,
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+				utils.TransactionGasPriceFlag,
+				utils.TransactionGasLimitFlag,
+				utils.DataNoteFlag,
+				utils.DataNoPinFlag,
+				utils.DataIPFSAddressFlag,
+				utils.DataClusterAddressFlag,
+				utils.AccountAddressFlag,
+				utils.WalletFileFlag,
+			},
+		},
+		{
+			Action:      dataShow,
+			Name:        "show",
+			Usage:       "Display on-chain record of cid",
+			ArgsUsage:   "<cid>",
+			Description: "Display the uploader, size and pin status of cid registered in content registry contract.",
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+			},
+		},
+	},
+}
+
+func dataUpload(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	if ctx.NArg() < 1 {
+		PrintErrorMsg("Missing argument. File expected.")
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	file := ctx.Args().First()
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("stat file:%s error:%s", file, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", file)
+	}
+
+	gasPrice := ctx.Uint64(utils.TransactionGasPriceFlag.Name)
+	gasLimit := ctx.Uint64(utils.TransactionGasLimitFlag.Name)
+	networkId, err := utils.GetNetworkId()
+	if err != nil {
+		return err
+	}
+	if networkId == config.NETWORK_ID_SOLO_NET {
+		gasPrice = 0
+	}
+	signer, err := cmdcom.GetAccount(ctx, ctx.String(utils.GetFlagName(utils.AccountAddressFlag)))
+	if err != nil {
+		return err
+	}
+
+	httpcom.SetIPFSHost(ctx.String(utils.GetFlagName(utils.DataIPFSAddressFlag)))
+	httpcom.SetClusterHost(ctx.String(utils.GetFlagName(utils.DataClusterAddressFlag)))
+	pinned := !ctx.Bool(utils.GetFlagName(utils.DataNoPinFlag))
+	cid, err := httpcom.AddFileIPFS(file, pinned)
+	if err != nil {
+		return fmt.Errorf("upload file to ipfs cluster error:%s", err)
+	}
+	record, err := utils.GetContentRecord(cid)
+	if err != nil {
+		return fmt.Errorf("get content record error:%s", err)
+	}
+	if record != nil {
+		PrintInfoMsg("Cid:%s has been registered by %s at height %d", cid, record.Uploader, record.Height)
+		return nil
+	}
+	note := ctx.String(utils.GetFlagName(utils.DataNoteFlag))
+	txHash, err := utils.RegisterContent(gasPrice, gasLimit, signer, cid, uint64(info.Size()), filepath.Base(file), note, pinned)
+	if err != nil {
+		return fmt.Errorf("register content error:%s", err)
+	}
+	PrintInfoMsg("Upload file:%s", file)
+	PrintInfoMsg("  Cid:%s", cid)
+	PrintInfoMsg("  Size:%d", info.Size())
+	PrintInfoMsg("  Pinned:%t", pinned)
+	PrintInfoMsg("  Uploader:%s", signer.Address.ToBase58())
+	PrintInfoMsg("  TxHash:%s", txHash)
+	PrintInfoMsg("\nTip:")
+	PrintInfoMsg("  Using './dad-go info status %s' to query transaction status.", txHash)
+	PrintInfoMsg("  Using './dad-go data show %s' to query content record.", cid)
+	return nil
+}
+
+func dataShow(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	if ctx.NArg() < 1 {
+		PrintErrorMsg("Missing argument. Cid expected.")
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	cid := ctx.Args().First()
+	record, err := utils.GetContentRecord(cid)
+	if err != nil {
+		return fmt.Errorf("get content record error:%s", err)
+	}
+	if record == nil {
+		return fmt.Errorf("cid:%s is not registered", cid)
+	}
+	PrintInfoMsg("Cid:%s", record.Cid)
+	PrintInfoMsg("  Size:%d", record.Size)
+	PrintInfoMsg("  Uploader:%s", record.Uploader)
+	PrintInfoMsg("  FileName:%s", record.FileName)
+	PrintInfoMsg("  Note:%s", record.Note)
+	PrintInfoMsg("  Pinned:%t", record.Pinned)
+	PrintInfoMsg("  Height:%d", record.Height)
+	return nil
+}
@@ -0,0 +1,516 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	cmdcom "github.com/ontio/dad-go/cmd/common"
+	"github.com/ontio/dad-go/cmd/utils"
+	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/common/config"
+	"github.com/ontio/dad-go/core/types"
+	"github.com/ontio/dad-go/smartcontract/service/native/governance"
+	"github.com/urfave/cli"
+)
+
+var governanceTxFlags = []cli.Flag{
+	utils.RPCPortFlag,
+	utils.TransactionGasPriceFlag,
+	utils.TransactionGasLimitFlag,
+	utils.AccountAddressFlag,
+	utils.WalletFileFlag,
+	utils.GovernanceBuildTxFlag,
+	utils.TransactionPayerFlag,
+}
+
+var GovernanceCommand = cli.Command{
+	Name:  "governance",
+	Usage: "Handle consensus governance",
+	Description: `Governance commands can register candidate, authorize or withdraw pos, quit node, set peer cost,
+withdraw fee, and query the storage of governance contract.
+Write commands sign the transaction with the account and send it by default. If the peer owner is a
+multi-signature address, using --buildtx to build the raw transaction, then signing it by multisigtx
+and sending it by sendtx.`,
+	Subcommands: []cli.Command{
+		{
+			Action:      governanceRegisterCandidate,
+			Name:        "registercandidate",
+			Usage:       "Register peer as consensus candidate",
+			ArgsUsage:   " ",
+			Description: "Register peer as consensus candidate with init pos, the candidate can join consensus after being approved. If account does not specified, using default account",
+			Flags: append([]cli.Flag{
+				utils.GovernancePeerPubkeyFlag,
+				utils.GovernanceInitPosFlag,
+				utils.GovernanceOntIdFlag,
+				utils.GovernanceKeyNoFlag,
+			}, governanceTxFlags...),
+		},
+		{
+			Action:      governanceQuitNode,
+			Name:        "quitnode",
+			Usage:       "Quit peer from candidates or consensus nodes",
+			ArgsUsage:   " ",
+			Description: "Quit peer from candidates or consensus nodes, the init pos can be withdrawn after quitting. If account does not specified, using default account",
+			Flags: append([]cli.Flag{
+				utils.GovernancePeerPubkeyFlag,
+			}, governanceTxFlags...),
+		},
+		{
+			Action:      governanceAuthorize,
+			Name:        "authorize",
+			Usage:       "Authorize pos to peers",
+			ArgsUsage:   " ",
+			Description: "Authorize pos to peers to share their incomes. If account does not specified, using default account",
+			Flags: append([]cli.Flag{
+				utils.GovernancePeerPubkeyFlag,
+				utils.GovernancePosFlag,
+			}, governanceTxFlags...),
+		},
+		{
+			Action:      governanceWithdraw,
+			Name:        "withdraw",
+			Usage:       "Withdraw unfrozen pos from peers",
+			ArgsUsage:   " ",
+			Description: "Withdraw unfrozen pos from peers. If account does not specified, using default account",
+			Flags: append([]cli.Flag{
+				utils.GovernancePeerPubkeyFlag,
+				utils.GovernancePosFlag,
+			}, governanceTxFlags...),
+		},
+		{
+			Action:      governanceSetPeerCost,
+			Name:        "setpeercost",
+			Usage:       "Set the income percentage peer keeps",
+			ArgsUsage:   " ",
+			Description: "Set the income percentage peer keeps for itself, it takes effect after two views. If account does not specified, using default account",
+			Flags: append([]cli.Flag{
+				utils.GovernancePeerPubkeyFlag,
+				utils.GovernancePeerCostFlag,
+			}, governanceTxFlags...),
+		},
+		{
+			Action:      governanceWithdrawFee,
+			Name:        "withdrawfee",
+			Usage:       "Withdraw split fee",
+			ArgsUsage:   " ",
+			Description: "Withdraw the split fee of account. If account does not specified, using default account",
+			Flags:       governanceTxFlags,
+		},
+		{
+			Action:      governancePeerPool,
+			Name:        "peerpool",
+			Usage:       "Show peers of current view",
+			ArgsUsage:   "[<peerpubkey>]",
+			Description: "Show all the peers of current view, or the peer of public key",
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+			},
+		},
+		{
+			Action:      governanceAuthorizeInfo,
+			Name:        "authorizeinfo",
+			Usage:       "Show pos authorized to peer",
+			ArgsUsage:   "<peerpubkey> <address|label|index>",
+			Description: "Show pos authorized by address to peer",
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+				utils.WalletFileFlag,
+			},
+		},
+		{
+			Action:      governanceSplitFee,
+			Name:        "splitfee",
+			Usage:       "Show split fee of address",
+			ArgsUsage:   "<address|label|index>",
+			Description: "Show split fee of address which can be withdrawn",
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+				utils.WalletFileFlag,
+			},
+		},
+		{
+			Action:      governancePromisePos,
+			Name:        "promisepos",
+			Usage:       "Show promise pos of peer",
+			ArgsUsage:   "<peerpubkey>",
+			Description: "Show promise pos of peer",
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+			},
+		},
+	},
+}
+
+func governanceRegisterCandidate(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	peerPubkey := ctx.String(utils.GetFlagName(utils.GovernancePeerPubkeyFlag))
+	ontId := ctx.String(utils.GetFlagName(utils.GovernanceOntIdFlag))
+	if peerPubkey == "" || ontId == "" || !ctx.IsSet(utils.GetFlagName(utils.GovernanceInitPosFlag)) {
+		PrintErrorMsg("Missing %s %s or %s argument.", utils.GovernancePeerPubkeyFlag.Name, utils.GovernanceInitPosFlag.Name,
+			utils.GovernanceOntIdFlag.Name)
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	address, err := getGovernanceAddress(ctx)
+	if err != nil {
+		return err
+	}
+	initPos := uint32(ctx.Uint(utils.GetFlagName(utils.GovernanceInitPosFlag)))
+	keyNo := uint32(ctx.Uint(utils.GetFlagName(utils.GovernanceKeyNoFlag)))
+	gasPrice, gasLimit, err := getGovernanceGas(ctx)
+	if err != nil {
+		return err
+	}
+	tx := utils.RegisterCandidateTx(gasPrice, gasLimit, peerPubkey, address, initPos, ontId, keyNo)
+	return sendGovernanceTx(ctx, "Register candidate", address, tx)
+}
+
+func governanceQuitNode(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	peerPubkey := ctx.String(utils.GetFlagName(utils.GovernancePeerPubkeyFlag))
+	if peerPubkey == "" {
+		PrintErrorMsg("Missing %s argument.", utils.GovernancePeerPubkeyFlag.Name)
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	address, err := getGovernanceAddress(ctx)
+	if err != nil {
+		return err
+	}
+	gasPrice, gasLimit, err := getGovernanceGas(ctx)
+	if err != nil {
+		return err
+	}
+	tx := utils.QuitNodeTx(gasPrice, gasLimit, peerPubkey, address)
+	return sendGovernanceTx(ctx, "Quit node", address, tx)
+}
+
+func governanceAuthorize(ctx *cli.Context) error {
+	return governanceAuthorizeOrWithdraw(ctx, true)
+}
+
+func governanceWithdraw(ctx *cli.Context) error {
+	return governanceAuthorizeOrWithdraw(ctx, false)
+}
+
+func governanceAuthorizeOrWithdraw(ctx *cli.Context, authorize bool) error {
+	SetRpcPort(ctx)
+	peerPubkeys := ctx.String(utils.GetFlagName(utils.GovernancePeerPubkeyFlag))
+	posStr := ctx.String(utils.GetFlagName(utils.GovernancePosFlag))
+	if peerPubkeys == "" || posStr == "" {
+		PrintErrorMsg("Missing %s or %s argument.", utils.GovernancePeerPubkeyFlag.Name, utils.GovernancePosFlag.Name)
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	peerPubkeyList := splitGovernanceList(peerPubkeys)
+	posList := make([]uint32, 0)
+	for _, v := range splitGovernanceList(posStr) {
+		pos, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid pos:%s", v)
+		}
+		posList = append(posList, uint32(pos))
+	}
+	if len(peerPubkeyList) != len(posList) {
+		return fmt.Errorf("count of %s:%d doesn't match count of %s:%d", utils.GovernancePeerPubkeyFlag.Name,
+			len(peerPubkeyList), utils.GovernancePosFlag.Name, len(posList))
+	}
+	address, err := getGovernanceAddress(ctx)
+	if err != nil {
+		return err
+	}
+	gasPrice, gasLimit, err := getGovernanceGas(ctx)
+	if err != nil {
+		return err
+	}
+	if authorize {
+		tx, err := utils.AuthorizeForPeerTx(gasPrice, gasLimit, address, peerPubkeyList, posList)
+		if err != nil {
+			return err
+		}
+		return sendGovernanceTx(ctx, "Authorize for peer", address, tx)
+	}
+	tx, err := utils.WithdrawTx(gasPrice, gasLimit, address, peerPubkeyList, posList)
+	if err != nil {
+		return err
+	}
+	return sendGovernanceTx(ctx, "Withdraw", address, tx)
+}
+
+func governanceSetPeerCost(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	peerPubkey := ctx.String(utils.GetFlagName(utils.GovernancePeerPubkeyFlag))
+	if peerPubkey == "" || !ctx.IsSet(utils.GetFlagName(utils.GovernancePeerCostFlag)) {
+		PrintErrorMsg("Missing %s or %s argument.", utils.GovernancePeerPubkeyFlag.Name, utils.GovernancePeerCostFlag.Name)
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	peerCost := ctx.Uint(utils.GetFlagName(utils.GovernancePeerCostFlag))
+	if peerCost > 100 {
+		return fmt.Errorf("%s should be between 0 and 100", utils.GovernancePeerCostFlag.Name)
+	}
+	address, err := getGovernanceAddress(ctx)
+	if err != nil {
+		return err
+	}
+	gasPrice, gasLimit, err := getGovernanceGas(ctx)
+	if err != nil {
+		return err
+	}
+	tx := utils.SetPeerCostTx(gasPrice, gasLimit, peerPubkey, address, uint32(peerCost))
+	return sendGovernanceTx(ctx, "Set peer cost", address, tx)
+}
+
+func governanceWithdrawFee(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	address, err := getGovernanceAddress(ctx)
+	if err != nil {
+		return err
+	}
+	gasPrice, gasLimit, err := getGovernanceGas(ctx)
+	if err != nil {
+		return err
+	}
+	tx := utils.WithdrawFeeTx(gasPrice, gasLimit, address)
+	return sendGovernanceTx(ctx, "Withdraw fee", address, tx)
+}
+
+func governancePeerPool(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	view, err := utils.GetGovernanceView()
+	if err != nil {
+		return err
+	}
+	peerPoolMap, err := utils.GetPeerPoolMap(view.View)
+	if err != nil {
+		return err
+	}
+	peers := make([]*governance.PeerPoolItem, 0, len(peerPoolMap.PeerPoolMap))
+	if ctx.NArg() > 0 {
+		peer, ok := peerPoolMap.PeerPoolMap[ctx.Args().First()]
+		if !ok {
+			return fmt.Errorf("peer %s not found in view %d", ctx.Args().First(), view.View)
+		}
+		peers = append(peers, peer)
+	} else {
+		for _, peer := range peerPoolMap.PeerPoolMap {
+			peers = append(peers, peer)
+		}
+		sort.Slice(peers, func(i, j int) bool {
+			return peers[i].Index < peers[j].Index
+		})
+	}
+	PrintInfoMsg("View:%d", view.View)
+	for _, peer := range peers {
+		PrintInfoMsg("Peer:%d", peer.Index)
+		PrintInfoMsg("  PeerPubkey:%s", peer.PeerPubkey)
+		PrintInfoMsg("  Address:%s", peer.Address.ToBase58())
+		PrintInfoMsg("  Status:%s", governanceStatusName(peer.Status))
+		PrintInfoMsg("  InitPos:%d", peer.InitPos)
+		PrintInfoMsg("  TotalPos:%d", peer.TotalPos)
+	}
+	return nil
+}
+
+func governanceAuthorizeInfo(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	if ctx.NArg() < 2 {
+		PrintErrorMsg("Missing peerpubkey or address argument.")
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	peerPubkey := ctx.Args().Get(0)
+	address, err := parseGovernanceAddress(ctx, ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	info, err := utils.GetAuthorizeInfo(peerPubkey, address)
+	if err != nil {
+		return err
+	}
+	PrintInfoMsg("Authorize info:")
+	PrintInfoMsg("  PeerPubkey:%s", info.PeerPubkey)
+	PrintInfoMsg("  Address:%s", info.Address.ToBase58())
+	PrintInfoMsg("  ConsensusPos:%d", info.ConsensusPos)
+	PrintInfoMsg("  CandidatePos:%d", info.CandidatePos)
+	PrintInfoMsg("  NewPos:%d", info.NewPos)
+	PrintInfoMsg("  WithdrawConsensusPos:%d", info.WithdrawConsensusPos)
+	PrintInfoMsg("  WithdrawCandidatePos:%d", info.WithdrawCandidatePos)
+	PrintInfoMsg("  WithdrawUnfreezePos:%d", info.WithdrawUnfreezePos)
+	return nil
+}
+
+func governanceSplitFee(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	if ctx.NArg() < 1 {
+		PrintErrorMsg("Missing address argument.")
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	address, err := parseGovernanceAddress(ctx, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	splitFee, err := utils.GetSplitFeeAddress(address)
+	if err != nil {
+		return err
+	}
+	PrintInfoMsg("Split fee:")
+	PrintInfoMsg("  Address:%s", splitFee.Address.ToBase58())
+	PrintInfoMsg("  Amount:%s ONG", utils.FormatOng(splitFee.Amount))
+	return nil
+}
+
+func governancePromisePos(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	if ctx.NArg() < 1 {
+		PrintErrorMsg("Missing peerpubkey argument.")
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	promisePos, err := utils.GetPromisePos(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	PrintInfoMsg("Promise pos:")
+	PrintInfoMsg("  PeerPubkey:%s", promisePos.PeerPubkey)
+	PrintInfoMsg("  PromisePos:%d", promisePos.PromisePos)
+	return nil
+}
+
+//sendGovernanceTx signs the transaction by the account of address and sends it, or prints the unsigned raw
+//transaction if buildtx flag is set
+func sendGovernanceTx(ctx *cli.Context, action string, address common.Address, tx *types.MutableTransaction) error {
+	if ctx.Bool(utils.GetFlagName(utils.GovernanceBuildTxFlag)) {
+		payer := address
+		payerAddr := ctx.String(utils.GetFlagName(utils.TransactionPayerFlag))
+		if payerAddr != "" {
+			var err error
+			payer, err = parseGovernanceAddress(ctx, payerAddr)
+			if err != nil {
+				return err
+			}
+		}
+		tx.Payer = payer
+		immut, err := tx.IntoImmutable()
+		if err != nil {
+			return fmt.Errorf("IntoImmutable error:%s", err)
+		}
+		sink := common.ZeroCopySink{}
+		immut.Serialization(&sink)
+		PrintInfoMsg("%s raw tx:", action)
+		PrintInfoMsg(hex.EncodeToString(sink.Bytes()))
+		PrintInfoMsg("\nTip:")
+		PrintInfoMsg("  Using './dad-go multisigtx' or './dad-go sigtx' to sign the transaction, and './dad-go sendtx' to send it.")
+		return nil
+	}
+	signer, err := cmdcom.GetAccount(ctx, address.ToBase58())
+	if err != nil {
+		return err
+	}
+	txHash, err := utils.InvokeSmartContract(signer, tx)
+	if err != nil {
+		return fmt.Errorf("%s error:%s", strings.ToLower(action), err)
+	}
+	PrintInfoMsg(action)
+	PrintInfoMsg("  Address:%s", address.ToBase58())
+	PrintInfoMsg("  TxHash:%s", txHash)
+	PrintInfoMsg("\nTip:")
+	PrintInfoMsg("  Using './dad-go info status %s' to query transaction status.", txHash)
+	return nil
+}
+
+//getGovernanceAddress return the address of account flag, which is the default account if the flag is not set
+func getGovernanceAddress(ctx *cli.Context) (common.Address, error) {
+	address := ctx.String(utils.GetFlagName(utils.AccountAddressFlag))
+	if address == "" {
+		if ctx.Bool(utils.GetFlagName(utils.GovernanceBuildTxFlag)) {
+			return common.ADDRESS_EMPTY, fmt.Errorf("missing %s argument", utils.AccountAddressFlag.Name)
+		}
+		wallet, err := cmdcom.OpenWallet(ctx)
+		if err != nil {
+			return common.ADDRESS_EMPTY, err
+		}
+		acc := wallet.GetDefaultAccountMetadata()
+		if acc == nil {
+			return common.ADDRESS_EMPTY, fmt.Errorf("cannot get default account")
+		}
+		address = acc.Address
+	}
+	return parseGovernanceAddress(ctx, address)
+}
+
+func parseGovernanceAddress(ctx *cli.Context, address string) (common.Address, error) {
+	addr, err := cmdcom.ParseAddress(address, ctx)
+	if err != nil {
+		return common.ADDRESS_EMPTY, err
+	}
+	return common.AddressFromBase58(addr)
+}
+
+func getGovernanceGas(ctx *cli.Context) (uint64, uint64, error) {
+	gasPrice := ctx.Uint64(utils.TransactionGasPriceFlag.Name)
+	gasLimit := ctx.Uint64(utils.TransactionGasLimitFlag.Name)
+	if ctx.Bool(utils.GetFlagName(utils.GovernanceBuildTxFlag)) {
+		return gasPrice, gasLimit, nil
+	}
+	networkId, err := utils.GetNetworkId()
+	if err != nil {
+		return 0, 0, err
+	}
+	if networkId == config.NETWORK_ID_SOLO_NET {
+		gasPrice = 0
+	}
+	return gasPrice, gasLimit, nil
+}
+
+func splitGovernanceList(str string) []string {
+	list := make([]string, 0)
+	for _, v := range strings.Split(str, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func governanceStatusName(status governance.Status) string {
+	switch status {
+	case governance.RegisterCandidateStatus:
+		return "RegisterCandidate"
+	case governance.CandidateStatus:
+		return "Candidate"
+	case governance.ConsensusStatus:
+		return "Consensus"
+	case governance.QuitConsensusStatus:
+		return "QuitConsensus"
+	case governance.QuitingStatus:
+		return "Quiting"
+	case governance.BlackStatus:
+		return "Black"
+	default:
+		return fmt.Sprintf("Unknown(%d)", status)
+	}
+}
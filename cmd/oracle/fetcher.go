@@ -0,0 +1,159 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package oracle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DEFAULT_FETCH_TIMEOUT = 10 * time.Second
+
+//Fetcher fetch the outcome of oracle request, request is the string set in createOracleRequest
+type Fetcher interface {
+	Fetch(request string) (string, error)
+}
+
+//HttpRequest is the request format handled by HttpFetcher, for example
+//  {"url": "https://api.example.com/price?pair=ONT-USD", "path": "$.data.price"}
+type HttpRequest struct {
+	Url  string `json:"url"`
+	Path string `json:"path"`
+}
+
+//HttpFetcher get json document from url and select the outcome by json path
+type HttpFetcher struct {
+	client *http.Client
+}
+
+func NewHttpFetcher(timeout time.Duration) *HttpFetcher {
+	return &HttpFetcher{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (this *HttpFetcher) Fetch(request string) (string, error) {
+	req := &HttpRequest{}
+	err := json.Unmarshal([]byte(request), req)
+	if err != nil {
+		return "", fmt.Errorf("invalid http request:%s", err)
+	}
+	if req.Url == "" {
+		return "", fmt.Errorf("invalid http request: missing url")
+	}
+	resp, err := this.client.Get(req.Url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("http get %s status:%d", req.Url, resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read response body error:%s", err)
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return "", fmt.Errorf("invalid json response:%s", err)
+	}
+	value, err := SelectJsonPath(doc, req.Path)
+	if err != nil {
+		return "", err
+	}
+	return formatOutcome(value)
+}
+
+//SelectJsonPath select value from json document decoded by encoding/json. Only the dot and index
+//notation is supported, such as $.data.prices[0].value
+func SelectJsonPath(doc interface{}, path string) (interface{}, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	value := doc
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			name := path[:end]
+			path = path[end:]
+			if name == "" {
+				return nil, fmt.Errorf("invalid json path: empty field name")
+			}
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("json path field %s: not an object", name)
+			}
+			value, ok = obj[name]
+			if !ok {
+				return nil, fmt.Errorf("json path field %s: not found", name)
+			}
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid json path: unclosed [")
+			}
+			index, err := strconv.Atoi(path[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid json path index %s", path[1:end])
+			}
+			path = path[end+1:]
+			arr, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("json path index %d: not an array", index)
+			}
+			if index < 0 || index >= len(arr) {
+				return nil, fmt.Errorf("json path index %d: out of range", index)
+			}
+			value = arr[index]
+		default:
+			return nil, fmt.Errorf("invalid json path at %s", path)
+		}
+	}
+	return value, nil
+}
+
+func formatOutcome(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case nil:
+		return "", fmt.Errorf("outcome is null")
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
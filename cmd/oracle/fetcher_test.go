@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package oracle
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/price" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"pair":"ONT-USD","prices":[{"value":12345678901234567890},{"value":0.5}],"ok":true}}`)
+	}))
+	defer server.Close()
+
+	fetcher := NewHttpFetcher(time.Second)
+	request := func(path string) string {
+		return fmt.Sprintf(`{"url":"%s/price","path":"%s"}`, server.URL, path)
+	}
+
+	outcome, err := fetcher.Fetch(request("$.data.pair"))
+	assert.Nil(t, err)
+	assert.Equal(t, "ONT-USD", outcome)
+
+	outcome, err = fetcher.Fetch(request("$.data.prices[0].value"))
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678901234567890", outcome)
+
+	outcome, err = fetcher.Fetch(request("$.data.prices[1].value"))
+	assert.Nil(t, err)
+	assert.Equal(t, "0.5", outcome)
+
+	outcome, err = fetcher.Fetch(request("$.data.ok"))
+	assert.Nil(t, err)
+	assert.Equal(t, "true", outcome)
+
+	_, err = fetcher.Fetch(request("$.data.prices[2].value"))
+	assert.NotNil(t, err)
+	_, err = fetcher.Fetch(request("$.data.missing"))
+	assert.NotNil(t, err)
+	_, err = fetcher.Fetch(fmt.Sprintf(`{"url":"%s/unknown","path":"$"}`, server.URL))
+	assert.NotNil(t, err)
+	_, err = fetcher.Fetch("not json")
+	assert.NotNil(t, err)
+}
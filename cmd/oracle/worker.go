@@ -0,0 +1,235 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package oracle
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/ontio/dad-go/common/log"
+)
+
+const (
+	CREATE_ORACLE_REQUEST_EVENT = "createOracleRequest"
+	WS_HEARTBEAT_INTERVAL       = 60 * time.Second
+	WS_VERSION                  = "1.0.0"
+)
+
+//Request is an oracle request waiting for outcome. TxHash is the key of request in oracle contract,
+//which is the hex string of the raw bytes of the transaction hash
+type Request struct {
+	TxHash  string
+	Request string
+}
+
+//OutcomeSubmitter send setOracleOutcome transaction and return its tx hash
+type OutcomeSubmitter func(txHash, outcome string) (string, error)
+
+//PendingLoader return the requests created before the worker subscribed
+type PendingLoader func() ([]*Request, error)
+
+//Worker subscribe the notify events of oracle contract from websocket server, fetch the outcome of
+//every new request and submit it to chain
+type Worker struct {
+	wsAddr   string
+	contract string
+	fetcher  Fetcher
+	submit   OutcomeSubmitter
+	pending  PendingLoader
+	lock     sync.Mutex
+	handled  map[string]bool
+}
+
+//NewWorker return a worker, contract is the hex string of oracle contract address used by websocket filter
+func NewWorker(wsAddr, contract string, fetcher Fetcher, submit OutcomeSubmitter, pending PendingLoader) *Worker {
+	return &Worker{
+		wsAddr:   wsAddr,
+		contract: contract,
+		fetcher:  fetcher,
+		submit:   submit,
+		pending:  pending,
+		handled:  make(map[string]bool),
+	}
+}
+
+type wsNotifyEvent struct {
+	ContractAddress string
+	States          json.RawMessage
+}
+
+type wsMessage struct {
+	Action string
+	Error  int64
+	Desc   string
+	Result json.RawMessage
+}
+
+type wsExecuteNotify struct {
+	TxHash string
+	State  byte
+	Notify []wsNotifyEvent
+}
+
+//Run subscribe oracle events and handle requests until stop is closed or connection is broken
+func (this *Worker) Run(stop <-chan struct{}) error {
+	conn, _, err := websocket.DefaultDialer.Dial(this.wsAddr, nil)
+	if err != nil {
+		return fmt.Errorf("dial %s error:%s", this.wsAddr, err)
+	}
+	defer conn.Close()
+
+	var writeLock sync.Mutex
+	send := func(msg map[string]interface{}) error {
+		writeLock.Lock()
+		defer writeLock.Unlock()
+		return conn.WriteJSON(msg)
+	}
+	err = send(map[string]interface{}{
+		"Action":          "subscribe",
+		"Version":         WS_VERSION,
+		"SubscribeEvent":  true,
+		"ContractsFilter": []string{this.contract},
+	})
+	if err != nil {
+		return fmt.Errorf("subscribe error:%s", err)
+	}
+
+	//requests created before subscription
+	if this.pending != nil {
+		requests, err := this.pending()
+		if err != nil {
+			return fmt.Errorf("load pending requests error:%s", err)
+		}
+		for _, req := range requests {
+			this.HandleRequest(req)
+		}
+	}
+
+	msgs := make(chan []byte)
+	readErr := make(chan error, 1)
+	quit := make(chan struct{})
+	defer close(quit)
+	go func() {
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case msgs <- data:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(WS_HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case data := <-msgs:
+			this.handleMessage(data)
+		case <-heartbeat.C:
+			err := send(map[string]interface{}{"Action": "heartbeat", "Version": WS_VERSION})
+			if err != nil {
+				return fmt.Errorf("heartbeat error:%s", err)
+			}
+		case err := <-readErr:
+			return fmt.Errorf("read message error:%s", err)
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+func (this *Worker) handleMessage(data []byte) {
+	msg := &wsMessage{}
+	if err := json.Unmarshal(data, msg); err != nil {
+		log.Warnf("oracle worker: invalid message %s", data)
+		return
+	}
+	if msg.Error != 0 {
+		log.Warnf("oracle worker: action %s error:%d %s", msg.Action, msg.Error, msg.Desc)
+		return
+	}
+	if msg.Action != "Notify" {
+		return
+	}
+	notify := &wsExecuteNotify{}
+	if err := json.Unmarshal(msg.Result, notify); err != nil {
+		log.Warnf("oracle worker: invalid notify %s", msg.Result)
+		return
+	}
+	//failed transaction
+	if notify.State == 0 {
+		return
+	}
+	for _, evt := range notify.Notify {
+		if evt.ContractAddress != this.contract {
+			continue
+		}
+		states := make(map[string]interface{})
+		if err := json.Unmarshal(evt.States, &states); err != nil {
+			continue
+		}
+		name, _ := states["event"].(string)
+		if name != CREATE_ORACLE_REQUEST_EVENT {
+			continue
+		}
+		txHash, _ := states["txHash"].(string)
+		request, _ := states["request"].(string)
+		this.HandleRequest(&Request{TxHash: txHash, Request: request})
+	}
+}
+
+//HandleRequest fetch the outcome of request and submit it. A request is handled once, failed ones are
+//retried when they are loaded as pending again after reconnection
+func (this *Worker) HandleRequest(req *Request) {
+	this.lock.Lock()
+	if this.handled[req.TxHash] {
+		this.lock.Unlock()
+		return
+	}
+	this.handled[req.TxHash] = true
+	this.lock.Unlock()
+
+	outcome, err := this.fetcher.Fetch(req.Request)
+	if err != nil {
+		log.Errorf("oracle worker: fetch request %s error:%s", req.TxHash, err)
+		this.unmark(req.TxHash)
+		return
+	}
+	txHash, err := this.submit(req.TxHash, outcome)
+	if err != nil {
+		log.Errorf("oracle worker: submit outcome of request %s error:%s", req.TxHash, err)
+		this.unmark(req.TxHash)
+		return
+	}
+	log.Infof("oracle worker: request %s outcome %s submitted in tx %s", req.TxHash, outcome, txHash)
+}
+
+func (this *Worker) unmark(txHash string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.handled, txHash)
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package oracle
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+const testContract = "000000000000000000000000000000000000000b"
+
+type mapFetcher map[string]string
+
+func (this mapFetcher) Fetch(request string) (string, error) {
+	outcome, ok := this[request]
+	if !ok {
+		return "", fmt.Errorf("unknown request %s", request)
+	}
+	return outcome, nil
+}
+
+type outcomeRecorder struct {
+	lock     sync.Mutex
+	outcomes map[string]string
+}
+
+func (this *outcomeRecorder) submit(txHash, outcome string) (string, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.outcomes[txHash] = outcome
+	return "tx" + txHash, nil
+}
+
+func (this *outcomeRecorder) get(txHash string) (string, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	outcome, ok := this.outcomes[txHash]
+	return outcome, ok
+}
+
+func notifyMessage(contract, event, txHash, request string) string {
+	return fmt.Sprintf(`{"Action":"Notify","Error":0,"Desc":"SUCCESS","Result":{"TxHash":"aa","State":1,"GasConsumed":0,`+
+		`"Notify":[{"ContractAddress":"%s","States":{"event":"%s","txHash":"%s","request":"%s"}}]}}`,
+		contract, event, txHash, request)
+}
+
+func TestWorkerHandleMessage(t *testing.T) {
+	recorder := &outcomeRecorder{outcomes: make(map[string]string)}
+	worker := NewWorker("", testContract, mapFetcher{"btc": "100", "eth": "10"}, recorder.submit, nil)
+
+	worker.handleMessage([]byte(notifyMessage(testContract, CREATE_ORACLE_REQUEST_EVENT, "01", "btc")))
+	worker.handleMessage([]byte(notifyMessage(testContract, "setOracleOutcome", "02", "eth")))
+	worker.handleMessage([]byte(notifyMessage("0000000000000000000000000000000000000001", CREATE_ORACLE_REQUEST_EVENT, "03", "eth")))
+	worker.handleMessage([]byte(notifyMessage(testContract, CREATE_ORACLE_REQUEST_EVENT, "04", "unknown")))
+	worker.handleMessage([]byte("invalid"))
+
+	outcome, ok := recorder.get("01")
+	assert.True(t, ok)
+	assert.Equal(t, "100", outcome)
+	for _, txHash := range []string{"02", "03", "04"} {
+		_, ok := recorder.get(txHash)
+		assert.False(t, ok)
+	}
+
+	//handled request is not submitted again, failed one can be retried
+	recorder.outcomes = make(map[string]string)
+	worker.HandleRequest(&Request{TxHash: "01", Request: "btc"})
+	_, ok = recorder.get("01")
+	assert.False(t, ok)
+	worker.fetcher = mapFetcher{"unknown": "1"}
+	worker.HandleRequest(&Request{TxHash: "04", Request: "unknown"})
+	outcome, ok = recorder.get("04")
+	assert.True(t, ok)
+	assert.Equal(t, "1", outcome)
+}
+
+func TestWorkerRun(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	subscribed := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		subscribed <- string(data)
+		conn.WriteMessage(websocket.TextMessage, []byte(notifyMessage(testContract, CREATE_ORACLE_REQUEST_EVENT, "02", "eth")))
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	recorder := &outcomeRecorder{outcomes: make(map[string]string)}
+	pending := func() ([]*Request, error) {
+		return []*Request{{TxHash: "01", Request: "btc"}}, nil
+	}
+	wsAddr := "ws" + strings.TrimPrefix(server.URL, "http")
+	worker := NewWorker(wsAddr, testContract, mapFetcher{"btc": "100", "eth": "10"}, recorder.submit, pending)
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- worker.Run(stop)
+	}()
+
+	select {
+	case msg := <-subscribed:
+		assert.True(t, strings.Contains(msg, `"Action":"subscribe"`))
+		assert.True(t, strings.Contains(msg, testContract))
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscribe timeout")
+	}
+	for i := 0; i < 50; i++ {
+		if _, ok := recorder.get("02"); ok {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	close(stop)
+	assert.Nil(t, <-done)
+
+	outcome, ok := recorder.get("01")
+	assert.True(t, ok)
+	assert.Equal(t, "100", outcome)
+	outcome, ok = recorder.get("02")
+	assert.True(t, ok)
+	assert.Equal(t, "10", outcome)
+}
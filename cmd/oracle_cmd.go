@@ -0,0 +1,228 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ontio/dad-go/account"
+	cmdcom "github.com/ontio/dad-go/cmd/common"
+	"github.com/ontio/dad-go/cmd/oracle"
+	"github.com/ontio/dad-go/cmd/utils"
+	"github.com/ontio/dad-go/common/config"
+	"github.com/ontio/dad-go/common/log"
+	nutils "github.com/ontio/dad-go/smartcontract/service/native/utils"
+	"github.com/urfave/cli"
+)
+
+const ORACLE_RECONNECT_INTERVAL = 5 * time.Second
+
+var OracleCommand = cli.Command{
+	Name:        "oracle",
+	Usage:       "Run oracle node",
+	Description: "Oracle commands can register or quit oracle node, and run the worker which fetches data of oracle requests and submits the outcomes.",
+	Subcommands: []cli.Command{
+		{
+			Action:      oracleRegister,
+			Name:        "register",
+			Usage:       "Register account as oracle node",
+			ArgsUsage:   " ",
+			Description: "Register account as oracle node with guaranty, the node can work after being approved. If account does not specified, using default account",
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+				utils.TransactionGasPriceFlag,
+				utils.TransactionGasLimitFlag,
+				utils.OracleGuarantyFlag,
+				utils.AccountAddressFlag,
+				utils.WalletFileFlag,
+			},
+		},
+		{
+			Action:      oracleQuit,
+			Name:        "quit",
+			Usage:       "Quit oracle node",
+			ArgsUsage:   " ",
+			Description: "Quit account from oracle nodes and take back its guaranty. If account does not specified, using default account",
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+				utils.TransactionGasPriceFlag,
+				utils.TransactionGasLimitFlag,
+				utils.AccountAddressFlag,
+				utils.WalletFileFlag,
+			},
+		},
+		{
+			Action:    oracleRun,
+			Name:      "run",
+			Usage:     "Run oracle worker",
+			ArgsUsage: " ",
+			Description: `Run oracle worker, which subscribes the events of oracle contract from websocket server,
+fetches the data of oracle requests and submits the outcomes signed by account.
+The request of oracle should be in format of {"url":"<http url>","path":"<json path>"}, for example
+  {"url":"https://api.example.com/price?pair=ONT-USD","path":"$.data.price"}`,
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+				utils.WsPortFlag,
+				utils.TransactionGasPriceFlag,
+				utils.TransactionGasLimitFlag,
+				utils.OracleFetchTimeoutFlag,
+				utils.AccountAddressFlag,
+				utils.WalletFileFlag,
+			},
+		},
+	},
+}
+
+func oracleRegister(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	gasPrice, gasLimit, err := getOracleGas(ctx)
+	if err != nil {
+		return err
+	}
+	signer, err := cmdcom.GetAccount(ctx, ctx.String(utils.GetFlagName(utils.AccountAddressFlag)))
+	if err != nil {
+		return err
+	}
+	guaranty := ctx.Uint64(utils.GetFlagName(utils.OracleGuarantyFlag))
+	txHash, err := utils.RegisterOracleNode(gasPrice, gasLimit, signer, guaranty)
+	if err != nil {
+		return fmt.Errorf("register oracle node error:%s", err)
+	}
+	PrintInfoMsg("Register oracle node")
+	PrintInfoMsg("  Address:%s", signer.Address.ToBase58())
+	PrintInfoMsg("  Guaranty:%d", guaranty)
+	PrintInfoMsg("  TxHash:%s", txHash)
+	PrintInfoMsg("\nTip:")
+	PrintInfoMsg("  Using './dad-go info status %s' to query transaction status.", txHash)
+	return nil
+}
+
+func oracleQuit(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	gasPrice, gasLimit, err := getOracleGas(ctx)
+	if err != nil {
+		return err
+	}
+	signer, err := cmdcom.GetAccount(ctx, ctx.String(utils.GetFlagName(utils.AccountAddressFlag)))
+	if err != nil {
+		return err
+	}
+	txHash, err := utils.QuitOracleNode(gasPrice, gasLimit, signer)
+	if err != nil {
+		return fmt.Errorf("quit oracle node error:%s", err)
+	}
+	PrintInfoMsg("Quit oracle node")
+	PrintInfoMsg("  Address:%s", signer.Address.ToBase58())
+	PrintInfoMsg("  TxHash:%s", txHash)
+	PrintInfoMsg("\nTip:")
+	PrintInfoMsg("  Using './dad-go info status %s' to query transaction status.", txHash)
+	return nil
+}
+
+func oracleRun(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	gasPrice, gasLimit, err := getOracleGas(ctx)
+	if err != nil {
+		return err
+	}
+	signer, err := cmdcom.GetAccount(ctx, ctx.String(utils.GetFlagName(utils.AccountAddressFlag)))
+	if err != nil {
+		return err
+	}
+	wsPort := config.DefConfig.Ws.HttpWsPort
+	if ctx.IsSet(utils.GetFlagName(utils.WsPortFlag)) {
+		wsPort = ctx.Uint(utils.GetFlagName(utils.WsPortFlag))
+	}
+	wsAddr := fmt.Sprintf("ws://localhost:%d", wsPort)
+	timeout := time.Duration(ctx.Uint(utils.GetFlagName(utils.OracleFetchTimeoutFlag))) * time.Second
+
+	worker := oracle.NewWorker(wsAddr,
+		nutils.OracleContractAddress.ToHexString(),
+		oracle.NewHttpFetcher(timeout),
+		newOracleOutcomeSubmitter(gasPrice, gasLimit, signer),
+		loadPendingOracleRequests)
+
+	stop := make(chan struct{})
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sc
+		close(stop)
+	}()
+
+	PrintInfoMsg("Oracle worker of %s is running, connecting to %s", signer.Address.ToBase58(), wsAddr)
+	for {
+		err := worker.Run(stop)
+		select {
+		case <-stop:
+			PrintInfoMsg("Oracle worker exit")
+			return nil
+		default:
+		}
+		log.Errorf("oracle worker error:%s, reconnect after %s", err, ORACLE_RECONNECT_INTERVAL)
+		select {
+		case <-stop:
+			PrintInfoMsg("Oracle worker exit")
+			return nil
+		case <-time.After(ORACLE_RECONNECT_INTERVAL):
+		}
+	}
+}
+
+func getOracleGas(ctx *cli.Context) (uint64, uint64, error) {
+	gasPrice := ctx.Uint64(utils.TransactionGasPriceFlag.Name)
+	gasLimit := ctx.Uint64(utils.TransactionGasLimitFlag.Name)
+	networkId, err := utils.GetNetworkId()
+	if err != nil {
+		return 0, 0, err
+	}
+	if networkId == config.NETWORK_ID_SOLO_NET {
+		gasPrice = 0
+	}
+	return gasPrice, gasLimit, nil
+}
+
+func newOracleOutcomeSubmitter(gasPrice, gasLimit uint64, signer *account.Account) oracle.OutcomeSubmitter {
+	return func(txHash, outcome string) (string, error) {
+		return utils.SetOracleOutcome(gasPrice, gasLimit, signer, txHash, outcome)
+	}
+}
+
+func loadPendingOracleRequests() ([]*oracle.Request, error) {
+	requests, err := utils.GetPendingOracleRequests()
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]*oracle.Request, 0, len(requests))
+	for _, req := range requests {
+		if req.Status != "pending" {
+			continue
+		}
+		key, err := utils.OracleRequestKey(req.TxHash)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, &oracle.Request{TxHash: key, Request: req.Request})
+	}
+	return pending, nil
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ontio/dad-go/account"
+	cmdcom "github.com/ontio/dad-go/cmd/common"
+	"github.com/ontio/dad-go/cmd/utils"
+	"github.com/ontio/dad-go/core/types"
+	"github.com/urfave/cli"
+)
+
+var PartialSignedTxCommand = cli.Command{
+	Name:      "psbt",
+	Usage:     "Manage partial signed transaction of multi signature account",
+	ArgsUsage: "[arguments...]",
+	Description: `Partial signed transaction file is created by 'asset transfer' or 'contract invoke' from a multi signature account of wallet.
+The file records which members have signed, and can be passed around members until the number of signatures reaches M.`,
+	Action: cli.ShowSubcommandHelp,
+	Subcommands: []cli.Command{
+		{
+			Action:    showPartialSignedTx,
+			Name:      "show",
+			Usage:     "Show signing status of partial signed transaction",
+			ArgsUsage: "<file>",
+		},
+		{
+			Action:    signPartialSignedTx,
+			Name:      "sign",
+			Usage:     "Sign to partial signed transaction",
+			ArgsUsage: "<file>",
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+				utils.WalletFileFlag,
+				utils.AccountAddressFlag,
+				utils.SendTxFlag,
+			},
+			Description: "Sign to partial signed transaction by member account, and save signature to the file. With --send flag, transaction will be sent when signatures are enough.",
+		},
+		{
+			Action:    sendPartialSignedTx,
+			Name:      "send",
+			Usage:     "Send partial signed transaction whose signatures are enough",
+			ArgsUsage: "<file>",
+			Flags: []cli.Flag{
+				utils.RPCPortFlag,
+			},
+		},
+	},
+}
+
+func showPartialSignedTx(ctx *cli.Context) error {
+	if ctx.NArg() < 1 {
+		PrintErrorMsg("Missing <file> argument.")
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	ptx, err := utils.LoadPartialSignedTx(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	printPartialSignedTx(ptx)
+	return nil
+}
+
+func signPartialSignedTx(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	if ctx.NArg() < 1 {
+		PrintErrorMsg("Missing <file> argument.")
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	file := ctx.Args().First()
+	ptx, err := utils.LoadPartialSignedTx(file)
+	if err != nil {
+		return err
+	}
+	acc, err := cmdcom.GetAccount(ctx)
+	if err != nil {
+		return fmt.Errorf("GetAccount error:%s", err)
+	}
+	err = ptx.Sign(acc)
+	if err != nil {
+		return err
+	}
+	err = ptx.Save(file)
+	if err != nil {
+		return fmt.Errorf("save %s error:%s", file, err)
+	}
+	PrintInfoMsg("Sign to %s by %s successfully.", file, acc.Address.ToBase58())
+	printPartialSignedTx(ptx)
+	if !ctx.IsSet(utils.GetFlagName(utils.SendTxFlag)) || !ptx.IsComplete() {
+		return nil
+	}
+	return sendFinalizedTx(ptx)
+}
+
+func sendPartialSignedTx(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	if ctx.NArg() < 1 {
+		PrintErrorMsg("Missing <file> argument.")
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	ptx, err := utils.LoadPartialSignedTx(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	return sendFinalizedTx(ptx)
+}
+
+func sendFinalizedTx(ptx *utils.PartialSignedTx) error {
+	tx, err := ptx.Finalize()
+	if err != nil {
+		return err
+	}
+	txHash, err := utils.SendRawTransaction(tx)
+	if err != nil {
+		return fmt.Errorf("SendTransaction error:%s", err)
+	}
+	PrintInfoMsg("Send transaction success.")
+	PrintInfoMsg("  TxHash:%s", txHash)
+	PrintInfoMsg("\nTip:")
+	PrintInfoMsg("  Using './dad-go info status %s' to query transaction status.", txHash)
+	return nil
+}
+
+func printPartialSignedTx(ptx *utils.PartialSignedTx) {
+	PrintInfoMsg("TxHash:%s", ptx.TxHash)
+	PrintInfoMsg("MultiSigAddress:%s", ptx.Address)
+	PrintInfoMsg("Signed:%d/%d", ptx.SignedCount(), ptx.M)
+	for i, signer := range ptx.Signers {
+		signed := "not signed"
+		if signer.Signature != "" {
+			signed = "signed"
+		}
+		PrintInfoMsg("  Index:%d Label:%s PubKey:%s %s", i+1, signer.Label, signer.PubKey, signed)
+	}
+}
+
+//getMultiSigAccount return multi signature account in wallet by address or label, nil if not found
+func getMultiSigAccount(ctx *cli.Context, address string) *account.MultiSigAccountData {
+	wallet, err := cmdcom.OpenWallet(ctx)
+	if err != nil {
+		return nil
+	}
+	return wallet.GetMultiSigAccount(address)
+}
+
+//signMultiSigTx sign the transaction of multi signature account by a member in wallet.
+//The transaction is sent if signatures are enough, otherwise saved to partial signed tx file
+//for other members to sign, and the returned tx hash is empty.
+func signMultiSigTx(ctx *cli.Context, msAcc *account.MultiSigAccountData, mutTx *types.MutableTransaction) (string, error) {
+	ptx, err := utils.NewPartialSignedTx(mutTx, msAcc)
+	if err != nil {
+		return "", err
+	}
+	wallet, err := cmdcom.OpenWallet(ctx)
+	if err != nil {
+		return "", err
+	}
+	signerAddr := ctx.String(utils.GetFlagName(utils.MultiSigSignerFlag))
+	if signerAddr == "" {
+		pubKeys, err := msAcc.GetPubKeys()
+		if err != nil {
+			return "", err
+		}
+		for _, pubKey := range pubKeys {
+			addr := types.AddressFromPubKey(pubKey).ToBase58()
+			if wallet.GetAccountMetadataByAddress(addr) != nil {
+				signerAddr = addr
+				break
+			}
+		}
+		if signerAddr == "" {
+			return "", fmt.Errorf("no member of %s in wallet", msAcc.Address)
+		}
+	}
+	signer, err := cmdcom.GetAccount(ctx, signerAddr)
+	if err != nil {
+		return "", err
+	}
+	err = ptx.Sign(signer)
+	if err != nil {
+		return "", err
+	}
+	if ptx.IsComplete() {
+		tx, err := ptx.Finalize()
+		if err != nil {
+			return "", err
+		}
+		return utils.SendRawTransaction(tx)
+	}
+
+	file := ctx.String(utils.GetFlagName(utils.PartialSignedTxFileFlag))
+	if file == "" {
+		file = ptx.TxHash + ".psbt"
+	}
+	err = ptx.Save(file)
+	if err != nil {
+		return "", fmt.Errorf("save %s error:%s", file, err)
+	}
+	PrintInfoMsg("Partial signed transaction saved to %s", file)
+	printPartialSignedTx(ptx)
+	PrintInfoMsg("\nTip:")
+	PrintInfoMsg("  Using './dad-go psbt sign %s' by other members to sign the transaction.", file)
+	return "", nil
+}
@@ -0,0 +1,445 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package sandbox provides an in-memory ledger to deploy and invoke contracts locally
+package sandbox
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ontio/ontology-crypto/keypair"
+	"github.com/ontio/ontology/account"
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/core/genesis"
+	"github.com/ontio/ontology/core/payload"
+	"github.com/ontio/ontology/core/store/leveldbstore"
+	"github.com/ontio/ontology/core/store/overlaydb"
+	"github.com/ontio/ontology/core/types"
+	"github.com/ontio/ontology/smartcontract"
+	"github.com/ontio/ontology/smartcontract/event"
+	_ "github.com/ontio/ontology/smartcontract/service/native/init"
+	"github.com/ontio/ontology/smartcontract/service/native/ont"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+	"github.com/ontio/ontology/smartcontract/service/neovm"
+	"github.com/ontio/ontology/smartcontract/service/wasmvm"
+	"github.com/ontio/ontology/smartcontract/storage"
+	vmtypes "github.com/ontio/ontology/vm/neovm/types"
+)
+
+// ExecuteResult is the outcome of a transaction executed in sandbox
+type ExecuteResult struct {
+	TxHash common.Uint256
+	State  byte
+	Gas    uint64
+	Result interface{} // hex string of neovm stack item or wasm return bytes
+	Notify []*event.NotifyEventInfo
+	Error  error
+}
+
+type snapshot struct {
+	writeSet  *overlaydb.MemDB
+	blocks    int
+	pending   []*types.Transaction
+	timestamp uint32
+}
+
+// Sandbox is a local ledger with the genesis native contracts. All the states are kept in the write set of
+// an overlay db over an empty memory store, so that they can be snapshotted and reverted cheaply.
+type Sandbox struct {
+	Owner     *account.Account // the solo bookkeeper, which holds the ONT distributed by genesis
+	GasLimit  uint64
+	GasPrice  uint64 // gas is charged in ONG from the payer when the transaction is committed
+	BlockTime uint32
+
+	config    *config.OntologyConfig
+	backend   *leveldbstore.LevelDBStore
+	overlay   *overlaydb.OverlayDB
+	store     *ledgerStore
+	gasTable  map[string]uint64
+	blocks    []*types.Block       // sealed blocks, indexed by height
+	pending   []*types.Transaction // transactions executed in the current block
+	timestamp uint32               // timestamp of the current block
+	signers   []common.Address
+	nonce     uint32
+	snapshots map[uint32]*snapshot
+	snapId    uint32
+}
+
+// NewSandbox creates a sandbox and executes the genesis block of a solo chain
+func NewSandbox(gasLimit, gasPrice uint64, blockTime uint32) (*Sandbox, error) {
+	backend, err := leveldbstore.NewMemLevelDBStore()
+	if err != nil {
+		return nil, fmt.Errorf("NewMemLevelDBStore error:%s", err)
+	}
+	owner := account.NewAccount("")
+	if owner == nil {
+		return nil, fmt.Errorf("create bookkeeper account failed")
+	}
+	genesisConfig := config.NewGenesisConfig()
+	genesisConfig.ConsensusType = config.CONSENSUS_TYPE_SOLO
+	genesisConfig.SOLO = &config.SOLOConfig{
+		GenBlockTime: uint(blockTime),
+		Bookkeepers:  []string{hex.EncodeToString(keypair.SerializePublicKey(owner.PublicKey))},
+	}
+	sandboxConfig := config.NewOntologyConfig()
+	sandboxConfig.Genesis = genesisConfig
+	sandboxConfig.P2PNode.NetworkId = config.NETWORK_ID_SOLO_NET
+	sandboxConfig.Common.EnableEventLog = true
+
+	self := &Sandbox{
+		Owner:     owner,
+		GasLimit:  gasLimit,
+		GasPrice:  gasPrice,
+		BlockTime: blockTime,
+		config:    sandboxConfig,
+		backend:   backend,
+		overlay:   overlaydb.NewOverlayDB(backend),
+		gasTable:  make(map[string]uint64),
+		signers:   []common.Address{owner.Address},
+		snapshots: make(map[uint32]*snapshot),
+	}
+	self.store = &ledgerStore{sandbox: self}
+	neovm.GAS_TABLE.Range(func(k, value interface{}) bool {
+		self.gasTable[k.(string)] = value.(uint64)
+		return true
+	})
+
+	restore := self.useConfig()
+	defer restore()
+	block, err := genesis.BuildGenesisBlock([]keypair.PublicKey{owner.PublicKey}, genesisConfig)
+	if err != nil {
+		return nil, fmt.Errorf("BuildGenesisBlock error:%s", err)
+	}
+	for _, tx := range block.Transactions {
+		res, err := self.execute(tx, 0, block.Header.Timestamp, math.MaxUint64, true)
+		if err != nil {
+			return nil, fmt.Errorf("execute genesis tx %s error:%s", tx.Hash().ToHexString(), err)
+		}
+		if res.Error != nil {
+			return nil, fmt.Errorf("execute genesis tx %s error:%s", tx.Hash().ToHexString(), res.Error)
+		}
+	}
+	self.blocks = append(self.blocks, block)
+	self.pending = nil
+	self.timestamp = uint32(time.Now().Unix())
+	return self, nil
+}
+
+// Height returns the height of the current block, in which the transactions are executed
+func (self *Sandbox) Height() uint32 {
+	return uint32(len(self.blocks))
+}
+
+// Timestamp returns the timestamp of the current block
+func (self *Sandbox) Timestamp() uint32 {
+	return self.timestamp
+}
+
+// SetSigners sets the addresses which pass the witness check of the following transactions,
+// the first one is also the payer
+func (self *Sandbox) SetSigners(signers []common.Address) {
+	self.signers = signers
+}
+
+func (self *Sandbox) Signers() []common.Address {
+	return self.signers
+}
+
+// Deploy deploys the code as a contract of vm type
+func (self *Sandbox) Deploy(code []byte, vmType payload.VmType, name string) (*ExecuteResult, common.Address, error) {
+	deploy, err := payload.NewDeployCode(code, vmType, name, "", "", "", "")
+	if err != nil {
+		return nil, common.ADDRESS_EMPTY, err
+	}
+	tx, err := self.newTransaction(types.Deploy, deploy)
+	if err != nil {
+		return nil, common.ADDRESS_EMPTY, err
+	}
+	res, err := self.execute(tx, self.Height(), self.timestamp, tx.GasLimit, true)
+	if err != nil {
+		return nil, common.ADDRESS_EMPTY, err
+	}
+	return res, deploy.Address(), nil
+}
+
+// Invoke executes the invoke code by vm type, the states are committed only if commit is true
+func (self *Sandbox) Invoke(code []byte, vmType payload.VmType, commit bool) (*ExecuteResult, error) {
+	txType := types.InvokeNeo
+	if vmType == payload.WASMVM_TYPE {
+		txType = types.InvokeWasm
+	}
+	tx, err := self.newTransaction(txType, &payload.InvokeCode{Code: code})
+	if err != nil {
+		return nil, err
+	}
+	return self.execute(tx, self.Height(), self.timestamp, tx.GasLimit, commit)
+}
+
+func (self *Sandbox) newTransaction(txType types.TransactionType, txPayload types.Payload) (*types.Transaction, error) {
+	self.nonce++
+	mutable := &types.MutableTransaction{
+		TxType:   txType,
+		Nonce:    self.nonce,
+		GasLimit: self.GasLimit,
+		GasPrice: self.GasPrice,
+		Payload:  txPayload,
+		Sigs:     make([]types.Sig, 0, 0),
+	}
+	if len(self.signers) > 0 {
+		mutable.Payer = self.signers[0]
+	}
+	tx, err := mutable.IntoImmutable()
+	if err != nil {
+		return nil, err
+	}
+	// witness check of sandbox is passed by the signers without signatures
+	tx.SignedAddr = append([]common.Address{}, self.signers...)
+	return tx, nil
+}
+
+func (self *Sandbox) execute(tx *types.Transaction, height, timestamp uint32, gasLimit uint64,
+	commit bool) (*ExecuteResult, error) {
+	restore := self.useConfig()
+	defer restore()
+	res := &ExecuteResult{TxHash: tx.Hash(), State: event.CONTRACT_STATE_FAIL}
+	conf := &smartcontract.Config{
+		Time:      timestamp,
+		Height:    height,
+		Tx:        tx,
+		BlockHash: self.store.GetCurrentBlockHash(),
+	}
+	cache := storage.NewCacheDB(self.overlay)
+	switch tx.TxType {
+	case types.Deploy:
+		deploy := tx.Payload.(*payload.DeployCode)
+		if deploy.VmType() == payload.WASMVM_TYPE {
+			if _, err := wasmvm.ReadWasmModule(deploy.GetRawCode(), true); err != nil {
+				return nil, err
+			}
+		}
+		dep, err := cache.GetContract(deploy.Address())
+		if err != nil {
+			return nil, err
+		}
+		if dep != nil {
+			return nil, fmt.Errorf("contract %s has been deployed", deploy.Address().ToHexString())
+		}
+		cache.PutContract(deploy)
+		res.Gas = self.gasTable[neovm.CONTRACT_CREATE_NAME] +
+			calcGasByCodeLen(len(deploy.GetRawCode()), self.gasTable[neovm.UINT_DEPLOY_CODE_LEN_NAME])
+		res.State = event.CONTRACT_STATE_SUCCESS
+	case types.InvokeNeo, types.InvokeWasm:
+		invoke := tx.Payload.(*payload.InvokeCode)
+		codeGas := calcGasByCodeLen(len(invoke.Code), self.gasTable[neovm.UINT_INVOKE_CODE_LEN_NAME])
+		if gasLimit < codeGas {
+			return nil, fmt.Errorf("gas limit insufficient, code length need gas:%d", codeGas)
+		}
+		sc := smartcontract.SmartContract{
+			Config:       conf,
+			CacheDB:      cache,
+			Store:        self.store,
+			GasTable:     self.gasTable,
+			Gas:          gasLimit - codeGas,
+			WasmExecStep: config.DEFAULT_WASM_MAX_STEPCOUNT,
+			PreExec:      !commit,
+		}
+		engine, err := sc.NewExecuteEngine(invoke.Code, tx.TxType)
+		if err != nil {
+			return nil, err
+		}
+		result, err := engine.Invoke()
+		res.Gas = gasLimit - sc.Gas
+		if res.Gas < neovm.MIN_TRANSACTION_GAS {
+			res.Gas = neovm.MIN_TRANSACTION_GAS
+		}
+		res.Notify = sc.Notifications
+		if err != nil {
+			res.Error = err
+			break
+		}
+		if tx.TxType == types.InvokeNeo {
+			if result != nil {
+				res.Result, err = result.(*vmtypes.VmValue).ConvertNeoVmValueHexString()
+				if err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			res.Result = common.ToHexString(result.([]byte))
+		}
+		res.State = event.CONTRACT_STATE_SUCCESS
+	default:
+		return nil, fmt.Errorf("unsupported transaction type:%d", tx.TxType)
+	}
+	if commit {
+		if res.State != event.CONTRACT_STATE_SUCCESS {
+			// the states of failed transaction are discarded, but the gas is still charged
+			cache = storage.NewCacheDB(self.overlay)
+		}
+		fee, overflow := common.SafeMul(res.Gas, tx.GasPrice)
+		if overflow {
+			return nil, fmt.Errorf("gas fee overflow, gas:%d gas price:%d", res.Gas, tx.GasPrice)
+		}
+		notifies, err := self.chargeGas(conf, cache, tx.Payer, fee)
+		if err != nil {
+			return nil, err
+		}
+		res.Notify = append(res.Notify, notifies...)
+		cache.Commit()
+		self.pending = append(self.pending, tx)
+	}
+	return res, nil
+}
+
+// chargeGas transfers the gas fee in ONG from payer to the governance contract, as the ledger does
+func (self *Sandbox) chargeGas(conf *smartcontract.Config, cache *storage.CacheDB, payer common.Address,
+	fee uint64) ([]*event.NotifyEventInfo, error) {
+	if fee == 0 {
+		return nil, nil
+	}
+	sc := smartcontract.SmartContract{
+		Config:  conf,
+		CacheDB: cache,
+		Store:   self.store,
+		Gas:     math.MaxUint64,
+	}
+	service, err := sc.NewNativeService()
+	if err != nil {
+		return nil, err
+	}
+	transfers := &ont.Transfers{States: []ont.State{{From: payer, To: utils.GovernanceContractAddress, Value: fee}}}
+	_, err = service.NativeCall(utils.OngContractAddress, ont.TRANSFER_NAME, common.SerializeToBytes(transfers))
+	if err != nil {
+		return nil, fmt.Errorf("charge gas fee %d from payer %s error:%s", fee, payer.ToBase58(), err)
+	}
+	return sc.Notifications, nil
+}
+
+// useConfig installs the sandbox config as the global config read by the contract services,
+// and returns the function to restore the previous one
+func (self *Sandbox) useConfig() func() {
+	prev := config.DefConfig
+	config.DefConfig = self.config
+	return func() {
+		config.DefConfig = prev
+	}
+}
+
+// NextBlocks seals the current block and the following count-1 empty blocks,
+// the timestamp of each block is BlockTime later than its previous one
+func (self *Sandbox) NextBlocks(count uint32) {
+	for i := uint32(0); i < count; i++ {
+		prev := self.blocks[len(self.blocks)-1]
+		block := &types.Block{
+			Header: &types.Header{
+				Version:       prev.Header.Version,
+				PrevBlockHash: prev.Hash(),
+				Timestamp:     self.timestamp,
+				Height:        self.Height(),
+			},
+			Transactions: self.pending,
+		}
+		block.RebuildMerkleRoot()
+		self.blocks = append(self.blocks, block)
+		self.pending = nil
+		self.timestamp += self.BlockTime
+	}
+}
+
+// AdvanceTime moves the timestamp of the current block forward
+func (self *Sandbox) AdvanceTime(seconds uint32) {
+	self.timestamp += seconds
+}
+
+// SetBalance overwrites the ONT or ONG balance of address, total supply is not changed
+func (self *Sandbox) SetBalance(asset, address common.Address, amount uint64) error {
+	if asset != utils.OntContractAddress && asset != utils.OngContractAddress {
+		return fmt.Errorf("asset %s is neither ONT nor ONG", asset.ToHexString())
+	}
+	cache := storage.NewCacheDB(self.overlay)
+	cache.Put(ont.GenBalanceKey(asset, address), utils.GenUInt64StorageItem(amount).ToArray())
+	cache.Commit()
+	return nil
+}
+
+// GetBalance returns the ONT or ONG balance of address
+func (self *Sandbox) GetBalance(asset, address common.Address) (uint64, error) {
+	if asset != utils.OntContractAddress && asset != utils.OngContractAddress {
+		return 0, fmt.Errorf("asset %s is neither ONT nor ONG", asset.ToHexString())
+	}
+	item, err := self.store.getStorageItem(ont.GenBalanceKey(asset, address))
+	if err != nil || item == nil {
+		return 0, err
+	}
+	source := common.NewZeroCopySource(item.Value)
+	balance, eof := source.NextUint64()
+	if eof {
+		return 0, fmt.Errorf("invalid balance of %s", address.ToBase58())
+	}
+	return balance, nil
+}
+
+// Snapshot records the current states and returns the id to revert to
+func (self *Sandbox) Snapshot() uint32 {
+	self.snapId++
+	self.snapshots[self.snapId] = &snapshot{
+		writeSet:  cloneMemDB(self.overlay.GetWriteSet()),
+		blocks:    len(self.blocks),
+		pending:   append([]*types.Transaction{}, self.pending...),
+		timestamp: self.timestamp,
+	}
+	return self.snapId
+}
+
+// Revert restores the states recorded by snapshot id, the snapshot and those taken after it are discarded
+func (self *Sandbox) Revert(id uint32) error {
+	snap, ok := self.snapshots[id]
+	if !ok {
+		return fmt.Errorf("snapshot %d not found", id)
+	}
+	overlay := overlaydb.NewOverlayDB(self.backend)
+	snap.writeSet.ForEach(func(key, val []byte) {
+		overlay.Put(key, val)
+	})
+	self.overlay = overlay
+	self.blocks = self.blocks[:snap.blocks]
+	self.pending = append([]*types.Transaction{}, snap.pending...)
+	self.timestamp = snap.timestamp
+	for snapId := range self.snapshots {
+		if snapId >= id {
+			delete(self.snapshots, snapId)
+		}
+	}
+	return nil
+}
+
+func cloneMemDB(db *overlaydb.MemDB) *overlaydb.MemDB {
+	clone := overlaydb.NewMemDB(db.Size(), db.Len())
+	db.ForEach(func(key, val []byte) {
+		clone.Put(key, val)
+	})
+	return clone
+}
+
+func calcGasByCodeLen(codeLen int, codeGas uint64) uint64 {
+	return uint64(codeLen/neovm.PER_UNIT_CODE_LEN) * codeGas
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/common/constants"
+	"github.com/ontio/ontology/core/payload"
+	"github.com/ontio/ontology/smartcontract/event"
+	nutils "github.com/ontio/ontology/smartcontract/service/native/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenesisBalance(t *testing.T) {
+	defConfig := config.DefConfig
+	sb, err := NewSandbox(20000000, 0, 6)
+	assert.Nil(t, err)
+	assert.True(t, defConfig == config.DefConfig)
+	assert.Equal(t, uint32(1), sb.Height())
+
+	balance, err := sb.GetBalance(nutils.OntContractAddress, sb.Owner.Address)
+	assert.Nil(t, err)
+	assert.Equal(t, constants.ONT_TOTAL_SUPPLY, balance)
+}
+
+func TestSnapshotRevert(t *testing.T) {
+	sb, err := NewSandbox(20000000, 0, 6)
+	assert.Nil(t, err)
+	addr := common.Address{1, 2, 3}
+
+	id := sb.Snapshot()
+	timestamp := sb.Timestamp()
+	assert.Nil(t, sb.SetBalance(nutils.OngContractAddress, addr, 100))
+	sb.NextBlocks(2)
+	assert.Equal(t, uint32(3), sb.Height())
+	assert.Equal(t, timestamp+12, sb.Timestamp())
+
+	balance, err := sb.GetBalance(nutils.OngContractAddress, addr)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(100), balance)
+
+	assert.Nil(t, sb.Revert(id))
+	assert.Equal(t, uint32(1), sb.Height())
+	assert.Equal(t, timestamp, sb.Timestamp())
+	balance, err = sb.GetBalance(nutils.OngContractAddress, addr)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), balance)
+	assert.NotNil(t, sb.Revert(id))
+}
+
+func TestChargeGas(t *testing.T) {
+	sb, err := NewSandbox(20000000, 500, 6)
+	assert.Nil(t, err)
+	payer := sb.Owner.Address
+
+	// the bookkeeper holds no ONG after genesis
+	_, _, err = sb.Deploy([]byte{0x51, 0x66}, payload.NEOVM_TYPE, "foo")
+	assert.NotNil(t, err)
+
+	assert.Nil(t, sb.SetBalance(nutils.OngContractAddress, payer, 1000000000000))
+	governance, err := sb.GetBalance(nutils.OngContractAddress, nutils.GovernanceContractAddress)
+	assert.Nil(t, err)
+	res, _, err := sb.Deploy([]byte{0x51, 0x66}, payload.NEOVM_TYPE, "foo")
+	assert.Nil(t, err)
+	assert.Equal(t, event.CONTRACT_STATE_SUCCESS, res.State)
+	fee := res.Gas * 500
+	balance, err := sb.GetBalance(nutils.OngContractAddress, payer)
+	assert.Nil(t, err)
+	assert.Equal(t, 1000000000000-fee, balance)
+	balance, err = sb.GetBalance(nutils.OngContractAddress, nutils.GovernanceContractAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, governance+fee, balance)
+
+	// pre-execution is not charged
+	res, err = sb.Invoke([]byte{0x51}, payload.NEOVM_TYPE, false)
+	assert.Nil(t, err)
+	assert.Equal(t, event.CONTRACT_STATE_SUCCESS, res.State)
+	balance, err = sb.GetBalance(nutils.OngContractAddress, payer)
+	assert.Nil(t, err)
+	assert.Equal(t, 1000000000000-fee, balance)
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package sandbox
+
+import (
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/payload"
+	"github.com/ontio/ontology/core/states"
+	"github.com/ontio/ontology/core/store"
+	scom "github.com/ontio/ontology/core/store/common"
+	"github.com/ontio/ontology/core/types"
+)
+
+// ledgerStore serves the ledger queries of smart contract runtime from sandbox,
+// the other methods of store.LedgerStore are not supported
+type ledgerStore struct {
+	store.LedgerStore
+	sandbox *Sandbox
+}
+
+func (self *ledgerStore) GetCurrentBlockHeight() uint32 {
+	if len(self.sandbox.blocks) == 0 {
+		return 0
+	}
+	return uint32(len(self.sandbox.blocks)) - 1
+}
+
+func (self *ledgerStore) GetCurrentBlockHash() common.Uint256 {
+	blocks := self.sandbox.blocks
+	if len(blocks) == 0 {
+		return common.UINT256_EMPTY
+	}
+	return blocks[len(blocks)-1].Hash()
+}
+
+func (self *ledgerStore) GetBlockHash(height uint32) common.Uint256 {
+	if height >= uint32(len(self.sandbox.blocks)) {
+		return common.UINT256_EMPTY
+	}
+	return self.sandbox.blocks[height].Hash()
+}
+
+func (self *ledgerStore) GetBlockByHeight(height uint32) (*types.Block, error) {
+	if height >= uint32(len(self.sandbox.blocks)) {
+		return nil, scom.ErrNotFound
+	}
+	return self.sandbox.blocks[height], nil
+}
+
+func (self *ledgerStore) GetBlockByHash(blockHash common.Uint256) (*types.Block, error) {
+	for _, block := range self.sandbox.blocks {
+		if block.Hash() == blockHash {
+			return block, nil
+		}
+	}
+	return nil, scom.ErrNotFound
+}
+
+func (self *ledgerStore) GetHeaderByHeight(height uint32) (*types.Header, error) {
+	block, err := self.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header, nil
+}
+
+func (self *ledgerStore) GetHeaderByHash(blockHash common.Uint256) (*types.Header, error) {
+	block, err := self.GetBlockByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header, nil
+}
+
+func (self *ledgerStore) GetTransaction(txHash common.Uint256) (*types.Transaction, uint32, error) {
+	for height, block := range self.sandbox.blocks {
+		for _, tx := range block.Transactions {
+			if tx.Hash() == txHash {
+				return tx, uint32(height), nil
+			}
+		}
+	}
+	for _, tx := range self.sandbox.pending {
+		if tx.Hash() == txHash {
+			return tx, self.sandbox.Height(), nil
+		}
+	}
+	return nil, 0, scom.ErrNotFound
+}
+
+func (self *ledgerStore) GetContractState(contractHash common.Address) (*payload.DeployCode, error) {
+	value, err := self.sandbox.overlay.Get(append([]byte{byte(scom.ST_CONTRACT)}, contractHash[:]...))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, scom.ErrNotFound
+	}
+	contract := new(payload.DeployCode)
+	if err := contract.Deserialization(common.NewZeroCopySource(value)); err != nil {
+		return nil, err
+	}
+	return contract, nil
+}
+
+func (self *ledgerStore) GetStorageItem(key *states.StorageKey) (*states.StorageItem, error) {
+	item, err := self.getStorageItem(append(key.ContractAddress[:], key.Key...))
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, scom.ErrNotFound
+	}
+	return item, nil
+}
+
+// getStorageItem returns nil if the key of contract storage doesn't exist
+func (self *ledgerStore) getStorageItem(key []byte) (*states.StorageItem, error) {
+	value, err := self.sandbox.overlay.Get(append([]byte{byte(scom.ST_STORAGE)}, key...))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	item := new(states.StorageItem)
+	if err := item.Deserialization(common.NewZeroCopySource(value)); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
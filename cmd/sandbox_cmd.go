@@ -0,0 +1,449 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ontio/dad-go/cmd/abi"
+	"github.com/ontio/dad-go/cmd/sandbox"
+	"github.com/ontio/dad-go/cmd/utils"
+	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/core/payload"
+	cutils "github.com/ontio/dad-go/core/utils"
+	"github.com/ontio/dad-go/smartcontract/event"
+	"github.com/ontio/dad-go/smartcontract/service/native/abi_registry"
+	nutils "github.com/ontio/dad-go/smartcontract/service/native/utils"
+	"github.com/urfave/cli"
+)
+
+var SandboxCommand = cli.Command{
+	Action:    runSandbox,
+	Name:      "sandbox",
+	Usage:     "Run a local in-memory ledger for contract development",
+	ArgsUsage: "[script file]",
+	Description: `Sandbox starts an in-memory ledger with the genesis native contracts, and executes the commands read
+from script file, or from stdin if script file does not specified. The ONT of genesis is held by the sandbox
+bookkeeper, which is also the default signer. The gas of committed transactions is charged in ONG from the payer,
+use setbalance to fund the payer, or set --gasprice to 0. Commands:
+
+  deploy <code file> [abi file]           Deploy NeoVM (hex) or WasmVM (binary) contract code
+  invoke <contract> <method> [params]     Invoke contract method and commit the states
+  call <contract> <method> [params]       Pre-execute contract method without committing the states
+  signer [address...]                     Set or show the signers of transactions, the first is the payer
+  balance <address>                       Show ONT and ONG balance of address
+  setbalance <address> <ont|ong> <amount> Set ONT or ONG balance of address
+  block [count]                           Seal the current block and advance count blocks
+  time <seconds>                          Advance the timestamp of the current block
+  snapshot                                Take a snapshot of the states and return its id
+  revert <id>                             Revert the states to snapshot
+  exit                                    Quit sandbox
+
+  Params of NeoVM method are parsed by the abi if deployed with abi file, for example: invoke <contract> transfer
+  AFmseVrdL9f9oyCzZefL9tG6UbvhPbdYzM AFmseVrdL9f9oyCzZefL9tG6UbvhPbdYzM 100. Otherwise params use the format
+  of contract invoke command, for example: string:foo,[int:0,bool:true].
+`,
+	Flags: []cli.Flag{
+		utils.SandboxGasLimitFlag,
+		utils.SandboxGasPriceFlag,
+		utils.SandboxBlockTimeFlag,
+	},
+}
+
+type sandboxContract struct {
+	vmType   payload.VmType
+	neovmAbi *abi.NeovmContractAbi
+	abi      *abi_registry.ContractAbi
+}
+
+type sandboxShell struct {
+	sandbox   *sandbox.Sandbox
+	contracts map[common.Address]*sandboxContract
+	snapshots map[uint32]map[common.Address]*sandboxContract // contracts deployed when the snapshot taken
+}
+
+type sandboxEvent struct {
+	Contract string
+	States   interface{}
+	Decoded  *abi_registry.DecodedNotify `json:",omitempty"`
+}
+
+func runSandbox(ctx *cli.Context) error {
+	gasLimit := ctx.Uint64(utils.GetFlagName(utils.SandboxGasLimitFlag))
+	gasPrice := ctx.Uint64(utils.GetFlagName(utils.SandboxGasPriceFlag))
+	blockTime := ctx.Uint(utils.GetFlagName(utils.SandboxBlockTimeFlag))
+	sb, err := sandbox.NewSandbox(gasLimit, gasPrice, uint32(blockTime))
+	if err != nil {
+		return fmt.Errorf("create sandbox error:%s", err)
+	}
+	shell := &sandboxShell{
+		sandbox:   sb,
+		contracts: make(map[common.Address]*sandboxContract),
+		snapshots: make(map[uint32]map[common.Address]*sandboxContract),
+	}
+	PrintInfoMsg("Sandbox started at height:%d", sb.Height())
+	PrintInfoMsg("  Bookkeeper:%s", sb.Owner.Address.ToBase58())
+
+	var input io.Reader = os.Stdin
+	interactive := true
+	if ctx.NArg() > 0 {
+		file, err := os.Open(ctx.Args().First())
+		if err != nil {
+			return fmt.Errorf("open script file error:%s", err)
+		}
+		defer file.Close()
+		input = file
+		interactive = false
+	}
+
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; ; line++ {
+		if interactive {
+			fmt.Print("> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+		args := strings.Fields(scanner.Text())
+		if len(args) == 0 || strings.HasPrefix(args[0], "#") {
+			continue
+		}
+		if args[0] == "exit" || args[0] == "quit" {
+			return nil
+		}
+		err := shell.execute(args)
+		if err != nil {
+			if !interactive {
+				return fmt.Errorf("line %d %s error:%s", line, args[0], err)
+			}
+			PrintErrorMsg("%s error:%s", args[0], err)
+		}
+	}
+	return scanner.Err()
+}
+
+func (this *sandboxShell) execute(args []string) error {
+	switch args[0] {
+	case "deploy":
+		return this.deploy(args[1:])
+	case "invoke":
+		return this.invoke(args[1:], true)
+	case "call":
+		return this.invoke(args[1:], false)
+	case "signer":
+		return this.setSigners(args[1:])
+	case "balance":
+		return this.balance(args[1:])
+	case "setbalance":
+		return this.setBalance(args[1:])
+	case "block":
+		return this.nextBlocks(args[1:])
+	case "time":
+		return this.advanceTime(args[1:])
+	case "snapshot":
+		this.snapshot()
+		return nil
+	case "revert":
+		return this.revert(args[1:])
+	default:
+		return fmt.Errorf("unknown command, run 'dad-go sandbox --help' for usage")
+	}
+}
+
+func (this *sandboxShell) deploy(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("missing argument, code file expected")
+	}
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("read code file error:%s", err)
+	}
+	contract := &sandboxContract{vmType: payload.NEOVM_TYPE}
+	code := data
+	if bytes.HasPrefix(data, []byte("\x00asm")) {
+		contract.vmType = payload.WASMVM_TYPE
+	} else {
+		code, err = common.HexToBytes(strings.TrimSpace(string(data)))
+		if err != nil {
+			return fmt.Errorf("neovm code convert hex to bytes error:%s", err)
+		}
+	}
+	if len(args) > 1 {
+		abiData, err := ioutil.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("read abi file error:%s", err)
+		}
+		contract.abi, err = abi_registry.ParseContractAbi(abiData)
+		if err != nil {
+			return fmt.Errorf("parse abi error:%s", err)
+		}
+		if contract.vmType == payload.NEOVM_TYPE {
+			contract.neovmAbi, err = utils.NewNeovmContractAbi(abiData)
+			if err != nil {
+				return fmt.Errorf("parse neovm abi error:%s", err)
+			}
+		}
+	}
+
+	name := strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+	res, address, err := this.sandbox.Deploy(code, contract.vmType, name)
+	if err != nil {
+		return err
+	}
+	this.contracts[address] = contract
+	PrintInfoMsg("Deploy contract:%s", address.ToHexString())
+	this.printResult(res)
+	return nil
+}
+
+func (this *sandboxShell) invoke(args []string, commit bool) error {
+	if len(args) < 2 {
+		return fmt.Errorf("missing argument, contract and method expected")
+	}
+	address, err := parseSandboxAddress(args[0])
+	if err != nil {
+		return err
+	}
+	contract, ok := this.contracts[address]
+	if !ok {
+		contract = &sandboxContract{vmType: payload.NEOVM_TYPE}
+	}
+	method := args[1]
+
+	var code []byte
+	if contract.vmType == payload.WASMVM_TYPE {
+		params, err := utils.ParseParams(strings.Join(args[2:], " "))
+		if err != nil {
+			return fmt.Errorf("parseParams error:%s", err)
+		}
+		code, err = cutils.BuildWasmVMInvokeCode(address, append([]interface{}{method}, params...))
+		if err != nil {
+			return fmt.Errorf("BuildWasmVMInvokeCode error:%s", err)
+		}
+	} else {
+		var params []interface{}
+		if contract.neovmAbi != nil {
+			funcAbi := contract.neovmAbi.GetFunc(method)
+			if funcAbi == nil {
+				return fmt.Errorf("method %s not found in abi", method)
+			}
+			params, err = utils.ParseNeovmFunc(args[2:], funcAbi)
+			if err != nil {
+				return fmt.Errorf("ParseNeovmFunc error:%s", err)
+			}
+		} else {
+			methodParams, err := utils.ParseParams(strings.Join(args[2:], " "))
+			if err != nil {
+				return fmt.Errorf("parseParams error:%s", err)
+			}
+			params = []interface{}{method, methodParams}
+		}
+		code, err = cutils.BuildNeoVMInvokeCode(address, params)
+		if err != nil {
+			return fmt.Errorf("BuildNeoVMInvokeCode error:%s", err)
+		}
+	}
+
+	res, err := this.sandbox.Invoke(code, contract.vmType, commit)
+	if err != nil {
+		return err
+	}
+	this.printResult(res)
+	return nil
+}
+
+func (this *sandboxShell) printResult(res *sandbox.ExecuteResult) {
+	if res.State == event.CONTRACT_STATE_SUCCESS {
+		PrintInfoMsg("  State:success")
+	} else {
+		PrintInfoMsg("  State:failed")
+	}
+	PrintInfoMsg("  TxHash:%s", res.TxHash.ToHexString())
+	PrintInfoMsg("  Gas:%d", res.Gas)
+	if res.Error != nil {
+		PrintInfoMsg("  Error:%s", res.Error)
+	}
+	if res.Result != nil {
+		PrintInfoMsg("  Result:%v", res.Result)
+	}
+	if len(res.Notify) == 0 {
+		return
+	}
+	events := make([]*sandboxEvent, 0, len(res.Notify))
+	for _, notify := range res.Notify {
+		evt := &sandboxEvent{Contract: notify.ContractAddress.ToHexString(), States: notify.States}
+		if contract, ok := this.contracts[notify.ContractAddress]; ok && contract.abi != nil {
+			decoded, err := contract.abi.DecodeNotify(notify.States, contract.vmType == payload.NEOVM_TYPE)
+			if err == nil {
+				evt.Decoded = decoded
+			}
+		}
+		events = append(events, evt)
+	}
+	PrintInfoMsg("  Events:")
+	PrintJsonObject(events)
+}
+
+func (this *sandboxShell) setSigners(args []string) error {
+	if len(args) == 0 {
+		for _, signer := range this.sandbox.Signers() {
+			PrintInfoMsg("Signer:%s", signer.ToBase58())
+		}
+		return nil
+	}
+	signers := make([]common.Address, 0, len(args))
+	for _, arg := range args {
+		address, err := parseSandboxAddress(arg)
+		if err != nil {
+			return err
+		}
+		signers = append(signers, address)
+	}
+	this.sandbox.SetSigners(signers)
+	return nil
+}
+
+func (this *sandboxShell) balance(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("missing argument, address expected")
+	}
+	address, err := parseSandboxAddress(args[0])
+	if err != nil {
+		return err
+	}
+	ontBalance, err := this.sandbox.GetBalance(nutils.OntContractAddress, address)
+	if err != nil {
+		return err
+	}
+	ongBalance, err := this.sandbox.GetBalance(nutils.OngContractAddress, address)
+	if err != nil {
+		return err
+	}
+	PrintInfoMsg("BalanceOf:%s", address.ToBase58())
+	PrintInfoMsg("  ONT:%s", utils.FormatOnt(ontBalance))
+	PrintInfoMsg("  ONG:%s", utils.FormatOng(ongBalance))
+	return nil
+}
+
+func (this *sandboxShell) setBalance(args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("missing argument, address, asset and amount expected")
+	}
+	address, err := parseSandboxAddress(args[0])
+	if err != nil {
+		return err
+	}
+	var asset common.Address
+	var amount uint64
+	switch strings.ToLower(args[1]) {
+	case "ont":
+		asset = nutils.OntContractAddress
+		amount = utils.ParseOnt(args[2])
+	case "ong":
+		asset = nutils.OngContractAddress
+		amount = utils.ParseOng(args[2])
+	default:
+		return fmt.Errorf("unsupported asset:%s", args[1])
+	}
+	if err := utils.CheckAssetAmount(args[1], amount); err != nil {
+		return err
+	}
+	return this.sandbox.SetBalance(asset, address, amount)
+}
+
+func (this *sandboxShell) nextBlocks(args []string) error {
+	count := uint64(1)
+	if len(args) > 0 {
+		var err error
+		count, err = strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid block count:%s", args[0])
+		}
+	}
+	this.sandbox.NextBlocks(uint32(count))
+	PrintInfoMsg("Height:%d Timestamp:%d", this.sandbox.Height(), this.sandbox.Timestamp())
+	return nil
+}
+
+func (this *sandboxShell) advanceTime(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("missing argument, seconds expected")
+	}
+	seconds, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid seconds:%s", args[0])
+	}
+	this.sandbox.AdvanceTime(uint32(seconds))
+	PrintInfoMsg("Height:%d Timestamp:%d", this.sandbox.Height(), this.sandbox.Timestamp())
+	return nil
+}
+
+func (this *sandboxShell) snapshot() {
+	id := this.sandbox.Snapshot()
+	contracts := make(map[common.Address]*sandboxContract, len(this.contracts))
+	for address, contract := range this.contracts {
+		contracts[address] = contract
+	}
+	this.snapshots[id] = contracts
+	PrintInfoMsg("Snapshot:%d", id)
+}
+
+func (this *sandboxShell) revert(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("missing argument, snapshot id expected")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot id:%s", args[0])
+	}
+	if err := this.sandbox.Revert(uint32(id)); err != nil {
+		return err
+	}
+	//the contracts deployed after snapshot are reverted with the states
+	this.contracts = this.snapshots[uint32(id)]
+	for snapId := range this.snapshots {
+		if snapId >= uint32(id) {
+			delete(this.snapshots, snapId)
+		}
+	}
+	PrintInfoMsg("Height:%d Timestamp:%d", this.sandbox.Height(), this.sandbox.Timestamp())
+	return nil
+}
+
+//parseSandboxAddress accept both base58 and hex address
+func parseSandboxAddress(str string) (common.Address, error) {
+	address, err := common.AddressFromBase58(str)
+	if err == nil {
+		return address, nil
+	}
+	address, err = common.AddressFromHexString(str)
+	if err != nil {
+		return common.ADDRESS_EMPTY, fmt.Errorf("invalid address:%s", str)
+	}
+	return address, nil
+}
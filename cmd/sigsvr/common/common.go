@@ -22,10 +22,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/ontio/dad-go/account"
+	"github.com/ontio/dad-go/cmd/sigsvr/policy"
 	"github.com/ontio/dad-go/cmd/sigsvr/store"
+	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/core/types"
 )
 
 var DefWalletStore *store.WalletStore
+var DefPolicy *policy.Policy
+var DefAuditLogger *policy.AuditLogger
 
 type CliRpcRequest struct {
 	Qid     string          `json:"qid"`
@@ -33,6 +38,9 @@ type CliRpcRequest struct {
 	Account string          `json:"account"`
 	Pwd     string          `json:"pwd"`
 	Method  string          `json:"method"`
+	Token   string          `json:"token"`
+	Client  string          `json:"-"`
+	TxHash  string          `json:"-"`
 }
 
 func (this *CliRpcRequest) GetAccount() (*account.Account, error) {
@@ -56,6 +64,17 @@ func (this *CliRpcRequest) GetAccount() (*account.Account, error) {
 	return acc, nil
 }
 
+//CheckTx checks tx against sig server policy before signing. payer is set to tx
+//if tx has no payer, as signing does, so that the recorded tx hash is the signed one
+func (this *CliRpcRequest) CheckTx(tx *types.MutableTransaction, payer common.Address) error {
+	if tx.Payer == common.ADDRESS_EMPTY {
+		tx.Payer = payer
+	}
+	txHash := tx.Hash()
+	this.TxHash = txHash.ToHexString()
+	return DefPolicy.CheckTx(tx)
+}
+
 type CliRpcResponse struct {
 	Qid       string      `json:"qid"`
 	Method    string      `json:"method"`
@@ -95,6 +95,19 @@ func SigMutilRawTransaction(req *clisvrcom.CliRpcRequest, resp *clisvrcom.CliRpc
 		resp.ErrorCode = clisvrcom.CLIERR_ACCOUNT_UNLOCK
 		return
 	}
+	payer, err := types.AddressFromMultiPubKeys(pubKeys, rawReq.M)
+	if err != nil {
+		log.Infof("Cli Qid:%s SigMutilRawTransaction AddressFromMultiPubKeys error:%s", req.Qid, err)
+		resp.ErrorCode = clisvrcom.CLIERR_INVALID_PARAMS
+		return
+	}
+	err = req.CheckTx(mutTx, payer)
+	if err != nil {
+		log.Infof("Cli Qid:%s SigMutilRawTransaction CheckTx error:%s", req.Qid, err)
+		resp.ErrorCode = clisvrcom.CLIERR_POLICY_DENIED
+		resp.ErrorInfo = err.Error()
+		return
+	}
 	err = cliutil.MultiSigTransaction(mutTx, uint16(rawReq.M), pubKeys, signer)
 	if err != nil {
 		log.Infof("Cli Qid:%s SigMutilRawTransaction MultiSigTransaction error:%s", req.Qid, err)
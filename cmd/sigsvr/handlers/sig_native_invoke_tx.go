@@ -88,6 +88,13 @@ func SigNativeInvokeTx(req *clisvrcom.CliRpcRequest, resp *clisvrcom.CliRpcRespo
 		resp.ErrorCode = clisvrcom.CLIERR_ACCOUNT_UNLOCK
 		return
 	}
+	err = req.CheckTx(tx, signer.Address)
+	if err != nil {
+		log.Infof("Cli Qid:%s SigNativeInvokeTx CheckTx error:%s", req.Qid, err)
+		resp.ErrorCode = clisvrcom.CLIERR_POLICY_DENIED
+		resp.ErrorInfo = err.Error()
+		return
+	}
 	err = cliutil.SignTransaction(signer, tx)
 	if err != nil {
 		log.Infof("Cli Qid:%s SigNativeInvokeTx SignTransaction error:%s", req.Qid, err)
@@ -82,6 +82,13 @@ func SigNeoVMInvokeTx(req *clisvrcom.CliRpcRequest, resp *clisvrcom.CliRpcRespon
 		resp.ErrorCode = clisvrcom.CLIERR_ACCOUNT_UNLOCK
 		return
 	}
+	err = req.CheckTx(mutable, signer.Address)
+	if err != nil {
+		log.Infof("Cli Qid:%s SigNeoVMInvokeTx CheckTx error:%s", req.Qid, err)
+		resp.ErrorCode = clisvrcom.CLIERR_POLICY_DENIED
+		resp.ErrorInfo = err.Error()
+		return
+	}
 	err = cliutil.SignTransaction(signer, mutable)
 	if err != nil {
 		log.Infof("Cli Qid:%s SigNeoVMInvokeTx SignTransaction error:%s", req.Qid, err)
@@ -93,6 +93,13 @@ func SigNeoVMInvokeAbiTx(req *clisvrcom.CliRpcRequest, resp *clisvrcom.CliRpcRes
 		resp.ErrorCode = clisvrcom.CLIERR_ACCOUNT_UNLOCK
 		return
 	}
+	err = req.CheckTx(mutable, signer.Address)
+	if err != nil {
+		log.Infof("Cli Qid:%s SigNeoVMInvokeAbiTx CheckTx error:%s", req.Qid, err)
+		resp.ErrorCode = clisvrcom.CLIERR_POLICY_DENIED
+		resp.ErrorInfo = err.Error()
+		return
+	}
 	err = cliutil.SignTransaction(signer, mutable)
 	if err != nil {
 		log.Infof("Cli Qid:%s SigNeoVMInvokeAbiTx SignTransaction error:%s", req.Qid, err)
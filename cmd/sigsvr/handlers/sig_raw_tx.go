@@ -68,9 +68,12 @@ func SigRawTransaction(req *clisvrcom.CliRpcRequest, resp *clisvrcom.CliRpcRespo
 		resp.ErrorCode = clisvrcom.CLIERR_ACCOUNT_UNLOCK
 		return
 	}
-	var emptyAddress = common.Address{}
-	if mutable.Payer == emptyAddress {
-		mutable.Payer = signer.Address
+	err = req.CheckTx(mutable, signer.Address)
+	if err != nil {
+		log.Infof("Cli Qid:%s SigRawTransaction CheckTx error:%s", req.Qid, err)
+		resp.ErrorCode = clisvrcom.CLIERR_POLICY_DENIED
+		resp.ErrorInfo = err.Error()
+		return
 	}
 
 	txHash := mutable.Hash()
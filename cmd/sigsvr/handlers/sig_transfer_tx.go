@@ -85,6 +85,13 @@ func SigTransferTransaction(req *clisvrcom.CliRpcRequest, resp *clisvrcom.CliRpc
 		resp.ErrorCode = clisvrcom.CLIERR_ACCOUNT_UNLOCK
 		return
 	}
+	err = req.CheckTx(mutable, signer.Address)
+	if err != nil {
+		log.Infof("Cli Qid:%s SigTransferTransaction CheckTx error:%s", req.Qid, err)
+		resp.ErrorCode = clisvrcom.CLIERR_POLICY_DENIED
+		resp.ErrorInfo = err.Error()
+		return
+	}
 	err = cliutil.SignTransaction(signer, mutable)
 	if err != nil {
 		log.Infof("Cli Qid:%s SigTransferTransaction SignTransaction error:%s", req.Qid, err)
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/ontio/dad-go/cmd/sigsvr/common"
+	"github.com/ontio/dad-go/cmd/sigsvr/policy"
 	"github.com/ontio/dad-go/common/log"
 	"io/ioutil"
 	"net/http"
@@ -74,6 +75,7 @@ func (this *CliRpcServer) GetHandler(method string) func(req *common.CliRpcReque
 }
 
 func (this *CliRpcServer) Handler(w http.ResponseWriter, r *http.Request) {
+	req := &common.CliRpcRequest{}
 	resp := &common.CliRpcResponse{}
 	defer func() {
 		w.Header().Add("Access-Control-Allow-Headers", "Content-Type")
@@ -84,6 +86,13 @@ func (this *CliRpcServer) Handler(w http.ResponseWriter, r *http.Request) {
 		if resp.ErrorInfo == "" {
 			resp.ErrorInfo = common.GetCLIErrorDesc(resp.ErrorCode)
 		}
+		err := this.audit(r, req, resp)
+		if err != nil {
+			log.Errorf("CliRpcServer audit log error:%s", err)
+			resp.Result = nil
+			resp.ErrorCode = common.CLIERR_INTERNAL_ERR
+			resp.ErrorInfo = common.GetCLIErrorDesc(resp.ErrorCode)
+		}
 		data, err := json.Marshal(resp)
 		if err != nil {
 			log.Error("CliRpcServer json.Marshal JsonRpcResponse:%+v error:%s", resp, err)
@@ -110,7 +119,6 @@ func (this *CliRpcServer) Handler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
-	req := &common.CliRpcRequest{}
 	err = json.Unmarshal(data, req)
 	if err != nil {
 		log.Errorf("CliRpcServer json.Unmarshal JsonRpcRequest error:%s", err)
@@ -119,14 +127,35 @@ func (this *CliRpcServer) Handler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	pwd := req.Pwd
+	token := req.Token
 	req.Pwd = "*"
+	req.Token = "*"
 	logData, _ := json.Marshal(req)
 	log.Infof("[CliRpcRequest]%s", logData)
 
 	req.Pwd = pwd
+	req.Token = token
 	resp.Method = req.Method
 	resp.Qid = req.Qid
 
+	req.Client, err = common.DefPolicy.CheckClient(req.Token)
+	if err != nil {
+		log.Infof("Cli Qid:%s CheckClient error:%s", req.Qid, err)
+		if err == policy.ErrRateLimited {
+			resp.ErrorCode = common.CLIERR_RATE_LIMITED
+		} else {
+			resp.ErrorCode = common.CLIERR_UNAUTHORIZED
+		}
+		return
+	}
+	err = common.DefPolicy.CheckMethod(req.Method)
+	if err != nil {
+		log.Infof("Cli Qid:%s CheckMethod error:%s", req.Qid, err)
+		resp.ErrorCode = common.CLIERR_POLICY_DENIED
+		resp.ErrorInfo = err.Error()
+		return
+	}
+
 	handler := this.GetHandler(req.Method)
 	if handler == nil {
 		resp.ErrorCode = common.CLIERR_UNSUPPORT_METHOD
@@ -136,6 +165,24 @@ func (this *CliRpcServer) Handler(w http.ResponseWriter, r *http.Request) {
 	handler(req, resp)
 }
 
+func (this *CliRpcServer) audit(r *http.Request, req *common.CliRpcRequest, resp *common.CliRpcResponse) error {
+	decision := policy.DECISION_ALLOW
+	if resp.ErrorCode != common.CLIERR_OK {
+		decision = policy.DECISION_DENY
+	}
+	return common.DefAuditLogger.Log(&policy.AuditEntry{
+		Qid:       req.Qid,
+		Method:    req.Method,
+		Account:   req.Account,
+		Client:    req.Client,
+		Remote:    r.RemoteAddr,
+		TxHash:    req.TxHash,
+		Decision:  decision,
+		ErrorCode: resp.ErrorCode,
+		Reason:    resp.ErrorInfo,
+	})
+}
+
 func (this *CliRpcServer) Close() {
 	err := this.httpSvr.Close()
 	if err != nil {
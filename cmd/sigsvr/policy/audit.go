@@ -0,0 +1,250 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package policy
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/ontio/dad-go-crypto/keypair"
+	"github.com/ontio/dad-go/account"
+	cliutil "github.com/ontio/dad-go/cmd/utils"
+	"github.com/ontio/dad-go/core/signature"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	DECISION_ALLOW = "allow"
+	DECISION_DENY  = "deny"
+)
+
+//AuditEntry is one line of audit log. Hash covers all the other fields including PrevHash,
+//so entries form a chain, and Sig is the signature of Hash by the audit account
+type AuditEntry struct {
+	Seq       uint64 `json:"seq"`
+	Time      int64  `json:"time"`
+	Qid       string `json:"qid"`
+	Method    string `json:"method"`
+	Account   string `json:"account"`
+	Client    string `json:"client"`
+	Remote    string `json:"remote"`
+	TxHash    string `json:"tx_hash"`
+	Decision  string `json:"decision"`
+	ErrorCode int    `json:"error_code"`
+	Reason    string `json:"reason"`
+	PrevHash  string `json:"prev_hash"`
+	PubKey    string `json:"pub_key,omitempty"`
+	Hash      string `json:"hash"`
+	Sig       string `json:"sig,omitempty"`
+}
+
+func (this *AuditEntry) digest() ([]byte, error) {
+	entry := *this
+	entry.Hash = ""
+	entry.Sig = ""
+	data, err := json.Marshal(&entry)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+	return hash[:], nil
+}
+
+//AuditLogger appends audit entries to file. It never rewrites the file, and continues
+//the hash chain of entries already in it
+type AuditLogger struct {
+	file     *os.File
+	signer   *account.Account
+	seq      uint64
+	lastHash string
+	lock     sync.Mutex
+}
+
+func NewAuditLogger(file string, signer *account.Account) (*AuditLogger, error) {
+	seq, lastHash, err := readAuditTail(file)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log:%s error:%s", file, err)
+	}
+	return &AuditLogger{
+		file:     f,
+		signer:   signer,
+		seq:      seq,
+		lastHash: lastHash,
+	}, nil
+}
+
+func readAuditTail(file string) (uint64, string, error) {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("open audit log:%s error:%s", file, err)
+	}
+	defer f.Close()
+	var last *AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := &AuditEntry{}
+		err = json.Unmarshal(scanner.Bytes(), entry)
+		if err != nil {
+			return 0, "", fmt.Errorf("audit log:%s corrupted:%s", file, err)
+		}
+		last = entry
+	}
+	if err = scanner.Err(); err != nil {
+		return 0, "", fmt.Errorf("read audit log:%s error:%s", file, err)
+	}
+	if last == nil {
+		return 0, "", nil
+	}
+	return last.Seq, last.Hash, nil
+}
+
+//Log fills the sequence, chain hash and signature of entry, and appends it to log file
+func (this *AuditLogger) Log(entry *AuditEntry) error {
+	if this == nil {
+		return nil
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	entry.Seq = this.seq + 1
+	entry.Time = time.Now().Unix()
+	entry.PrevHash = this.lastHash
+	if this.signer != nil {
+		entry.PubKey = hex.EncodeToString(keypair.SerializePublicKey(this.signer.PublicKey))
+	}
+	hash, err := entry.digest()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hex.EncodeToString(hash)
+	if this.signer != nil {
+		sigData, err := cliutil.Sign(hash, this.signer)
+		if err != nil {
+			return fmt.Errorf("sign audit entry error:%s", err)
+		}
+		entry.Sig = hex.EncodeToString(sigData)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = this.file.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("write audit log error:%s", err)
+	}
+	err = this.file.Sync()
+	if err != nil {
+		return fmt.Errorf("sync audit log error:%s", err)
+	}
+	this.seq = entry.Seq
+	this.lastHash = entry.Hash
+	return nil
+}
+
+func (this *AuditLogger) Close() error {
+	if this == nil {
+		return nil
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.file.Close()
+}
+
+//VerifyAuditLog checks the hash chain and signatures of audit log, and returns the number of entries
+func VerifyAuditLog(file string) (uint64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	var seq uint64
+	prevHash := ""
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := &AuditEntry{}
+		err = json.Unmarshal(scanner.Bytes(), entry)
+		if err != nil {
+			return seq, fmt.Errorf("entry after seq:%d corrupted:%s", seq, err)
+		}
+		if entry.Seq != seq+1 {
+			return seq, fmt.Errorf("entry seq:%d expect:%d", entry.Seq, seq+1)
+		}
+		if entry.PrevHash != prevHash {
+			return seq, fmt.Errorf("entry seq:%d prev hash unmatch", entry.Seq)
+		}
+		hash, err := entry.digest()
+		if err != nil {
+			return seq, err
+		}
+		if hex.EncodeToString(hash) != entry.Hash {
+			return seq, fmt.Errorf("entry seq:%d hash unmatch", entry.Seq)
+		}
+		if entry.PubKey != "" {
+			err = verifyAuditSig(entry, hash)
+			if err != nil {
+				return seq, fmt.Errorf("entry seq:%d %s", entry.Seq, err)
+			}
+		}
+		seq = entry.Seq
+		prevHash = entry.Hash
+	}
+	if err = scanner.Err(); err != nil {
+		return seq, err
+	}
+	return seq, nil
+}
+
+func verifyAuditSig(entry *AuditEntry, hash []byte) error {
+	pkData, err := hex.DecodeString(entry.PubKey)
+	if err != nil {
+		return fmt.Errorf("invalid pub key:%s", err)
+	}
+	pk, err := keypair.DeserializePublicKey(pkData)
+	if err != nil {
+		return fmt.Errorf("invalid pub key:%s", err)
+	}
+	sigData, err := hex.DecodeString(entry.Sig)
+	if err != nil {
+		return fmt.Errorf("invalid sig:%s", err)
+	}
+	err = signature.Verify(pk, hash, sigData)
+	if err != nil {
+		return fmt.Errorf("verify sig error:%s", err)
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package policy
+
+import (
+	"fmt"
+	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/core/payload"
+	"github.com/ontio/dad-go/core/types"
+	cutils "github.com/ontio/dad-go/core/utils"
+	"github.com/ontio/dad-go/smartcontract/service/native/utils"
+	vm "github.com/ontio/dad-go/vm/neovm"
+	"math/big"
+)
+
+const (
+	ASSET_ONT = "ont"
+	ASSET_ONG = "ong"
+)
+
+//Transfer is an asset movement found in the invoke code of a transaction
+type Transfer struct {
+	Asset string
+	From  common.Address
+	To    common.Address
+	Value uint64
+}
+
+//Invoke is a contract call found in the invoke code of a transaction
+type Invoke struct {
+	Contract  common.Address
+	Method    string
+	Transfers []*Transfer
+}
+
+//vmStruct is the decoder representation of neovm struct and array
+type vmStruct struct {
+	items []interface{}
+}
+
+//DecodeInvokes returns the contract calls of an invoke transaction
+func DecodeInvokes(tx *types.MutableTransaction) ([]*Invoke, error) {
+	invokeCode, ok := tx.Payload.(*payload.InvokeCode)
+	if !ok {
+		return nil, fmt.Errorf("unsupported tx type:%d", tx.TxType)
+	}
+	switch tx.TxType {
+	case types.InvokeNeo:
+		return decodeNeoVMCode(invokeCode.Code)
+	case types.InvokeWasm:
+		invoke, err := decodeWasmCode(invokeCode.Code)
+		if err != nil {
+			return nil, err
+		}
+		return []*Invoke{invoke}, nil
+	default:
+		return nil, fmt.Errorf("unsupported tx type:%d", tx.TxType)
+	}
+}
+
+//decodeWasmCode decode the serialized WasmContractParam, whose first arg is the method name
+func decodeWasmCode(code []byte) (*Invoke, error) {
+	source := common.NewZeroCopySource(code)
+	contract, eof := source.NextAddress()
+	if eof {
+		return nil, fmt.Errorf("read wasm contract address error")
+	}
+	args, _, irregular, eof := source.NextVarBytes()
+	if irregular || eof {
+		return nil, fmt.Errorf("read wasm contract args error")
+	}
+	method, _, irregular, eof := common.NewZeroCopySource(args).NextString()
+	if irregular || eof {
+		return nil, fmt.Errorf("read wasm contract method error")
+	}
+	return &Invoke{Contract: contract, Method: method}, nil
+}
+
+//decodeNeoVMCode runs the push only subset of neovm used by invoke transactions,
+//and records every APPCALL and native invoke SYSCALL
+func decodeNeoVMCode(code []byte) ([]*Invoke, error) {
+	source := common.NewZeroCopySource(code)
+	stack := make([]interface{}, 0)
+	altStack := make([]interface{}, 0)
+	invokes := make([]*Invoke, 0)
+	pop := func(s *[]interface{}) (interface{}, error) {
+		if len(*s) == 0 {
+			return nil, fmt.Errorf("stack underflow")
+		}
+		item := (*s)[len(*s)-1]
+		*s = (*s)[:len(*s)-1]
+		return item, nil
+	}
+	popInt := func() (int, error) {
+		item, err := pop(&stack)
+		if err != nil {
+			return 0, err
+		}
+		data, ok := item.([]byte)
+		if !ok {
+			return 0, fmt.Errorf("expect integer")
+		}
+		val := common.BigIntFromNeoBytes(data)
+		if !val.IsInt64() || val.Int64() < 0 || val.Int64() > 1024 {
+			return 0, fmt.Errorf("invalid count:%s", val)
+		}
+		return int(val.Int64()), nil
+	}
+	for source.Len() > 0 {
+		b, _ := source.NextByte()
+		opCode := vm.OpCode(b)
+		switch {
+		case opCode == vm.PUSH0:
+			stack = append(stack, []byte{})
+		case opCode >= vm.PUSHBYTES1 && opCode <= vm.PUSHBYTES75:
+			data, eof := source.NextBytes(uint64(opCode))
+			if eof {
+				return nil, fmt.Errorf("read push data error")
+			}
+			stack = append(stack, data)
+		case opCode == vm.PUSHDATA1 || opCode == vm.PUSHDATA2 || opCode == vm.PUSHDATA4:
+			var size uint64
+			var eof bool
+			switch opCode {
+			case vm.PUSHDATA1:
+				var l uint8
+				l, eof = source.NextUint8()
+				size = uint64(l)
+			case vm.PUSHDATA2:
+				var l uint16
+				l, eof = source.NextUint16()
+				size = uint64(l)
+			default:
+				var l uint32
+				l, eof = source.NextUint32()
+				size = uint64(l)
+			}
+			if eof {
+				return nil, fmt.Errorf("read push data size error")
+			}
+			data, eof := source.NextBytes(size)
+			if eof {
+				return nil, fmt.Errorf("read push data error")
+			}
+			stack = append(stack, data)
+		case opCode == vm.PUSHM1 || (opCode >= vm.PUSH1 && opCode <= vm.PUSH16):
+			val := int64(opCode) - int64(vm.PUSH1) + 1
+			stack = append(stack, common.BigIntToNeoBytes(big.NewInt(val)))
+		case opCode == vm.NOP:
+		case opCode == vm.RET:
+			return invokes, nil
+		case opCode == vm.NEWSTRUCT:
+			count, err := popInt()
+			if err != nil {
+				return nil, err
+			}
+			st := &vmStruct{items: make([]interface{}, count)}
+			for i := 0; i < count; i++ {
+				st.items[i] = []byte{}
+			}
+			stack = append(stack, st)
+		case opCode == vm.PACK:
+			count, err := popInt()
+			if err != nil {
+				return nil, err
+			}
+			st := &vmStruct{items: make([]interface{}, 0, count)}
+			for i := 0; i < count; i++ {
+				item, err := pop(&stack)
+				if err != nil {
+					return nil, err
+				}
+				st.items = append(st.items, item)
+			}
+			stack = append(stack, st)
+		case opCode == vm.TOALTSTACK:
+			item, err := pop(&stack)
+			if err != nil {
+				return nil, err
+			}
+			altStack = append(altStack, item)
+		case opCode == vm.FROMALTSTACK:
+			item, err := pop(&altStack)
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, item)
+		case opCode == vm.DUPFROMALTSTACK:
+			if len(altStack) == 0 {
+				return nil, fmt.Errorf("alt stack underflow")
+			}
+			stack = append(stack, altStack[len(altStack)-1])
+		case opCode == vm.SWAP:
+			if len(stack) < 2 {
+				return nil, fmt.Errorf("stack underflow")
+			}
+			stack[len(stack)-1], stack[len(stack)-2] = stack[len(stack)-2], stack[len(stack)-1]
+		case opCode == vm.APPEND:
+			item, err := pop(&stack)
+			if err != nil {
+				return nil, err
+			}
+			arr, err := pop(&stack)
+			if err != nil {
+				return nil, err
+			}
+			st, ok := arr.(*vmStruct)
+			if !ok {
+				return nil, fmt.Errorf("append to non struct item")
+			}
+			st.items = append(st.items, item)
+		case opCode == vm.APPCALL || opCode == vm.TAILCALL:
+			contract, eof := source.NextAddress()
+			if eof {
+				return nil, fmt.Errorf("read appcall address error")
+			}
+			if contract == common.ADDRESS_EMPTY {
+				return nil, fmt.Errorf("dynamic appcall is not supported")
+			}
+			invoke := &Invoke{Contract: contract}
+			if len(stack) > 0 {
+				if method, ok := stack[len(stack)-1].([]byte); ok {
+					invoke.Method = string(method)
+				}
+			}
+			invokes = append(invokes, invoke)
+			stack = stack[:0]
+		case opCode == vm.SYSCALL:
+			name, _, irregular, eof := source.NextVarBytes()
+			if irregular || eof {
+				return nil, fmt.Errorf("read syscall name error")
+			}
+			if string(name) != cutils.NATIVE_INVOKE_NAME {
+				return nil, fmt.Errorf("syscall %s is not supported", name)
+			}
+			invoke, err := decodeNativeInvoke(&stack)
+			if err != nil {
+				return nil, err
+			}
+			invokes = append(invokes, invoke)
+		default:
+			return nil, fmt.Errorf("opcode 0x%x is not supported", byte(opCode))
+		}
+	}
+	return invokes, nil
+}
+
+//decodeNativeInvoke pops version, contract, method and args of a native invoke
+func decodeNativeInvoke(stack *[]interface{}) (*Invoke, error) {
+	if len(*stack) < 4 {
+		return nil, fmt.Errorf("native invoke stack underflow")
+	}
+	items := *stack
+	args := items[len(items)-4]
+	method, ok1 := items[len(items)-3].([]byte)
+	address, ok2 := items[len(items)-2].([]byte)
+	*stack = items[:len(items)-4]
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("invalid native invoke params")
+	}
+	contract, err := common.AddressParseFromBytes(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid native contract address:%s", err)
+	}
+	invoke := &Invoke{Contract: contract, Method: string(method)}
+
+	var asset string
+	switch contract {
+	case utils.OntContractAddress:
+		asset = ASSET_ONT
+	case utils.OngContractAddress:
+		asset = ASSET_ONG
+	default:
+		return invoke, nil
+	}
+	switch invoke.Method {
+	case "transfer":
+		states, ok := args.(*vmStruct)
+		if !ok {
+			return nil, fmt.Errorf("invalid transfer args")
+		}
+		for _, item := range states.items {
+			transfer, err := parseTransfer(asset, item, false)
+			if err != nil {
+				return nil, err
+			}
+			invoke.Transfers = append(invoke.Transfers, transfer)
+		}
+	case "transferFrom":
+		transfer, err := parseTransfer(asset, args, true)
+		if err != nil {
+			return nil, err
+		}
+		invoke.Transfers = append(invoke.Transfers, transfer)
+	case "approve":
+		transfer, err := parseTransfer(asset, args, false)
+		if err != nil {
+			return nil, err
+		}
+		invoke.Transfers = append(invoke.Transfers, transfer)
+	}
+	return invoke, nil
+}
+
+//parseTransfer parse ont.State {from, to, value} or ont.TransferFrom {sender, from, to, value}
+func parseTransfer(asset string, item interface{}, withSender bool) (*Transfer, error) {
+	st, ok := item.(*vmStruct)
+	if !ok {
+		return nil, fmt.Errorf("invalid transfer state")
+	}
+	fields := st.items
+	if withSender {
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid transferFrom state")
+		}
+		fields = fields[1:]
+	}
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("invalid transfer state")
+	}
+	data := make([][]byte, 0, 3)
+	for _, field := range fields {
+		b, ok := field.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("invalid transfer state field")
+		}
+		data = append(data, b)
+	}
+	from, err := common.AddressParseFromBytes(data[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address:%s", err)
+	}
+	to, err := common.AddressParseFromBytes(data[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid to address:%s", err)
+	}
+	value := common.BigIntFromNeoBytes(data[2])
+	if value.Sign() < 0 || !value.IsUint64() {
+		return nil, fmt.Errorf("invalid transfer value:%s", value)
+	}
+	return &Transfer{Asset: asset, From: from, To: to, Value: value.Uint64()}, nil
+}
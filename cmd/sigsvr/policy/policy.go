@@ -0,0 +1,307 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package policy
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	cliutil "github.com/ontio/dad-go/cmd/utils"
+	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/core/types"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnauthorized = errors.New("unauthorized client")
+	ErrRateLimited  = errors.New("rate limit exceeded")
+)
+
+//UncheckedMethods sign raw data or export keys, so tx policy cannot check them. They are denied
+//when any tx policy is configured, unless listed in PolicyConfig.UncheckedMethods
+var UncheckedMethods = map[string]bool{
+	"sigdata":       true,
+	"exportaccount": true,
+}
+
+type ClientConfig struct {
+	Name      string `json:"name"`
+	Token     string `json:"token"`
+	RateLimit uint   `json:"rate_limit"` //max requests per minute, 0 means no limit
+}
+
+type ContractConfig struct {
+	Address string   `json:"address"` //hex or base58 address
+	Methods []string `json:"methods"` //empty means all methods
+}
+
+//PolicyConfig is the json policy file of sig server. Empty fields put no restriction
+type PolicyConfig struct {
+	Clients          []*ClientConfig   `json:"clients"`
+	Methods          []string          `json:"methods"`
+	UncheckedMethods []string          `json:"unchecked_methods"` //unchecked methods allowed when tx policy is set
+	Contracts        []*ContractConfig `json:"contracts"`
+	DailyLimits      map[string]string `json:"daily_limits"` //asset => amount per from address per UTC day
+	UsageFile        string            `json:"usage_file"`   //file keeping daily usage across restarts
+	Destinations     []string          `json:"destinations"`
+	AuditLog         string            `json:"audit_log"`
+	AuditAccount     string            `json:"audit_account"`
+}
+
+type clientState struct {
+	config      *ClientConfig
+	windowStart time.Time
+	count       uint
+}
+
+type Policy struct {
+	Config       *PolicyConfig
+	clients      []*clientState
+	methods      map[string]bool
+	contracts    map[common.Address]map[string]bool
+	limits       map[string]uint64
+	destinations map[common.Address]bool
+	unchecked    map[string]bool
+	usageDay     string
+	usage        map[string]uint64 //asset:from => amount
+	now          func() time.Time
+	lock         sync.Mutex
+}
+
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read policy file:%s error:%s", file, err)
+	}
+	config := &PolicyConfig{}
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal policy file:%s error:%s", file, err)
+	}
+	if config.UsageFile == "" && len(config.DailyLimits) > 0 {
+		config.UsageFile = file + ".usage"
+	}
+	return NewPolicy(config)
+}
+
+func NewPolicy(config *PolicyConfig) (*Policy, error) {
+	p := &Policy{
+		Config:       config,
+		clients:      make([]*clientState, 0, len(config.Clients)),
+		methods:      make(map[string]bool),
+		contracts:    make(map[common.Address]map[string]bool),
+		limits:       make(map[string]uint64),
+		destinations: make(map[common.Address]bool),
+		unchecked:    make(map[string]bool),
+		usage:        make(map[string]uint64),
+		now:          time.Now,
+	}
+	for _, client := range config.Clients {
+		if client.Token == "" {
+			return nil, fmt.Errorf("client:%s token cannot empty", client.Name)
+		}
+		p.clients = append(p.clients, &clientState{config: client})
+	}
+	for _, method := range config.Methods {
+		p.methods[method] = true
+	}
+	for _, contract := range config.Contracts {
+		addr, err := parseAddress(contract.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract address:%s", contract.Address)
+		}
+		var methods map[string]bool
+		if len(contract.Methods) > 0 {
+			methods = make(map[string]bool)
+			for _, method := range contract.Methods {
+				methods[method] = true
+			}
+		}
+		p.contracts[addr] = methods
+	}
+	for asset, amount := range config.DailyLimits {
+		asset = strings.ToLower(asset)
+		switch asset {
+		case ASSET_ONT:
+			p.limits[asset] = cliutil.ParseOnt(amount)
+		case ASSET_ONG:
+			p.limits[asset] = cliutil.ParseOng(amount)
+		default:
+			return nil, fmt.Errorf("unsupport asset:%s in daily limits", asset)
+		}
+	}
+	for _, dest := range config.Destinations {
+		addr, err := common.AddressFromBase58(dest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination address:%s", dest)
+		}
+		p.destinations[addr] = true
+	}
+	for _, method := range config.UncheckedMethods {
+		if !UncheckedMethods[method] {
+			return nil, fmt.Errorf("method:%s in unchecked methods is checked by tx policy", method)
+		}
+		p.unchecked[method] = true
+	}
+	if config.UsageFile != "" {
+		usage, err := loadUsage(config.UsageFile)
+		if err != nil {
+			return nil, err
+		}
+		p.usageDay = usage.Day
+		p.usage = usage.Usage
+	}
+	return p, nil
+}
+
+func parseAddress(address string) (common.Address, error) {
+	addr, err := common.AddressFromHexString(address)
+	if err == nil {
+		return addr, nil
+	}
+	return common.AddressFromBase58(address)
+}
+
+//CheckClient returns the name of client the token belongs to, and counts the request in client rate limit
+func (this *Policy) CheckClient(token string) (string, error) {
+	if this == nil || len(this.clients) == 0 {
+		return "", nil
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	var client *clientState
+	for _, c := range this.clients {
+		if subtle.ConstantTimeCompare([]byte(c.config.Token), []byte(token)) == 1 {
+			client = c
+			break
+		}
+	}
+	if client == nil {
+		return "", ErrUnauthorized
+	}
+	if client.config.RateLimit == 0 {
+		return client.config.Name, nil
+	}
+	now := this.now()
+	if now.Sub(client.windowStart) >= time.Minute {
+		client.windowStart = now
+		client.count = 0
+	}
+	if client.count >= client.config.RateLimit {
+		return client.config.Name, ErrRateLimited
+	}
+	client.count++
+	return client.config.Name, nil
+}
+
+func (this *Policy) CheckMethod(method string) error {
+	if this == nil {
+		return nil
+	}
+	if UncheckedMethods[method] && this.hasTxPolicy() && !this.unchecked[method] {
+		return fmt.Errorf("method:%s bypasses tx policy and is not in unchecked methods", method)
+	}
+	if len(this.methods) == 0 {
+		return nil
+	}
+	if !this.methods[method] {
+		return fmt.Errorf("method:%s is not allowed", method)
+	}
+	return nil
+}
+
+func (this *Policy) hasTxPolicy() bool {
+	return len(this.contracts) > 0 || len(this.limits) > 0 || len(this.destinations) > 0
+}
+
+//CheckTx checks the contract calls and transfers of tx. The transfer and approve amount is counted
+//in daily limits when tx passed, whether or not it is sent to chain later
+func (this *Policy) CheckTx(tx *types.MutableTransaction) error {
+	if this == nil {
+		return nil
+	}
+	if !this.hasTxPolicy() {
+		return nil
+	}
+	invokes, err := DecodeInvokes(tx)
+	if err != nil {
+		return fmt.Errorf("cannot decode tx:%s", err)
+	}
+	if len(invokes) == 0 && len(this.contracts) > 0 {
+		return fmt.Errorf("tx has no contract call")
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	day := this.now().UTC().Format("2006-01-02")
+	usage := this.usage
+	if day != this.usageDay {
+		usage = make(map[string]uint64)
+	}
+	pending := make(map[string]uint64)
+	for _, invoke := range invokes {
+		if len(this.contracts) > 0 {
+			methods, ok := this.contracts[invoke.Contract]
+			if !ok {
+				return fmt.Errorf("contract:%s is not allowed", invoke.Contract.ToHexString())
+			}
+			if methods != nil && !methods[invoke.Method] {
+				return fmt.Errorf("method:%s of contract:%s is not allowed", invoke.Method, invoke.Contract.ToHexString())
+			}
+		}
+		for _, transfer := range invoke.Transfers {
+			if len(this.destinations) > 0 && !this.destinations[transfer.To] {
+				return fmt.Errorf("destination:%s is not allowed", transfer.To.ToBase58())
+			}
+			limit, ok := this.limits[transfer.Asset]
+			if !ok {
+				continue
+			}
+			key := transfer.Asset + ":" + transfer.From.ToBase58()
+			total := usage[key] + pending[key] + transfer.Value
+			if total < transfer.Value || total > limit {
+				return fmt.Errorf("%s daily limit of %s exceeded", transfer.Asset, transfer.From.ToBase58())
+			}
+			pending[key] += transfer.Value
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	newUsage := make(map[string]uint64, len(usage)+len(pending))
+	for key, amount := range usage {
+		newUsage[key] = amount
+	}
+	for key, amount := range pending {
+		newUsage[key] += amount
+	}
+	if this.Config.UsageFile != "" {
+		err = saveUsage(this.Config.UsageFile, &dailyUsage{Day: day, Usage: newUsage})
+		if err != nil {
+			return err
+		}
+	}
+	this.usageDay = day
+	this.usage = newUsage
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package policy
+
+import (
+	"github.com/ontio/dad-go/account"
+	cliutil "github.com/ontio/dad-go/cmd/utils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPolicyCheckTx(t *testing.T) {
+	from := account.NewAccount("")
+	to := account.NewAccount("")
+	other := account.NewAccount("")
+	p, err := NewPolicy(&PolicyConfig{
+		Contracts: []*ContractConfig{
+			{Address: "0100000000000000000000000000000000000000", Methods: []string{"transfer"}},
+		},
+		DailyLimits:  map[string]string{"ont": "10"},
+		Destinations: []string{to.Address.ToBase58()},
+	})
+	assert.Nil(t, err)
+	day := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return day }
+
+	tx, err := cliutil.TransferTx(0, 20000, "ont", from.Address.ToBase58(), to.Address.ToBase58(), 6)
+	assert.Nil(t, err)
+	invokes, err := DecodeInvokes(tx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(invokes))
+	assert.Equal(t, "transfer", invokes[0].Method)
+	assert.Equal(t, 1, len(invokes[0].Transfers))
+	assert.Equal(t, from.Address, invokes[0].Transfers[0].From)
+	assert.Equal(t, to.Address, invokes[0].Transfers[0].To)
+	assert.Equal(t, uint64(6), invokes[0].Transfers[0].Value)
+
+	assert.Nil(t, p.CheckTx(tx))
+	//daily limit exceeded
+	assert.NotNil(t, p.CheckTx(tx))
+	//limit is reset in next day
+	day = day.Add(24 * time.Hour)
+	assert.Nil(t, p.CheckTx(tx))
+
+	tx, err = cliutil.TransferTx(0, 20000, "ont", from.Address.ToBase58(), other.Address.ToBase58(), 1)
+	assert.Nil(t, err)
+	assert.NotNil(t, p.CheckTx(tx), "destination not allowed")
+
+	tx, err = cliutil.TransferTx(0, 20000, "ong", from.Address.ToBase58(), to.Address.ToBase58(), 1)
+	assert.Nil(t, err)
+	assert.NotNil(t, p.CheckTx(tx), "contract not allowed")
+
+	tx, err = cliutil.ApproveTx(0, 20000, "ont", from.Address.ToBase58(), to.Address.ToBase58(), 1)
+	assert.Nil(t, err)
+	assert.NotNil(t, p.CheckTx(tx), "method not allowed")
+}
+
+func TestPolicyCheckApprove(t *testing.T) {
+	from := account.NewAccount("")
+	to := account.NewAccount("")
+	p, err := NewPolicy(&PolicyConfig{
+		DailyLimits: map[string]string{"ont": "10"},
+	})
+	assert.Nil(t, err)
+
+	//approve is counted in daily limit as transfer
+	tx, err := cliutil.ApproveTx(0, 20000, "ont", from.Address.ToBase58(), to.Address.ToBase58(), 8)
+	assert.Nil(t, err)
+	assert.Nil(t, p.CheckTx(tx))
+	tx, err = cliutil.TransferTx(0, 20000, "ont", from.Address.ToBase58(), to.Address.ToBase58(), 3)
+	assert.Nil(t, err)
+	assert.NotNil(t, p.CheckTx(tx))
+	tx, err = cliutil.ApproveTx(0, 20000, "ont", from.Address.ToBase58(), to.Address.ToBase58(), 3)
+	assert.Nil(t, err)
+	assert.NotNil(t, p.CheckTx(tx))
+}
+
+func TestPolicyUsageFile(t *testing.T) {
+	file := "usage.tmp.json"
+	defer os.Remove(file)
+	from := account.NewAccount("")
+	to := account.NewAccount("")
+	config := &PolicyConfig{
+		DailyLimits: map[string]string{"ont": "10"},
+		UsageFile:   file,
+	}
+	day := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	p, err := NewPolicy(config)
+	assert.Nil(t, err)
+	p.now = func() time.Time { return day }
+	tx, err := cliutil.TransferTx(0, 20000, "ont", from.Address.ToBase58(), to.Address.ToBase58(), 6)
+	assert.Nil(t, err)
+	assert.Nil(t, p.CheckTx(tx))
+
+	//usage is kept after restart
+	p, err = NewPolicy(config)
+	assert.Nil(t, err)
+	p.now = func() time.Time { return day }
+	assert.NotNil(t, p.CheckTx(tx))
+	day = day.Add(24 * time.Hour)
+	assert.Nil(t, p.CheckTx(tx))
+
+	assert.Nil(t, ioutil.WriteFile(file, []byte("{"), 0600))
+	_, err = NewPolicy(config)
+	assert.NotNil(t, err)
+}
+
+func TestPolicyUncheckedMethods(t *testing.T) {
+	to := account.NewAccount("")
+	p, err := NewPolicy(&PolicyConfig{
+		Destinations: []string{to.Address.ToBase58()},
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, p.CheckMethod("sigdata"))
+	assert.NotNil(t, p.CheckMethod("exportaccount"))
+	assert.Nil(t, p.CheckMethod("sigrawtx"))
+
+	p, err = NewPolicy(&PolicyConfig{
+		Destinations:     []string{to.Address.ToBase58()},
+		UncheckedMethods: []string{"sigdata"},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, p.CheckMethod("sigdata"))
+	assert.NotNil(t, p.CheckMethod("exportaccount"))
+
+	//no tx policy to bypass
+	p, err = NewPolicy(&PolicyConfig{})
+	assert.Nil(t, err)
+	assert.Nil(t, p.CheckMethod("sigdata"))
+
+	_, err = NewPolicy(&PolicyConfig{UncheckedMethods: []string{"sigrawtx"}})
+	assert.NotNil(t, err)
+}
+
+func TestPolicyCheckClient(t *testing.T) {
+	p, err := NewPolicy(&PolicyConfig{
+		Clients: []*ClientConfig{{Name: "exchange", Token: "secret", RateLimit: 2}},
+		Methods: []string{"sigrawtx"},
+	})
+	assert.Nil(t, err)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	_, err = p.CheckClient("wrong")
+	assert.Equal(t, ErrUnauthorized, err)
+	for i := 0; i < 2; i++ {
+		name, err := p.CheckClient("secret")
+		assert.Nil(t, err)
+		assert.Equal(t, "exchange", name)
+	}
+	_, err = p.CheckClient("secret")
+	assert.Equal(t, ErrRateLimited, err)
+	now = now.Add(time.Minute)
+	_, err = p.CheckClient("secret")
+	assert.Nil(t, err)
+
+	assert.Nil(t, p.CheckMethod("sigrawtx"))
+	assert.NotNil(t, p.CheckMethod("exportaccount"))
+
+	var nilPolicy *Policy
+	_, err = nilPolicy.CheckClient("")
+	assert.Nil(t, err)
+	assert.Nil(t, nilPolicy.CheckMethod("exportaccount"))
+}
+
+func TestAuditLog(t *testing.T) {
+	file := "audit.tmp.log"
+	defer os.Remove(file)
+	signer := account.NewAccount("")
+
+	logger, err := NewAuditLogger(file, signer)
+	assert.Nil(t, err)
+	assert.Nil(t, logger.Log(&AuditEntry{Qid: "1", Method: "sigrawtx", Decision: DECISION_ALLOW}))
+	assert.Nil(t, logger.Log(&AuditEntry{Qid: "2", Method: "sigrawtx", Decision: DECISION_DENY}))
+	assert.Nil(t, logger.Close())
+
+	//reopen and continue the chain
+	logger, err = NewAuditLogger(file, signer)
+	assert.Nil(t, err)
+	assert.Nil(t, logger.Log(&AuditEntry{Qid: "3", Method: "sigdata", Decision: DECISION_ALLOW}))
+	assert.Nil(t, logger.Close())
+
+	count, err := VerifyAuditLog(file)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	data, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	tampered := strings.Replace(string(data), `"qid":"2"`, `"qid":"9"`, 1)
+	assert.Nil(t, ioutil.WriteFile(file, []byte(tampered), 0600))
+	_, err = VerifyAuditLog(file)
+	assert.NotNil(t, err)
+}
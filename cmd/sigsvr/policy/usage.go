@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+//dailyUsage is the amount counted in daily limits of the UTC day, kept in usage file
+type dailyUsage struct {
+	Day   string            `json:"day"`
+	Usage map[string]uint64 `json:"usage"` //asset:from => amount
+}
+
+func loadUsage(file string) (*dailyUsage, error) {
+	usage := &dailyUsage{}
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		usage.Usage = make(map[string]uint64)
+		return usage, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read usage file:%s error:%s", file, err)
+	}
+	err = json.Unmarshal(data, usage)
+	if err != nil {
+		return nil, fmt.Errorf("usage file:%s corrupted:%s", file, err)
+	}
+	if usage.Usage == nil {
+		usage.Usage = make(map[string]uint64)
+	}
+	return usage, nil
+}
+
+//saveUsage writes usage to a temp file and renames it, so the usage file is never left half written
+func saveUsage(file string, usage *dailyUsage) error {
+	data, err := json.Marshal(usage)
+	if err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open usage file:%s error:%s", tmp, err)
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write usage file:%s error:%s", tmp, err)
+	}
+	err = os.Rename(tmp, file)
+	if err != nil {
+		return fmt.Errorf("rename usage file:%s error:%s", file, err)
+	}
+	return nil
+}
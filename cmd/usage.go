@@ -101,7 +101,18 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.LogLevelFlag,
 			utils.DisableLogFileFlag,
 			utils.DisableEventLogFlag,
+			utils.EnableAddressIndexFlag,
+			utils.MetricsPortFlag,
 			utils.DataDirFlag,
+			utils.PruneBlocksFlag,
+		},
+	},
+	{
+		Name: "SNAPSHOT",
+		Flags: []cli.Flag{
+			utils.SnapshotIntervalFlag,
+			utils.SnapshotHeightFlag,
+			utils.SnapshotHashFlag,
 		},
 	},
 	{
@@ -124,7 +135,12 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.AccountLowSecurityFlag,
 			utils.AccountMultiMFlag,
 			utils.AccountMultiPubKeyFlag,
+			utils.AccountMultiLabelsFlag,
 			utils.IdentityFlag,
+			utils.AccountDeriveFlag,
+			utils.WalletMnemonicFlag,
+			utils.WalletRestoreFlag,
+			utils.WalletHDPathFlag,
 		},
 	},
 	{
@@ -142,6 +158,10 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.TxpoolPreExecDisableFlag,
 			utils.DisableSyncVerifyTxFlag,
 			utils.DisableBroadcastNetTxFlag,
+			utils.TxpoolPriceBumpFlag,
+			utils.TxpoolAccountSlotsFlag,
+			utils.TxpoolAccountQueueFlag,
+			utils.TxpoolGlobalSlotsFlag,
 		},
 	},
 	{
@@ -164,6 +184,7 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.RPCPortFlag,
 			utils.RPCLocalEnableFlag,
 			utils.RPCLocalProtFlag,
+			utils.RPCTraceEnableFlag,
 		},
 	},
 	{
@@ -224,6 +245,8 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.ForceSendTxFlag,
 			utils.TransactionPayerFlag,
 			utils.PrepareExecTransactionFlag,
+			utils.MultiSigSignerFlag,
+			utils.PartialSignedTxFileFlag,
 			utils.TransferFromAmountFlag,
 			utils.WithdrawONGReceiveAccountFlag,
 			utils.WithdrawONGAmountFlag,
@@ -252,6 +275,13 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.ImportEndHeightFlag,
 		},
 	},
+	{
+		Name: "LIGHT CLIENT",
+		Flags: []cli.Flag{
+			utils.LightClientCheckpointFlag,
+			utils.LightClientStateFlag,
+		},
+	},
 	{
 		Name: "MISC",
 	},
@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ontio/ontology/account"
+	cutils "github.com/ontio/ontology/core/utils"
+	httpcom "github.com/ontio/ontology/http/base/common"
+	"github.com/ontio/ontology/smartcontract/service/native/content_registry"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//RegisterContent register the cid of file uploaded to ipfs in content registry contract, signer is the uploader
+func RegisterContent(gasPrice, gasLimit uint64, signer *account.Account, cid string, size uint64, fileName, note string, pinned bool) (string, error) {
+	param := &content_registry.RegisterContentParam{
+		Uploader: signer.Address,
+		Cid:      cid,
+		Size:     size,
+		FileName: fileName,
+		Note:     note,
+		Pinned:   pinned,
+	}
+	invokeCode, err := cutils.BuildNativeInvokeCode(utils.ContentRegistryContractAddress, 0,
+		content_registry.REGISTER_CONTENT_NAME, []interface{}{param})
+	if err != nil {
+		return "", fmt.Errorf("build invoke code error:%s", err)
+	}
+	tx := NewInvokeTransaction(gasPrice, gasLimit, invokeCode)
+	return InvokeSmartContract(signer, tx)
+}
+
+//GetContentRecord return the on-chain record of cid, nil if cid is not registered
+func GetContentRecord(cid string) (*httpcom.ContentRecord, error) {
+	data, ontErr := sendRpcRequest("getcontent", []interface{}{cid})
+	if ontErr != nil {
+		switch ontErr.ErrorCode {
+		case ERROR_UNKNOWN_CONTENT:
+			return nil, nil
+		}
+		return nil, ontErr.Error
+	}
+	record := &httpcom.ContentRecord{}
+	err := json.Unmarshal(data, record)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal ContentRecord:%s error:%s", data, err)
+	}
+	return record, nil
+}
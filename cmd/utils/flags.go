@@ -23,14 +23,18 @@ import (
 
 	"github.com/ontio/dad-go/common/config"
 	"github.com/ontio/dad-go/smartcontract/service/neovm"
+	tc "github.com/ontio/dad-go/txnpool/common"
 	"github.com/urfave/cli"
 )
 
 const (
-	DEFAULT_EXPORT_FILE   = "./OntBlocks.dat"
-	DEFAULT_ABI_PATH      = "./abi"
-	DEFAULT_EXPORT_HEIGHT = 0
-	DEFAULT_WALLET_PATH   = "./wallet_data"
+	DEFAULT_EXPORT_FILE        = "./OntBlocks.dat"
+	DEFAULT_ABI_PATH           = "./abi"
+	DEFAULT_EXPORT_HEIGHT      = 0
+	DEFAULT_WALLET_PATH        = "./wallet_data"
+	DEFAULT_LIGHT_STATE        = "./lightclient.json"
+	DEFAULT_SANDBOX_GAS_LIMIT  = 20000000
+	DEFAULT_SANDBOX_BLOCK_TIME = 6
 )
 
 var (
@@ -52,6 +56,15 @@ var (
 		Name:  "disable-event-log",
 		Usage: "Discard event log output by smart contract execution",
 	}
+	EnableAddressIndexFlag = cli.BoolFlag{
+		Name:  "enable-address-index",
+		Usage: "Index transactions by related address to support address history query. Costs extra disk space",
+	}
+	MetricsPortFlag = cli.UintFlag{
+		Name:  "metrics-port",
+		Usage: "The listening port of http server exporting node metrics in prometheus text format. 0 means disable `<number>`",
+		Value: config.DEFAULT_METRICS_PORT,
+	}
 	WalletFileFlag = cli.StringFlag{
 		Name:  "wallet,w",
 		Value: config.DEFAULT_WALLET_FILE_NAME,
@@ -72,6 +85,24 @@ var (
 		Usage: "Block data storage `<path>`",
 		Value: config.DEFAULT_DATA_DIR,
 	}
+	PruneBlocksFlag = cli.UintFlag{
+		Name:  "prune-blocks",
+		Usage: "Keep blocks and event notifies of the latest `<number>` blocks only, 0 means keep all",
+	}
+
+	//Snapshot setting
+	SnapshotIntervalFlag = cli.UintFlag{
+		Name:  "snapshot-interval",
+		Usage: "Take a state snapshot every `<number>` blocks for fast sync of other nodes, 0 means disable",
+	}
+	SnapshotHeightFlag = cli.UintFlag{
+		Name:  "snapshot-height",
+		Usage: "Bootstrap an empty ledger from the state snapshot at block `<height>`",
+	}
+	SnapshotHashFlag = cli.StringFlag{
+		Name:  "snapshot-hash",
+		Usage: "Trusted manifest `<hash>` of the snapshot set by --snapshot-height",
+	}
 
 	//Consensus setting
 	EnableConsensusFlag = cli.BoolFlag{
@@ -164,6 +195,10 @@ var (
 		Usage: "Json rpc local server listening port `<number>`",
 		Value: config.DEFAULT_RPC_LOCAL_PORT,
 	}
+	RPCTraceEnableFlag = cli.BoolFlag{
+		Name:  "rpctrace",
+		Usage: "Enable the json rpc methods tracing transaction execution",
+	}
 
 	//Websocket setting
 	WsEnabledFlag = cli.BoolFlag{
@@ -264,6 +299,26 @@ var (
 		Name:  "pubkey",
 		Usage: "Pub key list of multi `<addresses>`, separate addreses with comma `,`",
 	}
+	AccountMultiLabelsFlag = cli.StringFlag{
+		Name:  "labels",
+		Usage: "Label list of multi signature account members, in the same order of --pubkey, separate labels with comma `,`",
+	}
+	AccountDeriveFlag = cli.BoolFlag{
+		Name:  "derive",
+		Usage: "Derive account from the mnemonic of HD wallet. Only ecdsa P-256 and sm2 are supported",
+	}
+	WalletMnemonicFlag = cli.BoolFlag{
+		Name:  "mnemonic",
+		Usage: "Create HD wallet with a new mnemonic, or display the mnemonic of HD wallet",
+	}
+	WalletRestoreFlag = cli.BoolFlag{
+		Name:  "restore",
+		Usage: "Restore HD wallet from an existing mnemonic",
+	}
+	WalletHDPathFlag = cli.StringFlag{
+		Name:  "hdpath",
+		Usage: "Derive `<path>` of HD wallet, account index is appended to it. Default is m/44'/1024'/0'/0",
+	}
 	IdentityFlag = cli.BoolFlag{
 		Name:  "ontid",
 		Usage: "create an ONT ID instead of account",
@@ -408,6 +463,14 @@ var (
 		Name:  "raw-tx",
 		Usage: "Raw `<transaction>` encode with hex string",
 	}
+	MultiSigSignerFlag = cli.StringFlag{
+		Name:  "signer",
+		Usage: "Member `<address>` in wallet to sign the transaction of multi signature account. If not specific, using the first member in wallet",
+	}
+	PartialSignedTxFileFlag = cli.StringFlag{
+		Name:  "psbt",
+		Usage: "Partial signed transaction `<file>` of multi signature account. Default is <txhash>.psbt",
+	}
 	PrepareExecTransactionFlag = cli.BoolFlag{
 		Name:  "prepare,p",
 		Usage: "Prepare execute transaction, without commit to ledger",
@@ -446,6 +509,10 @@ var (
 		Usage: "Wallet data `<path>`",
 		Value: DEFAULT_WALLET_PATH,
 	}
+	CliPolicyFileFlag = cli.StringFlag{
+		Name:  "policy",
+		Usage: "Signing policy `<file>` of sig server. No restriction if not set",
+	}
 
 	//Export setting
 	ExportFileFlag = cli.StringFlag{
@@ -486,6 +553,118 @@ var (
 		Usage: "Disable broadcast tx from network in tx pool",
 	}
 
+	TxpoolPriceBumpFlag = cli.Uint64Flag{
+		Name:  "tx-pool-price-bump",
+		Usage: "Min gas price bump `<percentage>` to replace a tx with same payer and nonce in tx pool",
+		Value: config.DEFAULT_TXPOOL_PRICE_BUMP,
+	}
+	TxpoolAccountSlotsFlag = cli.UintFlag{
+		Name:  "tx-pool-account-slots",
+		Usage: "Max pending tx `<number>` of a payer in tx pool, 0 means no limit",
+		Value: config.DEFAULT_TXPOOL_ACCOUNT_SLOTS,
+	}
+	TxpoolAccountQueueFlag = cli.UintFlag{
+		Name:  "tx-pool-account-queue",
+		Usage: "Max queued tx `<number>` of a payer in tx pool",
+		Value: config.DEFAULT_TXPOOL_ACCOUNT_QUEUE,
+	}
+	TxpoolGlobalSlotsFlag = cli.UintFlag{
+		Name:  "tx-pool-global-slots",
+		Usage: "Max tx `<number>` in tx pool, the cheapest txs are evicted when it is full",
+		Value: tc.MAX_CAPACITY,
+	}
+
+	//Oracle setting
+	OracleGuarantyFlag = cli.Uint64Flag{
+		Name:  "guaranty",
+		Usage: "Guaranty `<amount>` of ONT to register oracle node",
+		Value: 1000,
+	}
+	OracleFetchTimeoutFlag = cli.UintFlag{
+		Name:  "fetchtimeout",
+		Usage: "Timeout `<seconds>` of fetching the data of oracle request",
+		Value: 10,
+	}
+
+	//Content registry setting
+	DataNoteFlag = cli.StringFlag{
+		Name:  "note",
+		Usage: "Note `<text>` of the uploaded file recorded on chain",
+	}
+	DataNoPinFlag = cli.BoolFlag{
+		Name:  "nopin",
+		Usage: "Upload file to ipfs without pinning it by ipfs cluster",
+	}
+	DataIPFSAddressFlag = cli.StringFlag{
+		Name:  "ipfs",
+		Usage: "Address `<host:port>` of ipfs daemon api",
+		Value: "127.0.0.1:5001",
+	}
+	DataClusterAddressFlag = cli.StringFlag{
+		Name:  "cluster",
+		Usage: "Address `<host:port>` of ipfs cluster rest api",
+		Value: "127.0.0.1:9094",
+	}
+
+	//Governance setting
+	GovernancePeerPubkeyFlag = cli.StringFlag{
+		Name:  "peerpubkey",
+		Usage: "Public key `<hex>` of consensus peer. Multiple public keys separated by ',' if the command supports",
+	}
+	GovernanceInitPosFlag = cli.UintFlag{
+		Name:  "initpos",
+		Usage: "Init pos `<amount>` of ONT staked by the peer owner",
+	}
+	GovernancePosFlag = cli.StringFlag{
+		Name:  "pos",
+		Usage: "Pos `<amount>` of ONT for each peer, separated by ',' in the same order of peer public keys",
+	}
+	GovernanceOntIdFlag = cli.StringFlag{
+		Name:  "ontid",
+		Usage: "ONT ID `<did>` of the peer owner, which should be authorized by the governance contract",
+	}
+	GovernanceKeyNoFlag = cli.UintFlag{
+		Name:  "keyno",
+		Usage: "Index `<number>` of the public key in ONT ID used to sign the transaction",
+		Value: 1,
+	}
+	GovernancePeerCostFlag = cli.UintFlag{
+		Name:  "peercost",
+		Usage: "Percentage `<number>` of the incomes the peer keeps for itself, from 0 to 100",
+	}
+	GovernanceBuildTxFlag = cli.BoolFlag{
+		Name:  "buildtx",
+		Usage: "Build the unsigned raw transaction only, which can be signed by sigtx or multisigtx and sent by sendtx",
+	}
+
+	//Light client setting
+	LightClientCheckpointFlag = cli.StringFlag{
+		Name:  "checkpoint",
+		Usage: "Trusted block `<height:hash>` to start header verification from. A chain config block loads fastest",
+	}
+	LightClientStateFlag = cli.StringFlag{
+		Name:  "lightstate",
+		Usage: "Light client state `<file>`. The last verified header is saved in it, and used as checkpoint next time",
+		Value: DEFAULT_LIGHT_STATE,
+	}
+
+	//Sandbox setting
+	SandboxGasLimitFlag = cli.Uint64Flag{
+		Name:  "gaslimit",
+		Usage: "Gas limit of each transaction executed in sandbox",
+		Value: DEFAULT_SANDBOX_GAS_LIMIT,
+	}
+	SandboxGasPriceFlag = cli.Uint64Flag{
+		Name:  "gasprice",
+		Usage: "Gas price of each transaction executed in sandbox, the gas is charged in ONG from the payer",
+		Value: config.DEFAULT_GAS_PRICE,
+	}
+	SandboxBlockTimeFlag = cli.UintFlag{
+		Name:  "blocktime",
+		Usage: "Timestamp interval `<seconds>` between blocks of sandbox",
+		Value: DEFAULT_SANDBOX_BLOCK_TIME,
+	}
+
 	NonOptionFlag = cli.StringFlag{
 		Name:  "option",
 		Usage: "this command does not need option, please run directly",
@@ -0,0 +1,140 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/types"
+	httpcom "github.com/ontio/ontology/http/base/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//RegisterCandidateTx return the transaction registering peer as candidate with init pos, the transaction should
+//be signed by address and the key of ontId
+func RegisterCandidateTx(gasPrice, gasLimit uint64, peerPubkey string, address common.Address, initPos uint32,
+	ontId string, keyNo uint32) *types.MutableTransaction {
+	param := &governance.RegisterCandidateParam{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+		InitPos:    initPos,
+		Caller:     []byte(ontId),
+		KeyNo:      keyNo,
+	}
+	sink := common.NewZeroCopySink(nil)
+	param.Serialization(sink)
+	return newGovernanceTx(gasPrice, gasLimit, governance.REGISTER_CANDIDATE, sink.Bytes())
+}
+
+//QuitNodeTx return the transaction quitting peer from candidates or consensus nodes
+func QuitNodeTx(gasPrice, gasLimit uint64, peerPubkey string, address common.Address) *types.MutableTransaction {
+	param := &governance.QuitNodeParam{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+	}
+	sink := common.NewZeroCopySink(nil)
+	param.Serialization(sink)
+	return newGovernanceTx(gasPrice, gasLimit, governance.QUIT_NODE, sink.Bytes())
+}
+
+//AuthorizeForPeerTx return the transaction authorizing pos of address to peers
+func AuthorizeForPeerTx(gasPrice, gasLimit uint64, address common.Address, peerPubkeys []string,
+	posList []uint32) (*types.MutableTransaction, error) {
+	param := &governance.AuthorizeForPeerParam{
+		Address:        address,
+		PeerPubkeyList: peerPubkeys,
+		PosList:        posList,
+	}
+	sink := common.NewZeroCopySink(nil)
+	if err := param.Serialization(sink); err != nil {
+		return nil, err
+	}
+	return newGovernanceTx(gasPrice, gasLimit, governance.AUTHORIZE_FOR_PEER, sink.Bytes()), nil
+}
+
+//WithdrawTx return the transaction withdrawing unfrozen pos of address from peers
+func WithdrawTx(gasPrice, gasLimit uint64, address common.Address, peerPubkeys []string,
+	withdrawList []uint32) (*types.MutableTransaction, error) {
+	param := &governance.WithdrawParam{
+		Address:        address,
+		PeerPubkeyList: peerPubkeys,
+		WithdrawList:   withdrawList,
+	}
+	sink := common.NewZeroCopySink(nil)
+	if err := param.Serialization(sink); err != nil {
+		return nil, err
+	}
+	return newGovernanceTx(gasPrice, gasLimit, governance.WITHDRAW, sink.Bytes()), nil
+}
+
+//SetPeerCostTx return the transaction setting the income percentage the peer keeps
+func SetPeerCostTx(gasPrice, gasLimit uint64, peerPubkey string, address common.Address, peerCost uint32) *types.MutableTransaction {
+	param := &governance.SetPeerCostParam{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+		PeerCost:   peerCost,
+	}
+	sink := common.NewZeroCopySink(nil)
+	param.Serialization(sink)
+	return newGovernanceTx(gasPrice, gasLimit, governance.SET_PEER_COST, sink.Bytes())
+}
+
+//WithdrawFeeTx return the transaction withdrawing the split fee of address
+func WithdrawFeeTx(gasPrice, gasLimit uint64, address common.Address) *types.MutableTransaction {
+	param := &governance.WithdrawFeeParam{
+		Address: address,
+	}
+	sink := common.NewZeroCopySink(nil)
+	param.Serialization(sink)
+	return newGovernanceTx(gasPrice, gasLimit, governance.WITHDRAW_FEE, sink.Bytes())
+}
+
+func newGovernanceTx(gasPrice, gasLimit uint64, method string, args []byte) *types.MutableTransaction {
+	return NewNativeRawInvokeTransaction(gasPrice, gasLimit, utils.GovernanceContractAddress, method, args)
+}
+
+//GetGovernanceView return the current view of governance contract
+func GetGovernanceView() (*governance.GovernanceView, error) {
+	return httpcom.QueryGovernanceView(getGovernanceStorage)
+}
+
+//GetPeerPoolMap return the peers of governance contract in view
+func GetPeerPoolMap(view uint32) (*governance.PeerPoolMap, error) {
+	return httpcom.QueryPeerPoolMap(getGovernanceStorage, view)
+}
+
+//GetAuthorizeInfo return the pos authorized by address to peer, an empty info is returned if address doesn't
+//authorize to the peer
+func GetAuthorizeInfo(peerPubkey string, address common.Address) (*governance.AuthorizeInfo, error) {
+	return httpcom.QueryAuthorizeInfo(getGovernanceStorage, peerPubkey, address)
+}
+
+//GetSplitFeeAddress return the split fee of address which can be withdrawn
+func GetSplitFeeAddress(address common.Address) (*governance.SplitFeeAddress, error) {
+	return httpcom.QuerySplitFeeAddress(getGovernanceStorage, address)
+}
+
+//GetPromisePos return the promise pos of peer
+func GetPromisePos(peerPubkey string) (*governance.PromisePos, error) {
+	return httpcom.QueryPromisePos(getGovernanceStorage, peerPubkey)
+}
+
+func getGovernanceStorage(key []byte) ([]byte, error) {
+	return GetStorage(utils.GovernanceContractAddress.ToHexString(), key)
+}
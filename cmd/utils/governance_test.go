@@ -0,0 +1,48 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/payload"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGovernanceTx(t *testing.T) {
+	address := common.AddressFromVmCode([]byte("governance"))
+	peerPubkey := "02c0cd1b2d7b7d3b1e5a2d8e0c7b1f3c7d5a6e8f9b0c1d2e3f4a5b6c7d8e9f0a1b"
+
+	tx := QuitNodeTx(500, 20000, peerPubkey, address)
+	param := &governance.QuitNodeParam{PeerPubkey: peerPubkey, Address: address}
+	sink := common.NewZeroCopySink(nil)
+	param.Serialization(sink)
+	code := tx.Payload.(*payload.InvokeCode).Code
+	assert.True(t, bytes.Contains(code, sink.Bytes()))
+	assert.True(t, bytes.Contains(code, []byte(governance.QUIT_NODE)))
+
+	_, err := AuthorizeForPeerTx(500, 20000, address, []string{peerPubkey}, []uint32{100, 200})
+	assert.NotNil(t, err)
+	tx, err = WithdrawTx(500, 20000, address, []string{peerPubkey}, []uint32{100})
+	assert.Nil(t, err)
+	assert.True(t, bytes.Contains(tx.Payload.(*payload.InvokeCode).Code, []byte(governance.WITHDRAW)))
+}
@@ -605,6 +605,40 @@ func GetTxHeight(txHash string) (uint32, error) {
 	return height, nil
 }
 
+func GetMerkleProof(txHash string) (*httpcom.MerkleProof, error) {
+	data, ontErr := sendRpcRequest("getmerkleproof", []interface{}{txHash})
+	if ontErr != nil {
+		switch ontErr.ErrorCode {
+		case ERROR_INVALID_PARAMS:
+			return nil, fmt.Errorf("cannot find tx by:%s", txHash)
+		}
+		return nil, ontErr.Error
+	}
+	proof := &httpcom.MerkleProof{}
+	err := json.Unmarshal(data, proof)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal error:%s", err)
+	}
+	return proof, nil
+}
+
+//GetStorage return the storage value of contract, nil if the key doesn't exist
+func GetStorage(contractAddress string, key []byte) ([]byte, error) {
+	data, ontErr := sendRpcRequest("getstorage", []interface{}{contractAddress, hex.EncodeToString(key)})
+	if ontErr != nil {
+		return nil, ontErr.Error
+	}
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal error:%s", err)
+	}
+	if value == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(value)
+}
+
 func DeployContract(
 	gasPrice,
 	gasLimit uint64,
@@ -0,0 +1,113 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ontio/ontology/account"
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/types"
+	cutils "github.com/ontio/ontology/core/utils"
+	httpcom "github.com/ontio/ontology/http/base/common"
+	"github.com/ontio/ontology/smartcontract/service/native/oracle"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+	"github.com/ontio/ontology/vm/neovm"
+)
+
+type oracleParam interface {
+	Serialize(w io.Writer) error
+}
+
+//RegisterOracleNode register signer as oracle node with guaranty ont, the node can work after being approved
+func RegisterOracleNode(gasPrice, gasLimit uint64, signer *account.Account, guaranty uint64) (string, error) {
+	param := &oracle.RegisterOracleNodeParam{
+		Address:  hex.EncodeToString(signer.Address[:]),
+		Guaranty: guaranty,
+	}
+	return invokeOracleContract(gasPrice, gasLimit, signer, oracle.REGISTER_ORACLE_NODE, param)
+}
+
+//QuitOracleNode quit signer from oracle nodes and take back its guaranty
+func QuitOracleNode(gasPrice, gasLimit uint64, signer *account.Account) (string, error) {
+	param := &oracle.QuitOracleNodeParam{
+		Address: hex.EncodeToString(signer.Address[:]),
+	}
+	return invokeOracleContract(gasPrice, gasLimit, signer, oracle.QUIT_ORACLE_NODE, param)
+}
+
+//SetOracleOutcome submit outcome of request, txHash is the request key used by oracle contract
+func SetOracleOutcome(gasPrice, gasLimit uint64, signer *account.Account, txHash, outcome string) (string, error) {
+	param := &oracle.SetOracleOutcomeParam{
+		TxHash:  txHash,
+		Address: hex.EncodeToString(signer.Address[:]),
+		Outcome: outcome,
+	}
+	return invokeOracleContract(gasPrice, gasLimit, signer, oracle.SET_ORACLE_OUTCOME, param)
+}
+
+//GetPendingOracleRequests return the oracle requests waiting for outcomes
+func GetPendingOracleRequests() ([]*httpcom.OracleRequest, error) {
+	data, ontErr := sendRpcRequest("listpendingoraclerequests", []interface{}{})
+	if ontErr != nil {
+		return nil, ontErr.Error
+	}
+	requests := make([]*httpcom.OracleRequest, 0)
+	err := json.Unmarshal(data, &requests)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal OracleRequests:%s error:%s", data, err)
+	}
+	return requests, nil
+}
+
+//OracleRequestKey convert tx hash string to the request key used by oracle contract
+func OracleRequestKey(txHash string) (string, error) {
+	hash, err := common.Uint256FromHexString(txHash)
+	if err != nil {
+		return "", fmt.Errorf("invalid tx hash:%s", txHash)
+	}
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func invokeOracleContract(gasPrice, gasLimit uint64, signer *account.Account, method string, param oracleParam) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := param.Serialize(buf); err != nil {
+		return "", fmt.Errorf("serialize %s param error:%s", method, err)
+	}
+	tx := NewNativeRawInvokeTransaction(gasPrice, gasLimit, utils.OracleContractAddress, method, buf.Bytes())
+	return InvokeSmartContract(signer, tx)
+}
+
+//NewNativeRawInvokeTransaction return native contract invoke transaction, the input of native contract is args
+//as it is, for contracts decoding their own serialization format
+func NewNativeRawInvokeTransaction(gasPrice, gasLimit uint64, contract common.Address, method string, args []byte) *types.MutableTransaction {
+	builder := neovm.NewParamsBuilder(new(bytes.Buffer))
+	builder.EmitPushByteArray(args)
+	builder.EmitPushByteArray([]byte(method))
+	builder.EmitPushByteArray(contract[:])
+	builder.EmitPushInteger(big.NewInt(0))
+	builder.Emit(neovm.SYSCALL)
+	builder.EmitPushByteArray([]byte(cutils.NATIVE_INVOKE_NAME))
+	return NewInvokeTransaction(gasPrice, gasLimit, builder.ToArray())
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ontio/ontology-crypto/keypair"
+	"github.com/ontio/ontology/account"
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/signature"
+	"github.com/ontio/ontology/core/types"
+)
+
+const PARTIAL_SIGNED_TX_VERSION = 1
+
+//PartialSignedTx is a transaction of multi signature account waiting for signatures of members.
+//The file can be passed around cosigners until the number of signatures reaches M
+type PartialSignedTx struct {
+	Version int              `json:"version"`
+	TxHash  string           `json:"txHash"`
+	RawTx   string           `json:"rawTx"`
+	Address string           `json:"address"`
+	M       int              `json:"m"`
+	Signers []*PartialSigner `json:"signers"`
+}
+
+//PartialSigner is a member of multi signature account, Signature is empty if member has not signed yet
+type PartialSigner struct {
+	Label     string `json:"label"`
+	PubKey    string `json:"publicKey"`
+	Signature string `json:"signature,omitempty"`
+}
+
+func NewPartialSignedTx(mutTx *types.MutableTransaction, msAcc *account.MultiSigAccountData) (*PartialSignedTx, error) {
+	addr, err := common.AddressFromBase58(msAcc.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid multi signature address:%s", err)
+	}
+	if mutTx.Payer == common.ADDRESS_EMPTY {
+		mutTx.Payer = addr
+	}
+	tx, err := mutTx.IntoImmutable()
+	if err != nil {
+		return nil, fmt.Errorf("IntoImmutable error:%s", err)
+	}
+	txHash := tx.Hash()
+	ptx := &PartialSignedTx{
+		Version: PARTIAL_SIGNED_TX_VERSION,
+		TxHash:  txHash.ToHexString(),
+		RawTx:   hex.EncodeToString(common.SerializeToBytes(tx)),
+		Address: msAcc.Address,
+		M:       msAcc.M,
+		Signers: make([]*PartialSigner, 0, len(msAcc.Members)),
+	}
+	for _, member := range msAcc.Members {
+		ptx.Signers = append(ptx.Signers, &PartialSigner{Label: member.Label, PubKey: member.PubKey})
+	}
+	return ptx, nil
+}
+
+func LoadPartialSignedTx(file string) (*PartialSignedTx, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	ptx := &PartialSignedTx{}
+	err = json.Unmarshal(data, ptx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid partial signed tx file:%s", err)
+	}
+	if ptx.Version != PARTIAL_SIGNED_TX_VERSION {
+		return nil, fmt.Errorf("unsupported partial signed tx version:%d", ptx.Version)
+	}
+	return ptx, nil
+}
+
+func (this *PartialSignedTx) Save(file string) error {
+	data, err := json.MarshalIndent(this, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+func (this *PartialSignedTx) GetTransaction() (*types.MutableTransaction, error) {
+	data, err := hex.DecodeString(this.RawTx)
+	if err != nil {
+		return nil, fmt.Errorf("RawTx hex decode error:%s", err)
+	}
+	tx, err := types.TransactionFromRawBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("TransactionFromRawBytes error:%s", err)
+	}
+	txHash := tx.Hash()
+	if txHash.ToHexString() != this.TxHash {
+		return nil, fmt.Errorf("tx hash mismatch, expect:%s got:%s", this.TxHash, txHash.ToHexString())
+	}
+	return tx.IntoMutable()
+}
+
+//Sign add signature of signer, signer should be a member of the multi signature account
+func (this *PartialSignedTx) Sign(signer *account.Account) error {
+	mutTx, err := this.GetTransaction()
+	if err != nil {
+		return err
+	}
+	pubKey := hex.EncodeToString(keypair.SerializePublicKey(signer.PublicKey))
+	for _, member := range this.Signers {
+		if member.PubKey != pubKey {
+			continue
+		}
+		txHash := mutTx.Hash()
+		sigData, err := Sign(txHash.ToArray(), signer)
+		if err != nil {
+			return fmt.Errorf("sign error:%s", err)
+		}
+		member.Signature = hex.EncodeToString(sigData)
+		return nil
+	}
+	return fmt.Errorf("signer:%s is not a member of %s", signer.Address.ToBase58(), this.Address)
+}
+
+//SignedCount return the number of valid signatures
+func (this *PartialSignedTx) SignedCount() int {
+	sigs, _, err := this.validSignatures()
+	if err != nil {
+		return 0
+	}
+	return len(sigs)
+}
+
+func (this *PartialSignedTx) IsComplete() bool {
+	return this.SignedCount() >= this.M
+}
+
+func (this *PartialSignedTx) validSignatures() ([][]byte, []keypair.PublicKey, error) {
+	mutTx, err := this.GetTransaction()
+	if err != nil {
+		return nil, nil, err
+	}
+	txHash := mutTx.Hash()
+	sigs := make([][]byte, 0, this.M)
+	pubKeys := make([]keypair.PublicKey, 0, len(this.Signers))
+	for _, member := range this.Signers {
+		data, err := hex.DecodeString(member.PubKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid public key:%s", member.PubKey)
+		}
+		pubKey, err := keypair.DeserializePublicKey(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid public key:%s", member.PubKey)
+		}
+		pubKeys = append(pubKeys, pubKey)
+		if member.Signature == "" || len(sigs) >= this.M {
+			continue
+		}
+		sigData, err := hex.DecodeString(member.Signature)
+		if err != nil {
+			continue
+		}
+		if signature.Verify(pubKey, txHash.ToArray(), sigData) == nil {
+			sigs = append(sigs, sigData)
+		}
+	}
+	return sigs, pubKeys, nil
+}
+
+//Finalize return the transaction with multi signature, can be sent after the number of signatures reaches M
+func (this *PartialSignedTx) Finalize() (*types.Transaction, error) {
+	sigs, pubKeys, err := this.validSignatures()
+	if err != nil {
+		return nil, err
+	}
+	if len(sigs) < this.M {
+		return nil, fmt.Errorf("not enough signatures, %d of %d signed", len(sigs), this.M)
+	}
+	addr, err := types.AddressFromMultiPubKeys(pubKeys, this.M)
+	if err != nil {
+		return nil, err
+	}
+	if addr.ToBase58() != this.Address {
+		return nil, fmt.Errorf("members do not match multi signature address:%s", this.Address)
+	}
+	mutTx, err := this.GetTransaction()
+	if err != nil {
+		return nil, err
+	}
+	mutTx.Sigs = append(mutTx.Sigs, types.Sig{
+		PubKeys: pubKeys,
+		M:       uint16(this.M),
+		SigData: sigs,
+	})
+	return mutTx.IntoImmutable()
+}
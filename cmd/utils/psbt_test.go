@@ -0,0 +1,63 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package utils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ontio/ontology-crypto/keypair"
+	"github.com/ontio/ontology/account"
+	"github.com/ontio/ontology/core/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartialSignedTx(t *testing.T) {
+	accs := []*account.Account{account.NewAccount(""), account.NewAccount(""), account.NewAccount("")}
+	pubKeys := []keypair.PublicKey{accs[0].PublicKey, accs[1].PublicKey, accs[2].PublicKey}
+	msAcc, err := account.NewMultiSigAccountData("team", 2, pubKeys, []string{"a", "b", "c"})
+	assert.Nil(t, err)
+
+	mutTx, err := TransferTx(0, 20000, ASSET_ONT, msAcc.Address, accs[0].Address.ToBase58(), 1)
+	assert.Nil(t, err)
+	ptx, err := NewPartialSignedTx(mutTx, msAcc)
+	assert.Nil(t, err)
+	assert.Nil(t, ptx.Sign(accs[2]))
+	assert.NotNil(t, ptx.Sign(account.NewAccount("")))
+	assert.Equal(t, 1, ptx.SignedCount())
+	assert.False(t, ptx.IsComplete())
+	_, err = ptx.Finalize()
+	assert.NotNil(t, err)
+
+	file := "./psbt_test.json"
+	defer os.Remove(file)
+	assert.Nil(t, ptx.Save(file))
+	ptx, err = LoadPartialSignedTx(file)
+	assert.Nil(t, err)
+	assert.Nil(t, ptx.Sign(accs[0]))
+	assert.True(t, ptx.IsComplete())
+
+	tx, err := ptx.Finalize()
+	assert.Nil(t, err)
+	assert.Equal(t, msAcc.Address, tx.Payer.ToBase58())
+	txHash := tx.Hash()
+	sig, err := tx.Sigs[len(tx.Sigs)-1].GetSig()
+	assert.Nil(t, err)
+	assert.Nil(t, signature.VerifyMultiSignature(txHash.ToArray(), sig.PubKeys, int(sig.M), sig.SigData))
+}
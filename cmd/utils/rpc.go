@@ -33,6 +33,7 @@ const JSON_RPC_VERSION = "2.0"
 
 const (
 	ERROR_INVALID_PARAMS   = rpcerr.INVALID_PARAMS
+	ERROR_UNKNOWN_CONTENT  = rpcerr.UNKNOWN_CONTENT
 	ERROR_dad-go_COMMON  = 10000
 	ERROR_dad-go_SUCCESS = 0
 )
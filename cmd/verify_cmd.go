@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ontio/dad-go/cmd/utils"
+	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/core/types"
+	"github.com/ontio/dad-go/lightclient"
+	"github.com/urfave/cli"
+)
+
+var VerifyCommand = cli.Command{
+	Action:    verifyTx,
+	Name:      "verify",
+	Usage:     "Verify transaction inclusion with block headers from a trusted checkpoint",
+	ArgsUsage: "<txhash>",
+	Flags: []cli.Flag{
+		utils.RPCPortFlag,
+		utils.LightClientCheckpointFlag,
+		utils.LightClientStateFlag,
+	},
+	Description: `Verify that a transaction is in the chain without trusting the node. Block headers are synced
+from the trusted checkpoint, and each header is verified with the bookkeeper signatures. Then the
+transaction is verified with the block merkle proof of getmerkleproof against the verified header.
+The last verified header is saved in light client state file, so the following verifications
+only sync the new headers. The checkpoint can be get by "./dad-go info block <height>" from a node you trust.`,
+}
+
+func verifyTx(ctx *cli.Context) error {
+	SetRpcPort(ctx)
+	if ctx.NArg() < 1 {
+		PrintErrorMsg("Missing argument. TxHash expected.")
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	txHash, err := common.Uint256FromHexString(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid tx hash:%s", ctx.Args().First())
+	}
+
+	stateFile := ctx.String(utils.GetFlagName(utils.LightClientStateFlag))
+	client, err := newLightClient(ctx, stateFile)
+	if err != nil {
+		return err
+	}
+	height, err := client.VerifyTx(txHash)
+	if err != nil {
+		return fmt.Errorf("verify tx:%s failed:%s", txHash.ToHexString(), err)
+	}
+	err = saveLightClientState(stateFile, client.State())
+	if err != nil {
+		PrintWarnMsg("Save light client state error:%s", err)
+	}
+	PrintInfoMsg("Transaction:%s verified.", txHash.ToHexString())
+	PrintInfoMsg("  Block height:%d", height)
+	PrintInfoMsg("  Verified header height:%d", client.Header().Height)
+	return nil
+}
+
+func newLightClient(ctx *cli.Context, stateFile string) (*lightclient.LightClient, error) {
+	source := &rpcLightClientSource{}
+	checkpoint := ctx.String(utils.GetFlagName(utils.LightClientCheckpointFlag))
+	if checkpoint != "" {
+		height, hash, err := parseCheckpoint(checkpoint)
+		if err != nil {
+			return nil, err
+		}
+		PrintInfoMsg("Loading checkpoint:%d...", height)
+		return lightclient.NewLightClient(source, height, hash)
+	}
+	data, err := ioutil.ReadFile(stateFile)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("please specify the trusted checkpoint by --%s flag", utils.GetFlagName(utils.LightClientCheckpointFlag))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read light client state:%s error:%s", stateFile, err)
+	}
+	state := &lightclient.State{}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal light client state error:%s", err)
+	}
+	return lightclient.NewLightClientFromState(source, state)
+}
+
+func parseCheckpoint(checkpoint string) (uint32, common.Uint256, error) {
+	items := strings.Split(checkpoint, ":")
+	if len(items) != 2 {
+		return 0, common.UINT256_EMPTY, fmt.Errorf("invalid checkpoint:%s, <height:hash> expected", checkpoint)
+	}
+	height, err := strconv.ParseUint(items[0], 10, 32)
+	if err != nil {
+		return 0, common.UINT256_EMPTY, fmt.Errorf("invalid checkpoint height:%s", items[0])
+	}
+	hash, err := common.Uint256FromHexString(items[1])
+	if err != nil {
+		return 0, common.UINT256_EMPTY, fmt.Errorf("invalid checkpoint hash:%s", items[1])
+	}
+	return uint32(height), hash, nil
+}
+
+func saveLightClientState(stateFile string, state *lightclient.State) error {
+	data, err := json.MarshalIndent(state, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(stateFile, data, 0644)
+}
+
+//rpcLightClientSource fetches untrusted chain data from node rpc
+type rpcLightClientSource struct{}
+
+func (this *rpcLightClientSource) GetHeaderByHeight(height uint32) (*types.Header, error) {
+	data, err := utils.GetBlockData(height)
+	if err != nil {
+		return nil, err
+	}
+	//header is the prefix of block data
+	return types.HeaderFromRawBytes(data)
+}
+
+func (this *rpcLightClientSource) GetBlockByHeight(height uint32) (*types.Block, error) {
+	data, err := utils.GetBlockData(height)
+	if err != nil {
+		return nil, err
+	}
+	return types.BlockFromRawBytes(data)
+}
+
+func (this *rpcLightClientSource) GetMerkleProof(txHash common.Uint256) (*lightclient.MerkleProof, error) {
+	rsp, err := utils.GetMerkleProof(txHash.ToHexString())
+	if err != nil {
+		return nil, err
+	}
+	proof := &lightclient.MerkleProof{
+		BlockHeight:    rsp.BlockHeight,
+		CurBlockHeight: rsp.CurBlockHeight,
+	}
+	proof.TransactionsRoot, err = common.Uint256FromHexString(rsp.TransactionsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transactions root:%s", rsp.TransactionsRoot)
+	}
+	proof.CurBlockRoot, err = common.Uint256FromHexString(rsp.CurBlockRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block root:%s", rsp.CurBlockRoot)
+	}
+	for _, h := range rsp.TargetHashes {
+		hash, err := common.Uint256FromHexString(h)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target hash:%s", h)
+		}
+		proof.TargetHashes = append(proof.TargetHashes, hash)
+	}
+	return proof, nil
+}
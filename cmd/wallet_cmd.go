@@ -19,13 +19,16 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/hex"
 	"fmt"
-
-	"errors"
-	"reflect"
+	"os"
+	"strings"
 
 	"github.com/ontio/dad-go-crypto/keypair"
+	s "github.com/ontio/dad-go-crypto/signature"
 	"github.com/ontio/dad-go/account"
+	cmdcom "github.com/ontio/dad-go/cmd/common"
 	"github.com/ontio/dad-go/cmd/utils"
 	"github.com/ontio/dad-go/common"
 	"github.com/ontio/dad-go/common/password"
@@ -34,120 +37,172 @@ import (
 
 var (
 	WalletCommand = cli.Command{
-		Action:      utils.MigrateFlags(walletCommand),
+		Action:      cli.ShowSubcommandHelp,
 		Name:        "wallet",
-		Usage:       "dad-go wallet [create|show|balance] [OPTION]",
-		ArgsUsage:   "",
-		Category:    "WALLET COMMANDS",
-		Description: `[create/show/balance]`,
+		Usage:       "Manage wallets",
+		ArgsUsage:   "[arguments...]",
+		Description: `Wallet commands can be used to create HD wallet from a new or an existing mnemonic, and view wallet info.`,
 		Subcommands: []cli.Command{
 			{
-				Action:      utils.MigrateFlags(walletCreate),
-				Name:        "create",
-				Usage:       "dad-go wallet create [OPTION]\n",
-				Flags:       append(NodeFlags, ContractFlags...),
-				Category:    "WALLET COMMANDS",
-				Description: ``,
-			},
-			{
-				Action:      utils.MigrateFlags(walletShow),
-				Name:        "show",
-				Usage:       "dad-go wallet show [OPTION]\n",
-				Flags:       append(NodeFlags, ContractFlags...),
-				Category:    "WALLET COMMANDS",
-				Description: ``,
+				Action:    walletCreate,
+				Name:      "create",
+				Usage:     "Create a new wallet",
+				ArgsUsage: "[sub-command options]",
+				Flags: []cli.Flag{
+					utils.WalletFileFlag,
+					utils.WalletMnemonicFlag,
+					utils.WalletRestoreFlag,
+					utils.WalletHDPathFlag,
+					utils.AccountTypeFlag,
+					utils.AccountQuantityFlag,
+				},
+				Description: ` Create a new wallet file with a default account.
+   With --mnemonic option, a new mnemonic is generated and the accounts of wallet are derived from it. Please backup the mnemonic, it can restore all the derived accounts.
+   With --restore option, input an existing mnemonic to restore the wallet, --number specifies how many accounts to derive.
+   HD wallet only support ecdsa with P-256 curve and sm2 key type, specified by --type option.`,
 			},
 			{
-				Action:      utils.MigrateFlags(walletBalance),
-				Name:        "balance",
-				Usage:       "dad-go wallet balance\n",
-				Flags:       append(NodeFlags, ContractFlags...),
-				Category:    "WALLET COMMANDS",
-				Description: ``,
+				Action:    walletShow,
+				Name:      "show",
+				Usage:     "Show wallet info",
+				ArgsUsage: "[sub-command options]",
+				Flags: []cli.Flag{
+					utils.WalletFileFlag,
+					utils.WalletMnemonicFlag,
+				},
+				Description: `Show wallet info. With --mnemonic option, display the mnemonic of HD wallet`,
 			},
 		},
 	}
 )
 
-func walletCommand(context *cli.Context) error {
-	showWalletHelp()
-	return nil
-}
-
 func walletCreate(ctx *cli.Context) error {
-	encrypt := ctx.String(utils.EncryptTypeFlag.Name)
-
-	name := ctx.String(utils.WalletNameFlag.Name)
-	if name == "" {
-		fmt.Println("Invalid wallet name.")
-		return errors.New("Wallet name is necessary")
+	optionFile := checkFileName(ctx)
+	if common.FileExisted(optionFile) {
+		return fmt.Errorf("wallet file %s already exists", optionFile)
 	}
-	if common.FileExisted(name) {
-		fmt.Printf("CAUTION: '%s' already exists!\n", name)
-		return errors.New("File alreay exists")
-	}
-	tmpPassword, err := password.GetConfirmedPassword()
+	optionMnemonic := ctx.Bool(utils.GetFlagName(utils.WalletMnemonicFlag))
+	optionRestore := ctx.Bool(utils.GetFlagName(utils.WalletRestoreFlag))
+	optionPath := ctx.String(utils.GetFlagName(utils.WalletHDPathFlag))
+	optionNumber := checkNumber(ctx)
+	keyType, curve, scheme, err := getHDKeyType(ctx)
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
-	password := string(tmpPassword)
-	wallet := account.Create(name, encrypt, []byte(password))
-	account := wallet.GetDefaultAccount()
 
-	pubKey := account.PubKey()
-	address := account.Address
+	mnemonic := ""
+	if optionRestore {
+		fmt.Printf("Please input mnemonic:")
+		reader := bufio.NewReader(os.Stdin)
+		mnemonic, err = reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("read mnemonic error: %s", err)
+		}
+		mnemonic = strings.TrimSpace(mnemonic)
+	}
+	pass, err := password.GetConfirmedPassword()
+	if err != nil {
+		return err
+	}
+	defer cmdcom.ClearPasswd(pass)
+	wallet, err := account.Open(optionFile)
+	if err != nil {
+		return fmt.Errorf("error opening wallet: %s", err)
+	}
 
-	pubKeyBytes := keypair.SerializePublicKey(pubKey)
-	fmt.Println("public key:     \t", common.ToHexString(pubKeyBytes))
-	fmt.Println("hex address:    \t", common.ToHexString(address[:]))
-	fmt.Println("base58 address: \t", address.ToBase58())
+	var accs []*account.Account
+	switch {
+	case optionRestore:
+		accs, err = wallet.RestoreHDWallet(mnemonic, optionPath, optionNumber, keyType, curve, scheme, pass)
+		if err != nil {
+			os.Remove(optionFile)
+			return fmt.Errorf("restore wallet error: %s", err)
+		}
+	case optionMnemonic:
+		mnemonic, err = wallet.CreateHDWallet("", optionPath, pass)
+		if err != nil {
+			os.Remove(optionFile)
+			return fmt.Errorf("create HD wallet error: %s", err)
+		}
+		for i := 0; i < optionNumber; i++ {
+			acc, err := wallet.DeriveAccount("", keyType, curve, scheme, pass)
+			if err != nil {
+				return fmt.Errorf("derive account error: %s", err)
+			}
+			accs = append(accs, acc)
+		}
+	default:
+		acc, err := wallet.NewAccount("", keypair.PK_ECDSA, keypair.P256, s.SHA256withECDSA, pass)
+		if err != nil {
+			return fmt.Errorf("error creating new account: %s", err)
+		}
+		accs = append(accs, acc)
+	}
 
+	for i, acc := range accs {
+		PrintInfoMsg("Index:%d", i+1)
+		PrintInfoMsg("Address:%s", acc.Address.ToBase58())
+		PrintInfoMsg("Public key:%s", hex.EncodeToString(keypair.SerializePublicKey(acc.PublicKey)))
+		if accMeta := wallet.GetAccountMetadataByAddress(acc.Address.ToBase58()); accMeta.HDPath != "" {
+			PrintInfoMsg("HD path:%s", accMeta.HDPath)
+		}
+	}
+	if optionMnemonic && !optionRestore {
+		PrintWarnMsg("Mnemonic:%s", mnemonic)
+		PrintWarnMsg("Please write down the mnemonic and keep it safe, it can restore all the derived accounts.")
+	}
+	PrintInfoMsg("Create wallet %s successfully.", optionFile)
 	return nil
 }
 
 func walletShow(ctx *cli.Context) error {
-	client := account.GetClient(ctx)
-	cli := reflect.ValueOf(client)
-	if !cli.IsValid() || cli.IsNil() || nil == client {
-		fmt.Println("Can't get local account.")
-		return errors.New("Can't get local account. ")
+	optionFile := checkFileName(ctx)
+	if !common.FileExisted(optionFile) {
+		return fmt.Errorf("cannot find wallet file: %s", optionFile)
 	}
-	acct := client.GetDefaultAccount()
-	if acct == nil {
-		fmt.Println("can not get default account")
-		return errors.New("can not get default account")
+	wallet, err := account.Open(optionFile)
+	if err != nil {
+		return fmt.Errorf("error opening wallet %s: %s", optionFile, err)
 	}
-
-	pubKey := acct.PubKey()
-	address := acct.Address
-
-	pubKeyBytes := keypair.SerializePublicKey(pubKey)
-	fmt.Println("public key:     \t", common.ToHexString(pubKeyBytes))
-	fmt.Println("hex address:    \t", common.ToHexString(address[:]))
-	fmt.Println("base58 address: \t", address.ToBase58())
-	return nil
-}
-
-func walletBalance(ctx *cli.Context) error {
-	client := account.GetClient(ctx)
-	if client == nil {
-		fmt.Println("Can't get local account.")
-		return errors.New("Can't get local account. ")
+	walletData := wallet.GetWalletData()
+	PrintInfoMsg("Wallet:%s", optionFile)
+	PrintInfoMsg("Accounts:%d", wallet.GetAccountNum())
+	if accMeta := wallet.GetDefaultAccountMetadata(); accMeta != nil {
+		PrintInfoMsg("Default account:%s", accMeta.Address)
 	}
-
-	acct := client.GetDefaultAccount()
-	if acct == nil {
-		fmt.Println("can not get default account")
-		return errors.New("can not get default account")
+	if !wallet.IsHDWallet() {
+		PrintInfoMsg("HD wallet:false")
+		return nil
 	}
-
-	base58Addr := acct.Address.ToBase58()
-	balance, err := ontSdk.Rpc.GetBalanceWithBase58(base58Addr)
-	if nil != err {
-		fmt.Printf("Get Balance with base58 err: %s", err.Error())
+	PrintInfoMsg("HD wallet:true")
+	PrintInfoMsg("HD path:%s", walletData.HDWallet.Path)
+	if !ctx.Bool(utils.GetFlagName(utils.WalletMnemonicFlag)) {
+		return nil
+	}
+	pass, err := password.GetAccountPassword()
+	if err != nil {
+		return err
+	}
+	defer cmdcom.ClearPasswd(pass)
+	mnemonic, err := wallet.GetMnemonic(pass)
+	if err != nil {
 		return err
 	}
-	fmt.Printf("ONT: %d; ONG: %d; ONGAppove: %d\n Address(base58): %s\n", balance.Ont.Int64(), balance.Ong.Int64(), balance.OngAppove.Int64(), base58Addr)
+	PrintWarnMsg("Mnemonic:%s", mnemonic)
 	return nil
 }
+
+//getHDKeyType return key type of HD wallet accounts, only ecdsa with P-256 and sm2 are supported
+func getHDKeyType(ctx *cli.Context) (keypair.KeyType, byte, s.SignatureScheme, error) {
+	keyType, ok := keyTypeMap[ctx.String(utils.GetFlagName(utils.AccountTypeFlag))]
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("invalid key type: %s", ctx.String(utils.GetFlagName(utils.AccountTypeFlag)))
+	}
+	switch keyType.code {
+	case keypair.PK_ECDSA:
+		return keypair.PK_ECDSA, keypair.P256, s.SHA256withECDSA, nil
+	case keypair.PK_SM2:
+		return keypair.PK_SM2, keypair.SM2P256V1, s.SM3withSM2, nil
+	}
+	return 0, 0, 0, fmt.Errorf("key type %s does not support HD wallet", keyType.name)
+}
@@ -61,6 +61,7 @@ const (
 	DEFAULT_MAX_CONN_OUT_BOUND              = uint(1024)
 	DEFAULT_MAX_CONN_IN_BOUND_FOR_SINGLE_IP = uint(16)
 	DEFAULT_HTTP_INFO_PORT                  = uint(0)
+	DEFAULT_METRICS_PORT                    = uint(0)
 	DEFAULT_MAX_TX_IN_BLOCK                 = 60000
 	DEFAULT_MAX_SYNC_HEADER                 = 500
 	DEFAULT_ENABLE_CONSENSUS                = true
@@ -71,9 +72,14 @@ const (
 	DEFAULT_GAS_PRICE                       = 500
 	DEFAULT_WASM_GAS_FACTOR                 = uint64(10)
 	DEFAULT_WASM_MAX_STEPCOUNT              = uint64(8000000)
+	DEFAULT_TXPOOL_PRICE_BUMP               = uint64(10)
+	DEFAULT_TXPOOL_ACCOUNT_SLOTS            = uint(1024)
+	DEFAULT_TXPOOL_ACCOUNT_QUEUE            = uint(4096)
 
 	DEFAULT_DATA_DIR      = "./Chain"
 	DEFAULT_RESERVED_FILE = "./peers.rsv"
+
+	MIN_PRUNE_BLOCKS = uint32(1000) //Min number of latest blocks kept in pruning mode
 )
 
 const (
@@ -119,6 +125,16 @@ func GetStateHashCheckHeight(id uint32) uint32 {
 	return STATE_HASH_CHECK_HEIGHT[id]
 }
 
+var STORAGE_TRIE_ENABLE_HEIGHT = map[uint32]uint32{
+	NETWORK_ID_MAIN_NET:    constants.STORAGE_TRIE_HEIGHT_MAINNET, //Network main
+	NETWORK_ID_POLARIS_NET: constants.STORAGE_TRIE_HEIGHT_POLARIS, //Network polaris
+	NETWORK_ID_SOLO_NET:    0,                                     //Network solo
+}
+
+func GetStorageTrieHeight(id uint32) uint32 {
+	return STORAGE_TRIE_ENABLE_HEIGHT[id]
+}
+
 var OPCODE_HASKEY_ENABLE_HEIGHT = map[uint32]uint32{
 	NETWORK_ID_MAIN_NET:    constants.OPCODE_HEIGHT_UPDATE_FIRST_MAINNET, //Network main
 	NETWORK_ID_POLARIS_NET: constants.OPCODE_HEIGHT_UPDATE_FIRST_POLARIS, //Network polaris
@@ -129,6 +145,76 @@ func GetOpcodeUpdateCheckHeight(id uint32) uint32 {
 	return OPCODE_HASKEY_ENABLE_HEIGHT[id]
 }
 
+var ORACLE_ENABLE_HEIGHT = map[uint32]uint32{
+	NETWORK_ID_MAIN_NET:    constants.ORACLE_HEIGHT_MAINNET, //Network main
+	NETWORK_ID_POLARIS_NET: constants.ORACLE_HEIGHT_POLARIS, //Network polaris
+	NETWORK_ID_SOLO_NET:    0,                               //Network solo
+}
+
+func GetOracleHeight(id uint32) uint32 {
+	return ORACLE_ENABLE_HEIGHT[id]
+}
+
+var CONTENT_REGISTRY_ENABLE_HEIGHT = map[uint32]uint32{
+	NETWORK_ID_MAIN_NET:    constants.CONTENT_REGISTRY_HEIGHT_MAINNET, //Network main
+	NETWORK_ID_POLARIS_NET: constants.CONTENT_REGISTRY_HEIGHT_POLARIS, //Network polaris
+	NETWORK_ID_SOLO_NET:    0,                                         //Network solo
+}
+
+func GetContentRegistryHeight(id uint32) uint32 {
+	return CONTENT_REGISTRY_ENABLE_HEIGHT[id]
+}
+
+var FAULTY_NODE_REPORT_ENABLE_HEIGHT = map[uint32]uint32{
+	NETWORK_ID_MAIN_NET:    constants.FAULTY_NODE_REPORT_HEIGHT_MAINNET, //Network main
+	NETWORK_ID_POLARIS_NET: constants.FAULTY_NODE_REPORT_HEIGHT_POLARIS, //Network polaris
+	NETWORK_ID_SOLO_NET:    0,                                           //Network solo
+}
+
+func GetFaultyNodeReportHeight(id uint32) uint32 {
+	return FAULTY_NODE_REPORT_ENABLE_HEIGHT[id]
+}
+
+var STORAGE_ITERATOR_ENABLE_HEIGHT = map[uint32]uint32{
+	NETWORK_ID_MAIN_NET:    constants.STORAGE_ITERATOR_HEIGHT_MAINNET, //Network main
+	NETWORK_ID_POLARIS_NET: constants.STORAGE_ITERATOR_HEIGHT_POLARIS, //Network polaris
+	NETWORK_ID_SOLO_NET:    0,                                         //Network solo
+}
+
+func GetStorageIteratorHeight(id uint32) uint32 {
+	return STORAGE_ITERATOR_ENABLE_HEIGHT[id]
+}
+
+var CRYPTO_ENABLE_HEIGHT = map[uint32]uint32{
+	NETWORK_ID_MAIN_NET:    constants.CRYPTO_HEIGHT_MAINNET, //Network main
+	NETWORK_ID_POLARIS_NET: constants.CRYPTO_HEIGHT_POLARIS, //Network polaris
+	NETWORK_ID_SOLO_NET:    0,                               //Network solo
+}
+
+func GetCryptoHeight(id uint32) uint32 {
+	return CRYPTO_ENABLE_HEIGHT[id]
+}
+
+var ABI_REGISTRY_ENABLE_HEIGHT = map[uint32]uint32{
+	NETWORK_ID_MAIN_NET:    constants.ABI_REGISTRY_HEIGHT_MAINNET, //Network main
+	NETWORK_ID_POLARIS_NET: constants.ABI_REGISTRY_HEIGHT_POLARIS, //Network polaris
+	NETWORK_ID_SOLO_NET:    0,                                     //Network solo
+}
+
+func GetAbiRegistryHeight(id uint32) uint32 {
+	return ABI_REGISTRY_ENABLE_HEIGHT[id]
+}
+
+var FEE_SPLIT_CURVE_ENABLE_HEIGHT = map[uint32]uint32{
+	NETWORK_ID_MAIN_NET:    constants.FEE_SPLIT_CURVE_HEIGHT_MAINNET, //Network main
+	NETWORK_ID_POLARIS_NET: constants.FEE_SPLIT_CURVE_HEIGHT_POLARIS, //Network polaris
+	NETWORK_ID_SOLO_NET:    0,                                        //Network solo
+}
+
+func GetFeeSplitCurveHeight(id uint32) uint32 {
+	return FEE_SPLIT_CURVE_ENABLE_HEIGHT[id]
+}
+
 func GetNetworkName(id uint32) string {
 	name, ok := NETWORK_NAME[id]
 	if ok {
@@ -480,13 +566,19 @@ type SOLOConfig struct {
 }
 
 type CommonConfig struct {
-	LogLevel       uint
-	NodeType       string
-	EnableEventLog bool
-	SystemFee      map[string]int64
-	GasLimit       uint64
-	GasPrice       uint64
-	DataDir        string
+	LogLevel           uint
+	NodeType           string
+	EnableEventLog     bool
+	EnableAddressIndex bool
+	MetricsPort        uint
+	SystemFee          map[string]int64
+	GasLimit           uint64
+	GasPrice           uint64
+	DataDir            string
+	SnapshotInterval   uint32 //Take state snapshot every interval blocks for fast sync of other nodes, 0 means disable
+	SnapshotHeight     uint32 //Height of the state snapshot to bootstrap an empty ledger from, 0 means sync from genesis
+	SnapshotHash       string //Trusted manifest hash of the snapshot to bootstrap from
+	PruneBlocks        uint32 //Keep blocks and event notifies of the latest number of blocks only, 0 means keep all
 }
 
 type ConsensusConfig struct {
@@ -494,6 +586,13 @@ type ConsensusConfig struct {
 	MaxTxInBlock    uint
 }
 
+type TxPoolConfig struct {
+	PriceBump    uint64 //Min percentage of gas price raised by the tx replacing one with same payer and nonce
+	AccountSlots uint   //Max number of pending txs of a payer, the txs beyond it are queued
+	AccountQueue uint   //Max number of queued txs of a payer
+	GlobalSlots  uint   //Max number of txs in the tx pool, 0 for the default capacity of tx pool
+}
+
 type P2PRsvConfig struct {
 	ReservedPeers []string `json:"reserved"`
 	MaskPeers     []string `json:"mask"`
@@ -521,6 +620,7 @@ type RpcConfig struct {
 	EnableHttpJsonRpc bool
 	HttpJsonPort      uint
 	HttpLocalPort     uint
+	EnableTrace       bool //enable the rpc methods tracing transaction execution
 }
 
 type RestfulConfig struct {
@@ -542,6 +642,7 @@ type dad-goConfig struct {
 	Genesis   *GenesisConfig
 	Common    *CommonConfig
 	Consensus *ConsensusConfig
+	TxPool    *TxPoolConfig
 	P2PNode   *P2PNodeConfig
 	Rpc       *RpcConfig
 	Restful   *RestfulConfig
@@ -562,6 +663,11 @@ func Newdad-goConfig() *dad-goConfig {
 			EnableConsensus: true,
 			MaxTxInBlock:    DEFAULT_MAX_TX_IN_BLOCK,
 		},
+		TxPool: &TxPoolConfig{
+			PriceBump:    DEFAULT_TXPOOL_PRICE_BUMP,
+			AccountSlots: DEFAULT_TXPOOL_ACCOUNT_SLOTS,
+			AccountQueue: DEFAULT_TXPOOL_ACCOUNT_QUEUE,
+		},
 		P2PNode: &P2PNodeConfig{
 			ReservedCfg:               &P2PRsvConfig{},
 			ReservedPeersOnly:         false,
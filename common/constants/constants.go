@@ -19,6 +19,7 @@
 package constants
 
 import (
+	"math"
 	"time"
 )
 
@@ -83,6 +84,38 @@ const (
 const STATE_HASH_HEIGHT_MAINNET = 3000000
 const STATE_HASH_HEIGHT_POLARIS = 850000
 
+// ledger storage trie enable height, not scheduled yet
+const STORAGE_TRIE_HEIGHT_MAINNET = math.MaxUint32
+const STORAGE_TRIE_HEIGHT_POLARIS = math.MaxUint32
+
 // neovm opcode update check height
 const OPCODE_HEIGHT_UPDATE_FIRST_MAINNET = 6300000
 const OPCODE_HEIGHT_UPDATE_FIRST_POLARIS = 2100000
+
+// oracle native contract enable height, not scheduled yet
+const ORACLE_HEIGHT_MAINNET = math.MaxUint32
+const ORACLE_HEIGHT_POLARIS = math.MaxUint32
+
+// content registry native contract enable height, not scheduled yet
+const CONTENT_REGISTRY_HEIGHT_MAINNET = math.MaxUint32
+const CONTENT_REGISTRY_HEIGHT_POLARIS = math.MaxUint32
+
+// faulty node report enable height, not scheduled yet
+const FAULTY_NODE_REPORT_HEIGHT_MAINNET = math.MaxUint32
+const FAULTY_NODE_REPORT_HEIGHT_POLARIS = math.MaxUint32
+
+// storage iterator syscalls enable height, not scheduled yet
+const STORAGE_ITERATOR_HEIGHT_MAINNET = math.MaxUint32
+const STORAGE_ITERATOR_HEIGHT_POLARIS = math.MaxUint32
+
+// crypto syscalls enable height, not scheduled yet
+const CRYPTO_HEIGHT_MAINNET = math.MaxUint32
+const CRYPTO_HEIGHT_POLARIS = math.MaxUint32
+
+// abi registry native contract enable height, not scheduled yet
+const ABI_REGISTRY_HEIGHT_MAINNET = math.MaxUint32
+const ABI_REGISTRY_HEIGHT_POLARIS = math.MaxUint32
+
+// fee split curve update enable height, not scheduled yet
+const FEE_SPLIT_CURVE_HEIGHT_MAINNET = math.MaxUint32
+const FEE_SPLIT_CURVE_HEIGHT_POLARIS = math.MaxUint32
@@ -0,0 +1,454 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package metrics implements a small dependency free metrics registry which
+// can be exported in the prometheus text exposition format
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	TYPE_COUNTER = "counter"
+	TYPE_GAUGE   = "gauge"
+	TYPE_SUMMARY = "summary"
+)
+
+//Collector is a metric family which can be written in text exposition format
+type Collector interface {
+	Name() string
+	Help() string
+	Type() string
+	Samples() []Sample
+}
+
+//Sample is one line of a metric family
+type Sample struct {
+	Suffix string
+	Labels []string // label names and values in pairs
+	Value  float64
+}
+
+type float64Value struct {
+	bits uint64
+}
+
+func (this *float64Value) Add(delta float64) {
+	for {
+		old := atomic.LoadUint64(&this.bits)
+		val := math.Float64bits(math.Float64frombits(old) + delta)
+		if atomic.CompareAndSwapUint64(&this.bits, old, val) {
+			return
+		}
+	}
+}
+
+func (this *float64Value) Set(val float64) {
+	atomic.StoreUint64(&this.bits, math.Float64bits(val))
+}
+
+func (this *float64Value) Get() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&this.bits))
+}
+
+type desc struct {
+	name string
+	help string
+}
+
+func (this *desc) Name() string {
+	return this.name
+}
+
+func (this *desc) Help() string {
+	return this.help
+}
+
+//Counter is a monotonically increasing value
+type Counter struct {
+	desc
+	val float64Value
+}
+
+func NewCounter(name, help string) *Counter {
+	return &Counter{desc: desc{name: name, help: help}}
+}
+
+func (this *Counter) Inc() {
+	this.val.Add(1)
+}
+
+func (this *Counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	this.val.Add(delta)
+}
+
+func (this *Counter) Get() float64 {
+	return this.val.Get()
+}
+
+func (this *Counter) Type() string {
+	return TYPE_COUNTER
+}
+
+func (this *Counter) Samples() []Sample {
+	return []Sample{{Value: this.Get()}}
+}
+
+//Gauge is a value which can go up and down
+type Gauge struct {
+	desc
+	val float64Value
+}
+
+func NewGauge(name, help string) *Gauge {
+	return &Gauge{desc: desc{name: name, help: help}}
+}
+
+func (this *Gauge) Set(val float64) {
+	this.val.Set(val)
+}
+
+func (this *Gauge) Add(delta float64) {
+	this.val.Add(delta)
+}
+
+func (this *Gauge) Get() float64 {
+	return this.val.Get()
+}
+
+func (this *Gauge) Type() string {
+	return TYPE_GAUGE
+}
+
+func (this *Gauge) Samples() []Sample {
+	return []Sample{{Value: this.Get()}}
+}
+
+//GaugeFunc reads its value from the callback at collecting time
+type GaugeFunc struct {
+	desc
+	fn func() float64
+}
+
+func NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	return &GaugeFunc{desc: desc{name: name, help: help}, fn: fn}
+}
+
+func (this *GaugeFunc) Type() string {
+	return TYPE_GAUGE
+}
+
+func (this *GaugeFunc) Samples() []Sample {
+	return []Sample{{Value: this.fn()}}
+}
+
+//VecFunc reads labeled values from the callback at collecting time, each key of
+//the returned map is the value of the only label
+type VecFunc struct {
+	desc
+	typ   string
+	label string
+	fn    func() map[string]float64
+}
+
+func NewCounterVecFunc(name, help, label string, fn func() map[string]float64) *VecFunc {
+	return &VecFunc{desc: desc{name: name, help: help}, typ: TYPE_COUNTER, label: label, fn: fn}
+}
+
+func NewGaugeVecFunc(name, help, label string, fn func() map[string]float64) *VecFunc {
+	return &VecFunc{desc: desc{name: name, help: help}, typ: TYPE_GAUGE, label: label, fn: fn}
+}
+
+func (this *VecFunc) Type() string {
+	return this.typ
+}
+
+func (this *VecFunc) Samples() []Sample {
+	values := this.fn()
+	samples := make([]Sample, 0, len(values))
+	for key, val := range values {
+		samples = append(samples, Sample{Labels: []string{this.label, key}, Value: val})
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Labels[1] < samples[j].Labels[1]
+	})
+	return samples
+}
+
+type vec struct {
+	desc
+	labels []string
+	lock   sync.RWMutex
+	values map[string]interface{}
+}
+
+func (this *vec) get(newValue func() interface{}, lvs ...string) interface{} {
+	if len(lvs) != len(this.labels) {
+		panic(fmt.Sprintf("metric %s: expect %d label values, got %d", this.name, len(this.labels), len(lvs)))
+	}
+	key := strings.Join(lvs, "\xff")
+	this.lock.RLock()
+	val, ok := this.values[key]
+	this.lock.RUnlock()
+	if ok {
+		return val
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if val, ok = this.values[key]; ok {
+		return val
+	}
+	val = newValue()
+	this.values[key] = val
+	return val
+}
+
+func (this *vec) collect(fn func(labels []string, val interface{})) {
+	this.lock.RLock()
+	keys := make([]string, 0, len(this.values))
+	for key := range this.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	vals := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		vals = append(vals, this.values[key])
+	}
+	this.lock.RUnlock()
+
+	for i, key := range keys {
+		lvs := strings.Split(key, "\xff")
+		labels := make([]string, 0, 2*len(this.labels))
+		for j, name := range this.labels {
+			labels = append(labels, name, lvs[j])
+		}
+		fn(labels, vals[i])
+	}
+}
+
+//CounterVec is a set of counters partitioned by label values
+type CounterVec struct {
+	vec
+}
+
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{vec{desc: desc{name: name, help: help}, labels: labels, values: make(map[string]interface{})}}
+}
+
+func (this *CounterVec) With(lvs ...string) *Counter {
+	return this.get(func() interface{} { return &Counter{desc: this.desc} }, lvs...).(*Counter)
+}
+
+func (this *CounterVec) Type() string {
+	return TYPE_COUNTER
+}
+
+func (this *CounterVec) Samples() []Sample {
+	var samples []Sample
+	this.collect(func(labels []string, val interface{}) {
+		samples = append(samples, Sample{Labels: labels, Value: val.(*Counter).Get()})
+	})
+	return samples
+}
+
+//Summary tracks the count and the sum of observations
+type Summary struct {
+	desc
+	count float64Value
+	sum   float64Value
+}
+
+func NewSummary(name, help string) *Summary {
+	return &Summary{desc: desc{name: name, help: help}}
+}
+
+func (this *Summary) Observe(val float64) {
+	this.count.Add(1)
+	this.sum.Add(val)
+}
+
+//ObserveSince records the seconds elapsed since start
+func (this *Summary) ObserveSince(start time.Time) {
+	this.Observe(time.Since(start).Seconds())
+}
+
+func (this *Summary) Type() string {
+	return TYPE_SUMMARY
+}
+
+func (this *Summary) samples(labels []string) []Sample {
+	return []Sample{
+		{Suffix: "_sum", Labels: labels, Value: this.sum.Get()},
+		{Suffix: "_count", Labels: labels, Value: this.count.Get()},
+	}
+}
+
+func (this *Summary) Samples() []Sample {
+	return this.samples(nil)
+}
+
+//SummaryVec is a set of summaries partitioned by label values
+type SummaryVec struct {
+	vec
+}
+
+func NewSummaryVec(name, help string, labels ...string) *SummaryVec {
+	return &SummaryVec{vec{desc: desc{name: name, help: help}, labels: labels, values: make(map[string]interface{})}}
+}
+
+func (this *SummaryVec) With(lvs ...string) *Summary {
+	return this.get(func() interface{} { return &Summary{desc: this.desc} }, lvs...).(*Summary)
+}
+
+func (this *SummaryVec) Type() string {
+	return TYPE_SUMMARY
+}
+
+func (this *SummaryVec) Samples() []Sample {
+	var samples []Sample
+	this.collect(func(labels []string, val interface{}) {
+		samples = append(samples, val.(*Summary).samples(labels)...)
+	})
+	return samples
+}
+
+//Registry holds the collectors of the node
+type Registry struct {
+	lock       sync.RWMutex
+	collectors map[string]Collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]Collector)}
+}
+
+//Register adds the collector to the registry, a collector registered with the
+//same name is replaced
+func (this *Registry) Register(c Collector) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.collectors[c.Name()] = c
+}
+
+func (this *Registry) Unregister(name string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.collectors, name)
+}
+
+//WriteTo writes all the collectors in the prometheus text exposition format
+func (this *Registry) WriteTo(w io.Writer) (int64, error) {
+	this.lock.RLock()
+	names := make([]string, 0, len(this.collectors))
+	for name := range this.collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collectors := make([]Collector, 0, len(names))
+	for _, name := range names {
+		collectors = append(collectors, this.collectors[name])
+	}
+	this.lock.RUnlock()
+
+	cw := &countWriter{w: bufio.NewWriter(w)}
+	for _, c := range collectors {
+		fmt.Fprintf(cw, "# HELP %s %s\n", c.Name(), escapeHelp(c.Help()))
+		fmt.Fprintf(cw, "# TYPE %s %s\n", c.Name(), c.Type())
+		for _, s := range c.Samples() {
+			cw.Write([]byte(c.Name() + s.Suffix))
+			if len(s.Labels) > 0 {
+				cw.Write([]byte("{"))
+				for i := 0; i+1 < len(s.Labels); i += 2 {
+					if i > 0 {
+						cw.Write([]byte(","))
+					}
+					fmt.Fprintf(cw, "%s=\"%s\"", s.Labels[i], escapeLabel(s.Labels[i+1]))
+				}
+				cw.Write([]byte("}"))
+			}
+			fmt.Fprintf(cw, " %s\n", formatFloat(s.Value))
+		}
+	}
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+//ServeHTTP exports the registry to the prometheus scraper
+func (this *Registry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	this.WriteTo(w)
+}
+
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (this *countWriter) Write(p []byte) (int, error) {
+	if this.err != nil {
+		return 0, this.err
+	}
+	n, err := this.w.Write(p)
+	this.n += int64(n)
+	this.err = err
+	return n, err
+}
+
+func formatFloat(val float64) string {
+	switch {
+	case math.IsNaN(val):
+		return "NaN"
+	case math.IsInf(val, 1):
+		return "+Inf"
+	case math.IsInf(val, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(val, 'g', -1, 64)
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func escapeLabel(val string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(val)
+}
+
+//DefRegistry is the registry exported by the metrics http server
+var DefRegistry = NewRegistry()
+
+func Register(c Collector) {
+	DefRegistry.Register(c)
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryWriteTo(t *testing.T) {
+	reg := NewRegistry()
+	counter := NewCounter("test_counter_total", "a test counter")
+	counter.Inc()
+	counter.Add(2)
+	counter.Add(-1)
+	reg.Register(counter)
+
+	gauge := NewGauge("test_gauge", "a test gauge")
+	gauge.Set(10)
+	gauge.Add(-2.5)
+	reg.Register(gauge)
+
+	vec := NewCounterVec("test_msg_total", "messages", "type", "direction")
+	vec.With("tx", "in").Add(3)
+	vec.With("block", "out").Inc()
+	reg.Register(vec)
+
+	summary := NewSummaryVec("test_latency_seconds", "latency", "method")
+	summary.With("getblock").Observe(0.5)
+	summary.With("getblock").Observe(0.25)
+	reg.Register(summary)
+
+	reg.Register(NewGaugeVecFunc("test_pool_size", "pool size", "pool", func() map[string]float64 {
+		return map[string]float64{"pending": 2, "verified": 1}
+	}))
+
+	buf := bytes.NewBuffer(nil)
+	n, err := reg.WriteTo(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	expected := `# HELP test_counter_total a test counter
+# TYPE test_counter_total counter
+test_counter_total 3
+# HELP test_gauge a test gauge
+# TYPE test_gauge gauge
+test_gauge 7.5
+# HELP test_latency_seconds latency
+# TYPE test_latency_seconds summary
+test_latency_seconds_sum{method="getblock"} 0.75
+test_latency_seconds_count{method="getblock"} 2
+# HELP test_msg_total messages
+# TYPE test_msg_total counter
+test_msg_total{type="block",direction="out"} 1
+test_msg_total{type="tx",direction="in"} 3
+# HELP test_pool_size pool size
+# TYPE test_pool_size gauge
+test_pool_size{pool="pending"} 2
+test_pool_size{pool="verified"} 1
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestEscape(t *testing.T) {
+	reg := NewRegistry()
+	vec := NewCounterVec("test_escape", "line1\nline2", "label")
+	vec.With("a\"b\\c").Inc()
+	reg.Register(vec)
+	buf := bytes.NewBuffer(nil)
+	_, err := reg.WriteTo(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, "# HELP test_escape line1\\nline2\n# TYPE test_escape counter\ntest_escape{label=\"a\\\"b\\\\c\"} 1\n", buf.String())
+}
@@ -64,6 +64,10 @@ type CandidateInfo struct {
 
 	// indexed by endorserIndex
 	EndorseSigs map[uint32][]*CandidateEndorseSigInfo
+
+	// faulty proposers and endorsers detected in this round
+	FaultyProposals []*FaultyReport
+	FaultyVerifies  []*FaultyReport
 }
 
 type BlockPool struct {
@@ -161,6 +165,58 @@ func (pool *BlockPool) newBlockProposal(msg *blockProposalMsg) error {
 	return nil
 }
 
+func (pool *BlockPool) getProposalByProposer(blkNum uint32, proposer uint32) *blockProposalMsg {
+	pool.lock.RLock()
+	defer pool.lock.RUnlock()
+
+	c := pool.candidateBlocks[blkNum]
+	if c == nil {
+		return nil
+	}
+	for _, p := range c.Proposals {
+		if p.Block.getProposer() == proposer {
+			return p
+		}
+	}
+	return nil
+}
+
+//
+// add faulty report to CandidateInfo, return false if the peer has been reported in this round
+//
+func (pool *BlockPool) addFaultyReport(blkNum uint32, report *FaultyReport, forVerify bool) bool {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	candidate := pool.getCandidateInfoLocked(blkNum)
+	reports := candidate.FaultyProposals
+	if forVerify {
+		reports = candidate.FaultyVerifies
+	}
+	for _, r := range reports {
+		if r.FaultyID == report.FaultyID {
+			return false
+		}
+	}
+	if forVerify {
+		candidate.FaultyVerifies = append(candidate.FaultyVerifies, report)
+	} else {
+		candidate.FaultyProposals = append(candidate.FaultyProposals, report)
+	}
+	return true
+}
+
+func (pool *BlockPool) getFaultyReports(blkNum uint32) ([]*FaultyReport, []*FaultyReport) {
+	pool.lock.RLock()
+	defer pool.lock.RUnlock()
+
+	c := pool.candidateBlocks[blkNum]
+	if c == nil {
+		return nil, nil
+	}
+	return c.FaultyProposals, c.FaultyVerifies
+}
+
 func (pool *BlockPool) getBlockProposals(blkNum uint32) []*blockProposalMsg {
 	pool.lock.RLock()
 	defer pool.lock.RUnlock()
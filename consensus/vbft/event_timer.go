@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/ontio/dad-go/common/log"
+	"github.com/ontio/dad-go/common/metrics"
 )
 
 type TimerEventType int
@@ -44,6 +45,45 @@ const (
 	EventMax
 )
 
+func (evtType TimerEventType) String() string {
+	switch evtType {
+	case EventProposeBlockTimeout:
+		return "propose_block"
+	case EventProposalBackoff:
+		return "proposal_backoff"
+	case EventRandomBackoff:
+		return "random_backoff"
+	case EventPropose2ndBlockTimeout:
+		return "propose_2nd_block"
+	case EventEndorseBlockTimeout:
+		return "endorse_block"
+	case EventEndorseEmptyBlockTimeout:
+		return "endorse_empty_block"
+	case EventCommitBlockTimeout:
+		return "commit_block"
+	case EventPeerHeartbeat:
+		return "peer_heartbeat"
+	case EventTxPool:
+		return "txpool"
+	case EventTxBlockTimeout:
+		return "tx_block"
+	default:
+		return "normal"
+	}
+}
+
+var (
+	timerExpiries = metrics.NewCounterVec("ontology_vbft_timer_expiries_total",
+		"Count of expired vbft event timers by event type", "event")
+	viewChanges = metrics.NewCounter("ontology_vbft_view_changes_total",
+		"Count of rounds in which the leader failed to propose in time and the backup proposers took over")
+)
+
+func init() {
+	metrics.Register(timerExpiries)
+	metrics.Register(viewChanges)
+}
+
 var (
 	makeProposalTimeout    = 300 * time.Millisecond
 	make2ndProposalTimeout = 300 * time.Millisecond
@@ -134,6 +174,7 @@ func (self *EventTimer) StartTimer(Idx uint32, timeout time.Duration) {
 		defer self.lock.Unlock()
 		delete(self.normalTimers, Idx)
 
+		timerExpiries.With(EventMax.String()).Inc()
 		self.C <- &TimerEvent{
 			evtType:  EventMax,
 			blockNum: Idx,
@@ -201,6 +242,7 @@ func (self *EventTimer) startEventTimer(evtType TimerEventType, blockNum uint32)
 		return fmt.Errorf("invalid timeout for event %d, blkNum %d", evtType, blockNum)
 	}
 	timers[blockNum] = time.AfterFunc(timeout, func() {
+		timerExpiries.With(evtType.String()).Inc()
 		self.C <- &TimerEvent{
 			evtType:  evtType,
 			blockNum: blockNum,
@@ -358,6 +400,7 @@ func (self *EventTimer) startPeerTicker(peerIdx uint32) error {
 
 	timeout := self.getEventTimeout(EventPeerHeartbeat)
 	self.peerTickers[peerIdx] = time.AfterFunc(timeout, func() {
+		timerExpiries.With(EventPeerHeartbeat.String()).Inc()
 		self.C <- &TimerEvent{
 			evtType:  EventPeerHeartbeat,
 			blockNum: peerIdx,
@@ -25,11 +25,15 @@ import (
 
 	"github.com/ontio/ontology-crypto/keypair"
 	"github.com/ontio/ontology/common"
+	sysconfig "github.com/ontio/ontology/common/config"
 	"github.com/ontio/ontology/common/log"
 	"github.com/ontio/ontology/consensus/vbft/config"
 	"github.com/ontio/ontology/core/ledger"
 	"github.com/ontio/ontology/core/signature"
 	"github.com/ontio/ontology/core/types"
+	"github.com/ontio/ontology/core/utils"
+	gover "github.com/ontio/ontology/smartcontract/service/native/governance"
+	nutils "github.com/ontio/ontology/smartcontract/service/native/utils"
 )
 
 type ConsensusMsgPayload struct {
@@ -309,7 +313,7 @@ func (self *Server) constructProposalMsg(blkNum uint32, sysTxs, userTxs []*types
 
 func (self *Server) constructEndorseMsg(proposal *blockProposalMsg, forEmpty bool) (*blockEndorseMsg, error) {
 
-	// TODO, support faultyMsg reporting
+	faultyProposals, _ := self.blockPool.getFaultyReports(proposal.GetBlockNum())
 
 	var proposerSig, endorserSig []byte
 	var blkHash common.Uint256
@@ -338,6 +342,7 @@ func (self *Server) constructEndorseMsg(proposal *blockProposalMsg, forEmpty boo
 		BlockNum:          proposal.Block.getBlockNum(),
 		EndorsedBlockHash: blkHash,
 		EndorseForEmpty:   forEmpty,
+		FaultyProposals:   faultyProposals,
 		ProposerSig:       proposerSig,
 		EndorserSig:       endorserSig,
 	}
@@ -355,7 +360,7 @@ func (self *Server) constructEndorseMsg(proposal *blockProposalMsg, forEmpty boo
 
 func (self *Server) constructCommitMsg(proposal *blockProposalMsg, endorses []*blockEndorseMsg, forEmpty bool) (*blockCommitMsg, error) {
 
-	// TODO, support faultyMsg reporting
+	_, faultyVerifies := self.blockPool.getFaultyReports(proposal.GetBlockNum())
 
 	var proposerSig, committerSig []byte
 	var blkHash common.Uint256
@@ -402,6 +407,7 @@ func (self *Server) constructCommitMsg(proposal *blockProposalMsg, endorses []*b
 		BlockNum:                  proposal.Block.getBlockNum(),
 		CommitBlockHash:           blkHash,
 		CommitForEmpty:            forEmpty,
+		FaultyVerifies:            faultyVerifies,
 		ProposerSig:               proposerSig,
 		EndorsersSig:              endorsersSig,
 		CommitterSig:              committerSig,
@@ -465,3 +471,29 @@ func (self *Server) constructBlockSubmitMsg(blkNum uint32, stateRoot common.Uint
 	}
 	return msg, nil
 }
+
+//the report is deterministic for the evidence found at blkNum, so that duplicated reports are rejected by tx pool
+func (self *Server) constructFaultyReportTx(blkNum uint32, peerPubkey string, evidence *gover.FaultyEvidence) (*types.Transaction, error) {
+	param := &gover.ReportFaultyNodeParam{
+		Reporter:   self.account.Address,
+		PeerPubkey: peerPubkey,
+		Evidence:   *evidence,
+	}
+	mutable := utils.BuildNativeTransaction(nutils.GovernanceContractAddress, gover.REPORT_FAULTY_NODE,
+		common.SerializeToBytes(param))
+	mutable.GasPrice = sysconfig.DefConfig.Common.GasPrice
+	mutable.GasLimit = sysconfig.DefConfig.Common.GasLimit
+	mutable.Payer = self.account.Address
+	mutable.Nonce = blkNum
+	txHash := mutable.Hash()
+	sig, err := signature.Sign(self.account, txHash[:])
+	if err != nil {
+		return nil, fmt.Errorf("sign faulty report tx failed, tx hash:%s, err:%s", txHash.ToHexString(), err)
+	}
+	mutable.Sigs = []types.Sig{{
+		PubKeys: []keypair.PublicKey{self.account.PublicKey},
+		M:       1,
+		SigData: [][]byte{sig},
+	}}
+	return mutable.IntoImmutable()
+}
@@ -23,11 +23,14 @@ import (
 	"math"
 
 	"github.com/ontio/dad-go/common"
+	sysconfig "github.com/ontio/dad-go/common/config"
 	"github.com/ontio/dad-go/common/log"
 	"github.com/ontio/dad-go/consensus/vbft/config"
 	"github.com/ontio/dad-go/core/signature"
+	"github.com/ontio/dad-go/core/types"
 	"github.com/ontio/dad-go/p2pserver/message/msg_pack"
 	p2pmsg "github.com/ontio/dad-go/p2pserver/message/types"
+	gover "github.com/ontio/dad-go/smartcontract/service/native/governance"
 )
 
 func (self *Server) GetCurrentBlockNo() uint32 {
@@ -441,3 +444,117 @@ func (self *Server) broadcastToAll(data []byte) error {
 	self.p2p.Broadcast(msg)
 	return nil
 }
+
+//
+// check if the endorser has endorsed another block of the same proposer in this round,
+// report it as faulty if the endorsed blocks are found in msg pool
+//
+func (self *Server) checkFaultyEndorsement(msg *blockEndorseMsg) {
+	if msg.EndorseForEmpty || msg.Endorser == self.Index {
+		return
+	}
+	blkNum := msg.GetBlockNum()
+	for _, m := range self.msgPool.GetEndorsementsMsgs(blkNum) {
+		e, ok := m.(*blockEndorseMsg)
+		if !ok || e.Endorser != msg.Endorser || e.EndorsedProposer != msg.EndorsedProposer || e.EndorseForEmpty {
+			continue
+		}
+		if e.EndorsedBlockHash == msg.EndorsedBlockHash {
+			continue
+		}
+		header1 := self.findProposedHeader(blkNum, e.EndorsedBlockHash)
+		header2 := self.findProposedHeader(blkNum, msg.EndorsedBlockHash)
+		if header1 == nil || header2 == nil {
+			continue
+		}
+		self.reportFaultyPeer(msg.Endorser, blkNum, header1, e.EndorserSig, header2, msg.EndorserSig, true)
+		return
+	}
+}
+
+func (self *Server) findProposedHeader(blkNum uint32, blkHash common.Uint256) *types.Header {
+	for _, m := range self.msgPool.GetProposalMsgs(blkNum) {
+		p, ok := m.(*blockProposalMsg)
+		if !ok {
+			continue
+		}
+		if p.Block.Block.Hash() == blkHash {
+			return p.Block.Block.Header
+		}
+		if p.Block.EmptyBlock != nil && p.Block.EmptyBlock.Hash() == blkHash {
+			return p.Block.EmptyBlock.Header
+		}
+	}
+	return nil
+}
+
+//
+// check if the proposer has proposed another block in this round,
+// return the faulty report tx if the proposals are conflicting
+//
+func (self *Server) checkFaultyProposal(msg *blockProposalMsg) *types.Transaction {
+	blkNum := msg.GetBlockNum()
+	proposer := msg.Block.getProposer()
+	p := self.blockPool.getProposalByProposer(blkNum, proposer)
+	if p == nil {
+		return nil
+	}
+	return self.faultyReportTx(proposer, blkNum, p.Block.Block.Header, p.Block.Block.Header.SigData[0],
+		msg.Block.Block.Header, msg.Block.Block.Header.SigData[0], false)
+}
+
+//
+// build evidence of two conflicting headers signed by the peer, submit it to governance contract.
+// each faulty peer is reported once per round
+//
+func (self *Server) reportFaultyPeer(peerIdx uint32, blkNum uint32, header1 *types.Header, sig1 []byte,
+	header2 *types.Header, sig2 []byte, forVerify bool) {
+	if tx := self.faultyReportTx(peerIdx, blkNum, header1, sig1, header2, sig2, forVerify); tx != nil {
+		self.poolActor.AppendTx(tx)
+	}
+}
+
+func (self *Server) faultyReportTx(peerIdx uint32, blkNum uint32, header1 *types.Header, sig1 []byte,
+	header2 *types.Header, sig2 []byte, forVerify bool) *types.Transaction {
+	if peerIdx == self.Index {
+		return nil
+	}
+	if blkNum < sysconfig.GetFaultyNodeReportHeight(sysconfig.DefConfig.P2PNode.NetworkId) {
+		return nil
+	}
+	pk := self.peerPool.GetPeerPubKey(peerIdx)
+	if pk == nil {
+		log.Errorf("server %d failed to get faulty peer %d pk of block %d", self.Index, peerIdx, blkNum)
+		return nil
+	}
+	peerPubkey := vconfig.PubkeyID(pk)
+	sink1, sink2 := common.NewZeroCopySink(nil), common.NewZeroCopySink(nil)
+	header1.Serialization(sink1)
+	header2.Serialization(sink2)
+	evidence := &gover.FaultyEvidence{
+		Header1: sink1.Bytes(),
+		Sig1:    sig1,
+		Header2: sink2.Bytes(),
+		Sig2:    sig2,
+	}
+	if _, err := gover.VerifyFaultyEvidence(peerPubkey, evidence); err != nil {
+		log.Infof("server %d skip faulty report of peer %d, block %d: %s", self.Index, peerIdx, blkNum, err)
+		return nil
+	}
+	report := &FaultyReport{
+		FaultyID:      peerIdx,
+		FaultyMsgHash: header2.Hash(),
+	}
+	if !self.blockPool.addFaultyReport(blkNum, report, forVerify) {
+		return nil
+	}
+	log.Warnf("server %d detected faulty peer %d, block %d, hash %s and %s", self.Index, peerIdx, blkNum,
+		header1.Hash().ToHexString(), report.FaultyMsgHash.ToHexString())
+
+	tx, err := self.constructFaultyReportTx(blkNum, peerPubkey, evidence)
+	if err != nil {
+		log.Errorf("server %d failed to construct faulty report of peer %d: %s", self.Index, peerIdx, err)
+		return nil
+	}
+	return tx
+}
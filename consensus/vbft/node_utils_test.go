@@ -21,12 +21,18 @@ package vbft
 import (
 	"crypto/rand"
 	"crypto/sha512"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/ontio/dad-go-crypto/keypair"
+	"github.com/ontio/dad-go/account"
 	"github.com/ontio/dad-go/common"
+	sysconfig "github.com/ontio/dad-go/common/config"
 	"github.com/ontio/dad-go/common/log"
 	vconfig "github.com/ontio/dad-go/consensus/vbft/config"
+	"github.com/ontio/dad-go/core/signature"
+	"github.com/ontio/dad-go/core/types"
 )
 
 func peerPool() *PeerPool {
@@ -227,3 +233,84 @@ func testCalcParticipantPeers(t *testing.T, n, c int) {
 		t.Fatalf("peers(%d, %d, %d, %d, %d, %d): %v, %v, %v", n, c, len(peers), len(pp), len(pe), len(pc), pp, pe, pc)
 	}
 }
+
+func newTestProposal(t *testing.T, acc *account.Account, blkNum, timestamp uint32) *blockProposalMsg {
+	info := &vconfig.VbftBlockInfo{Proposer: 2}
+	payload, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("failed to build consensus payload: %s", err)
+	}
+	header := &types.Header{
+		Height:           blkNum,
+		Timestamp:        timestamp,
+		ConsensusData:    common.GetNonce(),
+		ConsensusPayload: payload,
+	}
+	hash := header.Hash()
+	sig, err := signature.Sign(acc, hash[:])
+	if err != nil {
+		t.Fatalf("failed to sign block: %s", err)
+	}
+	header.Bookkeepers = []keypair.PublicKey{acc.PublicKey}
+	header.SigData = [][]byte{sig}
+	return &blockProposalMsg{
+		Block: &Block{
+			Block: &types.Block{Header: header},
+			Info:  info,
+		},
+	}
+}
+
+func TestCheckFaultyProposal(t *testing.T) {
+	networkId := sysconfig.DefConfig.P2PNode.NetworkId
+	defer func() {
+		sysconfig.DefConfig.P2PNode.NetworkId = networkId
+	}()
+	sysconfig.DefConfig.P2PNode.NetworkId = sysconfig.NETWORK_ID_SOLO_NET
+
+	faulty := account.NewAccount("")
+	server := constructServer()
+	server.account = account.NewAccount("")
+	server.peerPool = constructPeerPool(false)
+	if err := server.peerPool.addPeer(&vconfig.PeerConfig{Index: 2, ID: vconfig.PubkeyID(faulty.PublicKey)}); err != nil {
+		t.Fatalf("failed to add peer: %s", err)
+	}
+	server.blockPool = &BlockPool{
+		HistoryLen:      64,
+		candidateBlocks: make(map[uint32]*CandidateInfo),
+	}
+
+	p1 := newTestProposal(t, faulty, 10, 1000)
+	p2 := newTestProposal(t, faulty, 10, 1001)
+	if err := server.blockPool.newBlockProposal(p1); err != nil {
+		t.Fatalf("failed to add proposal: %s", err)
+	}
+	if err := server.blockPool.newBlockProposal(p2); err != errDupProposal {
+		t.Fatalf("dup proposal not detected: %v", err)
+	}
+	tx := server.checkFaultyProposal(p2)
+	if tx == nil {
+		t.Fatalf("faulty proposal not reported")
+	}
+	if tx.Payer != server.account.Address || tx.Nonce != 10 {
+		t.Fatalf("invalid faulty report tx, payer %s, nonce %d", tx.Payer.ToBase58(), tx.Nonce)
+	}
+	// faulty peer is reported once per round
+	if server.checkFaultyProposal(p2) != nil {
+		t.Fatalf("faulty proposal reported twice")
+	}
+
+	// not enabled on main net yet
+	sysconfig.DefConfig.P2PNode.NetworkId = sysconfig.NETWORK_ID_MAIN_NET
+	p3 := newTestProposal(t, faulty, 11, 1000)
+	p4 := newTestProposal(t, faulty, 11, 1001)
+	if err := server.blockPool.newBlockProposal(p3); err != nil {
+		t.Fatalf("failed to add proposal: %s", err)
+	}
+	if err := server.blockPool.newBlockProposal(p4); err != errDupProposal {
+		t.Fatalf("dup proposal not detected: %v", err)
+	}
+	if server.checkFaultyProposal(p4) != nil {
+		t.Fatalf("faulty proposal reported before enable height")
+	}
+}
@@ -1231,7 +1231,9 @@ func (self *Server) processMsgEvent() error {
 				// add proposal to block-pool
 				if err := self.blockPool.newBlockProposal(pMsg); err != nil {
 					if err == errDupProposal {
-						// TODO: faulty proposer detected
+						if tx := self.checkFaultyProposal(pMsg); tx != nil {
+							self.poolActor.AppendTx(tx)
+						}
 					}
 					log.Errorf("failed to add block proposal (%d): %s", msgBlkNum, err)
 					return nil
@@ -1280,6 +1282,7 @@ func (self *Server) processMsgEvent() error {
 			msgBlkNum := pMsg.GetBlockNum()
 
 			if msgBlkNum == self.GetCurrentBlockNo() {
+				self.checkFaultyEndorsement(pMsg)
 				// add endorse to block-pool
 				self.blockPool.newBlockEndorsement(pMsg)
 				log.Infof("server %d received endorse from %d, for proposer %d, block %d, empty: %t",
@@ -2347,6 +2350,9 @@ func (self *Server) handleProposalTimeout(evt *TimerEvent) error {
 	proposals := self.blockPool.getBlockProposals(evt.blockNum)
 
 	log.Infof("server %d proposal timeout, known proposals %d, timeout: %d", self.Index, len(proposals), evt.evtType)
+	if evt.evtType == EventProposeBlockTimeout {
+		viewChanges.Inc()
+	}
 
 	// if no proposal available, random backoff
 	if len(proposals) == 0 {
@@ -30,6 +30,7 @@ import (
 	"github.com/ontio/ontology/core/types"
 	"github.com/ontio/ontology/smartcontract/event"
 	cstate "github.com/ontio/ontology/smartcontract/states"
+	vm "github.com/ontio/ontology/vm/neovm"
 )
 
 var DefLedger *Ledger
@@ -171,6 +172,38 @@ func (self *Ledger) GetStorageItem(codeHash common.Address, key []byte) ([]byte,
 	return storageItem.Value, nil
 }
 
+func (self *Ledger) FindStorageItems(contract common.Address, prefix []byte, onItem func(key, value []byte) bool) error {
+	return self.ldgStore.FindStorageItems(contract, prefix, onItem)
+}
+
+func (self *Ledger) GetStorageProof(contract common.Address, key []byte, height uint32) (*store.StorageProof, error) {
+	return self.ldgStore.GetStorageProof(contract, key, height)
+}
+
+func (self *Ledger) GetSnapshotManifest(height uint32) (*store.SnapshotManifest, error) {
+	return self.ldgStore.GetSnapshotManifest(height)
+}
+
+func (self *Ledger) GetSnapshotChunk(height, index uint32) ([]byte, error) {
+	return self.ldgStore.GetSnapshotChunk(height, index)
+}
+
+func (self *Ledger) AddSnapshotChunk(manifest *store.SnapshotManifest, index uint32, data []byte) error {
+	return self.ldgStore.AddSnapshotChunk(manifest, index, data)
+}
+
+func (self *Ledger) InstallSnapshot(manifest *store.SnapshotManifest) error {
+	return self.ldgStore.InstallSnapshot(manifest)
+}
+
+func (self *Ledger) IsSnapshotSyncing() bool {
+	return self.ldgStore.IsSnapshotSyncing()
+}
+
+func (self *Ledger) GetPrunedHeight() uint32 {
+	return self.ldgStore.GetPrunedHeight()
+}
+
 func (self *Ledger) GetContractState(contractHash common.Address) (*payload.DeployCode, error) {
 	return self.ldgStore.GetContractState(contractHash)
 }
@@ -187,6 +220,10 @@ func (self *Ledger) PreExecuteContractBatch(txes []*types.Transaction, atomic bo
 	return self.ldgStore.PreExecuteContractBatch(txes, atomic)
 }
 
+func (self *Ledger) PreExecuteContractWithTracer(tx *types.Transaction, tracer vm.Tracer) (*cstate.PreExecResult, error) {
+	return self.ldgStore.PreExecuteContractWithTracer(tx, tracer)
+}
+
 func (self *Ledger) GetEventNotifyByTx(tx common.Uint256) (*event.ExecuteNotify, error) {
 	return self.ldgStore.GetEventNotifyByTx(tx)
 }
@@ -195,6 +232,14 @@ func (self *Ledger) GetEventNotifyByBlock(height uint32) ([]*event.ExecuteNotify
 	return self.ldgStore.GetEventNotifyByBlock(height)
 }
 
+func (self *Ledger) GetAddressTxs(addr common.Address, startHeight, endHeight, offset, limit uint32) ([]*store.AddressTx, error) {
+	return self.ldgStore.GetAddressTxs(addr, startHeight, endHeight, offset, limit)
+}
+
+func (self *Ledger) GetAddressIndexStartHeight() (uint32, error) {
+	return self.ldgStore.GetAddressIndexStartHeight()
+}
+
 func (self *Ledger) GetCrossChainMsg(height uint32) (*types.CrossChainMsg, error) {
 	return self.ldgStore.GetCrossChainMsg(height)
 }
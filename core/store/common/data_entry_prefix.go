@@ -27,15 +27,19 @@ const (
 	DATA_HEADER                            = 0x01 //Block hash => block hash key prefix
 	DATA_TRANSACTION                       = 0x02 //Transction hash = > transaction key prefix
 	DATA_STATE_MERKLE_ROOT                 = 0x21 // block height => write set hash + state merkle root
+	DATA_STORAGE_TRIE_ROOT                 = 0x23 // block height => change hash + storage trie root + state merkle tree
 
 	// Transaction
-	ST_BOOKKEEPER DataEntryPrefix = 0x03 //BookKeeper state key prefix
-	ST_CONTRACT   DataEntryPrefix = 0x04 //Smart contract state key prefix
-	ST_STORAGE    DataEntryPrefix = 0x05 //Smart contract storage key prefix
-	ST_VALIDATOR  DataEntryPrefix = 0x07 //no use
-	ST_VOTE       DataEntryPrefix = 0x08 //Vote state key prefix
+	ST_BOOKKEEPER   DataEntryPrefix = 0x03 //BookKeeper state key prefix
+	ST_CONTRACT     DataEntryPrefix = 0x04 //Smart contract state key prefix
+	ST_STORAGE      DataEntryPrefix = 0x05 //Smart contract storage key prefix
+	ST_STORAGE_TRIE DataEntryPrefix = 0x06 //Storage trie node key prefix
+	ST_VALIDATOR    DataEntryPrefix = 0x07 //no use
+	ST_VOTE         DataEntryPrefix = 0x08 //Vote state key prefix
 
 	IX_HEADER_HASH_LIST DataEntryPrefix = 0x09 //Block height => block hash key prefix
+	IX_ADDRESS_TX       DataEntryPrefix = 0x15 //Address + block height + tx index => transaction hash key prefix
+	IX_ADDRESS_BLOCK    DataEntryPrefix = 0x16 //Block height => addresses indexed in the block key prefix
 
 	//SYSTEM
 	SYS_CURRENT_BLOCK        DataEntryPrefix = 0x10 //Current block key prefix
@@ -44,6 +48,11 @@ const (
 	SYS_BLOCK_MERKLE_TREE    DataEntryPrefix = 0x13 // Block merkle tree root key prefix
 	SYS_STATE_MERKLE_TREE    DataEntryPrefix = 0x20 // state merkle tree root key prefix
 	SYS_CROSS_CHAIN_MSG      DataEntryPrefix = 0x22 // state merkle tree root key prefix
+	SYS_SNAPSHOT_HEIGHT      DataEntryPrefix = 0x24 // height of the state snapshot the ledger is bootstrapped from
+	SYS_PRUNED_HEIGHT        DataEntryPrefix = 0x25 // height below which blocks and event notifies are pruned
+
+	SYS_STORAGE_TRIE_PRUNED_HEIGHT DataEntryPrefix = 0x26 // height below which storage trie roots are pruned
+	SYS_ADDRESS_INDEX_HEIGHT       DataEntryPrefix = 0x27 // first and last height of the blocks indexed continuously by address
 
 	EVENT_NOTIFY DataEntryPrefix = 0x14 //Event notify key prefix
 )
@@ -26,6 +26,7 @@ import (
 )
 
 var ErrNotFound = errors.New("not found")
+var ErrPruned = errors.New("pruned")
 
 //Store iterator for iterate store
 type StoreIterator interface {
@@ -0,0 +1,207 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledgerstore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/store"
+	scom "github.com/ontio/ontology/core/store/common"
+	"github.com/ontio/ontology/core/types"
+	"github.com/ontio/ontology/smartcontract/event"
+	"github.com/ontio/ontology/smartcontract/service/native/ont"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//SaveAddressTx persist the index entry of a transaction related to the address
+func (this *EventStore) SaveAddressTx(addr common.Address, height, txIndex uint32, txHash common.Uint256) {
+	key := genAddressTxKey(addr, height, txIndex)
+	this.store.BatchPut(key, txHash.ToArray())
+}
+
+//SaveBlockAddresses persist the addresses indexed in the block, so that the entries can be removed when pruning
+func (this *EventStore) SaveBlockAddresses(height uint32, addrs []common.Address) {
+	value := common.NewZeroCopySink(nil)
+	value.WriteVarUint(uint64(len(addrs)))
+	for _, addr := range addrs {
+		value.WriteAddress(addr)
+	}
+	this.store.BatchPut(genAddressBlockKey(height), value.Bytes())
+}
+
+//PruneAddressTxs removes the index entries of the transactions in the block
+func (this *EventStore) PruneAddressTxs(height uint32) error {
+	key := genAddressBlockKey(height)
+	data, err := this.store.Get(key)
+	if err == scom.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	source := common.NewZeroCopySource(data)
+	n, _, irregular, eof := source.NextVarUint()
+	if irregular || eof {
+		return fmt.Errorf("invalid addresses of block %d", height)
+	}
+	for i := uint64(0); i < n; i++ {
+		addr, eof := source.NextAddress()
+		if eof {
+			return fmt.Errorf("invalid addresses of block %d", height)
+		}
+		iter := this.store.NewRangeIterator(genAddressTxKey(addr, height, 0), genAddressTxKey(addr, height+1, 0))
+		for iter.Next() {
+			this.store.BatchDelete(iter.Key())
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return err
+		}
+	}
+	this.store.BatchDelete(key)
+	return nil
+}
+
+//SaveAddressIndexHeight persist the first and last height of the blocks indexed continuously
+func (this *EventStore) SaveAddressIndexHeight(start, end uint32) {
+	value := common.NewZeroCopySink(nil)
+	value.WriteUint32(start)
+	value.WriteUint32(end)
+	this.store.BatchPut([]byte{byte(scom.SYS_ADDRESS_INDEX_HEIGHT)}, value.Bytes())
+}
+
+//GetAddressIndexHeight return the first and last height of the blocks indexed continuously,
+//scom.ErrNotFound is returned if no block is indexed
+func (this *EventStore) GetAddressIndexHeight() (uint32, uint32, error) {
+	data, err := this.store.Get([]byte{byte(scom.SYS_ADDRESS_INDEX_HEIGHT)})
+	if err != nil {
+		return 0, 0, err
+	}
+	source := common.NewZeroCopySource(data)
+	start, eof := source.NextUint32()
+	if eof {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	end, eof := source.NextUint32()
+	if eof {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	return start, end, nil
+}
+
+//GetAddressTxs return the transactions related to the address in the height range [startHeight, endHeight],
+//in ascending order of block height and transaction index. The first offset entries are skipped.
+func (this *EventStore) GetAddressTxs(addr common.Address, startHeight, endHeight, offset, limit uint32) ([]*store.AddressTx, error) {
+	txs := make([]*store.AddressTx, 0)
+	if startHeight > endHeight || limit == 0 {
+		return txs, nil
+	}
+	start := genAddressTxKey(addr, startHeight, 0)
+	end := genAddressTxKey(addr, endHeight, math.MaxUint32)
+	iter := this.store.NewRangeIterator(start, end)
+	defer iter.Release()
+	for iter.Next() {
+		if offset > 0 {
+			offset--
+			continue
+		}
+		key := iter.Key()
+		if len(key) != 1+common.ADDR_LEN+8 {
+			return nil, fmt.Errorf("invalid address index key:%x", key)
+		}
+		txHash, err := common.Uint256ParseFromBytes(iter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("invalid address index value:%x", iter.Value())
+		}
+		txs = append(txs, &store.AddressTx{
+			TxHash:  txHash,
+			Height:  binary.BigEndian.Uint32(key[1+common.ADDR_LEN:]),
+			TxIndex: binary.BigEndian.Uint32(key[1+common.ADDR_LEN+4:]),
+		})
+		if uint32(len(txs)) >= limit {
+			break
+		}
+	}
+	return txs, iter.Error()
+}
+
+//address + big endian height + big endian tx index, so that entries of an address are sorted by block order
+func genAddressTxKey(addr common.Address, height, txIndex uint32) []byte {
+	key := make([]byte, 1+common.ADDR_LEN+8)
+	key[0] = byte(scom.IX_ADDRESS_TX)
+	copy(key[1:], addr[:])
+	binary.BigEndian.PutUint32(key[1+common.ADDR_LEN:], height)
+	binary.BigEndian.PutUint32(key[1+common.ADDR_LEN+4:], txIndex)
+	return key
+}
+
+func genAddressBlockKey(height uint32) []byte {
+	key := make([]byte, 5)
+	key[0] = byte(scom.IX_ADDRESS_BLOCK)
+	binary.BigEndian.PutUint32(key[1:], height)
+	return key
+}
+
+//getTxRelatedAddresses return the payer, signers and ont/ong transfer parties of the transaction
+func getTxRelatedAddresses(tx *types.Transaction, notify *event.ExecuteNotify) []common.Address {
+	addrs := make([]common.Address, 0)
+	exist := make(map[common.Address]bool)
+	add := func(addr common.Address) {
+		if addr == common.ADDRESS_EMPTY || exist[addr] {
+			return
+		}
+		exist[addr] = true
+		addrs = append(addrs, addr)
+	}
+	add(tx.Payer)
+	for _, addr := range tx.GetSignatureAddresses() {
+		add(addr)
+	}
+	if notify == nil {
+		return addrs
+	}
+	for _, n := range notify.Notify {
+		if n.ContractAddress != utils.OntContractAddress && n.ContractAddress != utils.OngContractAddress {
+			continue
+		}
+		states, ok := n.States.([]interface{})
+		if !ok || len(states) < 3 {
+			continue
+		}
+		if name, ok := states[0].(string); !ok || name != ont.TRANSFER_NAME {
+			continue
+		}
+		for _, state := range states[1:3] {
+			base58, ok := state.(string)
+			if !ok {
+				continue
+			}
+			addr, err := common.AddressFromBase58(base58)
+			if err != nil {
+				continue
+			}
+			add(addr)
+		}
+	}
+	return addrs
+}
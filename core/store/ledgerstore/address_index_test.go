@@ -0,0 +1,163 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledgerstore
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ontio/dad-go/common"
+	scom "github.com/ontio/dad-go/core/store/common"
+	"github.com/ontio/dad-go/core/types"
+	"github.com/ontio/dad-go/smartcontract/event"
+	"github.com/ontio/dad-go/smartcontract/service/native/ont"
+	"github.com/ontio/dad-go/smartcontract/service/native/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressTxs(t *testing.T) {
+	dir := "test/addressindex"
+	os.RemoveAll(dir)
+	defer os.RemoveAll(dir)
+	eventStore, err := NewEventStore(dir)
+	if err != nil {
+		t.Errorf("NewEventStore error %s", err)
+		return
+	}
+	defer eventStore.Close()
+
+	addr1 := common.Address{1}
+	addr2 := common.Address{2}
+	eventStore.NewBatch()
+	for height := uint32(1); height <= 10; height++ {
+		for txIndex := uint32(0); txIndex < 2; txIndex++ {
+			txHash := common.Uint256{byte(height), byte(txIndex)}
+			eventStore.SaveAddressTx(addr1, height, txIndex, txHash)
+			if height%2 == 0 {
+				eventStore.SaveAddressTx(addr2, height, txIndex, txHash)
+			}
+		}
+	}
+	err = eventStore.CommitTo()
+	assert.Nil(t, err)
+
+	txs, err := eventStore.GetAddressTxs(addr1, 0, 100, 0, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 20, len(txs))
+	assert.Equal(t, uint32(1), txs[0].Height)
+	assert.Equal(t, uint32(10), txs[19].Height)
+	assert.Equal(t, uint32(1), txs[19].TxIndex)
+	assert.Equal(t, common.Uint256{10, 1}, txs[19].TxHash)
+
+	txs, err = eventStore.GetAddressTxs(addr1, 3, 5, 0, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(txs))
+	assert.Equal(t, uint32(3), txs[0].Height)
+	assert.Equal(t, uint32(5), txs[5].Height)
+
+	txs, err = eventStore.GetAddressTxs(addr1, 3, 5, 4, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(txs))
+	assert.Equal(t, common.Uint256{5, 0}, txs[0].TxHash)
+
+	txs, err = eventStore.GetAddressTxs(addr2, 0, 100, 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(txs))
+	assert.Equal(t, common.Uint256{2, 1}, txs[0].TxHash)
+	assert.Equal(t, common.Uint256{4, 1}, txs[2].TxHash)
+
+	txs, err = eventStore.GetAddressTxs(common.Address{3}, 0, 100, 0, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(txs))
+}
+
+func TestPruneAddressTxs(t *testing.T) {
+	dir := "test/addressprune"
+	os.RemoveAll(dir)
+	defer os.RemoveAll(dir)
+	eventStore, err := NewEventStore(dir)
+	if err != nil {
+		t.Errorf("NewEventStore error %s", err)
+		return
+	}
+	defer eventStore.Close()
+
+	_, _, err = eventStore.GetAddressIndexHeight()
+	assert.Equal(t, scom.ErrNotFound, err)
+
+	addr1 := common.Address{1}
+	addr2 := common.Address{2}
+	eventStore.NewBatch()
+	for height := uint32(5); height <= 7; height++ {
+		eventStore.SaveAddressTx(addr1, height, 0, common.Uint256{byte(height), 0})
+		eventStore.SaveAddressTx(addr2, height, 1, common.Uint256{byte(height), 1})
+		eventStore.SaveBlockAddresses(height, []common.Address{addr1, addr2})
+	}
+	eventStore.SaveAddressIndexHeight(5, 7)
+	assert.Nil(t, eventStore.CommitTo())
+
+	start, end, err := eventStore.GetAddressIndexHeight()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(5), start)
+	assert.Equal(t, uint32(7), end)
+
+	eventStore.NewBatch()
+	assert.Nil(t, eventStore.PruneAddressTxs(5))
+	assert.Nil(t, eventStore.PruneAddressTxs(6))
+	//blocks not indexed are skipped
+	assert.Nil(t, eventStore.PruneAddressTxs(1))
+	assert.Nil(t, eventStore.CommitTo())
+
+	for _, addr := range []common.Address{addr1, addr2} {
+		txs, err := eventStore.GetAddressTxs(addr, 0, 100, 0, 100)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(txs))
+		assert.Equal(t, uint32(7), txs[0].Height)
+	}
+	_, err = eventStore.store.Get(genAddressBlockKey(5))
+	assert.Equal(t, scom.ErrNotFound, err)
+}
+
+func TestGetTxRelatedAddresses(t *testing.T) {
+	payer := common.Address{1}
+	from := common.Address{2}
+	to := common.Address{3}
+	tx := &types.Transaction{Payer: payer, SignedAddr: []common.Address{payer, from}}
+	notify := &event.ExecuteNotify{
+		Notify: []*event.NotifyEventInfo{
+			{
+				ContractAddress: utils.OntContractAddress,
+				States:          []interface{}{ont.TRANSFER_NAME, from.ToBase58(), to.ToBase58(), uint64(1)},
+			},
+			{
+				ContractAddress: utils.OngContractAddress,
+				States:          []interface{}{ont.TRANSFER_NAME, payer.ToBase58(), utils.GovernanceContractAddress.ToBase58(), uint64(1)},
+			},
+			{
+				ContractAddress: common.Address{4},
+				States:          []interface{}{ont.TRANSFER_NAME, common.Address{5}.ToBase58(), to.ToBase58(), uint64(1)},
+			},
+		},
+	}
+	addrs := getTxRelatedAddresses(tx, notify)
+	assert.Equal(t, []common.Address{payer, from, to, utils.GovernanceContractAddress}, addrs)
+
+	addrs = getTxRelatedAddresses(tx, nil)
+	assert.Equal(t, []common.Address{payer, from}, addrs)
+}
@@ -119,6 +119,9 @@ func (this *BlockStore) GetBlock(blockHash common.Uint256) (*types.Block, error)
 	txList := make([]*types.Transaction, 0, len(txHashes))
 	for _, txHash := range txHashes {
 		tx, _, err := this.GetTransaction(txHash)
+		if err == scom.ErrPruned {
+			return nil, err
+		}
 		if err != nil {
 			return nil, fmt.Errorf("GetTransaction %s error %s", txHash.ToHexString(), err)
 		}
@@ -398,6 +401,10 @@ func (this *BlockStore) loadTransaction(txHash common.Uint256) (*types.Transacti
 	if eof {
 		return nil, 0, io.ErrUnexpectedEOF
 	}
+	//only the height is kept for pruned transaction
+	if source.Len() == 0 {
+		return nil, height, scom.ErrPruned
+	}
 	tx = new(types.Transaction)
 	err = tx.Deserialization(source)
 	if err != nil {
@@ -442,6 +449,74 @@ func (this *BlockStore) SaveVersion(ver byte) error {
 	return this.store.Put(key, []byte{ver})
 }
 
+//GetSnapshotHeight return the height of state snapshot the ledger is bootstrapped from, blocks below it are
+//not kept by the store. Return 0 if the ledger is synced from genesis block.
+func (this *BlockStore) GetSnapshotHeight() (uint32, error) {
+	data, err := this.store.Get(this.getSnapshotHeightKey())
+	if err == scom.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	height, eof := common.NewZeroCopySource(data).NextUint32()
+	if eof {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return height, nil
+}
+
+//SaveSnapshotHeight persist the height of state snapshot the ledger is bootstrapped from
+func (this *BlockStore) SaveSnapshotHeight(height uint32) {
+	value := common.NewZeroCopySink(nil)
+	value.WriteUint32(height)
+	this.store.BatchPut(this.getSnapshotHeightKey(), value.Bytes())
+}
+
+//PruneBlock removes the transactions of block and return their hashes. The header is kept, and the height
+//of transaction is kept as well, so that the transaction is still known as included in ledger.
+func (this *BlockStore) PruneBlock(blockHash common.Uint256) ([]common.Uint256, error) {
+	header, txHashes, err := this.loadHeaderWithTx(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if this.enableCache {
+		this.cache.RemoveBlock(blockHash)
+	}
+	value := common.NewZeroCopySink(nil)
+	value.WriteUint32(header.Height)
+	for _, txHash := range txHashes {
+		if this.enableCache {
+			this.cache.RemoveTransaction(txHash)
+		}
+		this.store.BatchPut(this.getTransactionKey(txHash), value.Bytes())
+	}
+	return txHashes, nil
+}
+
+//GetPrunedHeight return the height below which blocks are pruned
+func (this *BlockStore) GetPrunedHeight() (uint32, error) {
+	data, err := this.store.Get(this.getPrunedHeightKey())
+	if err == scom.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	height, eof := common.NewZeroCopySource(data).NextUint32()
+	if eof {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return height, nil
+}
+
+//SavePrunedHeight persist the height below which blocks are pruned
+func (this *BlockStore) SavePrunedHeight(height uint32) {
+	value := common.NewZeroCopySink(nil)
+	value.WriteUint32(height)
+	this.store.BatchPut(this.getPrunedHeightKey(), value.Bytes())
+}
+
 //ClearAll clear all the data of block store
 func (this *BlockStore) ClearAll() error {
 	this.NewBatch()
@@ -496,6 +571,14 @@ func (this *BlockStore) getBlockMerkleTreeKey() []byte {
 	return []byte{byte(scom.SYS_BLOCK_MERKLE_TREE)}
 }
 
+func (this *BlockStore) getSnapshotHeightKey() []byte {
+	return []byte{byte(scom.SYS_SNAPSHOT_HEIGHT)}
+}
+
+func (this *BlockStore) getPrunedHeightKey() []byte {
+	return []byte{byte(scom.SYS_PRUNED_HEIGHT)}
+}
+
 func (this *BlockStore) getVersionKey() []byte {
 	return []byte{byte(scom.SYS_VERSION)}
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/ontio/dad-go/account"
 	"github.com/ontio/dad-go/common"
 	"github.com/ontio/dad-go/core/payload"
+	scom "github.com/ontio/dad-go/core/store/common"
 	"github.com/ontio/dad-go/core/types"
 	"github.com/ontio/dad-go/core/utils"
 	"github.com/ontio/dad-go/smartcontract/service/native/ont"
@@ -408,3 +409,53 @@ func newInvokeTransaction(gasPirce, gasLimit uint64, code []byte) *types.Transac
 	}
 	return res
 }
+
+func TestPruneBlock(t *testing.T) {
+	txTemp := &types.MutableTransaction{
+		TxType:  types.InvokeNeo,
+		Nonce:   uint32(time.Now().Unix()),
+		Payload: &payload.InvokeCode{},
+	}
+	tx, err := txTemp.IntoImmutable()
+	assert.Nil(t, err)
+	block := &types.Block{
+		Header: &types.Header{
+			Height:    uint32(100),
+			Timestamp: uint32(time.Now().Unix()),
+		},
+		Transactions: []*types.Transaction{tx},
+	}
+	blockHash := block.Hash()
+	txHash := tx.Hash()
+
+	testBlockStore.NewBatch()
+	err = testBlockStore.SaveBlock(block)
+	assert.Nil(t, err)
+	err = testBlockStore.CommitTo()
+	assert.Nil(t, err)
+
+	testBlockStore.NewBatch()
+	txHashes, err := testBlockStore.PruneBlock(blockHash)
+	assert.Nil(t, err)
+	assert.Equal(t, []common.Uint256{txHash}, txHashes)
+	testBlockStore.SavePrunedHeight(block.Header.Height + 1)
+	err = testBlockStore.CommitTo()
+	assert.Nil(t, err)
+
+	_, height, err := testBlockStore.GetTransaction(txHash)
+	assert.Equal(t, scom.ErrPruned, err)
+	assert.Equal(t, block.Header.Height, height)
+	exist, err := testBlockStore.ContainTransaction(txHash)
+	assert.Nil(t, err)
+	assert.True(t, exist)
+
+	_, err = testBlockStore.GetBlock(blockHash)
+	assert.Equal(t, scom.ErrPruned, err)
+	header, err := testBlockStore.GetHeader(blockHash)
+	assert.Nil(t, err)
+	assert.Equal(t, blockHash, header.Hash())
+
+	prunedHeight, err := testBlockStore.GetPrunedHeight()
+	assert.Nil(t, err)
+	assert.Equal(t, block.Header.Height+1, prunedHeight)
+}
@@ -119,6 +119,14 @@ func (this *EventStore) GetEventNotifyByBlock(height uint32) ([]*event.ExecuteNo
 	return evtNotifies, nil
 }
 
+//PruneEventNotify removes the event notifies of block and its transactions
+func (this *EventStore) PruneEventNotify(height uint32, txHashs []common.Uint256) {
+	this.store.BatchDelete(genEventNotifyByBlockKey(height))
+	for _, txHash := range txHashs {
+		this.store.BatchDelete(genEventNotifyByTxKey(txHash))
+	}
+}
+
 //CommitTo event store batch to store
 func (this *EventStore) CommitTo() error {
 	return this.store.BatchCommit()
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"github.com/ontio/ontology/merkle"
 	"github.com/ontio/ontology/smartcontract/service/native/utils"
+	vm "github.com/ontio/ontology/vm/neovm"
 	types2 "github.com/ontio/ontology/vm/neovm/types"
 	"hash"
 	"math"
@@ -37,6 +38,7 @@ import (
 	"github.com/ontio/ontology/common"
 	"github.com/ontio/ontology/common/config"
 	"github.com/ontio/ontology/common/log"
+	"github.com/ontio/ontology/common/metrics"
 	"github.com/ontio/ontology/consensus/vbft/config"
 	"github.com/ontio/ontology/core/payload"
 	"github.com/ontio/ontology/core/signature"
@@ -69,10 +71,21 @@ var (
 	MerkleTreeStorePath = "merkle_tree.db"
 )
 
+var (
+	blockHeightGauge  = metrics.NewGauge("ontology_ledger_block_height", "Current block height of the ledger")
+	blockExecDuration = metrics.NewSummary("ontology_ledger_block_execute_duration_seconds", "Time spent executing blocks")
+)
+
+func init() {
+	metrics.Register(blockHeightGauge)
+	metrics.Register(blockExecDuration)
+}
+
 type PrexecuteParam struct {
 	JitMode    bool
 	WasmFactor uint64
 	MinGas     bool
+	Tracer     vm.Tracer // records the neovm execution steps if not nil
 }
 
 //LedgerStoreImp is main store struct fo ledger
@@ -92,6 +105,11 @@ type LedgerStoreImp struct {
 	vbftPeerInfoblock    map[string]uint32 //pubInfo save pubkey,peerindex
 	lock                 sync.RWMutex
 	stateHashCheckHeight uint32
+	snapshotHeight       uint32       //height of the state snapshot the ledger is bootstrapped from
+	snapshotter          *snapshotter //snapshotter for exporting and syncing state snapshot
+	prunedHeight         uint32       //height below which blocks and event notifies are pruned
+	pruner               *pruner      //pruner for removing old blocks in pruning mode
+	storageTrieReady     bool         //storage trie of the current block exists, it is updated with every block
 }
 
 //NewLedgerStore return LedgerStoreImp instance
@@ -131,6 +149,12 @@ func NewLedgerStore(dataDir string, stateHashHeight uint32) (*LedgerStoreImp, er
 	}
 	ledgerStore.eventStore = eventState
 
+	snapshotStore, err := NewSnapshotStore(fmt.Sprintf("%s%s%s", dataDir, string(os.PathSeparator), DBDirSnapshot))
+	if err != nil {
+		return nil, fmt.Errorf("NewSnapshotStore error %s", err)
+	}
+	ledgerStore.snapshotter = &snapshotter{store: snapshotStore}
+
 	return ledgerStore, nil
 }
 
@@ -171,6 +195,7 @@ func (this *LedgerStoreImp) InitLedgerStoreWithGenesisBlock(genesisBlock *types.
 		if err != nil {
 			return fmt.Errorf("save genesis block error %s", err)
 		}
+		this.storageTrieReady = true
 		err = this.initGenesisBlock()
 		if err != nil {
 			return fmt.Errorf("init error %s", err)
@@ -198,33 +223,16 @@ func (this *LedgerStoreImp) InitLedgerStoreWithGenesisBlock(genesisBlock *types.
 		if err != nil {
 			return err
 		}
-		blkInfo, err := vconfig.VbftBlock(header)
+		peerInfo, err := this.getVbftPeerInfo(header)
 		if err != nil {
 			return err
 		}
-		var cfg *vconfig.ChainConfig
-		if blkInfo.NewChainConfig != nil {
-			cfg = blkInfo.NewChainConfig
-		} else {
-			cfgHeader, err := this.GetHeaderByHeight(blkInfo.LastConfigBlockNum)
-			if err != nil {
-				return err
-			}
-			Info, err := vconfig.VbftBlock(cfgHeader)
-			if err != nil {
-				return err
-			}
-			if Info.NewChainConfig == nil {
-				return fmt.Errorf("getNewChainConfig error block num:%d", blkInfo.LastConfigBlockNum)
-			}
-			cfg = Info.NewChainConfig
-		}
 		this.lock.Lock()
 		this.vbftPeerInfoheader = make(map[string]uint32)
 		this.vbftPeerInfoblock = make(map[string]uint32)
-		for _, p := range cfg.Peers {
-			this.vbftPeerInfoheader[p.ID] = p.Index
-			this.vbftPeerInfoblock[p.ID] = p.Index
+		for id, index := range peerInfo {
+			this.vbftPeerInfoheader[id] = index
+			this.vbftPeerInfoblock[id] = index
 		}
 		this.lock.Unlock()
 	}
@@ -233,6 +241,36 @@ func (this *LedgerStoreImp) InitLedgerStoreWithGenesisBlock(genesisBlock *types.
 	return err
 }
 
+//getVbftPeerInfo return the vbft peers in charge after the block of header
+func (this *LedgerStoreImp) getVbftPeerInfo(header *types.Header) (map[string]uint32, error) {
+	blkInfo, err := vconfig.VbftBlock(header)
+	if err != nil {
+		return nil, err
+	}
+	var cfg *vconfig.ChainConfig
+	if blkInfo.NewChainConfig != nil {
+		cfg = blkInfo.NewChainConfig
+	} else {
+		cfgHeader, err := this.GetHeaderByHeight(blkInfo.LastConfigBlockNum)
+		if err != nil {
+			return nil, err
+		}
+		Info, err := vconfig.VbftBlock(cfgHeader)
+		if err != nil {
+			return nil, err
+		}
+		if Info.NewChainConfig == nil {
+			return nil, fmt.Errorf("getNewChainConfig error block num:%d", blkInfo.LastConfigBlockNum)
+		}
+		cfg = Info.NewChainConfig
+	}
+	peerInfo := make(map[string]uint32)
+	for _, p := range cfg.Peers {
+		peerInfo[p.ID] = p.Index
+	}
+	return peerInfo, nil
+}
+
 func (this *LedgerStoreImp) hasAlreadyInitGenesisBlock() (bool, error) {
 	version, err := this.blockStore.GetVersion()
 	if err != nil && err != scom.ErrNotFound {
@@ -250,14 +288,56 @@ func (this *LedgerStoreImp) init() error {
 	if err != nil {
 		return fmt.Errorf("loadCurrentBlock error %s", err)
 	}
+	this.snapshotHeight, err = this.blockStore.GetSnapshotHeight()
+	if err != nil {
+		return fmt.Errorf("GetSnapshotHeight error %s", err)
+	}
+	this.prunedHeight, err = this.blockStore.GetPrunedHeight()
+	if err != nil {
+		return fmt.Errorf("GetPrunedHeight error %s", err)
+	}
 	err = this.loadHeaderIndexList()
 	if err != nil {
 		return fmt.Errorf("loadHeaderIndexList error %s", err)
 	}
+	err = this.initStorageTrie()
+	if err != nil {
+		return fmt.Errorf("initStorageTrie error %s", err)
+	}
 	err = this.recoverStore()
 	if err != nil {
 		return fmt.Errorf("recoverStore error %s", err)
 	}
+	this.startPruner()
+	return nil
+}
+
+//initStorageTrie builds the storage trie of the current state if the ledger doesn't have one, so that the trie is
+//updated block by block well before storage trie height instead of being built in the block at that height.
+//A ledger past storage trie height without storage trie has executed blocks with the old state hash, it can
+//only be resynced.
+func (this *LedgerStoreImp) initStorageTrie() error {
+	_, stateHeight, err := this.stateStore.GetCurrentBlock()
+	if err != nil {
+		return fmt.Errorf("stateStore.GetCurrentBlock error %s", err)
+	}
+	has, err := this.stateStore.HasStorageTrieRoot(stateHeight)
+	if err != nil {
+		return fmt.Errorf("HasStorageTrieRoot height:%d error %s", stateHeight, err)
+	}
+	if !has {
+		if trieHeight := storageTrieHeight(); stateHeight >= trieHeight {
+			return fmt.Errorf("storage trie of height %d not found, the node is upgraded after storage trie height %d, "+
+				"please resync the ledger from genesis block or a state snapshot", stateHeight, trieHeight)
+		}
+		log.Infof("building storage trie of block height %d, it may take a while", stateHeight)
+		storageRoot, err := this.stateStore.BuildStorageTrie(stateHeight)
+		if err != nil {
+			return fmt.Errorf("BuildStorageTrie height:%d error %s", stateHeight, err)
+		}
+		log.Infof("storage trie of block height %d built, root:%s", stateHeight, storageRoot.ToHexString())
+	}
+	this.storageTrieReady = true
 	return nil
 }
 
@@ -322,7 +402,7 @@ func (this *LedgerStoreImp) recoverStore() error {
 		if err != nil {
 			return fmt.Errorf("save to state store height:%d error:%s", i, err)
 		}
-		this.saveBlockToEventStore(block)
+		this.saveBlockToEventStore(block, result.Notify)
 		err = this.eventStore.CommitTo()
 		if err != nil {
 			return fmt.Errorf("eventStore.CommitTo height:%d error %s", i, err)
@@ -379,6 +459,7 @@ func (this *LedgerStoreImp) setCurrentBlock(height uint32, blockHash common.Uint
 	defer this.lock.Unlock()
 	this.currBlockHash = blockHash
 	this.currBlockHeight = height
+	blockHeightGauge.Set(float64(height))
 	return
 }
 
@@ -541,13 +622,19 @@ func (this *LedgerStoreImp) AddHeaders(headers []*types.Header) error {
 		return headers[i].Height < headers[j].Height
 	})
 	var err error
-	for _, header := range headers {
+	for i, header := range headers {
 		err = this.AddHeader(header)
 		if err != nil {
-			return err
+			headers = headers[:i]
+			break
 		}
 	}
-	return nil
+	if this.IsSnapshotSyncing() && len(headers) != 0 {
+		if e := this.saveSnapshotHeaders(headers); e != nil {
+			return fmt.Errorf("saveSnapshotHeaders error %s", e)
+		}
+	}
+	return err
 }
 
 func (this *LedgerStoreImp) GetStateMerkleRoot(height uint32) (common.Uint256, error) {
@@ -569,7 +656,11 @@ func (this *LedgerStoreImp) ExecuteBlock(block *types.Block) (result store.Execu
 		return
 	}
 
+	start := time.Now()
 	result, err = this.executeBlock(block)
+	if err == nil {
+		blockExecDuration.ObserveSince(start)
+	}
 	return
 }
 
@@ -721,6 +812,20 @@ func (this *LedgerStoreImp) executeBlock(block *types.Block) (result store.Execu
 	}
 	result.Hash = overlay.ChangeHash()
 	result.WriteSet = overlay.GetWriteSet()
+	result.ChangeHash = result.Hash
+	if block.Header.Height == 0 || this.storageTrieReady {
+		result.StorageRoot, err = this.stateStore.UpdateStorageTrie(overlay, block.Header.Height, block.Header.Height == 0)
+		if err != nil {
+			err = fmt.Errorf("update storage trie at block height:%d error:%s", block.Header.Height, err)
+			return
+		}
+		if block.Header.Height >= storageTrieHeight() && block.Header.Height > this.stateHashCheckHeight {
+			result.Hash = store.StorageTrieLeafHash(result.ChangeHash, result.StorageRoot)
+		}
+	} else if block.Header.Height >= storageTrieHeight() {
+		err = fmt.Errorf("storage trie of block height:%d is not ready", block.Header.Height-1)
+		return
+	}
 	if len(result.CrossStates) != 0 {
 		log.Infof("executeBlock: %d cross states generated at block height:%d", len(result.CrossStates), block.Header.Height)
 		result.CrossStatesRoot = merkle.TreeHasher{}.HashFullTreeWithLeafHash(result.CrossStates)
@@ -783,6 +888,10 @@ func (this *LedgerStoreImp) saveBlockToStateStore(block *types.Block, result sto
 		SaveNotify(this.eventStore, notify.TxHash, notify)
 	}
 
+	if blockHeight == 0 || this.storageTrieReady {
+		this.stateStore.AddStorageTrieRoot(blockHeight, result.ChangeHash, result.StorageRoot)
+	}
+
 	err := this.stateStore.AddStateMerkleTreeRoot(blockHeight, result.Hash)
 	if err != nil {
 		return fmt.Errorf("AddBlockMerkleTreeRoot error %s", err)
@@ -816,7 +925,7 @@ func (this *LedgerStoreImp) saveBlockToStateStore(block *types.Block, result sto
 	return nil
 }
 
-func (this *LedgerStoreImp) saveBlockToEventStore(block *types.Block) {
+func (this *LedgerStoreImp) saveBlockToEventStore(block *types.Block, notifies []*event.ExecuteNotify) {
 	blockHash := block.Hash()
 	blockHeight := block.Header.Height
 	txs := make([]common.Uint256, 0)
@@ -827,9 +936,47 @@ func (this *LedgerStoreImp) saveBlockToEventStore(block *types.Block) {
 	if len(txs) > 0 {
 		this.eventStore.SaveEventNotifyByBlock(block.Header.Height, txs)
 	}
+	if config.DefConfig.Common.EnableAddressIndex {
+		this.saveBlockToAddressIndex(block, notifies)
+	}
 	this.eventStore.SaveCurrentBlock(blockHeight, blockHash)
 }
 
+func (this *LedgerStoreImp) saveBlockToAddressIndex(block *types.Block, notifies []*event.ExecuteNotify) {
+	notifyMap := make(map[common.Uint256]*event.ExecuteNotify, len(notifies))
+	for _, notify := range notifies {
+		notifyMap[notify.TxHash] = notify
+	}
+	blockHeight := block.Header.Height
+	blockAddrs := make([]common.Address, 0)
+	exist := make(map[common.Address]bool)
+	for i, tx := range block.Transactions {
+		txHash := tx.Hash()
+		for _, addr := range getTxRelatedAddresses(tx, notifyMap[txHash]) {
+			this.eventStore.SaveAddressTx(addr, blockHeight, uint32(i), txHash)
+			if !exist[addr] {
+				exist[addr] = true
+				blockAddrs = append(blockAddrs, addr)
+			}
+		}
+	}
+	this.eventStore.SaveBlockAddresses(blockHeight, blockAddrs)
+
+	//the index restarts from this block if the blocks before are not indexed
+	start, end, err := this.eventStore.GetAddressIndexHeight()
+	if err != nil && err != scom.ErrNotFound {
+		log.Errorf("GetAddressIndexHeight error %s", err)
+	}
+	if err != nil || end+1 != blockHeight {
+		if err == nil {
+			log.Warnf("address index of blocks in (%d, %d) is missing, index restarts from height %d",
+				end, blockHeight, blockHeight)
+		}
+		start = blockHeight
+	}
+	this.eventStore.SaveAddressIndexHeight(start, blockHeight)
+}
+
 func (this *LedgerStoreImp) tryGetSavingBlockLock() (hasLocked bool) {
 	select {
 	case this.savingBlockSemaphore <- true:
@@ -877,7 +1024,7 @@ func (this *LedgerStoreImp) submitBlock(block *types.Block, crossChainMsg *types
 	if err != nil {
 		return fmt.Errorf("save to state store height:%d error:%s", blockHeight, err)
 	}
-	this.saveBlockToEventStore(block)
+	this.saveBlockToEventStore(block, result.Notify)
 	err = this.blockStore.CommitTo()
 	if err != nil {
 		return fmt.Errorf("blockStore.CommitTo height:%d error %s", blockHeight, err)
@@ -892,6 +1039,8 @@ func (this *LedgerStoreImp) submitBlock(block *types.Block, crossChainMsg *types
 		return fmt.Errorf("stateStore.CommitTo height:%d error %s", blockHeight, err)
 	}
 	this.setCurrentBlock(blockHeight, blockHash)
+	this.takeSnapshot(blockHeight)
+	this.notifyPrune(blockHeight)
 
 	if events.DefActorPublisher != nil {
 		events.DefActorPublisher.Publish(
@@ -1077,7 +1226,14 @@ func (this *LedgerStoreImp) GetTransaction(txHash common.Uint256) (*types.Transa
 
 //GetBlockByHash return block by block hash. Wrap function of BlockStore.GetBlockByHash
 func (this *LedgerStoreImp) GetBlockByHash(blockHash common.Uint256) (*types.Block, error) {
-	return this.blockStore.GetBlock(blockHash)
+	block, err := this.blockStore.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := this.checkSnapshotHeight(block.Header.Height); err != nil {
+		return nil, err
+	}
+	return block, nil
 }
 
 //GetBlockByHeight return block by height.
@@ -1110,14 +1266,68 @@ func (this *LedgerStoreImp) GetStorageItem(key *states.StorageKey) (*states.Stor
 	return this.stateStore.GetStorageState(key)
 }
 
+//FindStorageItems iterate the storage items of contract with key prefix. Wrap function of StateStore.FindStorageItems
+func (this *LedgerStoreImp) FindStorageItems(contract common.Address, prefix []byte,
+	onItem func(key, value []byte) bool) error {
+	return this.stateStore.FindStorageItems(contract, prefix, onItem)
+}
+
+//GetStorageProof return the proof of the storage value of the key in smart contract at block height.
+//Wrap function of StateStore.GetStorageProof
+func (this *LedgerStoreImp) GetStorageProof(contract common.Address, key []byte, height uint32) (*store.StorageProof, error) {
+	if height > this.GetCurrentBlockHeight() {
+		return nil, fmt.Errorf("block height %d is not committed", height)
+	}
+	if trieHeight := storageTrieHeight(); height < trieHeight {
+		return nil, fmt.Errorf("state merkle root of height %d does not cover storage trie before height %d", height, trieHeight)
+	}
+	return this.stateStore.GetStorageProof(contract, key, height)
+}
+
 //GetEventNotifyByTx return the events notify gen by executing of smart contract.  Wrap function of EventStore.GetEventNotifyByTx
 func (this *LedgerStoreImp) GetEventNotifyByTx(tx common.Uint256) (*event.ExecuteNotify, error) {
-	return this.eventStore.GetEventNotifyByTx(tx)
+	notify, err := this.eventStore.GetEventNotifyByTx(tx)
+	if err == scom.ErrNotFound {
+		if _, _, e := this.blockStore.GetTransaction(tx); e == scom.ErrPruned {
+			return nil, e
+		}
+	}
+	return notify, err
 }
 
 //GetEventNotifyByBlock return the transaction hash which have event notice after execution of smart contract. Wrap function of EventStore.GetEventNotifyByBlock
 func (this *LedgerStoreImp) GetEventNotifyByBlock(height uint32) ([]*event.ExecuteNotify, error) {
-	return this.eventStore.GetEventNotifyByBlock(height)
+	notifies, err := this.eventStore.GetEventNotifyByBlock(height)
+	if err != nil {
+		return nil, this.checkEventPruned(height, err)
+	}
+	return notifies, nil
+}
+
+//GetAddressTxs return the transactions related to the address in the height range. Wrap function of EventStore.GetAddressTxs
+func (this *LedgerStoreImp) GetAddressTxs(addr common.Address, startHeight, endHeight, offset, limit uint32) ([]*store.AddressTx, error) {
+	if !config.DefConfig.Common.EnableAddressIndex {
+		return nil, fmt.Errorf("address index is disabled")
+	}
+	return this.eventStore.GetAddressTxs(addr, startHeight, endHeight, offset, limit)
+}
+
+//GetAddressIndexStartHeight return the first height from which the blocks are indexed by address and not pruned
+func (this *LedgerStoreImp) GetAddressIndexStartHeight() (uint32, error) {
+	if !config.DefConfig.Common.EnableAddressIndex {
+		return 0, fmt.Errorf("address index is disabled")
+	}
+	start, _, err := this.eventStore.GetAddressIndexHeight()
+	if err == scom.ErrNotFound {
+		return this.GetCurrentBlockHeight() + 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if pruned := this.GetPrunedHeight(); start < pruned {
+		start = pruned
+	}
+	return start, nil
 }
 
 //PreExecuteContract return the result of smart contract execution without commit to store
@@ -1184,6 +1394,7 @@ func (this *LedgerStoreImp) PreExecuteContractWithParam(tx *types.Transaction, p
 			WasmExecStep: config.DEFAULT_WASM_MAX_STEPCOUNT,
 			JitMode:      preParam.JitMode,
 			PreExec:      true,
+			Tracer:       preParam.Tracer,
 		}
 		//start the smart contract executive function
 		engine, _ := sc.NewExecuteEngine(invoke.Code, tx.TxType)
@@ -1252,6 +1463,18 @@ func (this *LedgerStoreImp) PreExecuteContract(tx *types.Transaction) (*sstate.P
 	return this.PreExecuteContractWithParam(tx, param)
 }
 
+//PreExecuteContractWithTracer pre-execute the transaction like PreExecuteContract, with every neovm step reported to tracer
+func (this *LedgerStoreImp) PreExecuteContractWithTracer(tx *types.Transaction, tracer vm.Tracer) (*sstate.PreExecResult, error) {
+	param := PrexecuteParam{
+		JitMode:    false,
+		WasmFactor: 0,
+		MinGas:     true,
+		Tracer:     tracer,
+	}
+
+	return this.PreExecuteContractWithParam(tx, param)
+}
+
 //Close ledger store.
 func (this *LedgerStoreImp) Close() error {
 	// wait block saving complete, and get the lock to avoid subsequent block saving
@@ -1259,6 +1482,7 @@ func (this *LedgerStoreImp) Close() error {
 	defer this.releaseSavingBlockLock()
 
 	this.closing = true
+	this.stopPruner()
 
 	err := this.blockStore.Close()
 	if err != nil {
@@ -0,0 +1,275 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledgerstore
+
+import (
+	"fmt"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/common/log"
+	vconfig "github.com/ontio/ontology/consensus/vbft/config"
+	scom "github.com/ontio/ontology/core/store/common"
+)
+
+const (
+	PRUNE_BATCH_SIZE = uint32(100) //Number of blocks pruned in one batch, the saving block lock is released between batches
+
+	STORAGE_TRIE_PRUNE_INTERVAL = uint32(10000) //Storage trie is pruned once so many blocks more are pruned
+	STORAGE_TRIE_SWEEP_BATCH    = 10000         //Number of storage trie nodes checked in one batch
+)
+
+//pruner removes the transactions and event notifies of old blocks in background
+type pruner struct {
+	keep   uint32      //Number of latest blocks kept
+	notify chan uint32 //Height of the latest committed block
+	exitCh chan bool
+}
+
+//startPruner starts pruning in background if pruning mode is enabled
+func (this *LedgerStoreImp) startPruner() {
+	keep := config.DefConfig.Common.PruneBlocks
+	if keep == 0 || this.pruner != nil {
+		return
+	}
+	this.pruner = &pruner{
+		keep:   keep,
+		notify: make(chan uint32, 1),
+		exitCh: make(chan bool),
+	}
+	go this.pruneLoop(this.pruner)
+	this.notifyPrune(this.GetCurrentBlockHeight())
+}
+
+//notifyPrune tells the pruner a new block is committed, never blocks block saving
+func (this *LedgerStoreImp) notifyPrune(height uint32) {
+	if this.pruner == nil {
+		return
+	}
+	select {
+	case this.pruner.notify <- height:
+	default:
+	}
+}
+
+func (this *LedgerStoreImp) stopPruner() {
+	if this.pruner != nil {
+		close(this.pruner.exitCh)
+	}
+}
+
+func (this *LedgerStoreImp) pruneLoop(p *pruner) {
+	for {
+		select {
+		case <-p.exitCh:
+			return
+		case height := <-p.notify:
+			if height <= p.keep {
+				continue
+			}
+			if err := this.pruneBelow(p, height-p.keep); err != nil {
+				log.Errorf("prune blocks below height %d error %s", height-p.keep, err)
+			}
+			if err := this.pruneStorageTrie(p, height-p.keep); err != nil {
+				log.Errorf("prune storage trie below height %d error %s", height-p.keep, err)
+			}
+		}
+	}
+}
+
+//pruneBelow prunes the blocks below target batch by batch, so that block saving is not blocked for long
+func (this *LedgerStoreImp) pruneBelow(p *pruner, target uint32) error {
+	for {
+		select {
+		case <-p.exitCh:
+			return nil
+		default:
+		}
+		start := this.GetPrunedHeight()
+		if start >= target {
+			return nil
+		}
+		end := start + PRUNE_BATCH_SIZE
+		if end > target {
+			end = target
+		}
+		if err := this.pruneBlocks(start, end); err != nil {
+			return err
+		}
+	}
+}
+
+//pruneBlocks prunes the blocks in [start, end). The genesis block and the blocks changing the vbft chain
+//config are kept, as consensus loads the chain config from them.
+func (this *LedgerStoreImp) pruneBlocks(start, end uint32) error {
+	this.getSavingBlockLock()
+	defer this.releaseSavingBlockLock()
+	//the pruner is stopped when closing
+	if this.closing {
+		return nil
+	}
+
+	this.blockStore.NewBatch()
+	this.eventStore.NewBatch()
+	for height := start; height < end; height++ {
+		if height == 0 {
+			continue
+		}
+		blockHash, err := this.blockStore.GetBlockHash(height)
+		if err != nil {
+			return fmt.Errorf("GetBlockHash of height %d error %s", height, err)
+		}
+		header, err := this.blockStore.GetHeader(blockHash)
+		if err != nil {
+			return fmt.Errorf("GetHeader of height %d error %s", height, err)
+		}
+		if blkInfo, err := vconfig.VbftBlock(header); err == nil && blkInfo.NewChainConfig != nil {
+			continue
+		}
+		txHashes, err := this.blockStore.PruneBlock(blockHash)
+		if err != nil {
+			return fmt.Errorf("PruneBlock of height %d error %s", height, err)
+		}
+		this.eventStore.PruneEventNotify(height, txHashes)
+		if err := this.eventStore.PruneAddressTxs(height); err != nil {
+			return fmt.Errorf("PruneAddressTxs of height %d error %s", height, err)
+		}
+	}
+	this.blockStore.SavePrunedHeight(end)
+	// event store is idempotent to re-prune, so commit first before block store
+	err := this.eventStore.CommitTo()
+	if err != nil {
+		return fmt.Errorf("eventStore.CommitTo error %s", err)
+	}
+	err = this.blockStore.CommitTo()
+	if err != nil {
+		return fmt.Errorf("blockStore.CommitTo error %s", err)
+	}
+
+	this.lock.Lock()
+	this.prunedHeight = end
+	this.lock.Unlock()
+	log.Debugf("blocks in [%d, %d) pruned", start, end)
+	return nil
+}
+
+//pruneStorageTrie removes the storage trie roots below target and the trie nodes only reachable from them.
+//Nodes reachable from the kept roots are marked without the saving block lock, then the nodes are swept batch
+//by batch under the lock. The roots of the blocks saved in the meantime are marked before each batch, so that
+//a node written again by a new block is never removed.
+func (this *LedgerStoreImp) pruneStorageTrie(p *pruner, target uint32) error {
+	start, err := this.stateStore.GetStorageTriePrunedHeight()
+	if err != nil {
+		return fmt.Errorf("GetStorageTriePrunedHeight error %s", err)
+	}
+	if target < start+STORAGE_TRIE_PRUNE_INTERVAL {
+		return nil
+	}
+	_, markedHeight, err := this.stateStore.GetCurrentBlock()
+	if err != nil {
+		return fmt.Errorf("stateStore.GetCurrentBlock error %s", err)
+	}
+	marked := make(map[common.Uint256]struct{})
+	if err := this.stateStore.markStorageTrie(marked, target, markedHeight); err != nil {
+		return err
+	}
+
+	iter := this.stateStore.store.NewIterator([]byte{byte(scom.ST_STORAGE_TRIE)})
+	defer iter.Release()
+	for {
+		select {
+		case <-p.exitCh:
+			return nil
+		default:
+		}
+		keys := make([][]byte, 0, STORAGE_TRIE_SWEEP_BATCH)
+		for len(keys) < STORAGE_TRIE_SWEEP_BATCH && iter.Next() {
+			keys = append(keys, common.CopyBytes(iter.Key()))
+		}
+		if err := iter.Error(); err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			break
+		}
+		if err := this.sweepStorageTrie(marked, &markedHeight, keys); err != nil {
+			return err
+		}
+	}
+
+	this.getSavingBlockLock()
+	defer this.releaseSavingBlockLock()
+	if this.closing {
+		return nil
+	}
+	this.stateStore.NewBatch()
+	this.stateStore.pruneStorageTrieRoots(start, target)
+	err = this.stateStore.CommitTo()
+	if err != nil {
+		return fmt.Errorf("stateStore.CommitTo error %s", err)
+	}
+	log.Debugf("storage trie below height %d pruned", target)
+	return nil
+}
+
+//sweepStorageTrie deletes the storage trie nodes of keys which are not reachable from the kept roots
+func (this *LedgerStoreImp) sweepStorageTrie(marked map[common.Uint256]struct{}, markedHeight *uint32, keys [][]byte) error {
+	this.getSavingBlockLock()
+	defer this.releaseSavingBlockLock()
+	//the pruner is stopped when closing
+	if this.closing {
+		return nil
+	}
+	_, height, err := this.stateStore.GetCurrentBlock()
+	if err != nil {
+		return fmt.Errorf("stateStore.GetCurrentBlock error %s", err)
+	}
+	if height > *markedHeight {
+		if err := this.stateStore.markStorageTrie(marked, *markedHeight+1, height); err != nil {
+			return err
+		}
+		*markedHeight = height
+	}
+	this.stateStore.NewBatch()
+	this.stateStore.sweepStorageTrie(marked, keys)
+	err = this.stateStore.CommitTo()
+	if err != nil {
+		return fmt.Errorf("stateStore.CommitTo error %s", err)
+	}
+	return nil
+}
+
+//GetPrunedHeight return the height below which blocks and event notifies are not kept by the ledger, either
+//pruned or skipped by bootstrapping from state snapshot
+func (this *LedgerStoreImp) GetPrunedHeight() uint32 {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	if this.snapshotHeight > this.prunedHeight {
+		return this.snapshotHeight
+	}
+	return this.prunedHeight
+}
+
+//checkEventPruned reports the missing event notifies of pruned blocks as pruned
+func (this *LedgerStoreImp) checkEventPruned(height uint32, err error) error {
+	if err == scom.ErrNotFound && height != 0 && height < this.GetPrunedHeight() {
+		return scom.ErrPruned
+	}
+	return err
+}
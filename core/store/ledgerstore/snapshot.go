@@ -0,0 +1,593 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledgerstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/common/log"
+	"github.com/ontio/ontology/core/store"
+	scom "github.com/ontio/ontology/core/store/common"
+	"github.com/ontio/ontology/core/store/leveldbstore"
+	"github.com/ontio/ontology/core/types"
+	"github.com/ontio/ontology/merkle"
+)
+
+const (
+	SNAPSHOT_CHUNK_SIZE = 4 * 1024 * 1024 //Target size of a snapshot chunk in byte
+	SNAPSHOT_KEEP_COUNT = 2               //Number of latest snapshots kept on disk
+)
+
+var (
+	//Snapshot save path.
+	DBDirSnapshot = "snapshot"
+
+	//snapshotPrefixes are the state prefixes carried by snapshot chunks. The storage trie is rebuilt from
+	//storage items on install instead of being transferred, since it keeps the nodes of history roots too.
+	snapshotPrefixes = []scom.DataEntryPrefix{scom.ST_BOOKKEEPER, scom.ST_CONTRACT, scom.ST_STORAGE}
+)
+
+//stateSnapshot is a consistent read only view of the state store
+type stateSnapshot interface {
+	Get(key []byte) ([]byte, error)
+	NewIterator(prefix []byte) scom.StoreIterator
+	Release()
+}
+
+//newStateSnapshot return a read only view of the current state, so that snapshot can be exported while
+//new blocks are committed.
+func (self *StateStore) newStateSnapshot() (stateSnapshot, error) {
+	db, ok := self.store.(*leveldbstore.LevelDBStore)
+	if !ok {
+		return nil, fmt.Errorf("state store does not support snapshot")
+	}
+	snap, err := db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+//exportSnapshot writes the state of snap as chunks and return the manifest of it
+func (self *StateStore) exportSnapshot(snap stateSnapshot, saveChunk func(index uint32, data []byte) error) (*store.SnapshotManifest, error) {
+	data, err := snap.Get(self.getCurrentBlockKey())
+	if err != nil {
+		return nil, fmt.Errorf("get current block error %s", err)
+	}
+	source := common.NewZeroCopySource(data)
+	blockHash, eof := source.NextHash()
+	height, eof := source.NextUint32()
+	if eof {
+		return nil, fmt.Errorf("invalid current block record")
+	}
+	if height <= self.stateHashCheckHeight {
+		return nil, fmt.Errorf("state merkle root of height %d does not cover storage trie", height)
+	}
+	manifest := &store.SnapshotManifest{
+		Version:       store.SNAPSHOT_VERSION,
+		Height:        height,
+		BlockHash:     blockHash,
+		StateTreeSize: height - self.stateHashCheckHeight,
+	}
+	data, err = snap.Get(self.genBlockMerkleTreeKey())
+	if err != nil {
+		return nil, fmt.Errorf("get block merkle tree error %s", err)
+	}
+	treeSize, hashes, err := decodeMerkleTree(data)
+	if err != nil {
+		return nil, err
+	}
+	if treeSize != height+1 {
+		return nil, fmt.Errorf("block merkle tree size %d is inconsistent with block height %d", treeSize, height)
+	}
+	manifest.BlockTreeHashes = hashes
+
+	data, err = snap.Get(self.genStorageTrieRootKey(height))
+	if err != nil {
+		return nil, fmt.Errorf("get storage trie root of height %d error %s", height, err)
+	}
+	source = common.NewZeroCopySource(data)
+	manifest.ChangeHash, _ = source.NextHash()
+	manifest.StorageRoot, eof = source.NextHash()
+	if eof {
+		return nil, fmt.Errorf("invalid storage trie root record of height %d", height)
+	}
+	for source.Len() > 0 {
+		hash, _ := source.NextHash()
+		manifest.StateTreeHashes = append(manifest.StateTreeHashes, hash)
+	}
+
+	data, err = snap.Get(self.genStateMerkleRootKey(height))
+	if err != nil {
+		return nil, fmt.Errorf("get state merkle root of height %d error %s", height, err)
+	}
+	source = common.NewZeroCopySource(data)
+	_, eof = source.NextHash()
+	manifest.StateMerkleRoot, eof = source.NextHash()
+	if eof {
+		return nil, fmt.Errorf("invalid state merkle root record of height %d", height)
+	}
+
+	data, err = snap.Get(self.genCrossStatesKey(height))
+	if err != nil && err != scom.ErrNotFound {
+		return nil, fmt.Errorf("get cross states of height %d error %s", height, err)
+	}
+	source = common.NewZeroCopySource(data)
+	for source.Len() > 0 {
+		hash, _ := source.NextHash()
+		manifest.CrossStates = append(manifest.CrossStates, hash)
+	}
+
+	chunk := &store.SnapshotChunk{}
+	size := 0
+	flush := func() error {
+		sink := common.NewZeroCopySink(make([]byte, 0, size+16))
+		chunk.Serialization(sink)
+		index := uint32(len(manifest.ChunkHashes))
+		if err := saveChunk(index, sink.Bytes()); err != nil {
+			return fmt.Errorf("save snapshot chunk %d error %s", index, err)
+		}
+		manifest.ChunkHashes = append(manifest.ChunkHashes, store.SnapshotChunkHash(sink.Bytes()))
+		chunk = &store.SnapshotChunk{}
+		size = 0
+		return nil
+	}
+	for _, prefix := range snapshotPrefixes {
+		iter := snap.NewIterator([]byte{byte(prefix)})
+		for iter.Next() {
+			entry := store.SnapshotEntry{Key: common.CopyBytes(iter.Key()), Value: common.CopyBytes(iter.Value())}
+			chunk.Entries = append(chunk.Entries, entry)
+			size += len(entry.Key) + len(entry.Value)
+			if size >= SNAPSHOT_CHUNK_SIZE {
+				if err := flush(); err != nil {
+					iter.Release()
+					return nil, err
+				}
+			}
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return nil, err
+		}
+	}
+	if len(chunk.Entries) != 0 {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+//importSnapshot replaces the state with the snapshot. The storage trie is rebuilt from the storage items and
+//checked against the storage root of manifest before anything is written, so that a partial or forged state
+//never reaches the store. The chunks must have been verified by the caller against the manifest.
+func (self *StateStore) importSnapshot(manifest *store.SnapshotManifest, getChunk func(index uint32) (*store.SnapshotChunk, error)) error {
+	overlay := self.NewOverlayDB()
+	for _, prefix := range append(snapshotPrefixes, scom.ST_STORAGE_TRIE) {
+		iter := self.store.NewIterator([]byte{byte(prefix)})
+		for iter.Next() {
+			overlay.Delete(common.CopyBytes(iter.Key()))
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return err
+		}
+	}
+	for i := range manifest.ChunkHashes {
+		chunk, err := getChunk(uint32(i))
+		if err != nil {
+			return fmt.Errorf("get snapshot chunk %d error %s", i, err)
+		}
+		for _, entry := range chunk.Entries {
+			if len(entry.Key) == 0 || !isSnapshotPrefix(entry.Key[0]) || len(entry.Value) == 0 {
+				return fmt.Errorf("invalid state entry in snapshot chunk %d", i)
+			}
+			overlay.Put(entry.Key, entry.Value)
+		}
+	}
+	storageRoot, err := self.UpdateStorageTrie(overlay, manifest.Height, true)
+	if err != nil {
+		return fmt.Errorf("rebuild storage trie error %s", err)
+	}
+	if storageRoot != manifest.StorageRoot {
+		return fmt.Errorf("storage root mismatch, expected:%s, got:%s",
+			manifest.StorageRoot.ToHexString(), storageRoot.ToHexString())
+	}
+
+	blockTree := merkle.NewTree(manifest.Height+1, manifest.BlockTreeHashes, nil)
+	deltaTree := self.deltaMerkleTree
+	self.deltaMerkleTree = merkle.NewTree(manifest.StateTreeSize, manifest.StateTreeHashes, nil)
+
+	self.NewBatch()
+	overlay.CommitTo()
+	self.AddStorageTrieRoot(manifest.Height, manifest.ChangeHash, manifest.StorageRoot)
+	//the trie nodes of genesis block are removed above
+	self.BatchDeleteRawKey(self.genStorageTrieRootKey(0))
+	self.saveStorageTriePrunedHeight(manifest.Height)
+	err = self.AddStateMerkleTreeRoot(manifest.Height, store.StorageTrieLeafHash(manifest.ChangeHash, manifest.StorageRoot))
+	if err == nil {
+		value := common.NewZeroCopySink(nil)
+		value.WriteUint32(blockTree.TreeSize())
+		for _, hash := range blockTree.Hashes() {
+			value.WriteHash(hash)
+		}
+		self.BatchPutRawKeyVal(self.genBlockMerkleTreeKey(), value.Bytes())
+		err = self.SaveCrossStates(manifest.Height, manifest.CrossStates)
+	}
+	if err == nil {
+		err = self.SaveCurrentBlock(manifest.Height, manifest.BlockHash)
+	}
+	if err == nil {
+		err = self.CommitTo()
+	}
+	if err != nil {
+		self.deltaMerkleTree = deltaTree
+		return err
+	}
+	//the hash store can not serve the proof of blocks before snapshot
+	self.merkleTree = blockTree
+	return nil
+}
+
+func isSnapshotPrefix(prefix byte) bool {
+	for _, p := range snapshotPrefixes {
+		if byte(p) == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+//SnapshotStore saves the manifest and chunks of snapshots in files, one directory per height. A snapshot
+//is complete once its manifest is saved.
+type SnapshotStore struct {
+	dir string
+}
+
+//NewSnapshotStore return snapshot store instance
+func NewSnapshotStore(dir string) (*SnapshotStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotStore{dir: dir}, nil
+}
+
+//SaveChunk save the serialized chunk of snapshot
+func (self *SnapshotStore) SaveChunk(height, index uint32, data []byte) error {
+	dir := self.heightDir(height)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, fmt.Sprintf("chunk_%d", index)), data)
+}
+
+//GetChunk return the serialized chunk of snapshot
+func (self *SnapshotStore) GetChunk(height, index uint32) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(self.heightDir(height), fmt.Sprintf("chunk_%d", index)))
+	if os.IsNotExist(err) {
+		return nil, scom.ErrNotFound
+	}
+	return data, err
+}
+
+//SaveManifest save the manifest and mark the snapshot complete
+func (self *SnapshotStore) SaveManifest(manifest *store.SnapshotManifest) error {
+	dir := self.heightDir(manifest.Height)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	sink := common.NewZeroCopySink(nil)
+	manifest.Serialization(sink)
+	return writeFileAtomic(filepath.Join(dir, "manifest"), sink.Bytes())
+}
+
+//GetManifest return the manifest of a complete snapshot
+func (self *SnapshotStore) GetManifest(height uint32) (*store.SnapshotManifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(self.heightDir(height), "manifest"))
+	if os.IsNotExist(err) {
+		return nil, scom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	manifest := &store.SnapshotManifest{}
+	if err := manifest.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+//Heights return the heights of snapshots in store in ascending order, including the incomplete ones
+func (self *SnapshotStore) Heights() ([]uint32, error) {
+	infos, err := ioutil.ReadDir(self.dir)
+	if err != nil {
+		return nil, err
+	}
+	var heights []uint32
+	for _, info := range infos {
+		height, err := strconv.ParseUint(info.Name(), 10, 32)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		heights = append(heights, uint32(height))
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	return heights, nil
+}
+
+//Remove delete the snapshot of height
+func (self *SnapshotStore) Remove(height uint32) error {
+	return os.RemoveAll(self.heightDir(height))
+}
+
+func (self *SnapshotStore) heightDir(height uint32) string {
+	return filepath.Join(self.dir, strconv.FormatUint(uint64(height), 10))
+}
+
+func writeFileAtomic(name string, data []byte) error {
+	tmp := name + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+//snapshotter takes state snapshots of the ledger at every interval blocks
+type snapshotter struct {
+	store   *SnapshotStore
+	running bool
+	lock    sync.Mutex
+}
+
+//takeSnapshot starts exporting the state of the block just committed, must be called with the saving block
+//lock held so that the state is not changed before the view is taken.
+func (this *LedgerStoreImp) takeSnapshot(height uint32) {
+	interval := config.DefConfig.Common.SnapshotInterval
+	if interval == 0 || height%interval != 0 || this.snapshotter == nil {
+		return
+	}
+	if height <= this.stateHashCheckHeight || height < storageTrieHeight() {
+		return
+	}
+	this.snapshotter.lock.Lock()
+	defer this.snapshotter.lock.Unlock()
+	if this.snapshotter.running {
+		log.Warnf("skip snapshot of height %d, the previous one is still in progress", height)
+		return
+	}
+	snap, err := this.stateStore.newStateSnapshot()
+	if err != nil {
+		log.Errorf("take snapshot of height %d error %s", height, err)
+		return
+	}
+	this.snapshotter.running = true
+	go func() {
+		defer func() {
+			snap.Release()
+			this.snapshotter.lock.Lock()
+			this.snapshotter.running = false
+			this.snapshotter.lock.Unlock()
+		}()
+		snapshots := this.snapshotter.store
+		manifest, err := this.stateStore.exportSnapshot(snap, func(index uint32, data []byte) error {
+			return snapshots.SaveChunk(height, index, data)
+		})
+		if err == nil {
+			err = snapshots.SaveManifest(manifest)
+		}
+		if err != nil {
+			log.Errorf("export snapshot of height %d error %s", height, err)
+			snapshots.Remove(height)
+			return
+		}
+		hash := manifest.Hash()
+		log.Infof("snapshot of height %d exported, chunks:%d, manifest hash:%s", height,
+			len(manifest.ChunkHashes), hash.ToHexString())
+		this.pruneSnapshots()
+	}()
+}
+
+//pruneSnapshots removes all but the latest SNAPSHOT_KEEP_COUNT snapshots
+func (this *LedgerStoreImp) pruneSnapshots() {
+	heights, err := this.snapshotter.store.Heights()
+	if err != nil {
+		log.Warnf("list snapshots error %s", err)
+		return
+	}
+	for i := 0; i+SNAPSHOT_KEEP_COUNT < len(heights); i++ {
+		if err := this.snapshotter.store.Remove(heights[i]); err != nil {
+			log.Warnf("remove snapshot of height %d error %s", heights[i], err)
+		}
+	}
+}
+
+//GetSnapshotManifest return the manifest of the complete snapshot of height
+func (this *LedgerStoreImp) GetSnapshotManifest(height uint32) (*store.SnapshotManifest, error) {
+	if this.snapshotter == nil {
+		return nil, scom.ErrNotFound
+	}
+	return this.snapshotter.store.GetManifest(height)
+}
+
+//GetSnapshotChunk return the serialized chunk of snapshot
+func (this *LedgerStoreImp) GetSnapshotChunk(height, index uint32) ([]byte, error) {
+	if this.snapshotter == nil {
+		return nil, scom.ErrNotFound
+	}
+	return this.snapshotter.store.GetChunk(height, index)
+}
+
+//AddSnapshotChunk saves a chunk of the snapshot being synced after checking it against the manifest
+func (this *LedgerStoreImp) AddSnapshotChunk(manifest *store.SnapshotManifest, index uint32, data []byte) error {
+	if this.snapshotter == nil {
+		return fmt.Errorf("snapshot store is not available")
+	}
+	if _, err := decodeSnapshotChunk(manifest, index, data); err != nil {
+		return err
+	}
+	return this.snapshotter.store.SaveChunk(manifest.Height, index, data)
+}
+
+func decodeSnapshotChunk(manifest *store.SnapshotManifest, index uint32, data []byte) (*store.SnapshotChunk, error) {
+	if index >= uint32(len(manifest.ChunkHashes)) {
+		return nil, fmt.Errorf("snapshot chunk index %d out of range", index)
+	}
+	if hash := store.SnapshotChunkHash(data); hash != manifest.ChunkHashes[index] {
+		return nil, fmt.Errorf("snapshot chunk %d hash mismatch, expected:%s, got:%s", index,
+			manifest.ChunkHashes[index].ToHexString(), hash.ToHexString())
+	}
+	chunk := &store.SnapshotChunk{}
+	if err := chunk.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+//IsSnapshotSyncing return whether the ledger is waiting for the state snapshot configured to bootstrap from
+func (this *LedgerStoreImp) IsSnapshotSyncing() bool {
+	height := config.DefConfig.Common.SnapshotHeight
+	return height != 0 && this.GetCurrentBlockHeight() == 0 && this.snapshotter != nil
+}
+
+//saveSnapshotHeaders persists the headers below the snapshot height, as their blocks will never be saved.
+//Only the header and block hash are kept, so the header index is still continuous after restart.
+func (this *LedgerStoreImp) saveSnapshotHeaders(headers []*types.Header) error {
+	height := config.DefConfig.Common.SnapshotHeight
+	this.getSavingBlockLock()
+	defer this.releaseSavingBlockLock()
+	this.blockStore.NewBatch()
+	for _, header := range headers {
+		if header.Height > height {
+			break
+		}
+		err := this.blockStore.SaveHeader(&types.Block{Header: header}, 0)
+		if err != nil {
+			return fmt.Errorf("save header height:%d error %s", header.Height, err)
+		}
+		this.blockStore.SaveBlockHash(header.Height, header.Hash())
+	}
+	err := this.blockStore.CommitTo()
+	if err != nil {
+		return err
+	}
+	for _, header := range headers {
+		if header.Height > height {
+			break
+		}
+		this.delHeaderCache(header.Hash())
+	}
+	return nil
+}
+
+//InstallSnapshot replaces the genesis state with the synced snapshot, after which the ledger continues from
+//the snapshot height. The header of snapshot block must have been added, and all chunks saved by AddSnapshotChunk.
+func (this *LedgerStoreImp) InstallSnapshot(manifest *store.SnapshotManifest) error {
+	this.getSavingBlockLock()
+	defer this.releaseSavingBlockLock()
+	if this.snapshotter == nil {
+		return fmt.Errorf("snapshot store is not available")
+	}
+	if this.GetCurrentBlockHeight() != 0 {
+		return fmt.Errorf("ledger is not empty, current block height:%d", this.GetCurrentBlockHeight())
+	}
+	height := manifest.Height
+	if height <= this.stateHashCheckHeight || height < storageTrieHeight() {
+		return fmt.Errorf("snapshot of height %d is not covered by storage trie", height)
+	}
+	if manifest.StateTreeSize != height-this.stateHashCheckHeight {
+		return fmt.Errorf("state merkle tree size %d is inconsistent with height %d", manifest.StateTreeSize, height)
+	}
+	header, err := this.GetHeaderByHeight(height)
+	if err != nil {
+		return fmt.Errorf("get header of height %d error %s", height, err)
+	}
+	if err := manifest.Verify(header); err != nil {
+		return err
+	}
+	err = this.stateStore.importSnapshot(manifest, func(index uint32) (*store.SnapshotChunk, error) {
+		data, err := this.snapshotter.store.GetChunk(height, index)
+		if err != nil {
+			return nil, err
+		}
+		return decodeSnapshotChunk(manifest, index, data)
+	})
+	if err != nil {
+		return fmt.Errorf("import snapshot error %s", err)
+	}
+
+	// state store is committed first, an interrupted install is redone on restart as the block store
+	// still points to genesis block
+	this.blockStore.NewBatch()
+	this.blockStore.SaveSnapshotHeight(height)
+	err = this.blockStore.SaveCurrentBlock(height, manifest.BlockHash)
+	if err != nil {
+		return err
+	}
+	err = this.blockStore.CommitTo()
+	if err != nil {
+		return fmt.Errorf("blockStore.CommitTo height:%d error %s", height, err)
+	}
+	this.eventStore.NewBatch()
+	this.eventStore.SaveCurrentBlock(height, manifest.BlockHash)
+	err = this.eventStore.CommitTo()
+	if err != nil {
+		return fmt.Errorf("eventStore.CommitTo height:%d error %s", height, err)
+	}
+	if err := this.snapshotter.store.SaveManifest(manifest); err != nil {
+		log.Warnf("save snapshot manifest of height %d error %s", height, err)
+	}
+
+	if strings.ToLower(config.DefConfig.Genesis.ConsensusType) == "vbft" {
+		peers, err := this.getVbftPeerInfo(header)
+		if err != nil {
+			return err
+		}
+		this.lock.Lock()
+		this.vbftPeerInfoblock = peers
+		this.lock.Unlock()
+	}
+	this.lock.Lock()
+	this.snapshotHeight = height
+	this.lock.Unlock()
+	this.setCurrentBlock(height, manifest.BlockHash)
+	log.Infof("snapshot of height %d installed, block hash:%s", height, manifest.BlockHash.ToHexString())
+	return nil
+}
+
+//checkSnapshotHeight reports the blocks before the snapshot the ledger is bootstrapped from as pruned
+func (this *LedgerStoreImp) checkSnapshotHeight(height uint32) error {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	if height != 0 && height < this.snapshotHeight {
+		return scom.ErrPruned
+	}
+	return nil
+}
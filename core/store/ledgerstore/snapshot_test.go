@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledgerstore
+
+import (
+	"testing"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/store"
+	scom "github.com/ontio/ontology/core/store/common"
+	"github.com/ontio/ontology/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	src := NewMemStateStore(0)
+	contract := common.Address{1}
+	blocks := []map[string][]byte{
+		{"k1": []byte("v1"), "k2": []byte("v2")},
+		{"k1": []byte("v1.1"), "k3": []byte("v3")},
+		{"k2": nil},
+	}
+	var header *types.Header
+	for h, items := range blocks {
+		height := uint32(h)
+		overlay := src.NewOverlayDB()
+		for k, v := range items {
+			if v == nil {
+				overlay.Delete(genStorageKey(contract, []byte(k)))
+			} else {
+				overlay.Put(genStorageKey(contract, []byte(k)), v)
+			}
+		}
+		if height == 0 {
+			overlay.Put([]byte{byte(scom.ST_BOOKKEEPER)}, []byte("bookkeeper"))
+		}
+		changeHash := overlay.ChangeHash()
+		storageRoot, err := src.UpdateStorageTrie(overlay, height, height == 0)
+		assert.Nil(t, err)
+		leaf := changeHash
+		if height > 0 {
+			leaf = store.StorageTrieLeafHash(changeHash, storageRoot)
+		}
+
+		src.NewBatch()
+		overlay.CommitTo()
+		txRoot := common.Uint256{byte(h + 1)}
+		assert.Nil(t, src.AddBlockMerkleTreeRoot(txRoot))
+		header = &types.Header{Height: height, TransactionsRoot: txRoot, BlockRoot: src.merkleTree.Root()}
+		assert.Nil(t, src.SaveCurrentBlock(height, header.Hash()))
+		src.AddStorageTrieRoot(height, changeHash, storageRoot)
+		assert.Nil(t, src.AddStateMerkleTreeRoot(height, leaf))
+		assert.Nil(t, src.CommitTo())
+	}
+
+	snap, err := src.newStateSnapshot()
+	assert.Nil(t, err)
+	chunks := make(map[uint32][]byte)
+	manifest, err := src.exportSnapshot(snap, func(index uint32, data []byte) error {
+		chunks[index] = data
+		return nil
+	})
+	snap.Release()
+	assert.Nil(t, err)
+	assert.Equal(t, len(manifest.ChunkHashes), len(chunks))
+	assert.Nil(t, manifest.Verify(header))
+
+	sink := common.NewZeroCopySink(nil)
+	manifest.Serialization(sink)
+	decoded := &store.SnapshotManifest{}
+	assert.Nil(t, decoded.Deserialization(common.NewZeroCopySource(sink.Bytes())))
+	assert.Equal(t, manifest.Hash(), decoded.Hash())
+
+	fake := *manifest
+	fake.StorageRoot = common.Uint256{1}
+	assert.NotNil(t, fake.Verify(header))
+
+	getChunk := func(index uint32) (*store.SnapshotChunk, error) {
+		return decodeSnapshotChunk(manifest, index, chunks[index])
+	}
+	_, err = decodeSnapshotChunk(manifest, 0, append(chunks[0], 0))
+	assert.NotNil(t, err)
+
+	dst := NewMemStateStore(0)
+	fake.StateMerkleRoot = dst.GetStateMerkleRootWithNewHash(store.StorageTrieLeafHash(fake.ChangeHash, fake.StorageRoot))
+	assert.NotNil(t, dst.importSnapshot(&fake, getChunk))
+
+	assert.Nil(t, dst.importSnapshot(manifest, getChunk))
+	blockHash, height, err := dst.GetCurrentBlock()
+	assert.Nil(t, err)
+	assert.Equal(t, header.Hash(), blockHash)
+	assert.Equal(t, header.Height, height)
+	assert.Equal(t, header.BlockRoot, dst.merkleTree.Root())
+
+	expectRoot, err := src.GetStateMerkleRoot(height)
+	assert.Nil(t, err)
+	stateRoot, err := dst.GetStateMerkleRoot(height)
+	assert.Nil(t, err)
+	assert.Equal(t, expectRoot, stateRoot)
+
+	expected := map[string][]byte{"k1": []byte("v1.1"), "k2": nil, "k3": []byte("v3")}
+	for k, v := range expected {
+		proof, err := dst.GetStorageProof(contract, []byte(k), height)
+		assert.Nil(t, err)
+		assert.Equal(t, v, proof.Value)
+	}
+}
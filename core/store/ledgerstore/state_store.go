@@ -146,6 +146,10 @@ func (self *StateStore) getMerkleTree(key []byte) (uint32, []common.Uint256, err
 	if err != nil {
 		return 0, nil, err
 	}
+	return decodeMerkleTree(data)
+}
+
+func decodeMerkleTree(data []byte) (uint32, []common.Uint256, error) {
 	value := bytes.NewBuffer(data)
 	treeSize, err := serialization.ReadUint32(value)
 	if err != nil {
@@ -312,6 +316,29 @@ func (self *StateStore) GetStorageState(key *states.StorageKey) (*states.Storage
 	return storageState, nil
 }
 
+//FindStorageItems call onItem with the key and value of each storage item of contract whose key starts with
+//prefix, iteration stops when onItem return false
+func (self *StateStore) FindStorageItems(contract common.Address, prefix []byte,
+	onItem func(key, value []byte) bool) error {
+	storePrefix, err := self.getStorageKey(&states.StorageKey{ContractAddress: contract, Key: prefix})
+	if err != nil {
+		return err
+	}
+	iter := self.store.NewIterator(storePrefix)
+	defer iter.Release()
+	for iter.Next() {
+		item := new(states.StorageItem)
+		if err := item.Deserialization(common.NewZeroCopySource(iter.Value())); err != nil {
+			return err
+		}
+		key := iter.Key()[1+common.ADDR_LEN:]
+		if !onItem(key, item.Value) {
+			break
+		}
+	}
+	return iter.Error()
+}
+
 //GetCurrentBlock return current block height and current hash in state store
 func (self *StateStore) GetCurrentBlock() (common.Uint256, uint32, error) {
 	key := self.getCurrentBlockKey()
@@ -460,7 +487,9 @@ func (self *StateStore) ClearAll() error {
 
 //Close state store
 func (self *StateStore) Close() error {
-	self.merkleHashStore.Close()
+	if self.merkleHashStore != nil {
+		self.merkleHashStore.Close()
+	}
 	return self.store.Close()
 }
 
@@ -0,0 +1,456 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledgerstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/core/store"
+	scom "github.com/ontio/ontology/core/store/common"
+	"github.com/ontio/ontology/core/store/overlaydb"
+	"github.com/ontio/ontology/trie"
+)
+
+//storageTrieDB adapts overlay db to trie.Database, trie nodes are saved under the ST_STORAGE_TRIE prefix
+//so that they are committed together with the write set of the block.
+type storageTrieDB struct {
+	overlay *overlaydb.OverlayDB
+}
+
+func (self *storageTrieDB) Get(key []byte) ([]byte, error) {
+	value, err := self.overlay.Get(genStorageTrieNodeKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, scom.ErrNotFound
+	}
+	return value, nil
+}
+
+func (self *storageTrieDB) Has(key []byte) (bool, error) {
+	value, err := self.overlay.Get(genStorageTrieNodeKey(key))
+	if err != nil {
+		return false, err
+	}
+	return len(value) != 0, nil
+}
+
+func (self *storageTrieDB) BatchPut(key, value []byte) error {
+	self.overlay.Put(genStorageTrieNodeKey(key), common.CopyBytes(value))
+	return nil
+}
+
+func genStorageTrieNodeKey(key []byte) []byte {
+	return append([]byte{byte(scom.ST_STORAGE_TRIE)}, key...)
+}
+
+const (
+	STORAGE_TRIE_BUILD_BATCH = 100000 //Number of storage items inserted before trie nodes are flushed when building storage trie
+)
+
+type storageChange struct {
+	key   []byte
+	value []byte
+}
+
+//storageTrieHeight return the block height from which the storage root is mixed in the state merkle tree
+func storageTrieHeight() uint32 {
+	return config.GetStorageTrieHeight(config.DefConfig.P2PNode.NetworkId)
+}
+
+//UpdateStorageTrie applies the storage items written in overlay to the storage trie of the previous block
+//and returns the new storage root. When rebuild is true, the trie is built from all the storage items instead.
+//The storage trie maps contract address to the root of the contract's own trie, which maps storage key to
+//the serialized storage item.
+func (self *StateStore) UpdateStorageTrie(overlay *overlaydb.OverlayDB, height uint32, rebuild bool) (common.Uint256, error) {
+	changes := make(map[common.Address][]storageChange)
+	collect := func(key, val []byte) {
+		if len(key) < 1+common.ADDR_LEN || key[0] != byte(scom.ST_STORAGE) {
+			return
+		}
+		var addr common.Address
+		copy(addr[:], key[1:1+common.ADDR_LEN])
+		changes[addr] = append(changes[addr], storageChange{
+			key:   common.CopyBytes(key[1+common.ADDR_LEN:]),
+			value: common.CopyBytes(val),
+		})
+	}
+	prevRoot := common.UINT256_EMPTY
+	if rebuild {
+		iter := overlay.NewIterator([]byte{byte(scom.ST_STORAGE)})
+		for has := iter.First(); has; has = iter.Next() {
+			collect(iter.Key(), iter.Value())
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return common.UINT256_EMPTY, err
+		}
+	} else {
+		_, root, _, err := self.GetStorageTrieRoot(height - 1)
+		if err != nil {
+			return common.UINT256_EMPTY, fmt.Errorf("get storage trie root height:%d error:%s", height-1, err)
+		}
+		prevRoot = root
+		overlay.GetWriteSet().ForEach(collect)
+	}
+	if len(changes) == 0 {
+		return prevRoot, nil
+	}
+
+	addrs := make([]common.Address, 0, len(changes))
+	for addr := range changes {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+
+	db := &storageTrieDB{overlay: overlay}
+	contracts, err := trie.New(prevRoot, db)
+	if err != nil {
+		return common.UINT256_EMPTY, err
+	}
+	for _, addr := range addrs {
+		contractRoot := common.UINT256_EMPTY
+		enc, err := contracts.TryGet(addr[:])
+		if err != nil {
+			return common.UINT256_EMPTY, err
+		}
+		if len(enc) != 0 {
+			contractRoot, err = common.Uint256ParseFromBytes(enc)
+			if err != nil {
+				return common.UINT256_EMPTY, err
+			}
+		}
+		storage, err := trie.New(contractRoot, db)
+		if err != nil {
+			return common.UINT256_EMPTY, err
+		}
+		for _, change := range changes[addr] {
+			if err := storage.TryUpdateOrDelete(change.key, change.value); err != nil {
+				return common.UINT256_EMPTY, err
+			}
+		}
+		contractRoot, err = storage.Commit()
+		if err != nil {
+			return common.UINT256_EMPTY, err
+		}
+		if contractRoot == common.UINT256_EMPTY {
+			err = contracts.TryDelete(addr[:])
+		} else {
+			err = contracts.TryUpdate(addr[:], contractRoot[:])
+		}
+		if err != nil {
+			return common.UINT256_EMPTY, err
+		}
+	}
+	return contracts.Commit()
+}
+
+//BuildStorageTrie builds the storage trie from all the storage items in store, and saves it as the storage trie
+//of block height. It is the migration run at startup for the ledger without storage trie, trie nodes are flushed
+//to store every STORAGE_TRIE_BUILD_BATCH items to bound the memory used.
+func (self *StateStore) BuildStorageTrie(height uint32) (common.Uint256, error) {
+	db := &storageTrieDB{overlay: self.NewOverlayDB()}
+	flush := func() error {
+		self.NewBatch()
+		db.overlay.CommitTo()
+		if err := self.CommitTo(); err != nil {
+			return err
+		}
+		db.overlay = self.NewOverlayDB()
+		return nil
+	}
+	contracts, err := trie.New(common.UINT256_EMPTY, db)
+	if err != nil {
+		return common.UINT256_EMPTY, err
+	}
+	var addr common.Address
+	var storage *trie.Trie
+	commitContract := func() error {
+		if storage == nil {
+			return nil
+		}
+		contractRoot, err := storage.Commit()
+		if err != nil || contractRoot == common.UINT256_EMPTY {
+			return err
+		}
+		return contracts.TryUpdate(addr[:], contractRoot[:])
+	}
+
+	iter := self.store.NewIterator([]byte{byte(scom.ST_STORAGE)})
+	defer iter.Release()
+	count := 0
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) < 1+common.ADDR_LEN {
+			continue
+		}
+		if storage == nil || !bytes.Equal(addr[:], key[1:1+common.ADDR_LEN]) {
+			if err := commitContract(); err != nil {
+				return common.UINT256_EMPTY, err
+			}
+			copy(addr[:], key[1:1+common.ADDR_LEN])
+			storage, err = trie.New(common.UINT256_EMPTY, db)
+			if err != nil {
+				return common.UINT256_EMPTY, err
+			}
+		}
+		err = storage.TryUpdateOrDelete(common.CopyBytes(key[1+common.ADDR_LEN:]), common.CopyBytes(iter.Value()))
+		if err != nil {
+			return common.UINT256_EMPTY, err
+		}
+		count++
+		if count%STORAGE_TRIE_BUILD_BATCH != 0 {
+			continue
+		}
+		contractRoot, err := storage.Commit()
+		if err != nil {
+			return common.UINT256_EMPTY, err
+		}
+		if err := flush(); err != nil {
+			return common.UINT256_EMPTY, err
+		}
+		storage, err = trie.New(contractRoot, db)
+		if err != nil {
+			return common.UINT256_EMPTY, err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return common.UINT256_EMPTY, err
+	}
+	if err := commitContract(); err != nil {
+		return common.UINT256_EMPTY, err
+	}
+	storageRoot, err := contracts.Commit()
+	if err != nil {
+		return common.UINT256_EMPTY, err
+	}
+	self.NewBatch()
+	db.overlay.CommitTo()
+	//the block is executed before the storage trie height, there is no write set hash or state tree to record
+	sink := common.NewZeroCopySink(make([]byte, 0, 2*common.UINT256_SIZE))
+	sink.WriteHash(common.UINT256_EMPTY)
+	sink.WriteHash(storageRoot)
+	self.store.BatchPut(self.genStorageTrieRootKey(height), sink.Bytes())
+	return storageRoot, self.CommitTo()
+}
+
+//HasStorageTrieRoot return whether the storage trie of block height is maintained
+func (self *StateStore) HasStorageTrieRoot(height uint32) (bool, error) {
+	_, err := self.store.Get(self.genStorageTrieRootKey(height))
+	if err == scom.ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+//AddStorageTrieRoot saves the storage root of block, together with the state merkle tree the block leaf is
+//appended to, which makes it possible to prove storage against the state merkle root of the block.
+func (self *StateStore) AddStorageTrieRoot(blockHeight uint32, changeHash, storageRoot common.Uint256) {
+	var hashes []common.Uint256
+	if blockHeight > self.stateHashCheckHeight {
+		hashes = self.deltaMerkleTree.Hashes()
+	}
+	sink := common.NewZeroCopySink(make([]byte, 0, 2*common.UINT256_SIZE+len(hashes)*common.UINT256_SIZE))
+	sink.WriteHash(changeHash)
+	sink.WriteHash(storageRoot)
+	for _, hash := range hashes {
+		sink.WriteHash(hash)
+	}
+	self.store.BatchPut(self.genStorageTrieRootKey(blockHeight), sink.Bytes())
+}
+
+//GetStorageTrieRoot return the write set hash, storage root and state merkle tree hashes of block
+func (self *StateStore) GetStorageTrieRoot(height uint32) (changeHash, storageRoot common.Uint256,
+	treeHashes []common.Uint256, err error) {
+	var value []byte
+	value, err = self.store.Get(self.genStorageTrieRootKey(height))
+	if err != nil {
+		return
+	}
+	if len(value) < 2*common.UINT256_SIZE || len(value)%common.UINT256_SIZE != 0 {
+		err = fmt.Errorf("invalid storage trie root record of height %d", height)
+		return
+	}
+	source := common.NewZeroCopySource(value)
+	changeHash, _ = source.NextHash()
+	storageRoot, _ = source.NextHash()
+	for source.Len() > 0 {
+		hash, _ := source.NextHash()
+		treeHashes = append(treeHashes, hash)
+	}
+	return
+}
+
+//GetStorageProof return the proof of storage item of contract at block height
+func (self *StateStore) GetStorageProof(contract common.Address, key []byte, height uint32) (*store.StorageProof, error) {
+	if height <= self.stateHashCheckHeight {
+		return nil, fmt.Errorf("state merkle root of height %d does not cover storage trie", height)
+	}
+	prunedHeight, err := self.GetStorageTriePrunedHeight()
+	if err != nil {
+		return nil, err
+	}
+	if height < prunedHeight {
+		return nil, fmt.Errorf("storage trie of height %d is pruned", height)
+	}
+	changeHash, storageRoot, treeHashes, err := self.GetStorageTrieRoot(height)
+	if err != nil {
+		return nil, err
+	}
+	stateRoot, err := self.GetStateMerkleRoot(height)
+	if err != nil {
+		return nil, err
+	}
+	proof := &store.StorageProof{
+		Height:          height,
+		ContractAddress: contract,
+		Key:             key,
+		ChangeHash:      changeHash,
+		StorageRoot:     storageRoot,
+		StateTreeHashes: treeHashes,
+		StateMerkleRoot: stateRoot,
+	}
+	if storageRoot == common.UINT256_EMPTY {
+		return proof, nil
+	}
+
+	db := &storageTrieDB{overlay: self.NewOverlayDB()}
+	contracts, err := trie.New(storageRoot, db)
+	if err != nil {
+		return nil, err
+	}
+	proof.AccountProof = contracts.Prove(contract[:])
+	enc, err := contracts.TryGet(contract[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return proof, nil
+	}
+	proof.ContractRoot, err = common.Uint256ParseFromBytes(enc)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := trie.New(proof.ContractRoot, db)
+	if err != nil {
+		return nil, err
+	}
+	proof.StorageProof = storage.Prove(key)
+	proof.Value, err = storage.TryGet(key)
+	if err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+//markStorageTrie adds the trie nodes reachable from the storage trie roots of heights [start, end] to marked,
+//the subtree of a node already marked is skipped
+func (self *StateStore) markStorageTrie(marked map[common.Uint256]struct{}, start, end uint32) error {
+	db := &storageTrieDB{overlay: self.NewOverlayDB()}
+	onNode := func(hash common.Uint256) bool {
+		if _, ok := marked[hash]; ok {
+			return false
+		}
+		marked[hash] = struct{}{}
+		return true
+	}
+	onContract := func(value []byte) error {
+		contractRoot, err := common.Uint256ParseFromBytes(value)
+		if err != nil {
+			return err
+		}
+		return trie.Walk(contractRoot, db, onNode, nil)
+	}
+	for height := start; height <= end; height++ {
+		_, storageRoot, _, err := self.GetStorageTrieRoot(height)
+		//blocks before the storage trie is built
+		if err == scom.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("get storage trie root height:%d error:%s", height, err)
+		}
+		if err := trie.Walk(storageRoot, db, onNode, onContract); err != nil {
+			return fmt.Errorf("mark storage trie height:%d error:%s", height, err)
+		}
+	}
+	return nil
+}
+
+//sweepStorageTrie deletes the trie nodes of keys which are not marked in batch
+func (self *StateStore) sweepStorageTrie(marked map[common.Uint256]struct{}, keys [][]byte) {
+	for _, key := range keys {
+		if len(key) < common.UINT256_SIZE {
+			continue
+		}
+		hash, err := common.Uint256ParseFromBytes(key[len(key)-common.UINT256_SIZE:])
+		if err != nil {
+			continue
+		}
+		if _, ok := marked[hash]; !ok {
+			self.store.BatchDelete(key)
+		}
+	}
+}
+
+//GetStorageTriePrunedHeight return the height below which storage trie roots are pruned
+func (self *StateStore) GetStorageTriePrunedHeight() (uint32, error) {
+	data, err := self.store.Get([]byte{byte(scom.SYS_STORAGE_TRIE_PRUNED_HEIGHT)})
+	if err == scom.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	height, eof := common.NewZeroCopySource(data).NextUint32()
+	if eof {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return height, nil
+}
+
+//pruneStorageTrieRoots removes the storage trie roots of heights [start, end) in batch
+func (self *StateStore) pruneStorageTrieRoots(start, end uint32) {
+	for height := start; height < end; height++ {
+		self.store.BatchDelete(self.genStorageTrieRootKey(height))
+	}
+	self.saveStorageTriePrunedHeight(end)
+}
+
+func (self *StateStore) saveStorageTriePrunedHeight(height uint32) {
+	value := common.NewZeroCopySink(nil)
+	value.WriteUint32(height)
+	self.store.BatchPut([]byte{byte(scom.SYS_STORAGE_TRIE_PRUNED_HEIGHT)}, value.Bytes())
+}
+
+func (self *StateStore) genStorageTrieRootKey(height uint32) []byte {
+	key := make([]byte, 5, 5)
+	key[0] = byte(scom.DATA_STORAGE_TRIE_ROOT)
+	binary.LittleEndian.PutUint32(key[1:], height)
+	return key
+}
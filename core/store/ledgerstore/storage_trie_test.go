@@ -0,0 +1,191 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledgerstore
+
+import (
+	"testing"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/store"
+	scom "github.com/ontio/ontology/core/store/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func genStorageKey(addr common.Address, key []byte) []byte {
+	return append(append([]byte{byte(scom.ST_STORAGE)}, addr[:]...), key...)
+}
+
+func TestStorageProof(t *testing.T) {
+	db := NewMemStateStore(0)
+	contract1 := common.Address{1}
+	contract2 := common.Address{2}
+
+	blocks := []map[string][]byte{
+		{"k1": []byte("v1"), "k2": []byte("v2")},
+		{"k1": []byte("v1.1"), "k3": []byte("v3")},
+		{"k2": nil},
+	}
+	for h, items := range blocks {
+		height := uint32(h)
+		overlay := db.NewOverlayDB()
+		for k, v := range items {
+			if v == nil {
+				overlay.Delete(genStorageKey(contract1, []byte(k)))
+			} else {
+				overlay.Put(genStorageKey(contract1, []byte(k)), v)
+			}
+		}
+		changeHash := overlay.ChangeHash()
+		storageRoot, err := db.UpdateStorageTrie(overlay, height, height == 0)
+		assert.Nil(t, err)
+		leaf := changeHash
+		if height > 0 {
+			leaf = store.StorageTrieLeafHash(changeHash, storageRoot)
+		}
+
+		db.NewBatch()
+		overlay.CommitTo()
+		db.AddStorageTrieRoot(height, changeHash, storageRoot)
+		err = db.AddStateMerkleTreeRoot(height, leaf)
+		assert.Nil(t, err)
+		err = db.CommitTo()
+		assert.Nil(t, err)
+	}
+
+	_, err := db.GetStorageProof(contract1, []byte("k1"), 0)
+	assert.NotNil(t, err)
+
+	expected := map[string][]byte{"k1": []byte("v1.1"), "k2": nil, "k3": []byte("v3"), "k4": nil}
+	for k, v := range expected {
+		proof, err := db.GetStorageProof(contract1, []byte(k), 2)
+		assert.Nil(t, err)
+		assert.Equal(t, v, proof.Value)
+		assert.Nil(t, proof.Verify())
+
+		proof.Value = []byte("fake")
+		assert.NotNil(t, proof.Verify())
+	}
+
+	proof, err := db.GetStorageProof(contract1, []byte("k2"), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v2"), proof.Value)
+	assert.Nil(t, proof.Verify())
+
+	proof, err = db.GetStorageProof(contract2, []byte("k1"), 2)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.Value)
+	assert.Equal(t, common.UINT256_EMPTY, proof.ContractRoot)
+	assert.Nil(t, proof.Verify())
+}
+
+func saveStorageBlocks(t *testing.T, db *StateStore, contract common.Address, blocks []map[string][]byte) {
+	for h, items := range blocks {
+		height := uint32(h)
+		overlay := db.NewOverlayDB()
+		for k, v := range items {
+			if v == nil {
+				overlay.Delete(genStorageKey(contract, []byte(k)))
+			} else {
+				overlay.Put(genStorageKey(contract, []byte(k)), v)
+			}
+		}
+		changeHash := overlay.ChangeHash()
+		storageRoot, err := db.UpdateStorageTrie(overlay, height, height == 0)
+		assert.Nil(t, err)
+
+		db.NewBatch()
+		overlay.CommitTo()
+		db.AddStorageTrieRoot(height, changeHash, storageRoot)
+		err = db.AddStateMerkleTreeRoot(height, store.StorageTrieLeafHash(changeHash, storageRoot))
+		assert.Nil(t, err)
+		err = db.CommitTo()
+		assert.Nil(t, err)
+	}
+}
+
+func countStorageTrieNodes(db *StateStore) int {
+	count := 0
+	iter := db.store.NewIterator([]byte{byte(scom.ST_STORAGE_TRIE)})
+	for iter.Next() {
+		count++
+	}
+	iter.Release()
+	return count
+}
+
+func TestBuildStorageTrie(t *testing.T) {
+	db := NewMemStateStore(0)
+	contract := common.Address{1}
+	saveStorageBlocks(t, db, contract, []map[string][]byte{
+		{"k1": []byte("v1"), "k2": []byte("v2")},
+		{"k1": []byte("v1.1"), "k3": []byte("v3")},
+		{"k2": nil},
+	})
+	_, expected, _, err := db.GetStorageTrieRoot(2)
+	assert.Nil(t, err)
+
+	has, err := db.HasStorageTrieRoot(3)
+	assert.Nil(t, err)
+	assert.False(t, has)
+	storageRoot, err := db.BuildStorageTrie(3)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, storageRoot)
+	has, err = db.HasStorageTrieRoot(3)
+	assert.Nil(t, err)
+	assert.True(t, has)
+}
+
+func TestPruneStorageTrie(t *testing.T) {
+	db := NewMemStateStore(0)
+	contract := common.Address{1}
+	saveStorageBlocks(t, db, contract, []map[string][]byte{
+		{"k1": []byte("v1"), "k2": []byte("v2")},
+		{"k1": []byte("v1.1"), "k3": []byte("v3")},
+		{"k2": nil},
+	})
+	before := countStorageTrieNodes(db)
+
+	marked := make(map[common.Uint256]struct{})
+	assert.Nil(t, db.markStorageTrie(marked, 2, 2))
+	var keys [][]byte
+	iter := db.store.NewIterator([]byte{byte(scom.ST_STORAGE_TRIE)})
+	for iter.Next() {
+		keys = append(keys, common.CopyBytes(iter.Key()))
+	}
+	iter.Release()
+	db.NewBatch()
+	db.sweepStorageTrie(marked, keys)
+	db.pruneStorageTrieRoots(0, 2)
+	assert.Nil(t, db.CommitTo())
+
+	assert.True(t, countStorageTrieNodes(db) < before)
+	assert.Equal(t, len(marked), countStorageTrieNodes(db))
+	prunedHeight, err := db.GetStorageTriePrunedHeight()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), prunedHeight)
+
+	_, err = db.GetStorageProof(contract, []byte("k1"), 1)
+	assert.NotNil(t, err)
+	for k, v := range map[string][]byte{"k1": []byte("v1.1"), "k2": nil, "k3": []byte("v3")} {
+		proof, err := db.GetStorageProof(contract, []byte(k), 2)
+		assert.Nil(t, err)
+		assert.Equal(t, v, proof.Value)
+		assert.Nil(t, proof.Verify())
+	}
+}
@@ -157,3 +157,44 @@ func (self *LevelDBStore) NewIterator(prefix []byte) common.StoreIterator {
 
 	return iter
 }
+
+//NewRangeIterator return a iterator of leveldb with the key range [start, limit)
+func (self *LevelDBStore) NewRangeIterator(start, limit []byte) common.StoreIterator {
+	return self.db.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+}
+
+//LevelDBSnapshot is a read only view of leveldb at the time it is taken
+type LevelDBSnapshot struct {
+	snap *leveldb.Snapshot
+}
+
+//NewSnapshot return a snapshot of the current state of leveldb, it must be released after use
+func (self *LevelDBStore) NewSnapshot() (*LevelDBSnapshot, error) {
+	snap, err := self.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDBSnapshot{snap: snap}, nil
+}
+
+//Get the value of a key from snapshot
+func (self *LevelDBSnapshot) Get(key []byte) ([]byte, error) {
+	dat, err := self.snap.Get(key, nil)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, err
+	}
+	return dat, nil
+}
+
+//NewIterator return a iterator of snapshot with the key prefix
+func (self *LevelDBSnapshot) NewIterator(prefix []byte) common.StoreIterator {
+	return self.snap.NewIterator(util.BytesPrefix(prefix), nil)
+}
+
+//Release the snapshot
+func (self *LevelDBSnapshot) Release() {
+	self.snap.Release()
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/types"
+	"github.com/ontio/ontology/merkle"
+)
+
+const (
+	SNAPSHOT_VERSION        = byte(1)
+	SNAPSHOT_MANIFEST_INDEX = uint32(math.MaxUint32) //Chunk index used to request the manifest of a snapshot
+	SNAPSHOT_MAX_CHUNK_SIZE = 8 * 1024 * 1024        //Max size of a serialized chunk, so that it fits in a p2p message
+)
+
+//SnapshotManifest describes the state snapshot taken at a block. The state entries are split into chunks,
+//each of them is verified by its hash in the manifest. The manifest itself is bound to the block header by
+//the block merkle tree, and to the state merkle root through the storage trie root of the block.
+type SnapshotManifest struct {
+	Version         byte
+	Height          uint32
+	BlockHash       common.Uint256
+	BlockTreeHashes []common.Uint256 //compact block merkle tree including the block, its root is the BlockRoot of header
+	StateTreeSize   uint32           //size of the state merkle tree before the block leaf is appended
+	StateTreeHashes []common.Uint256 //compact state merkle tree before the block leaf is appended
+	ChangeHash      common.Uint256   //hash of the raw write set of the block
+	StorageRoot     common.Uint256   //storage trie root after the block
+	StateMerkleRoot common.Uint256
+	CrossStates     []common.Uint256 //cross chain states of the block, needed to verify the cross chain msg of next block
+	ChunkHashes     []common.Uint256
+}
+
+//Serialization serialize the manifest
+func (self *SnapshotManifest) Serialization(sink *common.ZeroCopySink) {
+	sink.WriteByte(self.Version)
+	sink.WriteUint32(self.Height)
+	sink.WriteHash(self.BlockHash)
+	writeHashes(sink, self.BlockTreeHashes)
+	sink.WriteUint32(self.StateTreeSize)
+	writeHashes(sink, self.StateTreeHashes)
+	sink.WriteHash(self.ChangeHash)
+	sink.WriteHash(self.StorageRoot)
+	sink.WriteHash(self.StateMerkleRoot)
+	writeHashes(sink, self.CrossStates)
+	writeHashes(sink, self.ChunkHashes)
+}
+
+//Deserialization deserialize the manifest
+func (self *SnapshotManifest) Deserialization(source *common.ZeroCopySource) error {
+	var eof bool
+	var err error
+	self.Version, eof = source.NextByte()
+	self.Height, eof = source.NextUint32()
+	self.BlockHash, eof = source.NextHash()
+	if eof {
+		return io.ErrUnexpectedEOF
+	}
+	if self.BlockTreeHashes, err = readHashes(source); err != nil {
+		return err
+	}
+	self.StateTreeSize, eof = source.NextUint32()
+	if eof {
+		return io.ErrUnexpectedEOF
+	}
+	if self.StateTreeHashes, err = readHashes(source); err != nil {
+		return err
+	}
+	self.ChangeHash, eof = source.NextHash()
+	self.StorageRoot, eof = source.NextHash()
+	self.StateMerkleRoot, eof = source.NextHash()
+	if eof {
+		return io.ErrUnexpectedEOF
+	}
+	if self.CrossStates, err = readHashes(source); err != nil {
+		return err
+	}
+	self.ChunkHashes, err = readHashes(source)
+	return err
+}
+
+//Hash return the hash of manifest, which is used as the trusted identity of a snapshot
+func (self *SnapshotManifest) Hash() common.Uint256 {
+	sink := common.NewZeroCopySink(nil)
+	self.Serialization(sink)
+	return sha256.Sum256(sink.Bytes())
+}
+
+//Verify check the manifest is consistent with the header of the snapshot block. The caller is responsible
+//for checking the manifest hash, as the state merkle root is not committed in block header.
+func (self *SnapshotManifest) Verify(header *types.Header) error {
+	if self.Version != SNAPSHOT_VERSION {
+		return fmt.Errorf("unsupported snapshot version:%d", self.Version)
+	}
+	if header.Height != self.Height {
+		return fmt.Errorf("snapshot height mismatch, expected:%d, got:%d", header.Height, self.Height)
+	}
+	if blockHash := header.Hash(); blockHash != self.BlockHash {
+		return fmt.Errorf("snapshot block hash mismatch, expected:%s, got:%s",
+			blockHash.ToHexString(), self.BlockHash.ToHexString())
+	}
+	if bits.OnesCount32(self.Height+1) != len(self.BlockTreeHashes) {
+		return fmt.Errorf("invalid block merkle tree of size:%d", self.Height+1)
+	}
+	blockTree := merkle.NewTree(self.Height+1, self.BlockTreeHashes, nil)
+	if root := blockTree.Root(); root != header.BlockRoot {
+		return fmt.Errorf("block root mismatch, expected:%s, got:%s",
+			header.BlockRoot.ToHexString(), root.ToHexString())
+	}
+	if bits.OnesCount32(self.StateTreeSize) != len(self.StateTreeHashes) {
+		return fmt.Errorf("invalid state merkle tree of size:%d", self.StateTreeSize)
+	}
+	stateTree := merkle.NewTree(self.StateTreeSize, self.StateTreeHashes, nil)
+	leaf := StorageTrieLeafHash(self.ChangeHash, self.StorageRoot)
+	if root := stateTree.GetRootWithNewLeaf(leaf); root != self.StateMerkleRoot {
+		return fmt.Errorf("state merkle root mismatch, expected:%s, got:%s",
+			self.StateMerkleRoot.ToHexString(), root.ToHexString())
+	}
+	return nil
+}
+
+//SnapshotEntry is a raw key value pair of the state store
+type SnapshotEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+//SnapshotChunk is a batch of state entries in key order
+type SnapshotChunk struct {
+	Entries []SnapshotEntry
+}
+
+//Serialization serialize the chunk
+func (self *SnapshotChunk) Serialization(sink *common.ZeroCopySink) {
+	sink.WriteVarUint(uint64(len(self.Entries)))
+	for _, entry := range self.Entries {
+		sink.WriteVarBytes(entry.Key)
+		sink.WriteVarBytes(entry.Value)
+	}
+}
+
+//Deserialization deserialize the chunk
+func (self *SnapshotChunk) Deserialization(source *common.ZeroCopySource) error {
+	n, _, irregular, eof := source.NextVarUint()
+	if irregular {
+		return common.ErrIrregularData
+	}
+	if eof {
+		return io.ErrUnexpectedEOF
+	}
+	//each entry takes at least 2 bytes
+	if n > source.Len()/2 {
+		return fmt.Errorf("too many entries in snapshot chunk:%d", n)
+	}
+	self.Entries = make([]SnapshotEntry, 0, n)
+	for i := uint64(0); i < n; i++ {
+		key, _, irr1, eof1 := source.NextVarBytes()
+		value, _, irr2, eof2 := source.NextVarBytes()
+		if irr1 || irr2 {
+			return common.ErrIrregularData
+		}
+		if eof1 || eof2 {
+			return io.ErrUnexpectedEOF
+		}
+		self.Entries = append(self.Entries, SnapshotEntry{Key: key, Value: value})
+	}
+	return nil
+}
+
+//SnapshotChunkHash return the hash of serialized chunk
+func SnapshotChunkHash(data []byte) common.Uint256 {
+	return sha256.Sum256(data)
+}
+
+func writeHashes(sink *common.ZeroCopySink, hashes []common.Uint256) {
+	sink.WriteVarUint(uint64(len(hashes)))
+	for _, hash := range hashes {
+		sink.WriteHash(hash)
+	}
+}
+
+func readHashes(source *common.ZeroCopySource) ([]common.Uint256, error) {
+	n, _, irregular, eof := source.NextVarUint()
+	if irregular {
+		return nil, common.ErrIrregularData
+	}
+	if eof {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if n > source.Len()/common.UINT256_SIZE {
+		return nil, io.ErrUnexpectedEOF
+	}
+	hashes := make([]common.Uint256, 0, n)
+	for i := uint64(0); i < n; i++ {
+		hash, _ := source.NextHash()
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
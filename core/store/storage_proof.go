@@ -0,0 +1,102 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/merkle"
+	"github.com/ontio/ontology/rlp"
+	"github.com/ontio/ontology/trie"
+)
+
+//StorageProof proves a single storage item of a contract against the state merkle root of a block.
+//The storage trie root is bound into the write set hash of the block, which is the leaf appended
+//to the state merkle tree at that height.
+type StorageProof struct {
+	Height          uint32
+	ContractAddress common.Address
+	Key             []byte
+	Value           []byte           //serialized storage item, nil if the key does not exist
+	ChangeHash      common.Uint256   //hash of the raw block write set
+	StorageRoot     common.Uint256   //root of the contract trie, contract address => contract storage root
+	ContractRoot    common.Uint256   //root of the storage trie of the contract
+	StateTreeHashes []common.Uint256 //compact state merkle tree before the block leaf is appended
+	StateMerkleRoot common.Uint256
+	AccountProof    []rlp.RawValue
+	StorageProof    []rlp.RawValue
+}
+
+//StorageTrieLeafHash return the state merkle leaf of a block once the storage trie is enabled
+func StorageTrieLeafHash(changeHash, storageRoot common.Uint256) common.Uint256 {
+	var result common.Uint256
+	hasher := sha256.New()
+	hasher.Write(changeHash[:])
+	hasher.Write(storageRoot[:])
+	hasher.Sum(result[:0])
+	return result
+}
+
+//Verify check the proof is self-consistent and ends at StateMerkleRoot. The caller is responsible
+//for checking StateMerkleRoot against the one agreed by consensus at Height.
+func (self *StorageProof) Verify() error {
+	leaf := StorageTrieLeafHash(self.ChangeHash, self.StorageRoot)
+	tree := merkle.NewTree(0, self.StateTreeHashes, nil)
+	if root := tree.GetRootWithNewLeaf(leaf); root != self.StateMerkleRoot {
+		return fmt.Errorf("state merkle root mismatch, expected:%s, got:%s",
+			self.StateMerkleRoot.ToHexString(), root.ToHexString())
+	}
+	if self.StorageRoot == common.UINT256_EMPTY {
+		if self.ContractRoot != common.UINT256_EMPTY || self.Value != nil {
+			return fmt.Errorf("non-empty contract storage under empty storage root")
+		}
+		return nil
+	}
+	enc, err := trie.VerifyProof(self.StorageRoot, self.ContractAddress[:], self.AccountProof)
+	if err != nil {
+		return fmt.Errorf("verify account proof: %s", err)
+	}
+	if !bytes.Equal(enc, contractRootBytes(self.ContractRoot)) {
+		return fmt.Errorf("contract storage root mismatch")
+	}
+	if self.ContractRoot == common.UINT256_EMPTY {
+		if self.Value != nil {
+			return fmt.Errorf("storage value of contract without storage")
+		}
+		return nil
+	}
+	value, err := trie.VerifyProof(self.ContractRoot, self.Key, self.StorageProof)
+	if err != nil {
+		return fmt.Errorf("verify storage proof: %s", err)
+	}
+	if !bytes.Equal(value, self.Value) {
+		return fmt.Errorf("storage value mismatch")
+	}
+	return nil
+}
+
+func contractRootBytes(root common.Uint256) []byte {
+	if root == common.UINT256_EMPTY {
+		return nil
+	}
+	return root[:]
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/ontio/ontology/core/types"
 	"github.com/ontio/ontology/smartcontract/event"
 	cstates "github.com/ontio/ontology/smartcontract/states"
+	vm "github.com/ontio/ontology/vm/neovm"
 )
 
 type ExecuteResult struct {
@@ -36,6 +37,15 @@ type ExecuteResult struct {
 	CrossStates     []common.Uint256
 	CrossStatesRoot common.Uint256
 	Notify          []*event.ExecuteNotify
+	ChangeHash      common.Uint256 // hash of the block write set before the storage trie root is mixed in
+	StorageRoot     common.Uint256 // root of the storage trie after the block
+}
+
+// AddressTx is a transaction related to an address, as recorded by the address index
+type AddressTx struct {
+	TxHash  common.Uint256
+	Height  uint32
+	TxIndex uint32
 }
 
 // LedgerStore provides func with store package.
@@ -65,10 +75,25 @@ type LedgerStore interface {
 	GetContractState(contractHash common.Address) (*payload.DeployCode, error)
 	GetBookkeeperState() (*states.BookkeeperState, error)
 	GetStorageItem(key *states.StorageKey) (*states.StorageItem, error)
+	GetStorageProof(contract common.Address, key []byte, height uint32) (*StorageProof, error)
+	FindStorageItems(contract common.Address, prefix []byte, onItem func(key, value []byte) bool) error
 	PreExecuteContract(tx *types.Transaction) (*cstates.PreExecResult, error)
 	PreExecuteContractBatch(txes []*types.Transaction, atomic bool) ([]*cstates.PreExecResult, uint32, error)
+	PreExecuteContractWithTracer(tx *types.Transaction, tracer vm.Tracer) (*cstates.PreExecResult, error)
 	GetEventNotifyByTx(tx common.Uint256) (*event.ExecuteNotify, error)
 	GetEventNotifyByBlock(height uint32) ([]*event.ExecuteNotify, error)
+	GetAddressTxs(addr common.Address, startHeight, endHeight, offset, limit uint32) ([]*AddressTx, error)
+	GetAddressIndexStartHeight() (uint32, error)
+
+	//state snapshot
+	GetSnapshotManifest(height uint32) (*SnapshotManifest, error)
+	GetSnapshotChunk(height, index uint32) ([]byte, error)
+	AddSnapshotChunk(manifest *SnapshotManifest, index uint32, data []byte) error
+	InstallSnapshot(manifest *SnapshotManifest) error
+	IsSnapshotSyncing() bool
+
+	//pruning mode
+	GetPrunedHeight() uint32
 
 	//cross chain states root
 	GetCrossStatesRoot(height uint32) (common.Uint256, error)
@@ -22,9 +22,11 @@ import (
 	"github.com/ontio/ontology/common"
 	"github.com/ontio/ontology/core/ledger"
 	"github.com/ontio/ontology/core/payload"
+	"github.com/ontio/ontology/core/store"
 	"github.com/ontio/ontology/core/types"
 	"github.com/ontio/ontology/smartcontract/event"
 	cstate "github.com/ontio/ontology/smartcontract/states"
+	vm "github.com/ontio/ontology/vm/neovm"
 )
 
 const (
@@ -72,6 +74,16 @@ func GetStorageItem(address common.Address, key []byte) ([]byte, error) {
 	return ledger.DefLedger.GetStorageItem(address, key)
 }
 
+//FindStorageItems from ledger
+func FindStorageItems(address common.Address, prefix []byte, onItem func(key, value []byte) bool) error {
+	return ledger.DefLedger.FindStorageItems(address, prefix, onItem)
+}
+
+//GetStorageProof from ledger
+func GetStorageProof(address common.Address, key []byte, height uint32) (*store.StorageProof, error) {
+	return ledger.DefLedger.GetStorageProof(address, key, height)
+}
+
 //GetContractStateFromStore from ledger
 func GetContractStateFromStore(hash common.Address) (*payload.DeployCode, error) {
 	hash = updateNativeSCAddr(hash)
@@ -89,6 +101,11 @@ func PreExecuteContract(tx *types.Transaction) (*cstate.PreExecResult, error) {
 	return ledger.DefLedger.PreExecuteContract(tx)
 }
 
+//PreExecuteContractWithTracer from ledger
+func PreExecuteContractWithTracer(tx *types.Transaction, tracer vm.Tracer) (*cstate.PreExecResult, error) {
+	return ledger.DefLedger.PreExecuteContractWithTracer(tx, tracer)
+}
+
 func PreExecuteContractBatch(tx []*types.Transaction, atomic bool) ([]*cstate.PreExecResult, uint32, error) {
 	return ledger.DefLedger.PreExecuteContractBatch(tx, atomic)
 }
@@ -103,6 +120,21 @@ func GetEventNotifyByHeight(height uint32) ([]*event.ExecuteNotify, error) {
 	return ledger.DefLedger.GetEventNotifyByBlock(height)
 }
 
+//GetPrunedHeight from ledger
+func GetPrunedHeight() uint32 {
+	return ledger.DefLedger.GetPrunedHeight()
+}
+
+//GetAddressTxs from ledger
+func GetAddressTxs(addr common.Address, startHeight, endHeight, offset, limit uint32) ([]*store.AddressTx, error) {
+	return ledger.DefLedger.GetAddressTxs(addr, startHeight, endHeight, offset, limit)
+}
+
+//GetAddressIndexStartHeight from ledger
+func GetAddressIndexStartHeight() (uint32, error) {
+	return ledger.DefLedger.GetAddressIndexStartHeight()
+}
+
 //GetMerkleProof from ledger
 func GetMerkleProof(proofHeight uint32, rootHeight uint32) ([]common.Uint256, error) {
 	return ledger.DefLedger.GetMerkleProof(proofHeight, rootHeight)
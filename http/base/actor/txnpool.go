@@ -83,35 +83,66 @@ func GetTxsFromPool(byCount bool) map[common.Uint256]*types.Transaction {
 
 }
 
-//GetTxFromPool from txpool actor
-func GetTxFromPool(hash common.Uint256) (tcomn.TXEntry, error) {
+//GetTxFromPool from txpool actor, return the tx entry and its state in pool
+func GetTxFromPool(hash common.Uint256) (tcomn.TXEntry, tcomn.TxPoolState, error) {
 
 	future := txnPid.RequestFuture(&tcomn.GetTxnReq{hash}, REQ_TIMEOUT*time.Second)
 	result, err := future.Result()
 	if err != nil {
 		log.Errorf(ERR_ACTOR_COMM, err)
-		return tcomn.TXEntry{}, err
+		return tcomn.TXEntry{}, 0, err
 	}
 	rsp, ok := result.(*tcomn.GetTxnRsp)
 	if !ok {
-		return tcomn.TXEntry{}, errors.New("fail")
+		return tcomn.TXEntry{}, 0, errors.New("fail")
 	}
 	if rsp.Txn == nil {
-		return tcomn.TXEntry{}, errors.New("fail")
+		return tcomn.TXEntry{}, 0, errors.New("fail")
 	}
 
 	future = txnPid.RequestFuture(&tcomn.GetTxnStatusReq{hash}, REQ_TIMEOUT*time.Second)
 	result, err = future.Result()
 	if err != nil {
 		log.Errorf(ERR_ACTOR_COMM, err)
-		return tcomn.TXEntry{}, err
+		return tcomn.TXEntry{}, 0, err
 	}
 	txStatus, ok := result.(*tcomn.GetTxnStatusRsp)
 	if !ok {
-		return tcomn.TXEntry{}, errors.New("fail")
+		return tcomn.TXEntry{}, 0, errors.New("fail")
 	}
-	txnEntry := tcomn.TXEntry{rsp.Txn, txStatus.TxStatus}
-	return txnEntry, nil
+	txnEntry := tcomn.TXEntry{Tx: rsp.Txn, Attrs: txStatus.TxStatus}
+	return txnEntry, txStatus.State, nil
+}
+
+//GetTxPoolContent from txpool actor
+func GetTxPoolContent(req *tcomn.GetTxnPoolContentReq) (*tcomn.GetTxnPoolContentRsp, error) {
+	future := txnPid.RequestFuture(req, REQ_TIMEOUT*time.Second)
+	result, err := future.Result()
+	if err != nil {
+		log.Errorf(ERR_ACTOR_COMM, err)
+		return nil, err
+	}
+	rsp, ok := result.(*tcomn.GetTxnPoolContentRsp)
+	if !ok {
+		return nil, errors.New("fail")
+	}
+	return rsp, nil
+}
+
+//GetTxPoolGasPrice from txpool actor, return the min gas price and the
+//gas prices of pending txs in descending order
+func GetTxPoolGasPrice() (uint64, []uint64, error) {
+	future := txnPid.RequestFuture(&tcomn.GetTxnGasPriceReq{}, REQ_TIMEOUT*time.Second)
+	result, err := future.Result()
+	if err != nil {
+		log.Errorf(ERR_ACTOR_COMM, err)
+		return 0, nil, err
+	}
+	rsp, ok := result.(*tcomn.GetTxnGasPriceRsp)
+	if !ok {
+		return 0, nil, errors.New("fail")
+	}
+	return rsp.MinGasPrice, rsp.GasPrices, nil
 }
 
 //GetTxnCount from txpool actor
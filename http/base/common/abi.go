@@ -0,0 +1,211 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/log"
+	"github.com/ontio/ontology/core/payload"
+	scom "github.com/ontio/ontology/core/store/common"
+	bactor "github.com/ontio/ontology/http/base/actor"
+	"github.com/ontio/ontology/smartcontract/service/native/abi_registry"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+	"github.com/ontio/ontology/vm/crossvm_codec"
+)
+
+type ContractAbiRecord struct {
+	Contract string
+	Version  uint32
+	Height   uint32
+	Abi      json.RawMessage
+}
+
+//GetContractAbi return the abi of contract registered in abi registry, the latest one if version is 0,
+//nil if not registered
+func GetContractAbi(contract common.Address, version uint32) (*ContractAbiRecord, error) {
+	record, err := getAbiRecord(contract, version)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	return &ContractAbiRecord{
+		Contract: record.Contract.ToHexString(),
+		Version:  record.Version,
+		Height:   record.Height,
+		Abi:      json.RawMessage(record.Abi),
+	}, nil
+}
+
+func getAbiRecord(contract common.Address, version uint32) (*abi_registry.AbiRecord, error) {
+	registry := utils.AbiRegistryContractAddress
+	if version == 0 {
+		data, err := getAbiRegistryStorage(abi_registry.GenAbiMetaKey(registry, contract))
+		if err != nil || data == nil {
+			return nil, err
+		}
+		meta := new(abi_registry.AbiMeta)
+		if err := meta.Deserialization(common.NewZeroCopySource(data)); err != nil {
+			return nil, fmt.Errorf("AbiMeta.Deserialization error:%s", err)
+		}
+		version = meta.Version
+	}
+	data, err := getAbiRegistryStorage(abi_registry.GenAbiRecordKey(registry, contract, version))
+	if err != nil || data == nil {
+		return nil, err
+	}
+	record := new(abi_registry.AbiRecord)
+	if err := record.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, fmt.Errorf("AbiRecord.Deserialization error:%s", err)
+	}
+	return record, nil
+}
+
+//getAbiRegistryStorage return the var bytes stored by abi registry contract, nil if the key doesn't exist
+func getAbiRegistryStorage(key []byte) ([]byte, error) {
+	value, err := bactor.GetStorageItem(utils.AbiRegistryContractAddress, key[common.ADDR_LEN:])
+	if err != nil {
+		if err == scom.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	data, _, irregular, eof := common.NewZeroCopySource(value).NextVarBytes()
+	if irregular || eof {
+		return nil, fmt.Errorf("invalid storage value")
+	}
+	return data, nil
+}
+
+type contractNotifyAbi struct {
+	isWasm bool
+	abi    *abi_registry.ContractAbi
+}
+
+//NotifyDecoder decodes notifications by the abi registered in abi registry contract,
+//the abi of each contract is loaded only once
+type NotifyDecoder struct {
+	contracts map[common.Address]*contractNotifyAbi
+}
+
+func NewNotifyDecoder() *NotifyDecoder {
+	return &NotifyDecoder{contracts: make(map[common.Address]*contractNotifyAbi)}
+}
+
+//DecodeExecuteNotify fill the decoded field of notifications which can be decoded
+func (this *NotifyDecoder) DecodeExecuteNotify(notify *ExecuteNotify) {
+	for i := range notify.Notify {
+		this.decodeNotify(&notify.Notify[i])
+	}
+}
+
+func (this *NotifyDecoder) decodeNotify(info *NotifyEventInfo) {
+	contract, err := common.AddressFromHexString(info.ContractAddress)
+	if err != nil {
+		return
+	}
+	notifyAbi := this.getNotifyAbi(contract)
+	if notifyAbi == nil {
+		return
+	}
+	states := info.States
+	if notifyAbi.isWasm {
+		states = deserializeWasmNotify(states)
+	}
+	if notifyAbi.abi != nil {
+		decoded, err := notifyAbi.abi.DecodeNotify(states, !notifyAbi.isWasm)
+		if err != nil {
+			log.Debugf("DecodeNotify of contract %s error:%s", info.ContractAddress, err)
+			return
+		}
+		info.Decoded = decoded
+		return
+	}
+	if notifyAbi.isWasm {
+		// wasm notifications are self described, keep the event name and untyped parameters
+		info.Decoded = decodeUntypedNotify(states)
+	}
+}
+
+//getNotifyAbi return nil if the contract is not deployed, such as native contracts
+func (this *NotifyDecoder) getNotifyAbi(contract common.Address) *contractNotifyAbi {
+	if notifyAbi, ok := this.contracts[contract]; ok {
+		return notifyAbi
+	}
+	var notifyAbi *contractNotifyAbi
+	dep, err := bactor.GetContractStateFromStore(contract)
+	if err == nil && dep != nil {
+		notifyAbi = &contractNotifyAbi{isWasm: dep.VmType() == payload.WASMVM_TYPE}
+		record, err := getAbiRecord(contract, 0)
+		if err != nil {
+			log.Errorf("get abi of contract %s error:%s", contract.ToHexString(), err)
+		} else if record != nil {
+			notifyAbi.abi, err = abi_registry.ParseContractAbi([]byte(record.Abi))
+			if err != nil {
+				log.Errorf("parse abi of contract %s error:%s", contract.ToHexString(), err)
+			}
+		}
+	}
+	this.contracts[contract] = notifyAbi
+	return notifyAbi
+}
+
+//deserializeWasmNotify decodes the raw wasm notification, which is stored as bytes when it isn't
+//deserialized on execution
+func deserializeWasmNotify(states interface{}) interface{} {
+	var raw []byte
+	switch val := states.(type) {
+	case []byte:
+		raw = val
+	case string:
+		buf, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			return states
+		}
+		raw = buf
+	default:
+		return states
+	}
+	if !bytes.HasPrefix(raw, []byte("evt\x00")) {
+		return states
+	}
+	return crossvm_codec.DeserializeNotify(raw)
+}
+
+func decodeUntypedNotify(states interface{}) *abi_registry.DecodedNotify {
+	list, ok := states.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil
+	}
+	name, ok := list[0].(string)
+	if !ok {
+		return nil
+	}
+	decoded := &abi_registry.DecodedNotify{EventName: name, Params: make([]*abi_registry.DecodedParam, 0, len(list)-1)}
+	for _, value := range list[1:] {
+		decoded.Params = append(decoded.Params, &abi_registry.DecodedParam{Value: value})
+	}
+	return decoded
+}
@@ -22,8 +22,10 @@ package common
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -33,19 +35,29 @@ import (
 	"github.com/ontio/ontology/common/log"
 	"github.com/ontio/ontology/core/ledger"
 	"github.com/ontio/ontology/core/payload"
+	"github.com/ontio/ontology/core/store"
+	scom "github.com/ontio/ontology/core/store/common"
 	"github.com/ontio/ontology/core/types"
 	cutils "github.com/ontio/ontology/core/utils"
 	ontErrors "github.com/ontio/ontology/errors"
 	bactor "github.com/ontio/ontology/http/base/actor"
 	"github.com/ontio/ontology/smartcontract/event"
+	"github.com/ontio/ontology/smartcontract/service/native/abi_registry"
+	"github.com/ontio/ontology/smartcontract/service/native/content_registry"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
 	"github.com/ontio/ontology/smartcontract/service/native/ont"
 	"github.com/ontio/ontology/smartcontract/service/native/utils"
 	cstate "github.com/ontio/ontology/smartcontract/states"
+	tcomn "github.com/ontio/ontology/txnpool/common"
 	"github.com/ontio/ontology/vm/neovm"
 )
 
 const MAX_SEARCH_HEIGHT uint32 = 100
 const MAX_REQUEST_BODY_SIZE = 1 << 20
+const DEFAULT_MEMPOOL_PAGE_SIZE uint32 = 100
+const MAX_MEMPOOL_PAGE_SIZE uint32 = 1000
+const DEFAULT_ADDRESS_TXS_PAGE_SIZE uint32 = 20
+const MAX_ADDRESS_TXS_PAGE_SIZE uint32 = 100
 
 type BalanceOfRsp struct {
 	Ont    string `json:"ont"`
@@ -85,6 +97,7 @@ type PreExecuteResult struct {
 type NotifyEventInfo struct {
 	ContractAddress string
 	States          interface{}
+	Decoded         *abi_registry.DecodedNotify `json:",omitempty"`
 }
 
 type TxAttributeInfo struct {
@@ -113,6 +126,161 @@ type CrossStatesProof struct {
 	AuditPath string
 }
 
+type StorageProof struct {
+	Type            string
+	BlockHeight     uint32
+	ContractAddress string
+	Key             string
+	Value           string
+	ChangeHash      string
+	StorageRoot     string
+	ContractRoot    string
+	StateTreeHashes []string
+	StateMerkleRoot string
+	AccountProof    []string
+	StorageProof    []string
+}
+
+type OracleRequest struct {
+	TxHash          string `json:"txHash"`
+	Request         string `json:"request"`
+	Address         string `json:"address"`
+	Fee             uint64 `json:"fee"`
+	Quorum          uint32 `json:"quorum"`
+	AggregationMode uint8  `json:"aggregationMode"`
+	Deadline        uint32 `json:"deadline"`
+	PenalizeDissent bool   `json:"penalizeDissent"`
+	Status          string `json:"status"`
+}
+
+type OracleOutcome struct {
+	TxHash        string            `json:"txHash"`
+	Status        string            `json:"status"`
+	FinalOutcome  string            `json:"finalOutcome"`
+	OutcomeRecord map[string]string `json:"outcomeRecord"`
+}
+
+type AddressTxInfo struct {
+	TxHash  string
+	Height  uint32
+	TxIndex uint32
+}
+
+type AddressTxsInfo struct {
+	Address          string
+	StartHeight      uint32
+	EndHeight        uint32
+	Offset           uint32
+	IndexStartHeight uint32
+	Txs              []*AddressTxInfo
+}
+
+//GetAddressTxs return a page of the transactions related to the address in the height range,
+//endHeight 0 means current block height. The range is limited to the blocks indexed from IndexStartHeight
+func GetAddressTxs(addr common.Address, startHeight, endHeight, offset, limit uint32) (*AddressTxsInfo, error) {
+	if limit == 0 {
+		limit = DEFAULT_ADDRESS_TXS_PAGE_SIZE
+	}
+	if limit > MAX_ADDRESS_TXS_PAGE_SIZE {
+		limit = MAX_ADDRESS_TXS_PAGE_SIZE
+	}
+	currentHeight := bactor.GetCurrentBlockHeight()
+	if endHeight == 0 || endHeight > currentHeight {
+		endHeight = currentHeight
+	}
+	indexStartHeight, err := bactor.GetAddressIndexStartHeight()
+	if err != nil {
+		return nil, err
+	}
+	if startHeight < indexStartHeight {
+		startHeight = indexStartHeight
+	}
+	txs, err := bactor.GetAddressTxs(addr, startHeight, endHeight, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	info := &AddressTxsInfo{
+		Address:          addr.ToBase58(),
+		StartHeight:      startHeight,
+		EndHeight:        endHeight,
+		Offset:           offset,
+		IndexStartHeight: indexStartHeight,
+		Txs:              make([]*AddressTxInfo, 0, len(txs)),
+	}
+	for _, tx := range txs {
+		info.Txs = append(info.Txs, &AddressTxInfo{
+			TxHash:  tx.TxHash.ToHexString(),
+			Height:  tx.Height,
+			TxIndex: tx.TxIndex,
+		})
+	}
+	return info, nil
+}
+
+type ContentRecord struct {
+	Cid      string `json:"cid"`
+	Size     uint64 `json:"size"`
+	Uploader string `json:"uploader"`
+	FileName string `json:"fileName"`
+	Note     string `json:"note"`
+	Pinned   bool   `json:"pinned"`
+	Height   uint32 `json:"height"`
+}
+
+type FeeSplitCurve struct {
+	Xi   []uint32 `json:"xi"`
+	Yi   []uint32 `json:"yi"`
+	Yita uint32   `json:"yita"`
+	A    uint32   `json:"a"`
+	B    uint32   `json:"b"`
+}
+
+type FeeSplitCurveInfo struct {
+	Height       uint32         `json:"height"`
+	Current      *FeeSplitCurve `json:"current"`
+	ActiveHeight uint32         `json:"activeHeight"`
+	Scheduled    *FeeSplitCurve `json:"scheduled"`
+}
+
+type GovernanceViewInfo struct {
+	View   uint32
+	Height uint32
+	TxHash string
+}
+
+type PeerPoolItemInfo struct {
+	Index      uint32
+	PeerPubkey string
+	Address    string
+	Status     uint8
+	InitPos    uint64
+	TotalPos   uint64
+}
+
+type AuthorizeInfo struct {
+	PeerPubkey           string
+	Address              string
+	ConsensusPos         uint64
+	CandidatePos         uint64
+	NewPos               uint64
+	WithdrawConsensusPos uint64
+	WithdrawCandidatePos uint64
+	WithdrawUnfreezePos  uint64
+}
+
+type StakeStatus struct {
+	Address        string
+	TotalStake     uint64
+	Authorizations []*AuthorizeInfo
+}
+
+type PendingRewards struct {
+	Address    string
+	SplitFee   uint64 //fee split to address, can be withdrawn by withdrawFee
+	UnboundOng uint64 //ong unbound by the ont staked in governance contract, can be withdrawn by withdrawOng
+	Total      uint64
+}
+
 type Transactions struct {
 	Version    byte
 	Nonce      uint32
@@ -175,7 +343,90 @@ type TXNAttrInfo struct {
 }
 
 type TXNEntryInfo struct {
-	State []TXNAttrInfo // the result from each validator
+	State     []TXNAttrInfo // the result from each validator
+	Payer     string
+	Nonce     uint32
+	GasPrice  uint64
+	PoolState string // verifying, pending or queued
+}
+
+type MemPoolTxInfo struct {
+	Hash     string
+	Payer    string
+	GasPrice uint64
+	GasLimit uint64
+	Nonce    uint32
+	Size     int
+	State    string        // verifying, pending or queued
+	Attrs    []TXNAttrInfo // the result from each validator
+	Age      uint64        // seconds since the tx entered the pool
+}
+
+type MemPoolTxsInfo struct {
+	Total  uint32
+	Offset uint32
+	Txs    []*MemPoolTxInfo
+}
+
+//GetMemPoolTxs return a page of the transactions in tx pool, payer is nil for all payers
+func GetMemPoolTxs(payer *common.Address, minGasPrice uint64, offset, limit uint32) (*MemPoolTxsInfo, error) {
+	if limit == 0 {
+		limit = DEFAULT_MEMPOOL_PAGE_SIZE
+	}
+	if limit > MAX_MEMPOOL_PAGE_SIZE {
+		limit = MAX_MEMPOOL_PAGE_SIZE
+	}
+	rsp, err := bactor.GetTxPoolContent(&tcomn.GetTxnPoolContentReq{
+		Payer:       payer,
+		MinGasPrice: minGasPrice,
+		Offset:      offset,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	info := &MemPoolTxsInfo{
+		Total:  rsp.Total,
+		Offset: offset,
+		Txs:    make([]*MemPoolTxInfo, 0, len(rsp.Txs)),
+	}
+	for _, content := range rsp.Txs {
+		attrs := []TXNAttrInfo{}
+		for _, t := range content.Attrs {
+			attrs = append(attrs, TXNAttrInfo{t.Height, int(t.Type), int(t.ErrCode)})
+		}
+		var age uint64
+		if now.After(content.Time) {
+			age = uint64(now.Sub(content.Time) / time.Second)
+		}
+		info.Txs = append(info.Txs, &MemPoolTxInfo{
+			Hash:     content.Tx.Hash().ToHexString(),
+			Payer:    content.Tx.Payer.ToBase58(),
+			GasPrice: content.Tx.GasPrice,
+			GasLimit: content.Tx.GasLimit,
+			Nonce:    content.Tx.Nonce,
+			Size:     len(content.Tx.Raw),
+			State:    content.State.String(),
+			Attrs:    attrs,
+			Age:      age,
+		})
+	}
+	return info, nil
+}
+
+func GetTXNEntryInfo(txEntry tcomn.TXEntry, state tcomn.TxPoolState) TXNEntryInfo {
+	attrs := []TXNAttrInfo{}
+	for _, t := range txEntry.Attrs {
+		attrs = append(attrs, TXNAttrInfo{t.Height, int(t.Type), int(t.ErrCode)})
+	}
+	return TXNEntryInfo{
+		State:     attrs,
+		Payer:     txEntry.Tx.Payer.ToBase58(),
+		Nonce:     txEntry.Tx.Nonce,
+		GasPrice:  txEntry.Tx.GasPrice,
+		PoolState: state.String(),
+	}
 }
 
 func GetLogEvent(obj *event.LogEventArgs) (map[string]bool, LogEventArgs) {
@@ -189,7 +440,7 @@ func GetExecuteNotify(obj *event.ExecuteNotify) (map[string]bool, ExecuteNotify)
 	evts := []NotifyEventInfo{}
 	var contractAddrs = make(map[string]bool)
 	for _, v := range obj.Notify {
-		evts = append(evts, NotifyEventInfo{v.ContractAddress.ToHexString(), v.States})
+		evts = append(evts, NotifyEventInfo{ContractAddress: v.ContractAddress.ToHexString(), States: v.States})
 		contractAddrs[v.ContractAddress.ToHexString()] = true
 	}
 	txhash := obj.TxHash.ToHexString()
@@ -199,7 +450,7 @@ func GetExecuteNotify(obj *event.ExecuteNotify) (map[string]bool, ExecuteNotify)
 func ConvertPreExecuteResult(obj *cstate.PreExecResult) PreExecuteResult {
 	evts := []NotifyEventInfo{}
 	for _, v := range obj.Notify {
-		evts = append(evts, NotifyEventInfo{v.ContractAddress.ToHexString(), v.States})
+		evts = append(evts, NotifyEventInfo{ContractAddress: v.ContractAddress.ToHexString(), States: v.States})
 	}
 	return PreExecuteResult{obj.State, obj.Gas, obj.Result, evts}
 }
@@ -247,6 +498,35 @@ func TransferCrossChainMsg(msg *types.CrossChainMsg, pks []keypair.PublicKey) st
 	return common.ToHexString(sink.Bytes())
 }
 
+func GetStorageProofInfo(proof *store.StorageProof) StorageProof {
+	treeHashes := make([]string, 0, len(proof.StateTreeHashes))
+	for _, hash := range proof.StateTreeHashes {
+		treeHashes = append(treeHashes, hash.ToHexString())
+	}
+	accountProof := make([]string, 0, len(proof.AccountProof))
+	for _, node := range proof.AccountProof {
+		accountProof = append(accountProof, common.ToHexString(node))
+	}
+	storageProof := make([]string, 0, len(proof.StorageProof))
+	for _, node := range proof.StorageProof {
+		storageProof = append(storageProof, common.ToHexString(node))
+	}
+	return StorageProof{
+		Type:            "StorageProof",
+		BlockHeight:     proof.Height,
+		ContractAddress: proof.ContractAddress.ToHexString(),
+		Key:             common.ToHexString(proof.Key),
+		Value:           common.ToHexString(proof.Value),
+		ChangeHash:      proof.ChangeHash.ToHexString(),
+		StorageRoot:     proof.StorageRoot.ToHexString(),
+		ContractRoot:    proof.ContractRoot.ToHexString(),
+		StateTreeHashes: treeHashes,
+		StateMerkleRoot: proof.StateMerkleRoot.ToHexString(),
+		AccountProof:    accountProof,
+		StorageProof:    storageProof,
+	}
+}
+
 func SendTxToPool(txn *types.Transaction) (ontErrors.ErrCode, string) {
 	if errCode, desc := bactor.AppendTxToPool(txn); errCode != ontErrors.ErrNoError {
 		log.Warn("TxnPool verify error:", errCode.Error())
@@ -418,22 +698,346 @@ func GetContractAllowance(cVersion byte, contractAddr, fromAddr, toAddr common.A
 	return allowance.Uint64(), nil
 }
 
+//GetOracleRequest return the oracle request created by transaction txHash
+func GetOracleRequest(txHash common.Uint256) (*OracleRequest, error) {
+	request := new(OracleRequest)
+	err := preExecuteOracle("getOracleRequest", []interface{}{&struct{ TxHash string }{hex.EncodeToString(txHash[:])}}, request)
+	if err != nil {
+		return nil, err
+	}
+	request.TxHash, err = oracleTxHashToHexString(request.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+//GetPendingOracleRequests return the oracle requests waiting for outcomes, including expired ones not refunded
+func GetPendingOracleRequests() ([]*OracleRequest, error) {
+	requests := make([]*OracleRequest, 0)
+	err := preExecuteOracle("listPendingOracleRequests", []interface{}{}, &requests)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		request.TxHash, err = oracleTxHashToHexString(request.TxHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return requests, nil
+}
+
+//GetOracleOutcome return the outcomes set by oracle nodes and the final outcome of the oracle request
+func GetOracleOutcome(txHash common.Uint256) (*OracleOutcome, error) {
+	outcome := new(OracleOutcome)
+	err := preExecuteOracle("getOracleOutcome", []interface{}{&struct{ TxHash string }{hex.EncodeToString(txHash[:])}}, outcome)
+	if err != nil {
+		return nil, err
+	}
+	outcome.TxHash, err = oracleTxHashToHexString(outcome.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	return outcome, nil
+}
+
+//oracle contract keys requests by the hex of raw tx hash bytes, convert it to the usual tx hash string
+func oracleTxHashToHexString(str string) (string, error) {
+	buf, err := hex.DecodeString(str)
+	if err != nil {
+		return "", err
+	}
+	hash, err := common.Uint256ParseFromBytes(buf)
+	if err != nil {
+		return "", err
+	}
+	return hash.ToHexString(), nil
+}
+
+func preExecuteOracle(method string, params []interface{}, result interface{}) error {
+	mutable, err := NewNativeInvokeTransaction(0, 0, utils.OracleContractAddress, 0, method, params)
+	if err != nil {
+		return fmt.Errorf("NewNativeInvokeTransaction error:%s", err)
+	}
+	tx, err := mutable.IntoImmutable()
+	if err != nil {
+		return err
+	}
+	res, err := bactor.PreExecuteContract(tx)
+	if err != nil {
+		return fmt.Errorf("PrepareInvokeContract error:%s", err)
+	}
+	if res.State == 0 {
+		return fmt.Errorf("prepare invoke failed")
+	}
+	data, err := hex.DecodeString(res.Result.(string))
+	if err != nil {
+		return fmt.Errorf("hex.DecodeString error:%s", err)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("json.Unmarshal error:%s", err)
+	}
+	return nil
+}
+
+//GetContentRecord return the record of cid in content registry contract, nil if cid is not registered
+func GetContentRecord(cid string) (*ContentRecord, error) {
+	mutable, err := NewNativeInvokeTransaction(0, 0, utils.ContentRegistryContractAddress, 0,
+		content_registry.GET_CONTENT_NAME, []interface{}{cid})
+	if err != nil {
+		return nil, fmt.Errorf("NewNativeInvokeTransaction error:%s", err)
+	}
+	tx, err := mutable.IntoImmutable()
+	if err != nil {
+		return nil, err
+	}
+	res, err := bactor.PreExecuteContract(tx)
+	if err != nil {
+		return nil, fmt.Errorf("PrepareInvokeContract error:%s", err)
+	}
+	if res.State == 0 {
+		return nil, fmt.Errorf("prepare invoke failed")
+	}
+	data, err := hex.DecodeString(res.Result.(string))
+	if err != nil {
+		return nil, fmt.Errorf("hex.DecodeString error:%s", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	record := new(content_registry.ContentRecord)
+	if err := record.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, fmt.Errorf("ContentRecord.Deserialization error:%s", err)
+	}
+	return &ContentRecord{
+		Cid:      record.Cid,
+		Size:     record.Size,
+		Uploader: record.Uploader.ToBase58(),
+		FileName: record.FileName,
+		Note:     record.Note,
+		Pinned:   record.Pinned,
+		Height:   record.Height,
+	}, nil
+}
+
+//GetFeeSplitCurve return the fee split curve in effect for next block, and the curve scheduled if it is not active yet
+func GetFeeSplitCurve() (*FeeSplitCurveInfo, error) {
+	mutable, err := NewNativeInvokeTransaction(0, 0, utils.GovernanceContractAddress, 0,
+		governance.GET_FEE_SPLIT_CURVE, []interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("NewNativeInvokeTransaction error:%s", err)
+	}
+	tx, err := mutable.IntoImmutable()
+	if err != nil {
+		return nil, err
+	}
+	res, err := bactor.PreExecuteContract(tx)
+	if err != nil {
+		return nil, fmt.Errorf("PrepareInvokeContract error:%s", err)
+	}
+	if res.State == 0 {
+		return nil, fmt.Errorf("prepare invoke failed")
+	}
+	data, err := hex.DecodeString(res.Result.(string))
+	if err != nil {
+		return nil, fmt.Errorf("hex.DecodeString error:%s", err)
+	}
+	info := new(governance.FeeSplitCurveInfo)
+	if err := info.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, fmt.Errorf("FeeSplitCurveInfo.Deserialization error:%s", err)
+	}
+	height := bactor.GetCurrentBlockHeight() + 1
+	result := &FeeSplitCurveInfo{
+		Height:       height,
+		Current:      newFeeSplitCurve(info.Effective(height)),
+		ActiveHeight: info.ActiveHeight,
+	}
+	if height < info.ActiveHeight {
+		result.Scheduled = newFeeSplitCurve(&info.Curve)
+	}
+	return result, nil
+}
+
+func newFeeSplitCurve(curve *governance.FeeSplitCurve) *FeeSplitCurve {
+	return &FeeSplitCurve{
+		Xi:   curve.Xi,
+		Yi:   curve.Yi,
+		Yita: curve.Yita,
+		A:    curve.A,
+		B:    curve.B,
+	}
+}
+
+//getGovernanceStorage return the storage value of governance contract, nil if the key doesn't exist
+func getGovernanceStorage(key ...[]byte) ([]byte, error) {
+	value, err := bactor.GetStorageItem(utils.GovernanceContractAddress, bytes.Join(key, nil))
+	if err != nil {
+		if err == scom.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+func getGovernanceView() (*governance.GovernanceView, error) {
+	return QueryGovernanceView(ledgerGovernanceStorage)
+}
+
+func getPeerPoolMap(view uint32) (*governance.PeerPoolMap, error) {
+	return QueryPeerPoolMap(ledgerGovernanceStorage, view)
+}
+
+func getAuthorizeInfo(peerPubkey string, addr common.Address) (*governance.AuthorizeInfo, error) {
+	return QueryAuthorizeInfo(ledgerGovernanceStorage, peerPubkey, addr)
+}
+
+func ledgerGovernanceStorage(key []byte) ([]byte, error) {
+	return getGovernanceStorage(key)
+}
+
+func findLedgerGovernanceStorage(prefix []byte, onItem func(key, value []byte) bool) error {
+	return bactor.FindStorageItems(utils.GovernanceContractAddress, prefix, onItem)
+}
+
+//GetGovernanceView return the current view of governance contract
+func GetGovernanceView() (*GovernanceViewInfo, error) {
+	view, err := getGovernanceView()
+	if err != nil {
+		return nil, err
+	}
+	return &GovernanceViewInfo{
+		View:   view.View,
+		Height: view.Height,
+		TxHash: view.TxHash.ToHexString(),
+	}, nil
+}
+
+//GetPeerPool return the peers of current view sorted by index, or the peer of peerPubkey if it is not empty
+func GetPeerPool(peerPubkey string) ([]*PeerPoolItemInfo, error) {
+	view, err := getGovernanceView()
+	if err != nil {
+		return nil, err
+	}
+	peerPoolMap, err := getPeerPoolMap(view.View)
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]*PeerPoolItemInfo, 0, len(peerPoolMap.PeerPoolMap))
+	for _, item := range peerPoolMap.PeerPoolMap {
+		if peerPubkey != "" && item.PeerPubkey != peerPubkey {
+			continue
+		}
+		peers = append(peers, &PeerPoolItemInfo{
+			Index:      item.Index,
+			PeerPubkey: item.PeerPubkey,
+			Address:    item.Address.ToBase58(),
+			Status:     uint8(item.Status),
+			InitPos:    item.InitPos,
+			TotalPos:   item.TotalPos,
+		})
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Index < peers[j].Index
+	})
+	return peers, nil
+}
+
+//GetAuthorizeInfo return the pos authorized by address to peer
+func GetAuthorizeInfo(peerPubkey string, addr common.Address) (*AuthorizeInfo, error) {
+	info, err := getAuthorizeInfo(peerPubkey, addr)
+	if err != nil {
+		return nil, err
+	}
+	return newAuthorizeInfo(info), nil
+}
+
+func newAuthorizeInfo(info *governance.AuthorizeInfo) *AuthorizeInfo {
+	return &AuthorizeInfo{
+		PeerPubkey:           info.PeerPubkey,
+		Address:              info.Address.ToBase58(),
+		ConsensusPos:         info.ConsensusPos,
+		CandidatePos:         info.CandidatePos,
+		NewPos:               info.NewPos,
+		WithdrawConsensusPos: info.WithdrawConsensusPos,
+		WithdrawCandidatePos: info.WithdrawCandidatePos,
+		WithdrawUnfreezePos:  info.WithdrawUnfreezePos,
+	}
+}
+
+//GetStakeStatus return the total stake of address in governance contract, and all its authorizations to the
+//peers
+func GetStakeStatus(addr common.Address) (*StakeStatus, error) {
+	totalStake, err := QueryTotalStake(ledgerGovernanceStorage, addr)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := QueryAuthorizeInfos(findLedgerGovernanceStorage, addr)
+	if err != nil {
+		return nil, err
+	}
+	status := &StakeStatus{
+		Address:        addr.ToBase58(),
+		TotalStake:     totalStake.Stake,
+		Authorizations: make([]*AuthorizeInfo, 0, len(infos)),
+	}
+	for _, info := range infos {
+		status.Authorizations = append(status.Authorizations, newAuthorizeInfo(info))
+	}
+	sort.Slice(status.Authorizations, func(i, j int) bool {
+		return status.Authorizations[i].PeerPubkey < status.Authorizations[j].PeerPubkey
+	})
+	return status, nil
+}
+
+//GetPendingRewards return the ong address can claim from governance contract at the current block
+func GetPendingRewards(addr common.Address) (*PendingRewards, error) {
+	splitFee, err := QuerySplitFeeAddress(ledgerGovernanceStorage, addr)
+	if err != nil {
+		return nil, err
+	}
+	totalStake, err := QueryTotalStake(ledgerGovernanceStorage, addr)
+	if err != nil {
+		return nil, err
+	}
+	header, err := bactor.GetHeaderByHeight(bactor.GetCurrentBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+	return newPendingRewards(addr, splitFee, totalStake, header.Timestamp), nil
+}
+
+//newPendingRewards return the rewards of address if it claims in a block with timestamp, the same as the
+//governance contract computes with native.Time
+func newPendingRewards(addr common.Address, splitFee *governance.SplitFeeAddress, totalStake *governance.TotalStake,
+	timestamp uint32) *PendingRewards {
+	rewards := &PendingRewards{
+		Address:  addr.ToBase58(),
+		SplitFee: splitFee.Amount,
+	}
+	rewards.UnboundOng = utils.CalcUnbindOng(totalStake.Stake, totalStake.TimeOffset,
+		timestamp-constants.GENESIS_BLOCK_TIMESTAMP)
+	rewards.Total = rewards.SplitFee + rewards.UnboundOng
+	return rewards
+}
+
 func GetGasPrice() (map[string]interface{}, error) {
 	start := bactor.GetCurrentBlockHeight()
 	var gasPrice uint64 = 0
 	var height uint32 = 0
-	var end uint32 = 0
-	if start > MAX_SEARCH_HEIGHT {
-		end = start - MAX_SEARCH_HEIGHT
-	}
+	end := gasPriceSearchEnd(start)
 	for i := start; i >= end; i-- {
 		head, err := bactor.GetHeaderByHeight(i)
 		if err == nil && head.TransactionsRoot != common.UINT256_EMPTY {
-			height = i
 			blk, err := bactor.GetBlockByHeight(i)
+			if err == scom.ErrPruned {
+				break
+			}
 			if err != nil {
 				return nil, err
 			}
+			height = i
 			for _, v := range blk.Transactions {
 				gasPrice += v.GasPrice
 			}
@@ -521,6 +1125,11 @@ func GetAddress(str string) (common.Address, error) {
 	return address, err
 }
 
+type NodeVersionInfo struct {
+	Version      string
+	PrunedHeight uint32 //blocks and event notifies below the height are not kept by the node
+}
+
 type SyncStatus struct {
 	CurrentBlockHeight uint32
 	ConnectCount       uint32
@@ -0,0 +1,181 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
+	scom "github.com/ontio/ontology/core/store/common"
+	"github.com/ontio/ontology/core/types"
+	bactor "github.com/ontio/ontology/http/base/actor"
+	"github.com/ontio/ontology/smartcontract/service/neovm"
+)
+
+const (
+	ESTIMATE_GAS_PRICE_BLOCKS = 20  // The number of recent non-empty blocks sampled to estimate gas price
+	ESTIMATE_GAS_PADDING      = 20  // The percentage added to the gas consumed by pre-execution
+	LOW_GAS_PRICE_PERCENTILE  = 10  // The percentile of sampled gas prices for low suggestion
+	STD_GAS_PRICE_PERCENTILE  = 50  // The percentile of sampled gas prices for standard suggestion
+	FAST_GAS_PRICE_PERCENTILE = 90  // The percentile of sampled gas prices for fast suggestion
+	MAX_GAS_PRICE_PERCENTILE  = 100 // The max percentile
+)
+
+type GasPriceEstimate struct {
+	Low         uint64
+	Standard    uint64
+	Fast        uint64
+	MinGasPrice uint64 // the min gas price accepted by tx pool
+	Height      uint32 // the current block height
+	Blocks      uint32 // the number of sampled blocks
+	Txs         uint32 // the number of sampled transactions
+	PoolTxs     uint32 // the number of pending transactions in tx pool
+}
+
+type GasEstimate struct {
+	State    byte
+	Gas      uint64 // the gas consumed by pre-execution
+	GasLimit uint64 // the suggested gas limit
+}
+
+//EstimateGasPrice suggest gas prices by the transactions in recent blocks and the pending ones in tx pool
+func EstimateGasPrice() (*GasPriceEstimate, error) {
+	minGasPrice, poolPrices, err := bactor.GetTxPoolGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	height := bactor.GetCurrentBlockHeight()
+	estimate := &GasPriceEstimate{
+		MinGasPrice: minGasPrice,
+		Height:      height,
+		PoolTxs:     uint32(len(poolPrices)),
+	}
+	blockPrices := make([]uint64, 0)
+	end := gasPriceSearchEnd(height)
+	for i := int64(height); i >= int64(end) && estimate.Blocks < ESTIMATE_GAS_PRICE_BLOCKS; i-- {
+		head, err := bactor.GetHeaderByHeight(uint32(i))
+		if err != nil || head.TransactionsRoot == common.UINT256_EMPTY {
+			continue
+		}
+		blk, err := bactor.GetBlockByHeight(uint32(i))
+		if err == scom.ErrPruned {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range blk.Transactions {
+			blockPrices = append(blockPrices, tx.GasPrice)
+		}
+		estimate.Blocks++
+	}
+	estimate.Txs = uint32(len(blockPrices))
+	maxTxInBlock := int(config.DefConfig.Consensus.MaxTxInBlock)
+	estimate.Low, estimate.Standard, estimate.Fast = SuggestGasPrices(blockPrices, poolPrices, minGasPrice, maxTxInBlock)
+	return estimate, nil
+}
+
+//gasPriceSearchEnd returns the lowest height searched for gas prices, the blocks below pruned height are skipped
+func gasPriceSearchEnd(height uint32) uint32 {
+	var end uint32
+	if height > MAX_SEARCH_HEIGHT {
+		end = height - MAX_SEARCH_HEIGHT
+	}
+	if pruned := bactor.GetPrunedHeight(); end < pruned {
+		end = pruned
+	}
+	return end
+}
+
+//SuggestGasPrices return low, standard and fast gas prices. The suggestions are percentiles of the gas prices
+//in recent blocks, not lower than the min gas price. When the pending transactions in pool are more than a block
+//can hold, standard one is raised to the price of the last transaction fitting in next block, and fast one above it.
+//poolPrices should be in descending order.
+func SuggestGasPrices(blockPrices, poolPrices []uint64, minGasPrice uint64, maxTxInBlock int) (uint64, uint64, uint64) {
+	sorted := make([]uint64, len(blockPrices))
+	copy(sorted, blockPrices)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	low := maxUint64(percentile(sorted, LOW_GAS_PRICE_PERCENTILE), minGasPrice)
+	standard := maxUint64(percentile(sorted, STD_GAS_PRICE_PERCENTILE), minGasPrice)
+	fast := maxUint64(percentile(sorted, FAST_GAS_PRICE_PERCENTILE), minGasPrice)
+
+	if maxTxInBlock > 0 && len(poolPrices) >= maxTxInBlock {
+		nextBlockPrice := poolPrices[maxTxInBlock-1]
+		standard = maxUint64(standard, nextBlockPrice)
+		if nextBlockPrice < ^uint64(0) {
+			fast = maxUint64(fast, nextBlockPrice+1)
+		}
+	}
+	low = minUint64(low, standard)
+	fast = maxUint64(fast, standard)
+	return low, standard, fast
+}
+
+//percentile return the value at the percentile of the ascending sorted values, 0 if values is empty
+func percentile(values []uint64, p int) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+	index := (len(values) - 1) * p / MAX_GAS_PRICE_PERCENTILE
+	return values[index]
+}
+
+func maxUint64(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+//EstimateGas pre-execute the transaction and return the gas consumed and a padded gas limit
+func EstimateGas(tx *types.Transaction) (*GasEstimate, error) {
+	if tx.TxType != types.InvokeNeo && tx.TxType != types.InvokeWasm && tx.TxType != types.Deploy {
+		return nil, fmt.Errorf("unsupported transaction type:%d", tx.TxType)
+	}
+	result, err := bactor.PreExecuteContract(tx)
+	if err != nil {
+		return nil, err
+	}
+	if result.State == 0 {
+		return nil, fmt.Errorf("pre-execute transaction failed")
+	}
+	gasLimit, overflow := common.SafeMul(result.Gas, 100+ESTIMATE_GAS_PADDING)
+	if overflow {
+		return nil, fmt.Errorf("gas %d overflow", result.Gas)
+	}
+	gasLimit = gasLimit / 100
+	if gasLimit < neovm.MIN_TRANSACTION_GAS {
+		gasLimit = neovm.MIN_TRANSACTION_GAS
+	}
+	return &GasEstimate{
+		State:    result.State,
+		Gas:      result.Gas,
+		GasLimit: gasLimit,
+	}, nil
+}
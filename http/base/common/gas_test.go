@@ -0,0 +1,46 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestGasPrices(t *testing.T) {
+	// no transaction in recent blocks
+	low, standard, fast := SuggestGasPrices(nil, nil, 500, 10)
+	assert.Equal(t, []uint64{500, 500, 500}, []uint64{low, standard, fast})
+
+	blockPrices := []uint64{2500, 500, 1000, 3000, 500, 1500, 2000, 500, 500, 500, 1000}
+	low, standard, fast = SuggestGasPrices(blockPrices, nil, 500, 10)
+	assert.Equal(t, []uint64{500, 1000, 2500}, []uint64{low, standard, fast})
+
+	// not lower than the min gas price of tx pool
+	low, standard, fast = SuggestGasPrices(blockPrices, nil, 1200, 10)
+	assert.Equal(t, []uint64{1200, 1200, 2500}, []uint64{low, standard, fast})
+
+	// tx pool holds more txs than a block
+	poolPrices := []uint64{5000, 4000, 3000, 600, 500}
+	low, standard, fast = SuggestGasPrices(blockPrices, poolPrices, 500, 3)
+	assert.Equal(t, []uint64{500, 3000, 3001}, []uint64{low, standard, fast})
+	low, standard, fast = SuggestGasPrices(blockPrices, poolPrices, 500, 6)
+	assert.Equal(t, []uint64{500, 1000, 2500}, []uint64{low, standard, fast})
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//GovernanceStorage return the storage value of key in governance contract, nil if the key doesn't exist
+type GovernanceStorage func(key []byte) ([]byte, error)
+
+//GovernanceStorageFinder call onItem with each storage item of governance contract whose key starts with prefix,
+//iteration stops when onItem return false
+type GovernanceStorageFinder func(prefix []byte, onItem func(key, value []byte) bool) error
+
+//QueryGovernanceView return the current view of governance contract
+func QueryGovernanceView(get GovernanceStorage) (*governance.GovernanceView, error) {
+	data, err := get([]byte(governance.GOVERNANCE_VIEW))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("governance view not found")
+	}
+	view := new(governance.GovernanceView)
+	if err := view.Deserialize(bytes.NewBuffer(data)); err != nil {
+		return nil, fmt.Errorf("GovernanceView.Deserialize error:%s", err)
+	}
+	return view, nil
+}
+
+//QueryPeerPoolMap return the peers of governance contract in view
+func QueryPeerPoolMap(get GovernanceStorage, view uint32) (*governance.PeerPoolMap, error) {
+	viewBytes, err := governance.GetUint32Bytes(view)
+	if err != nil {
+		return nil, err
+	}
+	data, err := get(append([]byte(governance.PEER_POOL), viewBytes...))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("peer pool of view %d not found", view)
+	}
+	peerPoolMap := new(governance.PeerPoolMap)
+	if err := peerPoolMap.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, fmt.Errorf("PeerPoolMap.Deserialization error:%s", err)
+	}
+	return peerPoolMap, nil
+}
+
+//QueryAuthorizeInfo return the pos authorized by address to peer, an empty info is returned if address doesn't
+//authorize to the peer
+func QueryAuthorizeInfo(get GovernanceStorage, peerPubkey string, addr common.Address) (*governance.AuthorizeInfo, error) {
+	peerPubkeyPrefix, err := hex.DecodeString(peerPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer pubkey:%s", peerPubkey)
+	}
+	data, err := get(bytes.Join([][]byte{governance.AUTHORIZE_INFO_POOL, peerPubkeyPrefix, addr[:]}, nil))
+	if err != nil {
+		return nil, err
+	}
+	info := &governance.AuthorizeInfo{
+		PeerPubkey: peerPubkey,
+		Address:    addr,
+	}
+	if data == nil {
+		return info, nil
+	}
+	if err := info.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, fmt.Errorf("AuthorizeInfo.Deserialization error:%s", err)
+	}
+	return info, nil
+}
+
+//QueryAuthorizeInfos return all the non-empty authorizations of address, including those to peers which have
+//quit the current view
+func QueryAuthorizeInfos(find GovernanceStorageFinder, addr common.Address) ([]*governance.AuthorizeInfo, error) {
+	prefix := governance.AUTHORIZE_INFO_POOL
+	infos := make([]*governance.AuthorizeInfo, 0)
+	var decodeErr error
+	err := find(prefix, func(key, value []byte) bool {
+		if len(key) <= len(prefix)+common.ADDR_LEN || !bytes.HasSuffix(key, addr[:]) {
+			return true
+		}
+		info := new(governance.AuthorizeInfo)
+		if err := info.Deserialization(common.NewZeroCopySource(value)); err != nil {
+			decodeErr = fmt.Errorf("AuthorizeInfo.Deserialization error:%s", err)
+			return false
+		}
+		if isEmptyAuthorizeInfo(info) {
+			return true
+		}
+		infos = append(infos, info)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return infos, nil
+}
+
+func isEmptyAuthorizeInfo(info *governance.AuthorizeInfo) bool {
+	return info.ConsensusPos == 0 && info.CandidatePos == 0 && info.NewPos == 0 &&
+		info.WithdrawConsensusPos == 0 && info.WithdrawCandidatePos == 0 && info.WithdrawUnfreezePos == 0
+}
+
+//QuerySplitFeeAddress return the split fee of address which can be withdrawn
+func QuerySplitFeeAddress(get GovernanceStorage, addr common.Address) (*governance.SplitFeeAddress, error) {
+	data, err := get(append([]byte(governance.SPLIT_FEE_ADDRESS), addr[:]...))
+	if err != nil {
+		return nil, err
+	}
+	splitFee := &governance.SplitFeeAddress{
+		Address: addr,
+	}
+	if data == nil {
+		return splitFee, nil
+	}
+	if err := splitFee.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, fmt.Errorf("SplitFeeAddress.Deserialization error:%s", err)
+	}
+	return splitFee, nil
+}
+
+//QueryTotalStake return the total stake of address in governance contract
+func QueryTotalStake(get GovernanceStorage, addr common.Address) (*governance.TotalStake, error) {
+	data, err := get(append([]byte(governance.TOTAL_STAKE), addr[:]...))
+	if err != nil {
+		return nil, err
+	}
+	totalStake := &governance.TotalStake{
+		Address: addr,
+	}
+	if data == nil {
+		return totalStake, nil
+	}
+	if err := totalStake.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, fmt.Errorf("TotalStake.Deserialization error:%s", err)
+	}
+	return totalStake, nil
+}
+
+//QueryPromisePos return the promise pos of peer
+func QueryPromisePos(get GovernanceStorage, peerPubkey string) (*governance.PromisePos, error) {
+	peerPubkeyPrefix, err := hex.DecodeString(peerPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer pubkey:%s", peerPubkey)
+	}
+	data, err := get(append([]byte(governance.PROMISE_POS), peerPubkeyPrefix...))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("promise pos of peer %s not found", peerPubkey)
+	}
+	promisePos := new(governance.PromisePos)
+	if err := promisePos.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, fmt.Errorf("PromisePos.Deserialization error:%s", err)
+	}
+	return promisePos, nil
+}
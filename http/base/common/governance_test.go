@@ -0,0 +1,172 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/constants"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type memGovernanceStorage map[string][]byte
+
+func (self memGovernanceStorage) get(key []byte) ([]byte, error) {
+	return self[string(key)], nil
+}
+
+func (self memGovernanceStorage) find(prefix []byte, onItem func(key, value []byte) bool) error {
+	keys := make([]string, 0, len(self))
+	for key := range self {
+		if strings.HasPrefix(key, string(prefix)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !onItem([]byte(key), self[key]) {
+			break
+		}
+	}
+	return nil
+}
+
+func (self memGovernanceStorage) putAuthorizeInfo(info *governance.AuthorizeInfo) {
+	peerPubkeyPrefix, _ := hex.DecodeString(info.PeerPubkey)
+	sink := common.NewZeroCopySink(nil)
+	info.Serialization(sink)
+	self[string(bytes.Join([][]byte{governance.AUTHORIZE_INFO_POOL, peerPubkeyPrefix, info.Address[:]}, nil))] = sink.Bytes()
+}
+
+const (
+	testPeer1 = "02a6e6e3b3d4b1e8c5b6a2a4d9f7e1c3b5a7d9e2f4a6c8e0b2d4f6a8c0e2b4d6f8"
+	testPeer2 = "03b7f7f4c4e5c2f9d6c7b3b5eaf8f2d4c6b8eaf3f5b7d9f1c3e5f7b9d1f3c5e7f9"
+)
+
+func TestQueryGovernanceView(t *testing.T) {
+	store := make(memGovernanceStorage)
+	_, err := QueryGovernanceView(store.get)
+	assert.NotNil(t, err)
+
+	view := &governance.GovernanceView{View: 3, Height: 100, TxHash: common.Uint256{1}}
+	buf := new(bytes.Buffer)
+	assert.Nil(t, view.Serialize(buf))
+	store[governance.GOVERNANCE_VIEW] = buf.Bytes()
+	got, err := QueryGovernanceView(store.get)
+	assert.Nil(t, err)
+	assert.Equal(t, view, got)
+}
+
+func TestQueryAuthorizeInfo(t *testing.T) {
+	store := make(memGovernanceStorage)
+	addr := common.Address{1}
+	info, err := QueryAuthorizeInfo(store.get, testPeer1, addr)
+	assert.Nil(t, err)
+	assert.Equal(t, &governance.AuthorizeInfo{PeerPubkey: testPeer1, Address: addr}, info)
+
+	_, err = QueryAuthorizeInfo(store.get, "not hex", addr)
+	assert.NotNil(t, err)
+
+	expect := &governance.AuthorizeInfo{PeerPubkey: testPeer1, Address: addr, ConsensusPos: 1000, NewPos: 200}
+	store.putAuthorizeInfo(expect)
+	info, err = QueryAuthorizeInfo(store.get, testPeer1, addr)
+	assert.Nil(t, err)
+	assert.Equal(t, expect, info)
+}
+
+func TestQueryAuthorizeInfos(t *testing.T) {
+	store := make(memGovernanceStorage)
+	addr := common.Address{1}
+	other := common.Address{2}
+	// peer1 may have quit the current view, its authorization should still be found
+	store.putAuthorizeInfo(&governance.AuthorizeInfo{PeerPubkey: testPeer1, Address: addr, WithdrawUnfreezePos: 500})
+	store.putAuthorizeInfo(&governance.AuthorizeInfo{PeerPubkey: testPeer2, Address: addr, CandidatePos: 300})
+	store.putAuthorizeInfo(&governance.AuthorizeInfo{PeerPubkey: testPeer1, Address: other, ConsensusPos: 100})
+	// the authorization fully withdrawn is skipped
+	store.putAuthorizeInfo(&governance.AuthorizeInfo{PeerPubkey: testPeer2, Address: other})
+
+	infos, err := QueryAuthorizeInfos(store.find, addr)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(infos))
+	assert.Equal(t, testPeer1, infos[0].PeerPubkey)
+	assert.Equal(t, uint64(500), infos[0].WithdrawUnfreezePos)
+	assert.Equal(t, testPeer2, infos[1].PeerPubkey)
+	assert.Equal(t, uint64(300), infos[1].CandidatePos)
+
+	infos, err = QueryAuthorizeInfos(store.find, other)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(infos))
+	assert.Equal(t, other, infos[0].Address)
+
+	infos, err = QueryAuthorizeInfos(store.find, common.Address{3})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(infos))
+}
+
+func TestQueryTotalStakeAndSplitFee(t *testing.T) {
+	store := make(memGovernanceStorage)
+	addr := common.Address{1}
+	totalStake, err := QueryTotalStake(store.get, addr)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), totalStake.Stake)
+	splitFee, err := QuerySplitFeeAddress(store.get, addr)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), splitFee.Amount)
+
+	sink := common.NewZeroCopySink(nil)
+	(&governance.TotalStake{Address: addr, Stake: 10000, TimeOffset: 86400}).Serialization(sink)
+	store[governance.TOTAL_STAKE+string(addr[:])] = sink.Bytes()
+	sink = common.NewZeroCopySink(nil)
+	(&governance.SplitFeeAddress{Address: addr, Amount: 123}).Serialization(sink)
+	store[governance.SPLIT_FEE_ADDRESS+string(addr[:])] = sink.Bytes()
+
+	totalStake, err = QueryTotalStake(store.get, addr)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10000), totalStake.Stake)
+	assert.Equal(t, uint32(86400), totalStake.TimeOffset)
+	splitFee, err = QuerySplitFeeAddress(store.get, addr)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(123), splitFee.Amount)
+}
+
+func TestNewPendingRewards(t *testing.T) {
+	addr := common.Address{1}
+	splitFee := &governance.SplitFeeAddress{Address: addr, Amount: 123}
+	totalStake := &governance.TotalStake{Address: addr, Stake: 10000, TimeOffset: 86400}
+
+	// nothing unbound when claimed in the same block
+	rewards := newPendingRewards(addr, splitFee, totalStake, constants.GENESIS_BLOCK_TIMESTAMP+86400)
+	assert.Equal(t, uint64(0), rewards.UnboundOng)
+	assert.Equal(t, uint64(123), rewards.Total)
+
+	timestamp := constants.GENESIS_BLOCK_TIMESTAMP + 2*86400
+	rewards = newPendingRewards(addr, splitFee, totalStake, timestamp)
+	unbound := utils.CalcUnbindOng(10000, 86400, 2*86400)
+	assert.True(t, unbound > 0)
+	assert.Equal(t, addr.ToBase58(), rewards.Address)
+	assert.Equal(t, unbound, rewards.UnboundOng)
+	assert.Equal(t, 123+unbound, rewards.Total)
+}
@@ -56,6 +56,16 @@ var (
 	defaultProtocol = "http"
 )
 
+//SetIPFSHost set the host:port of ipfs daemon api
+func SetIPFSHost(host string) {
+	defaultIPFSHost = host
+}
+
+//SetClusterHost set the host:port of ipfs cluster rest api
+func SetClusterHost(host string) {
+	defaultClusterHost = host
+}
+
 func (r *httpResponseReader) Read(b []byte) (int, error) {
 	n, err := r.resp.Body.Read(b)
 
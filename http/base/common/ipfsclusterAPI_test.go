@@ -21,8 +21,12 @@ package common
 import (
 	"fmt"
 	"github.com/dad-go/common/log"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 )
 
@@ -48,3 +52,70 @@ func TestGetFileIPFS(t *testing.T) {
 	}
 	//os.Remove("testOut")
 }
+
+func TestAddFileIPFSCluster(t *testing.T) {
+	const ref = "QmVHzLjYvp4bposJDD2PNeJ9PAFixyQu3oFj6gqipgsukX"
+	ipfs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v0/add" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"Message":"%s"}`, err)
+			return
+		}
+		data, _ := ioutil.ReadAll(file)
+		fmt.Fprintf(w, `{"Name":"test","Hash":"%s","Size":"%d"}`, ref, len(data))
+	}))
+	defer ipfs.Close()
+	pinned := make(map[string]bool)
+	cluster := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cid := strings.TrimPrefix(r.URL.Path, "/pins/")
+		if r.Method != "POST" || cid == ref+"-fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"Message":"pin error"}`)
+			return
+		}
+		pinned[cid] = true
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer cluster.Close()
+
+	SetIPFSHost(strings.TrimPrefix(ipfs.URL, "http://"))
+	SetClusterHost(strings.TrimPrefix(cluster.URL, "http://"))
+	defer SetIPFSHost(fmt.Sprintf("127.0.0.1:%d", 5001))
+	defer SetClusterHost(fmt.Sprintf("127.0.0.1:%d", 9094))
+
+	file, err := ioutil.TempFile("", "ipfs")
+	if err != nil {
+		t.Fatalf("TempFile error:%s", err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString("dad-go content registry")
+	file.Close()
+
+	got, err := AddFileIPFS(file.Name(), false)
+	if err != nil {
+		t.Fatalf("AddFileIPFS error:%s", err)
+	}
+	if got != ref || pinned[ref] {
+		t.Fatalf("AddFileIPFS without cluster ref:%s pinned:%v", got, pinned[ref])
+	}
+	got, err = AddFileIPFS(file.Name(), true)
+	if err != nil {
+		t.Fatalf("AddFileIPFS error:%s", err)
+	}
+	if got != ref || !pinned[ref] {
+		t.Fatalf("AddFileIPFS with cluster ref:%s pinned:%v", got, pinned[ref])
+	}
+
+	resp, err := requestCluster("POST", "/pins/"+ref+"-fail", nil)
+	if err != nil {
+		t.Fatalf("requestCluster error:%s", err)
+	}
+	if err = formatClusterResponse(resp); err == nil || !strings.Contains(err.Error(), "pin error") {
+		t.Fatalf("formatClusterResponse expect pin error, got:%v", err)
+	}
+}
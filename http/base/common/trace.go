@@ -0,0 +1,65 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/ontio/ontology/core/types"
+	bactor "github.com/ontio/ontology/http/base/actor"
+	vm "github.com/ontio/ontology/vm/neovm"
+)
+
+type TransactionTrace struct {
+	State     byte
+	Gas       uint64
+	Result    interface{}
+	Error     string // the execution error, empty if the transaction succeed
+	Notify    []NotifyEventInfo
+	GasUsed   uint64 // the gas charged by the traced neovm steps
+	Steps     []*vm.StepLog
+	Truncated bool // true if the steps exceed the limits of tracer and the later ones are not logged
+}
+
+//TraceTransaction pre-execute the neovm transaction on current state and return the trace of every executed step.
+//The trace is returned even if the execution failed, so the failing step can be located.
+func TraceTransaction(tx *types.Transaction) (*TransactionTrace, error) {
+	if tx.TxType != types.InvokeNeo {
+		return nil, fmt.Errorf("unsupported transaction type:%d", tx.TxType)
+	}
+	logger := vm.NewStructLogger()
+	result, err := bactor.PreExecuteContractWithTracer(tx, logger)
+	if result == nil {
+		return nil, err
+	}
+	res := ConvertPreExecuteResult(result)
+	trace := &TransactionTrace{
+		State:     res.State,
+		Gas:       res.Gas,
+		Result:    res.Result,
+		Notify:    res.Notify,
+		GasUsed:   logger.GasUsed(),
+		Steps:     logger.Steps,
+		Truncated: logger.Truncated,
+	}
+	if err != nil {
+		trace.Error = err.Error()
+	}
+	return trace, nil
+}
@@ -39,6 +39,9 @@ const (
 	UNKNOWN_ASSET       int64 = 44002
 	UNKNOWN_BLOCK       int64 = 44003
 	UNKNOWN_CONTRACT    int64 = 44004
+	UNKNOWN_CONTENT     int64 = 44005
+	DATA_PRUNED         int64 = 44006
+	UNKNOWN_ABI         int64 = 44007
 
 	INTERNAL_ERROR  int64 = 45001
 	SMARTCODE_ERROR int64 = 47001
@@ -63,6 +66,9 @@ var ErrMap = map[int64]string{
 	UNKNOWN_ASSET:       "UNKNOWN ASSET",
 	UNKNOWN_BLOCK:       "UNKNOWN BLOCK",
 	UNKNOWN_CONTRACT:    "UNKNOWN CONTRACT",
+	UNKNOWN_CONTENT:     "UNKNOWN CONTENT",
+	DATA_PRUNED:         "DATA PRUNED",
+	UNKNOWN_ABI:         "UNKNOWN ABI",
 
 	INTERNAL_ERROR:                           "INTERNAL ERROR",
 	SMARTCODE_ERROR:                          "SMARTCODE EXEC ERROR",
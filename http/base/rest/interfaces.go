@@ -39,9 +39,16 @@ type ApiServer interface {
 	Stop()
 }
 
-// get node verison
+// get node verison, with the pruned height if verbose is 1
 func GetNodeVersion(cmd map[string]interface{}) map[string]interface{} {
 	resp := ResponsePack(berr.SUCCESS)
+	if verbose, ok := cmd["Verbose"].(string); ok && verbose == "1" {
+		resp["Result"] = bcomn.NodeVersionInfo{
+			Version:      config.Version,
+			PrunedHeight: bactor.GetPrunedHeight(),
+		}
+		return resp
+	}
 	resp["Result"] = config.Version
 	return resp
 }
@@ -103,6 +110,9 @@ func GetBlockHash(cmd map[string]interface{}) map[string]interface{} {
 
 func getBlock(hash common.Uint256, getTxBytes bool) (interface{}, int64) {
 	block, err := bactor.GetBlockFromStore(hash)
+	if err == scom.ErrPruned {
+		return nil, berr.DATA_PRUNED
+	}
 	if err != nil {
 		return nil, berr.UNKNOWN_BLOCK
 	}
@@ -150,6 +160,11 @@ func GetBlockHeightByTxHash(cmd map[string]interface{}) map[string]interface{} {
 		return ResponsePack(berr.INVALID_PARAMS)
 	}
 	height, tx, err := bactor.GetTxnWithHeightByTxHash(hash)
+	//the height of pruned transaction is kept
+	if err == scom.ErrPruned {
+		resp["Result"] = height
+		return resp
+	}
 	if err != nil {
 		return ResponsePack(berr.INTERNAL_ERROR)
 	}
@@ -178,6 +193,9 @@ func GetBlockTxsByHeight(cmd map[string]interface{}) map[string]interface{} {
 		return ResponsePack(berr.INVALID_PARAMS)
 	}
 	block, err := bactor.GetBlockFromStore(hash)
+	if err == scom.ErrPruned {
+		return ResponsePack(berr.DATA_PRUNED)
+	}
 	if err != nil {
 		return ResponsePack(berr.UNKNOWN_BLOCK)
 	}
@@ -206,6 +224,9 @@ func GetBlockByHeight(cmd map[string]interface{}) map[string]interface{} {
 	}
 	index := uint32(height)
 	block, err := bactor.GetBlockByHeight(index)
+	if err == scom.ErrPruned {
+		return ResponsePack(berr.DATA_PRUNED)
+	}
 	if err != nil || block == nil {
 		return ResponsePack(berr.UNKNOWN_BLOCK)
 	}
@@ -230,6 +251,9 @@ func GetTransactionByHash(cmd map[string]interface{}) map[string]interface{} {
 		return ResponsePack(berr.INVALID_PARAMS)
 	}
 	height, tx, err := bactor.GetTxnWithHeightByTxHash(hash)
+	if err == scom.ErrPruned {
+		return ResponsePack(berr.DATA_PRUNED)
+	}
 	if tx == nil {
 		return ResponsePack(berr.UNKNOWN_TRANSACTION)
 	}
@@ -315,11 +339,18 @@ func GetSmartCodeEventTxsByHeight(cmd map[string]interface{}) map[string]interfa
 		if scom.ErrNotFound == err {
 			return ResponsePack(berr.SUCCESS)
 		}
+		if scom.ErrPruned == err {
+			return ResponsePack(berr.DATA_PRUNED)
+		}
 		return ResponsePack(berr.INTERNAL_ERROR)
 	}
+	decoder := getNotifyDecoder(cmd)
 	eInfos := make([]*bcomn.ExecuteNotify, 0, len(eventInfos))
 	for _, eventInfo := range eventInfos {
 		_, notify := bcomn.GetExecuteNotify(eventInfo)
+		if decoder != nil {
+			decoder.DecodeExecuteNotify(&notify)
+		}
 		eInfos = append(eInfos, &notify)
 	}
 	resp["Result"] = eInfos
@@ -347,16 +378,30 @@ func GetSmartCodeEventByTxHash(cmd map[string]interface{}) map[string]interface{
 		if scom.ErrNotFound == err {
 			return ResponsePack(berr.SUCCESS)
 		}
+		if scom.ErrPruned == err {
+			return ResponsePack(berr.DATA_PRUNED)
+		}
 		return ResponsePack(berr.INTERNAL_ERROR)
 	}
 	if eventInfo == nil {
 		return ResponsePack(berr.INVALID_TRANSACTION)
 	}
 	_, notify := bcomn.GetExecuteNotify(eventInfo)
+	if decoder := getNotifyDecoder(cmd); decoder != nil {
+		decoder.DecodeExecuteNotify(&notify)
+	}
 	resp["Result"] = notify
 	return resp
 }
 
+//getNotifyDecoder return a decoder if the notifications are requested to be decoded by the abi registered on chain
+func getNotifyDecoder(cmd map[string]interface{}) *bcomn.NotifyDecoder {
+	if decode, ok := cmd["Decode"].(string); ok && decode == "1" {
+		return bcomn.NewNotifyDecoder()
+	}
+	return nil
+}
+
 //get contract state
 func GetContractState(cmd map[string]interface{}) map[string]interface{} {
 	resp := ResponsePack(berr.SUCCESS)
@@ -412,6 +457,42 @@ func GetStorage(cmd map[string]interface{}) map[string]interface{} {
 	return resp
 }
 
+//get storage proof of contract at block height, height defaults to current block height
+func GetStorageProof(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	str, ok := cmd["Hash"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	address, err := bcomn.GetAddress(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	str, ok = cmd["Key"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	key, err := common.HexToBytes(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	height := bactor.GetCurrentBlockHeight()
+	if str, ok := cmd["Height"].(string); ok && str != "" {
+		h, err := strconv.ParseUint(str, 10, 32)
+		if err != nil {
+			return ResponsePack(berr.INVALID_PARAMS)
+		}
+		height = uint32(h)
+	}
+	proof, err := bactor.GetStorageProof(address, key, height)
+	if err != nil {
+		log.Errorf("GetStorageProof, bactor.GetStorageProof error:%s", err)
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp["Result"] = bcomn.GetStorageProofInfo(proof)
+	return resp
+}
+
 //get balance of address
 func GetBalance(cmd map[string]interface{}) map[string]interface{} {
 	resp := ResponsePack(berr.SUCCESS)
@@ -443,10 +524,10 @@ func GetMerkleProof(cmd map[string]interface{}) map[string]interface{} {
 		return ResponsePack(berr.INVALID_PARAMS)
 	}
 	height, tx, err := bactor.GetTxnWithHeightByTxHash(hash)
-	if err != nil {
+	if err != nil && err != scom.ErrPruned {
 		return ResponsePack(berr.INVALID_PARAMS)
 	}
-	if tx == nil {
+	if tx == nil && err == nil {
 		return ResponsePack(berr.INVALID_PARAMS)
 	}
 	header, err := bactor.GetHeaderByHeight(height)
@@ -483,6 +564,43 @@ func GetGasPrice(cmd map[string]interface{}) map[string]interface{} {
 	return resp
 }
 
+//estimate gas prices by recent blocks and tx pool
+func EstimateGasPrice(cmd map[string]interface{}) map[string]interface{} {
+	result, err := bcomn.EstimateGasPrice()
+	if err != nil {
+		log.Errorf("EstimateGasPrice error:%s", err)
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp := ResponsePack(berr.SUCCESS)
+	resp["Result"] = result
+	return resp
+}
+
+//estimate gas limit of raw transaction by pre-execution
+func EstimateGas(cmd map[string]interface{}) map[string]interface{} {
+	str, ok := cmd["Data"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	bys, err := common.HexToBytes(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	txn, err := types.TransactionFromRawBytes(bys)
+	if err != nil {
+		return ResponsePack(berr.INVALID_TRANSACTION)
+	}
+	result, err := bcomn.EstimateGas(txn)
+	if err != nil {
+		resp := ResponsePack(berr.SMARTCODE_ERROR)
+		resp["Result"] = err.Error()
+		return resp
+	}
+	resp := ResponsePack(berr.SUCCESS)
+	resp["Result"] = result
+	return resp
+}
+
 //get allowance
 func GetAllowance(cmd map[string]interface{}) map[string]interface{} {
 	resp := ResponsePack(berr.SUCCESS)
@@ -565,6 +683,75 @@ func GetMemPoolTxCount(cmd map[string]interface{}) map[string]interface{} {
 	return resp
 }
 
+//get a page of the transactions in memory pool
+func GetMemPoolTxs(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	var payer *common.Address
+	if str, ok := cmd["Payer"].(string); ok && str != "" {
+		address, err := bcomn.GetAddress(str)
+		if err != nil {
+			return ResponsePack(berr.INVALID_PARAMS)
+		}
+		payer = &address
+	}
+	var minGasPrice uint64
+	if str, ok := cmd["MinGasPrice"].(string); ok && str != "" {
+		price, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return ResponsePack(berr.INVALID_PARAMS)
+		}
+		minGasPrice = price
+	}
+	var page [2]uint32
+	for i, name := range []string{"Offset", "Limit"} {
+		if str, ok := cmd[name].(string); ok && str != "" {
+			num, err := strconv.ParseUint(str, 10, 32)
+			if err != nil {
+				return ResponsePack(berr.INVALID_PARAMS)
+			}
+			page[i] = uint32(num)
+		}
+	}
+	info, err := bcomn.GetMemPoolTxs(payer, minGasPrice, page[0], page[1])
+	if err != nil {
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp["Result"] = info
+	return resp
+}
+
+//get transactions related to an address
+func GetAddressTxs(cmd map[string]interface{}) map[string]interface{} {
+	if !config.DefConfig.Common.EnableAddressIndex {
+		return ResponsePack(berr.INVALID_METHOD)
+	}
+	resp := ResponsePack(berr.SUCCESS)
+	str, ok := cmd["Addr"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	address, err := bcomn.GetAddress(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	var nums [4]uint32
+	for i, name := range []string{"StartHeight", "EndHeight", "Offset", "Limit"} {
+		if str, ok := cmd[name].(string); ok && str != "" {
+			num, err := strconv.ParseUint(str, 10, 32)
+			if err != nil {
+				return ResponsePack(berr.INVALID_PARAMS)
+			}
+			nums[i] = uint32(num)
+		}
+	}
+	info, err := bcomn.GetAddressTxs(address, nums[0], nums[1], nums[2], nums[3])
+	if err != nil {
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp["Result"] = info
+	return resp
+}
+
 //get memory poll transaction state
 func GetMemPoolTxState(cmd map[string]interface{}) map[string]interface{} {
 	resp := ResponsePack(berr.SUCCESS)
@@ -576,14 +763,210 @@ func GetMemPoolTxState(cmd map[string]interface{}) map[string]interface{} {
 	if err != nil {
 		return ResponsePack(berr.INVALID_PARAMS)
 	}
-	txEntry, err := bactor.GetTxFromPool(hash)
+	txEntry, state, err := bactor.GetTxFromPool(hash)
+	if err != nil {
+		return ResponsePack(berr.UNKNOWN_TRANSACTION)
+	}
+	resp["Result"] = bcomn.GetTXNEntryInfo(txEntry, state)
+	return resp
+}
+
+//get oracle request by the hash of transaction creating it
+func GetOracleRequest(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	str, ok := cmd["Hash"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	hash, err := common.Uint256FromHexString(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	request, err := bcomn.GetOracleRequest(hash)
 	if err != nil {
 		return ResponsePack(berr.UNKNOWN_TRANSACTION)
 	}
-	attrs := []bcomn.TXNAttrInfo{}
-	for _, t := range txEntry.Attrs {
-		attrs = append(attrs, bcomn.TXNAttrInfo{t.Height, int(t.Type), int(t.ErrCode)})
+	resp["Result"] = request
+	return resp
+}
+
+//list oracle requests waiting for outcomes
+func ListPendingOracleRequests(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	requests, err := bcomn.GetPendingOracleRequests()
+	if err != nil {
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp["Result"] = requests
+	return resp
+}
+
+//get outcomes of oracle request by the hash of transaction creating it
+func GetOracleOutcome(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	str, ok := cmd["Hash"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	hash, err := common.Uint256FromHexString(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	outcome, err := bcomn.GetOracleOutcome(hash)
+	if err != nil {
+		return ResponsePack(berr.UNKNOWN_TRANSACTION)
+	}
+	resp["Result"] = outcome
+	return resp
+}
+
+//get the fee split curve in effect and the one scheduled by governance
+func GetFeeSplitCurve(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	info, err := bcomn.GetFeeSplitCurve()
+	if err != nil {
+		log.Errorf("GetFeeSplitCurve error:%s", err)
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp["Result"] = info
+	return resp
+}
+
+//get the current view of governance contract
+func GetGovernanceView(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	view, err := bcomn.GetGovernanceView()
+	if err != nil {
+		log.Errorf("GetGovernanceView error:%s", err)
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp["Result"] = view
+	return resp
+}
+
+//get the consensus peers of current view, or the peer of the public key
+func GetPeerPool(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	peerPubkey, _ := cmd["PeerPubkey"].(string)
+	peers, err := bcomn.GetPeerPool(peerPubkey)
+	if err != nil {
+		log.Errorf("GetPeerPool error:%s", err)
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp["Result"] = peers
+	return resp
+}
+
+//get the pos authorized by address to peer
+func GetAuthorizeInfo(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	peerPubkey, ok := cmd["PeerPubkey"].(string)
+	if !ok || len(peerPubkey) == 0 {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	str, ok := cmd["Addr"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	address, err := common.AddressFromBase58(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	info, err := bcomn.GetAuthorizeInfo(peerPubkey, address)
+	if err != nil {
+		log.Errorf("GetAuthorizeInfo error:%s", err)
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	resp["Result"] = info
+	return resp
+}
+
+//get the total stake of address and its authorizations to the peers of current view
+func GetStakeStatus(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	str, ok := cmd["Addr"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	address, err := common.AddressFromBase58(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	status, err := bcomn.GetStakeStatus(address)
+	if err != nil {
+		log.Errorf("GetStakeStatus error:%s", err)
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp["Result"] = status
+	return resp
+}
+
+//get the ong address can claim from governance contract
+func GetPendingRewards(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	str, ok := cmd["Addr"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	address, err := common.AddressFromBase58(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	rewards, err := bcomn.GetPendingRewards(address)
+	if err != nil {
+		log.Errorf("GetPendingRewards error:%s", err)
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	resp["Result"] = rewards
+	return resp
+}
+
+//get the abi of contract registered in abi registry, the latest version if version is omitted or 0
+func GetContractAbi(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	str, ok := cmd["Hash"].(string)
+	if !ok {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	address, err := bcomn.GetAddress(str)
+	if err != nil {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	var version uint32
+	if str, ok := cmd["Version"].(string); ok && len(str) > 0 {
+		v, err := strconv.ParseUint(str, 10, 32)
+		if err != nil {
+			return ResponsePack(berr.INVALID_PARAMS)
+		}
+		version = uint32(v)
+	}
+	record, err := bcomn.GetContractAbi(address, version)
+	if err != nil {
+		log.Errorf("GetContractAbi error:%s", err)
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	if record == nil {
+		return ResponsePack(berr.UNKNOWN_ABI)
+	}
+	resp["Result"] = record
+	return resp
+}
+
+//resolve the on-chain record of content by its ipfs cid
+func GetContent(cmd map[string]interface{}) map[string]interface{} {
+	resp := ResponsePack(berr.SUCCESS)
+	cid, ok := cmd["Cid"].(string)
+	if !ok || len(cid) == 0 {
+		return ResponsePack(berr.INVALID_PARAMS)
+	}
+	record, err := bcomn.GetContentRecord(cid)
+	if err != nil {
+		log.Errorf("GetContent error:%s", err)
+		return ResponsePack(berr.INTERNAL_ERROR)
+	}
+	if record == nil {
+		return ResponsePack(berr.UNKNOWN_CONTENT)
 	}
-	resp["Result"] = bcomn.TXNEntryInfo{attrs}
+	resp["Result"] = record
 	return resp
 }
@@ -20,6 +20,9 @@ package rpc
 
 import (
 	"encoding/hex"
+	"fmt"
+	"math"
+
 	"github.com/ontio/ontology/common"
 	"github.com/ontio/ontology/common/config"
 	"github.com/ontio/ontology/common/log"
@@ -68,6 +71,9 @@ func GetBlock(params []interface{}) map[string]interface{} {
 		return responsePack(berr.INVALID_PARAMS, "")
 	}
 	block, err := bactor.GetBlockFromStore(hash)
+	if err == scom.ErrPruned {
+		return responsePack(berr.DATA_PRUNED, "block is pruned")
+	}
 	if err != nil {
 		return responsePack(berr.UNKNOWN_BLOCK, "unknown block")
 	}
@@ -153,6 +159,75 @@ func GetMemPoolTxCount(params []interface{}) map[string]interface{} {
 	return responseSuccess(count)
 }
 
+//get a page of the transactions in memory pool, all params are optional
+//   {"jsonrpc": "2.0", "method": "getmempooltxs", "params": ["payer address", min gas price, offset, limit], "id": 0}
+func GetMemPoolTxs(params []interface{}) map[string]interface{} {
+	var payer *common.Address
+	if len(params) >= 1 {
+		str, ok := params[0].(string)
+		if !ok {
+			return responsePack(berr.INVALID_PARAMS, "")
+		}
+		if str != "" {
+			address, err := bcomn.GetAddress(str)
+			if err != nil {
+				return responsePack(berr.INVALID_PARAMS, "")
+			}
+			payer = &address
+		}
+	}
+	var nums [3]uint64
+	for i := 1; i < len(params) && i <= len(nums); i++ {
+		num, ok := params[i].(float64)
+		if !ok || num < 0 {
+			return responsePack(berr.INVALID_PARAMS, "")
+		}
+		nums[i-1] = uint64(num)
+	}
+	if nums[1] > math.MaxUint32 || nums[2] > math.MaxUint32 {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	info, err := bcomn.GetMemPoolTxs(payer, nums[0], uint32(nums[1]), uint32(nums[2]))
+	if err != nil {
+		return responsePack(berr.INTERNAL_ERROR, nil)
+	}
+	return responseSuccess(info)
+}
+
+//get transactions related to an address
+// A JSON example for getaddresstxs method as following:
+//   {"jsonrpc": "2.0", "method": "getaddresstxs", "params": ["address", startHeight, endHeight, offset, limit], "id": 0}
+func GetAddressTxs(params []interface{}) map[string]interface{} {
+	if !config.DefConfig.Common.EnableAddressIndex {
+		return responsePack(berr.INVALID_METHOD, "")
+	}
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, nil)
+	}
+	str, ok := params[0].(string)
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	address, err := bcomn.GetAddress(str)
+	if err != nil {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	var nums [4]uint32
+	for i := 1; i < len(params) && i <= len(nums); i++ {
+		num, ok := params[i].(float64)
+		if !ok || num < 0 || num > math.MaxUint32 {
+			return responsePack(berr.INVALID_PARAMS, "")
+		}
+		nums[i-1] = uint32(num)
+	}
+	info, err := bcomn.GetAddressTxs(address, nums[0], nums[1], nums[2], nums[3])
+	if err != nil {
+		log.Errorf("GetAddressTxs error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	return responseSuccess(info)
+}
+
 //get memory pool transaction state
 func GetMemPoolTxState(params []interface{}) map[string]interface{} {
 	if len(params) < 1 {
@@ -165,16 +240,11 @@ func GetMemPoolTxState(params []interface{}) map[string]interface{} {
 		if err != nil {
 			return responsePack(berr.INVALID_PARAMS, "")
 		}
-		txEntry, err := bactor.GetTxFromPool(hash)
+		txEntry, state, err := bactor.GetTxFromPool(hash)
 		if err != nil {
 			return responsePack(berr.UNKNOWN_TRANSACTION, "unknown transaction")
 		}
-		attrs := []bcomn.TXNAttrInfo{}
-		for _, t := range txEntry.Attrs {
-			attrs = append(attrs, bcomn.TXNAttrInfo{t.Height, int(t.Type), int(t.ErrCode)})
-		}
-		info := bcomn.TXNEntryInfo{attrs}
-		return responseSuccess(info)
+		return responseSuccess(bcomn.GetTXNEntryInfo(txEntry, state))
 	default:
 		return responsePack(berr.INVALID_PARAMS, "")
 	}
@@ -197,6 +267,9 @@ func GetRawTransaction(params []interface{}) map[string]interface{} {
 			return responsePack(berr.INVALID_PARAMS, "")
 		}
 		h, t, err := bactor.GetTxnWithHeightByTxHash(hash)
+		if err == scom.ErrPruned {
+			return responsePack(berr.DATA_PRUNED, "transaction is pruned")
+		}
 		if err != nil {
 			return responsePack(berr.UNKNOWN_TRANSACTION, "unknown transaction")
 		}
@@ -265,6 +338,264 @@ func GetStorage(params []interface{}) map[string]interface{} {
 	return responseSuccess(common.ToHexString(value))
 }
 
+//get storage proof of contract at block height, height defaults to current block height
+//   {"jsonrpc": "2.0", "method": "getstorageproof", "params": ["code hash", "key", height], "id": 0}
+func GetStorageProof(params []interface{}) map[string]interface{} {
+	if len(params) < 2 {
+		return responsePack(berr.INVALID_PARAMS, nil)
+	}
+	str, ok := params[0].(string)
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	address, err := bcomn.GetAddress(str)
+	if err != nil {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	str, ok = params[1].(string)
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	key, err := hex.DecodeString(str)
+	if err != nil {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	height := bactor.GetCurrentBlockHeight()
+	if len(params) >= 3 {
+		h, ok := params[2].(float64)
+		if !ok {
+			return responsePack(berr.INVALID_PARAMS, "")
+		}
+		height = uint32(h)
+	}
+	proof, err := bactor.GetStorageProof(address, key, height)
+	if err != nil {
+		log.Errorf("GetStorageProof, bactor.GetStorageProof error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	return responseSuccess(bcomn.GetStorageProofInfo(proof))
+}
+
+//get oracle request by the hash of transaction creating it
+//   {"jsonrpc": "2.0", "method": "getoraclerequest", "params": ["tx hash"], "id": 0}
+func GetOracleRequest(params []interface{}) map[string]interface{} {
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, nil)
+	}
+	str, ok := params[0].(string)
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	hash, err := common.Uint256FromHexString(str)
+	if err != nil {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	request, err := bcomn.GetOracleRequest(hash)
+	if err != nil {
+		log.Errorf("GetOracleRequest error:%s", err)
+		return responsePack(berr.UNKNOWN_TRANSACTION, "")
+	}
+	return responseSuccess(request)
+}
+
+//list oracle requests waiting for outcomes
+//   {"jsonrpc": "2.0", "method": "listpendingoraclerequests", "params": [], "id": 0}
+func ListPendingOracleRequests(params []interface{}) map[string]interface{} {
+	requests, err := bcomn.GetPendingOracleRequests()
+	if err != nil {
+		log.Errorf("ListPendingOracleRequests error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	return responseSuccess(requests)
+}
+
+//get outcomes of oracle request by the hash of transaction creating it
+//   {"jsonrpc": "2.0", "method": "getoracleoutcome", "params": ["tx hash"], "id": 0}
+func GetOracleOutcome(params []interface{}) map[string]interface{} {
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, nil)
+	}
+	str, ok := params[0].(string)
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	hash, err := common.Uint256FromHexString(str)
+	if err != nil {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	outcome, err := bcomn.GetOracleOutcome(hash)
+	if err != nil {
+		log.Errorf("GetOracleOutcome error:%s", err)
+		return responsePack(berr.UNKNOWN_TRANSACTION, "")
+	}
+	return responseSuccess(outcome)
+}
+
+//get the fee split curve in effect and the one scheduled by governance
+//   {"jsonrpc": "2.0", "method": "getfeesplitcurve", "params": [], "id": 0}
+func GetFeeSplitCurve(params []interface{}) map[string]interface{} {
+	info, err := bcomn.GetFeeSplitCurve()
+	if err != nil {
+		log.Errorf("GetFeeSplitCurve error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	return responseSuccess(info)
+}
+
+//resolve the on-chain record of content by its ipfs cid
+//   {"jsonrpc": "2.0", "method": "getcontent", "params": ["cid"], "id": 0}
+func GetContent(params []interface{}) map[string]interface{} {
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, nil)
+	}
+	cid, ok := params[0].(string)
+	if !ok || len(cid) == 0 {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	record, err := bcomn.GetContentRecord(cid)
+	if err != nil {
+		log.Errorf("GetContent error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	if record == nil {
+		return responsePack(berr.UNKNOWN_CONTENT, "")
+	}
+	return responseSuccess(record)
+}
+
+//get the abi of contract registered in abi registry, the latest version if version is omitted or 0
+//   {"jsonrpc": "2.0", "method": "getcontractabi", "params": ["contract address", version], "id": 0}
+func GetContractAbi(params []interface{}) map[string]interface{} {
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, nil)
+	}
+	str, ok := params[0].(string)
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	address, err := bcomn.GetAddress(str)
+	if err != nil {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	var version uint32
+	if len(params) >= 2 {
+		v, ok := params[1].(float64)
+		if !ok || v < 0 {
+			return responsePack(berr.INVALID_PARAMS, "")
+		}
+		version = uint32(v)
+	}
+	record, err := bcomn.GetContractAbi(address, version)
+	if err != nil {
+		log.Errorf("GetContractAbi error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	if record == nil {
+		return responsePack(berr.UNKNOWN_ABI, "")
+	}
+	return responseSuccess(record)
+}
+
+//get the current view of governance contract
+//   {"jsonrpc": "2.0", "method": "getgovernanceview", "params": [], "id": 0}
+func GetGovernanceView(params []interface{}) map[string]interface{} {
+	view, err := bcomn.GetGovernanceView()
+	if err != nil {
+		log.Errorf("GetGovernanceView error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	return responseSuccess(view)
+}
+
+//get the consensus peers of current view, or the peer of the public key
+//   {"jsonrpc": "2.0", "method": "getpeerpool", "params": ["peer pubkey"], "id": 0}
+func GetPeerPool(params []interface{}) map[string]interface{} {
+	var peerPubkey string
+	if len(params) >= 1 {
+		str, ok := params[0].(string)
+		if !ok {
+			return responsePack(berr.INVALID_PARAMS, "")
+		}
+		peerPubkey = str
+	}
+	peers, err := bcomn.GetPeerPool(peerPubkey)
+	if err != nil {
+		log.Errorf("GetPeerPool error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	return responseSuccess(peers)
+}
+
+//get the pos authorized by address to peer
+//   {"jsonrpc": "2.0", "method": "getauthorizeinfo", "params": ["peer pubkey", "address"], "id": 0}
+func GetAuthorizeInfo(params []interface{}) map[string]interface{} {
+	if len(params) < 2 {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	peerPubkey, ok := params[0].(string)
+	if !ok || len(peerPubkey) == 0 {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	addr, ok := parseAddressParam(params[1])
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	info, err := bcomn.GetAuthorizeInfo(peerPubkey, addr)
+	if err != nil {
+		log.Errorf("GetAuthorizeInfo error:%s", err)
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	return responseSuccess(info)
+}
+
+//get the total stake of address and its authorizations to the peers of current view
+//   {"jsonrpc": "2.0", "method": "getstakestatus", "params": ["address"], "id": 0}
+func GetStakeStatus(params []interface{}) map[string]interface{} {
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	addr, ok := parseAddressParam(params[0])
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	status, err := bcomn.GetStakeStatus(addr)
+	if err != nil {
+		log.Errorf("GetStakeStatus error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	return responseSuccess(status)
+}
+
+//get the ong address can claim from governance contract
+//   {"jsonrpc": "2.0", "method": "getpendingrewards", "params": ["address"], "id": 0}
+func GetPendingRewards(params []interface{}) map[string]interface{} {
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	addr, ok := parseAddressParam(params[0])
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	rewards, err := bcomn.GetPendingRewards(addr)
+	if err != nil {
+		log.Errorf("GetPendingRewards error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	return responseSuccess(rewards)
+}
+
+func parseAddressParam(param interface{}) (common.Address, bool) {
+	str, ok := param.(string)
+	if !ok {
+		return common.ADDRESS_EMPTY, false
+	}
+	addr, err := common.AddressFromBase58(str)
+	if err != nil {
+		return common.ADDRESS_EMPTY, false
+	}
+	return addr, true
+}
+
 //send raw transaction
 // A JSON example for sendrawtransaction method as following:
 //   {"jsonrpc": "2.0", "method": "sendrawtransaction", "params": ["raw transactioin in hex"], "id": 0}
@@ -313,7 +644,22 @@ func SendRawTransaction(params []interface{}) map[string]interface{} {
 }
 
 //get node version
+// A JSON example for getversion method as following, the pruned height is returned if verbose is 1:
+//   {"jsonrpc": "2.0", "method": "getversion", "params": [1], "id": 0}
 func GetNodeVersion(params []interface{}) map[string]interface{} {
+	if len(params) >= 1 {
+		switch (params[0]).(type) {
+		case float64:
+			if uint32(params[0].(float64)) == 1 {
+				return responseSuccess(bcomn.NodeVersionInfo{
+					Version:      config.Version,
+					PrunedHeight: bactor.GetPrunedHeight(),
+				})
+			}
+		default:
+			return responsePack(berr.INVALID_PARAMS, "")
+		}
+	}
 	return responseSuccess(config.Version)
 }
 
@@ -367,6 +713,18 @@ func GetSmartCodeEvent(params []interface{}) map[string]interface{} {
 	if len(params) < 1 {
 		return responsePack(berr.INVALID_PARAMS, nil)
 	}
+	//decode the notifications by the abi registered on chain
+	var decoder *bcomn.NotifyDecoder
+	if len(params) >= 2 {
+		switch (params[1]).(type) {
+		case float64:
+			if uint32(params[1].(float64)) == 1 {
+				decoder = bcomn.NewNotifyDecoder()
+			}
+		default:
+			return responsePack(berr.INVALID_PARAMS, "")
+		}
+	}
 
 	switch (params[0]).(type) {
 	// block height
@@ -377,11 +735,17 @@ func GetSmartCodeEvent(params []interface{}) map[string]interface{} {
 			if err == scom.ErrNotFound {
 				return responseSuccess(nil)
 			}
+			if err == scom.ErrPruned {
+				return responsePack(berr.DATA_PRUNED, "event is pruned")
+			}
 			return responsePack(berr.INTERNAL_ERROR, "")
 		}
 		eInfos := make([]*bcomn.ExecuteNotify, 0, len(eventInfos))
 		for _, eventInfo := range eventInfos {
 			_, notify := bcomn.GetExecuteNotify(eventInfo)
+			if decoder != nil {
+				decoder.DecodeExecuteNotify(&notify)
+			}
 			eInfos = append(eInfos, &notify)
 		}
 		return responseSuccess(eInfos)
@@ -397,9 +761,15 @@ func GetSmartCodeEvent(params []interface{}) map[string]interface{} {
 			if scom.ErrNotFound == err {
 				return responseSuccess(nil)
 			}
+			if scom.ErrPruned == err {
+				return responsePack(berr.DATA_PRUNED, "event is pruned")
+			}
 			return responsePack(berr.INTERNAL_ERROR, "")
 		}
 		_, notify := bcomn.GetExecuteNotify(eventInfo)
+		if decoder != nil {
+			decoder.DecodeExecuteNotify(&notify)
+		}
 		return responseSuccess(notify)
 	default:
 		return responsePack(berr.INVALID_PARAMS, "")
@@ -421,8 +791,9 @@ func GetBlockHeightByTxHash(params []interface{}) map[string]interface{} {
 		if err != nil {
 			return responsePack(berr.INVALID_PARAMS, "")
 		}
+		//the height of pruned transaction is kept
 		height, _, err := bactor.GetTxnWithHeightByTxHash(hash)
-		if err != nil {
+		if err != nil && err != scom.ErrPruned {
 			return responsePack(berr.INVALID_PARAMS, "")
 		}
 		return responseSuccess(height)
@@ -498,7 +869,7 @@ func GetMerkleProof(params []interface{}) map[string]interface{} {
 		return responsePack(berr.INVALID_PARAMS, "")
 	}
 	height, _, err := bactor.GetTxnWithHeightByTxHash(hash)
-	if err != nil {
+	if err != nil && err != scom.ErrPruned {
 		return responsePack(berr.INVALID_PARAMS, "")
 	}
 	header, err := bactor.GetHeaderByHeight(height)
@@ -536,6 +907,9 @@ func GetBlockTxsByHeight(params []interface{}) map[string]interface{} {
 			return responsePack(berr.INVALID_PARAMS, "")
 		}
 		block, err := bactor.GetBlockFromStore(hash)
+		if err == scom.ErrPruned {
+			return responsePack(berr.DATA_PRUNED, "block is pruned")
+		}
 		if err != nil {
 			return responsePack(berr.UNKNOWN_BLOCK, "")
 		}
@@ -554,6 +928,99 @@ func GetGasPrice(params []interface{}) map[string]interface{} {
 	return responseSuccess(result)
 }
 
+//estimate gas prices by recent blocks and tx pool
+//   {"jsonrpc": "2.0", "method": "estimategasprice", "params": [], "id": 0}
+func EstimateGasPrice(params []interface{}) map[string]interface{} {
+	result, err := bcomn.EstimateGasPrice()
+	if err != nil {
+		log.Errorf("EstimateGasPrice error:%s", err)
+		return responsePack(berr.INTERNAL_ERROR, "")
+	}
+	return responseSuccess(result)
+}
+
+//estimate gas limit of raw transaction by pre-execution
+//   {"jsonrpc": "2.0", "method": "estimategas", "params": ["raw transaction in hex"], "id": 0}
+func EstimateGas(params []interface{}) map[string]interface{} {
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, nil)
+	}
+	str, ok := params[0].(string)
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	raw, err := common.HexToBytes(str)
+	if err != nil {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	txn, err := types.TransactionFromRawBytes(raw)
+	if err != nil {
+		return responsePack(berr.INVALID_TRANSACTION, "")
+	}
+	result, err := bcomn.EstimateGas(txn)
+	if err != nil {
+		return responsePack(berr.SMARTCODE_ERROR, err.Error())
+	}
+	return responseSuccess(result)
+}
+
+//trace the execution of a transaction in tx pool by pre-executing it on current state. Committed transactions
+//are rejected, since the state before their block is not kept and the current state would give a wrong trace
+func TraceTransaction(params []interface{}) map[string]interface{} {
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, nil)
+	}
+	str, ok := params[0].(string)
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	hash, err := common.Uint256FromHexString(str)
+	if err != nil {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	entry, _, err := bactor.GetTxFromPool(hash)
+	if err != nil {
+		height, txn, err := bactor.GetTxnWithHeightByTxHash(hash)
+		if err == scom.ErrPruned {
+			return responsePack(berr.DATA_PRUNED, "transaction is pruned")
+		}
+		if err != nil || txn == nil {
+			return responsePack(berr.UNKNOWN_TRANSACTION, "unknown transaction")
+		}
+		return responsePack(berr.INVALID_PARAMS,
+			fmt.Sprintf("transaction is committed at height %d, tracing committed transaction is not supported", height))
+	}
+	trace, err := bcomn.TraceTransaction(entry.Tx)
+	if err != nil {
+		return responsePack(berr.SMARTCODE_ERROR, err.Error())
+	}
+	return responseSuccess(trace)
+}
+
+//trace the execution of a raw transaction
+func TraceRawTransaction(params []interface{}) map[string]interface{} {
+	if len(params) < 1 {
+		return responsePack(berr.INVALID_PARAMS, nil)
+	}
+	str, ok := params[0].(string)
+	if !ok {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	raw, err := common.HexToBytes(str)
+	if err != nil {
+		return responsePack(berr.INVALID_PARAMS, "")
+	}
+	txn, err := types.TransactionFromRawBytes(raw)
+	if err != nil {
+		return responsePack(berr.INVALID_TRANSACTION, "")
+	}
+	trace, err := bcomn.TraceTransaction(txn)
+	if err != nil {
+		return responsePack(berr.SMARTCODE_ERROR, err.Error())
+	}
+	return responseSuccess(trace)
+}
+
 // get unbound ong of address
 func GetUnboundOng(params []interface{}) map[string]interface{} {
 	if len(params) < 1 {
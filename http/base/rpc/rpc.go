@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/ontio/dad-go/common/log"
+	"github.com/ontio/dad-go/common/metrics"
 	"github.com/ontio/dad-go/http/base/common"
 	berr "github.com/ontio/dad-go/http/base/error"
 	"io"
@@ -31,10 +32,15 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 )
 
+var rpcLatency = metrics.NewSummaryVec("ontology_rpc_request_duration_seconds",
+	"Time spent handling json rpc requests", "method")
+
 func init() {
 	mainMux.m = make(map[string]func([]interface{}) map[string]interface{})
+	metrics.Register(rpcLatency)
 }
 
 //an instance of the multiplexer
@@ -112,7 +118,9 @@ func Handle(w http.ResponseWriter, r *http.Request) {
 	//get the corresponding function
 	function, ok := mainMux.m[method]
 	if ok {
+		start := time.Now()
 		response := function(request["params"].([]interface{}))
+		rpcLatency.With(method).ObserveSince(start)
 		data, err := json.Marshal(map[string]interface{}{
 			"jsonrpc": "2.0",
 			"error":   response["error"],
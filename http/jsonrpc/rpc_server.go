@@ -44,12 +44,20 @@ func StartRPCServer() error {
 	rpc.HandleFunc("getrawtransaction", rpc.GetRawTransaction)
 	rpc.HandleFunc("sendrawtransaction", rpc.SendRawTransaction)
 	rpc.HandleFunc("getstorage", rpc.GetStorage)
+	rpc.HandleFunc("getstorageproof", rpc.GetStorageProof)
+	rpc.HandleFunc("getoraclerequest", rpc.GetOracleRequest)
+	rpc.HandleFunc("listpendingoraclerequests", rpc.ListPendingOracleRequests)
+	rpc.HandleFunc("getoracleoutcome", rpc.GetOracleOutcome)
+	rpc.HandleFunc("getcontent", rpc.GetContent)
 	rpc.HandleFunc("getversion", rpc.GetNodeVersion)
 	rpc.HandleFunc("getnetworkid", rpc.GetNetworkId)
 
 	rpc.HandleFunc("getcontractstate", rpc.GetContractState)
+	rpc.HandleFunc("getcontractabi", rpc.GetContractAbi)
 	rpc.HandleFunc("getmempooltxcount", rpc.GetMemPoolTxCount)
 	rpc.HandleFunc("getmempooltxstate", rpc.GetMemPoolTxState)
+	rpc.HandleFunc("getmempooltxs", rpc.GetMemPoolTxs)
+	rpc.HandleFunc("getaddresstxs", rpc.GetAddressTxs)
 	rpc.HandleFunc("getsmartcodeevent", rpc.GetSmartCodeEvent)
 	rpc.HandleFunc("getblockheightbytxhash", rpc.GetBlockHeightByTxHash)
 
@@ -58,8 +66,20 @@ func StartRPCServer() error {
 	rpc.HandleFunc("getmerkleproof", rpc.GetMerkleProof)
 	rpc.HandleFunc("getblocktxsbyheight", rpc.GetBlockTxsByHeight)
 	rpc.HandleFunc("getgasprice", rpc.GetGasPrice)
+	rpc.HandleFunc("estimategasprice", rpc.EstimateGasPrice)
+	rpc.HandleFunc("estimategas", rpc.EstimateGas)
+	if cfg.DefConfig.Rpc.EnableTrace {
+		rpc.HandleFunc("tracetransaction", rpc.TraceTransaction)
+		rpc.HandleFunc("tracerawtransaction", rpc.TraceRawTransaction)
+	}
 	rpc.HandleFunc("getunboundong", rpc.GetUnboundOng)
 	rpc.HandleFunc("getgrantong", rpc.GetGrantOng)
+	rpc.HandleFunc("getfeesplitcurve", rpc.GetFeeSplitCurve)
+	rpc.HandleFunc("getgovernanceview", rpc.GetGovernanceView)
+	rpc.HandleFunc("getpeerpool", rpc.GetPeerPool)
+	rpc.HandleFunc("getauthorizeinfo", rpc.GetAuthorizeInfo)
+	rpc.HandleFunc("getstakestatus", rpc.GetStakeStatus)
+	rpc.HandleFunc("getpendingrewards", rpc.GetPendingRewards)
 
 	rpc.HandleFunc("getcrosschainmsg", rpc.GetCrossChainMsg)
 	rpc.HandleFunc("getcrossstatesproof", rpc.GetCrossStatesProof)
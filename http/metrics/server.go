@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package metrics provides the http server exporting node metrics
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ontio/dad-go/common/config"
+	cmetrics "github.com/ontio/dad-go/common/metrics"
+)
+
+const METRICS_DIR = "/metrics"
+
+//StartServer serves the default metrics registry on the configured port. A
+//dedicated mux is used so that the other http services listening with the
+//default mux don't expose the metrics
+func StartServer() error {
+	mux := http.NewServeMux()
+	mux.Handle(METRICS_DIR, cmetrics.DefRegistry)
+	err := http.ListenAndServe(":"+strconv.Itoa(int(config.DefConfig.Common.MetricsPort)), mux)
+	if err != nil {
+		return fmt.Errorf("ListenAndServe error:%s", err)
+	}
+	return nil
+}
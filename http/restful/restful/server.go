@@ -62,22 +62,38 @@ const (
 	GET_BLK_HASH          = "/api/v1/block/hash/:height"
 	GET_TX                = "/api/v1/transaction/:hash"
 	GET_STORAGE           = "/api/v1/storage/:hash/:key"
+	GET_STORAGE_PROOF     = "/api/v1/storageproof/:hash/:key"
 	GET_BALANCE           = "/api/v1/balance/:addr"
 	GET_CONTRACT_STATE    = "/api/v1/contract/:hash"
+	GET_CONTRACT_ABI      = "/api/v1/contract/abi/:hash"
 	GET_SMTCOCE_EVT_TXS   = "/api/v1/smartcode/event/transactions/:height"
 	GET_SMTCOCE_EVTS      = "/api/v1/smartcode/event/txhash/:hash"
 	GET_BLK_HGT_BY_TXHASH = "/api/v1/block/height/txhash/:hash"
 	GET_MERKLE_PROOF      = "/api/v1/merkleproof/:hash"
 	GET_GAS_PRICE         = "/api/v1/gasprice"
+	GET_ESTIMATE_GASPRICE = "/api/v1/estimategasprice"
 	GET_ALLOWANCE         = "/api/v1/allowance/:asset/:from/:to"
 	GET_UNBOUNDONG        = "/api/v1/unboundong/:addr"
 	GET_GRANTONG          = "/api/v1/grantong/:addr"
+	GET_FEE_SPLIT_CURVE   = "/api/v1/feesplitcurve"
 	GET_MEMPOOL_TXCOUNT   = "/api/v1/mempool/txcount"
 	GET_MEMPOOL_TXSTATE   = "/api/v1/mempool/txstate/:hash"
+	GET_MEMPOOL_TXS       = "/api/v1/mempool/txs"
 	GET_VERSION           = "/api/v1/version"
 	GET_NETWORKID         = "/api/v1/networkid"
-
-	POST_RAW_TX = "/api/v1/transaction"
+	GET_ORACLE_REQUEST    = "/api/v1/oracle/request/:hash"
+	GET_ORACLE_PENDING    = "/api/v1/oracle/pending"
+	GET_ORACLE_OUTCOME    = "/api/v1/oracle/outcome/:hash"
+	GET_CONTENT           = "/api/v1/content/:cid"
+	GET_ADDRESS_TXS       = "/api/v1/address/txs/:addr"
+	GET_GOVERNANCE_VIEW   = "/api/v1/governance/view"
+	GET_PEER_POOL         = "/api/v1/governance/peerpool"
+	GET_AUTHORIZE_INFO    = "/api/v1/governance/authorizeinfo/:pubkey/:addr"
+	GET_STAKE_STATUS      = "/api/v1/governance/stakestatus/:addr"
+	GET_PENDING_REWARDS   = "/api/v1/governance/pendingrewards/:addr"
+
+	POST_RAW_TX       = "/api/v1/transaction"
+	POST_ESTIMATE_GAS = "/api/v1/estimategas"
 )
 
 //init restful server
@@ -147,20 +163,36 @@ func (this *restServer) registryMethod() {
 		GET_SMTCOCE_EVTS:      {name: "getsmartcodeeventbyhash", handler: rest.GetSmartCodeEventByTxHash},
 		GET_BLK_HGT_BY_TXHASH: {name: "getblockheightbytxhash", handler: rest.GetBlockHeightByTxHash},
 		GET_STORAGE:           {name: "getstorage", handler: rest.GetStorage},
+		GET_STORAGE_PROOF:     {name: "getstorageproof", handler: rest.GetStorageProof},
 		GET_BALANCE:           {name: "getbalance", handler: rest.GetBalance},
 		GET_ALLOWANCE:         {name: "getallowance", handler: rest.GetAllowance},
 		GET_MERKLE_PROOF:      {name: "getmerkleproof", handler: rest.GetMerkleProof},
 		GET_GAS_PRICE:         {name: "getgasprice", handler: rest.GetGasPrice},
+		GET_ESTIMATE_GASPRICE: {name: "estimategasprice", handler: rest.EstimateGasPrice},
 		GET_UNBOUNDONG:        {name: "getunboundong", handler: rest.GetUnboundOng},
 		GET_GRANTONG:          {name: "getgrantong", handler: rest.GetGrantOng},
+		GET_FEE_SPLIT_CURVE:   {name: "getfeesplitcurve", handler: rest.GetFeeSplitCurve},
 		GET_MEMPOOL_TXCOUNT:   {name: "getmempooltxcount", handler: rest.GetMemPoolTxCount},
 		GET_MEMPOOL_TXSTATE:   {name: "getmempooltxstate", handler: rest.GetMemPoolTxState},
+		GET_MEMPOOL_TXS:       {name: "getmempooltxs", handler: rest.GetMemPoolTxs},
 		GET_VERSION:           {name: "getversion", handler: rest.GetNodeVersion},
 		GET_NETWORKID:         {name: "getnetworkid", handler: rest.GetNetworkId},
+		GET_ORACLE_REQUEST:    {name: "getoraclerequest", handler: rest.GetOracleRequest},
+		GET_ORACLE_PENDING:    {name: "listpendingoraclerequests", handler: rest.ListPendingOracleRequests},
+		GET_ORACLE_OUTCOME:    {name: "getoracleoutcome", handler: rest.GetOracleOutcome},
+		GET_CONTENT:           {name: "getcontent", handler: rest.GetContent},
+		GET_CONTRACT_ABI:      {name: "getcontractabi", handler: rest.GetContractAbi},
+		GET_ADDRESS_TXS:       {name: "getaddresstxs", handler: rest.GetAddressTxs},
+		GET_GOVERNANCE_VIEW:   {name: "getgovernanceview", handler: rest.GetGovernanceView},
+		GET_PEER_POOL:         {name: "getpeerpool", handler: rest.GetPeerPool},
+		GET_AUTHORIZE_INFO:    {name: "getauthorizeinfo", handler: rest.GetAuthorizeInfo},
+		GET_STAKE_STATUS:      {name: "getstakestatus", handler: rest.GetStakeStatus},
+		GET_PENDING_REWARDS:   {name: "getpendingrewards", handler: rest.GetPendingRewards},
 	}
 
 	postMethodMap := map[string]Action{
-		POST_RAW_TX: {name: "sendrawtransaction", handler: rest.SendRawTransaction},
+		POST_RAW_TX:       {name: "sendrawtransaction", handler: rest.SendRawTransaction},
+		POST_ESTIMATE_GAS: {name: "estimategas", handler: rest.EstimateGas},
 	}
 	this.postMap = postMethodMap
 	this.getMap = getMethodMap
@@ -177,6 +209,8 @@ func (this *restServer) getPath(url string) string {
 		return GET_BLK_BY_HASH
 	} else if strings.Contains(url, strings.TrimRight(GET_TX, ":hash")) {
 		return GET_TX
+	} else if strings.Contains(url, strings.TrimRight(GET_CONTRACT_ABI, ":hash")) {
+		return GET_CONTRACT_ABI
 	} else if strings.Contains(url, strings.TrimRight(GET_CONTRACT_STATE, ":hash")) {
 		return GET_CONTRACT_STATE
 	} else if strings.Contains(url, strings.TrimRight(GET_SMTCOCE_EVT_TXS, ":height")) {
@@ -185,6 +219,8 @@ func (this *restServer) getPath(url string) string {
 		return GET_SMTCOCE_EVTS
 	} else if strings.Contains(url, strings.TrimRight(GET_BLK_HGT_BY_TXHASH, ":hash")) {
 		return GET_BLK_HGT_BY_TXHASH
+	} else if strings.Contains(url, strings.TrimRight(GET_STORAGE_PROOF, ":hash/:key")) {
+		return GET_STORAGE_PROOF
 	} else if strings.Contains(url, strings.TrimRight(GET_STORAGE, ":hash/:key")) {
 		return GET_STORAGE
 	} else if strings.Contains(url, strings.TrimRight(GET_BALANCE, ":addr")) {
@@ -199,6 +235,20 @@ func (this *restServer) getPath(url string) string {
 		return GET_GRANTONG
 	} else if strings.Contains(url, strings.TrimRight(GET_MEMPOOL_TXSTATE, ":hash")) {
 		return GET_MEMPOOL_TXSTATE
+	} else if strings.Contains(url, strings.TrimRight(GET_ORACLE_REQUEST, ":hash")) {
+		return GET_ORACLE_REQUEST
+	} else if strings.Contains(url, strings.TrimRight(GET_ORACLE_OUTCOME, ":hash")) {
+		return GET_ORACLE_OUTCOME
+	} else if strings.Contains(url, strings.TrimRight(GET_CONTENT, ":cid")) {
+		return GET_CONTENT
+	} else if strings.Contains(url, strings.TrimRight(GET_ADDRESS_TXS, ":addr")) {
+		return GET_ADDRESS_TXS
+	} else if strings.Contains(url, strings.TrimRight(GET_AUTHORIZE_INFO, ":pubkey/:addr")) {
+		return GET_AUTHORIZE_INFO
+	} else if strings.Contains(url, strings.TrimRight(GET_STAKE_STATUS, ":addr")) {
+		return GET_STAKE_STATUS
+	} else if strings.Contains(url, strings.TrimRight(GET_PENDING_REWARDS, ":addr")) {
+		return GET_PENDING_REWARDS
 	}
 	return url
 }
@@ -207,6 +257,8 @@ func (this *restServer) getPath(url string) string {
 func (this *restServer) getParams(r *http.Request, url string, req map[string]interface{}) map[string]interface{} {
 	switch url {
 	case GET_CONN_COUNT:
+	case GET_VERSION:
+		req["Verbose"] = r.FormValue("verbose")
 	case GET_BLK_TXS_BY_HEIGHT:
 		req["Height"] = getParam(r, "height")
 	case GET_BLK_BY_HEIGHT:
@@ -224,10 +276,13 @@ func (this *restServer) getParams(r *http.Request, url string, req map[string]in
 		req["PreExec"] = r.FormValue("preExec")
 	case GET_STORAGE:
 		req["Hash"], req["Key"] = getParam(r, "hash"), getParam(r, "key")
+	case GET_STORAGE_PROOF:
+		req["Hash"], req["Key"] = getParam(r, "hash"), getParam(r, "key")
+		req["Height"] = r.FormValue("height")
 	case GET_SMTCOCE_EVT_TXS:
-		req["Height"] = getParam(r, "height")
+		req["Height"], req["Decode"] = getParam(r, "height"), r.FormValue("decode")
 	case GET_SMTCOCE_EVTS:
-		req["Hash"] = getParam(r, "hash")
+		req["Hash"], req["Decode"] = getParam(r, "hash"), r.FormValue("decode")
 	case GET_BLK_HGT_BY_TXHASH:
 		req["Hash"] = getParam(r, "hash")
 	case GET_BALANCE:
@@ -243,6 +298,29 @@ func (this *restServer) getParams(r *http.Request, url string, req map[string]in
 		req["Addr"] = getParam(r, "addr")
 	case GET_MEMPOOL_TXSTATE:
 		req["Hash"] = getParam(r, "hash")
+	case GET_MEMPOOL_TXS:
+		req["Payer"], req["MinGasPrice"] = r.FormValue("payer"), r.FormValue("mingasprice")
+		req["Offset"], req["Limit"] = r.FormValue("offset"), r.FormValue("limit")
+	case GET_ORACLE_REQUEST:
+		req["Hash"] = getParam(r, "hash")
+	case GET_ORACLE_OUTCOME:
+		req["Hash"] = getParam(r, "hash")
+	case GET_CONTENT:
+		req["Cid"] = getParam(r, "cid")
+	case GET_CONTRACT_ABI:
+		req["Hash"], req["Version"] = getParam(r, "hash"), r.FormValue("version")
+	case GET_ADDRESS_TXS:
+		req["Addr"] = getParam(r, "addr")
+		req["StartHeight"], req["EndHeight"] = r.FormValue("startheight"), r.FormValue("endheight")
+		req["Offset"], req["Limit"] = r.FormValue("offset"), r.FormValue("limit")
+	case GET_PEER_POOL:
+		req["PeerPubkey"] = r.FormValue("peerpubkey")
+	case GET_AUTHORIZE_INFO:
+		req["PeerPubkey"], req["Addr"] = getParam(r, "pubkey"), getParam(r, "addr")
+	case GET_STAKE_STATUS:
+		req["Addr"] = getParam(r, "addr")
+	case GET_PENDING_REWARDS:
+		req["Addr"] = getParam(r, "addr")
 	default:
 	}
 	return req
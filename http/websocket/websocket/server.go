@@ -193,15 +193,31 @@ func (self *WsServer) registryMethod() {
 		"heartbeat":                 {handler: heartbeat},
 		"subscribe":                 {handler: subscribe},
 		"getstorage":                {handler: rest.GetStorage},
+		"getstorageproof":           {handler: rest.GetStorageProof},
 		"getallowance":              {handler: rest.GetAllowance},
 		"getmerkleproof":            {handler: rest.GetMerkleProof},
 		"getblocktxsbyheight":       {handler: rest.GetBlockTxsByHeight},
 		"getgasprice":               {handler: rest.GetGasPrice},
+		"estimategasprice":          {handler: rest.EstimateGasPrice},
+		"estimategas":               {handler: rest.EstimateGas},
 		"getunboundong":             {handler: rest.GetUnboundOng},
 		"getgrantong":               {handler: rest.GetGrantOng},
+		"getfeesplitcurve":          {handler: rest.GetFeeSplitCurve},
 		"getmempooltxcount":         {handler: rest.GetMemPoolTxCount},
 		"getmempooltxstate":         {handler: rest.GetMemPoolTxState},
+		"getmempooltxs":             {handler: rest.GetMemPoolTxs},
 		"getversion":                {handler: rest.GetNodeVersion},
+		"getoraclerequest":          {handler: rest.GetOracleRequest},
+		"listpendingoraclerequests": {handler: rest.ListPendingOracleRequests},
+		"getoracleoutcome":          {handler: rest.GetOracleOutcome},
+		"getcontent":                {handler: rest.GetContent},
+		"getcontractabi":            {handler: rest.GetContractAbi},
+		"getaddresstxs":             {handler: rest.GetAddressTxs},
+		"getgovernanceview":         {handler: rest.GetGovernanceView},
+		"getpeerpool":               {handler: rest.GetPeerPool},
+		"getauthorizeinfo":          {handler: rest.GetAuthorizeInfo},
+		"getstakestatus":            {handler: rest.GetStakeStatus},
+		"getpendingrewards":         {handler: rest.GetPendingRewards},
 		"getnetworkid":              {handler: rest.GetNetworkId},
 
 		"getsessioncount": {handler: getsessioncount},
@@ -333,6 +349,12 @@ func (self *WsServer) OnDataHandle(curSession *session.Session, bysMsg []byte, r
 	if raw, ok := req["Raw"].(float64); ok {
 		req["Raw"] = strconv.FormatInt(int64(raw), 10)
 	}
+	if decode, ok := req["Decode"].(float64); ok {
+		req["Decode"] = strconv.FormatInt(int64(decode), 10)
+	}
+	if version, ok := req["Version"].(float64); ok {
+		req["Version"] = strconv.FormatInt(int64(version), 10)
+	}
 	req["SessionId"] = curSession.GetSessionId()
 	resp := action.handler(req)
 	resp["Action"] = actionName
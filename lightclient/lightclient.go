@@ -0,0 +1,271 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+//Package lightclient verifies block headers and transaction inclusion without the full ledger.
+//Starting from a trusted checkpoint, headers are synced one by one and checked with the
+//bookkeeper signatures, the same way the ledger store verifies headers from peers.
+package lightclient
+
+import (
+	"fmt"
+
+	"github.com/ontio/dad-go/common"
+	vconfig "github.com/ontio/dad-go/consensus/vbft/config"
+	"github.com/ontio/dad-go/core/signature"
+	"github.com/ontio/dad-go/core/types"
+	"github.com/ontio/dad-go/merkle"
+)
+
+//MerkleProof is the block merkle proof of getmerkleproof rpc
+type MerkleProof struct {
+	BlockHeight      uint32
+	TransactionsRoot common.Uint256
+	CurBlockHeight   uint32
+	CurBlockRoot     common.Uint256
+	TargetHashes     []common.Uint256
+}
+
+//Source provides untrusted chain data, usually from a node rpc
+type Source interface {
+	GetHeaderByHeight(height uint32) (*types.Header, error)
+	GetBlockByHeight(height uint32) (*types.Block, error)
+	GetMerkleProof(txHash common.Uint256) (*MerkleProof, error)
+}
+
+//State is the persistent state of light client, which can be used as checkpoint later
+type State struct {
+	Height uint32            `json:"height"`
+	Hash   string            `json:"hash"`
+	Peers  map[string]uint32 `json:"peers,omitempty"` //vbft peers of current chain config
+}
+
+type LightClient struct {
+	source Source
+	header *types.Header
+	vbft   bool
+	peers  map[string]uint32
+}
+
+//NewLightClient creates light client from a trusted block hash. For vbft chain, the chain config
+//is loaded by following the prev block hash back to the last config block, so a config block
+//is the cheapest checkpoint
+func NewLightClient(source Source, height uint32, hash common.Uint256) (*LightClient, error) {
+	header, err := getTrustedHeader(source, height, hash)
+	if err != nil {
+		return nil, err
+	}
+	this := &LightClient{
+		source: source,
+		header: header,
+	}
+	blkInfo, err := vconfig.VbftBlock(header)
+	if err != nil {
+		//not vbft, bookkeepers are checked by NextBookkeeper of prev header
+		return this, nil
+	}
+	this.vbft = true
+	cfgHeader := header
+	for cfgHeader.Height > blkInfo.LastConfigBlockNum && blkInfo.NewChainConfig == nil {
+		prevHeader, err := source.GetHeaderByHeight(cfgHeader.Height - 1)
+		if err != nil {
+			return nil, fmt.Errorf("get header:%d error:%s", cfgHeader.Height-1, err)
+		}
+		if prevHeader.Hash() != cfgHeader.PrevBlockHash {
+			return nil, fmt.Errorf("header:%d hash unmatch", prevHeader.Height)
+		}
+		cfgHeader = prevHeader
+	}
+	cfgInfo, err := vconfig.VbftBlock(cfgHeader)
+	if err != nil {
+		return nil, err
+	}
+	if cfgInfo.NewChainConfig == nil {
+		return nil, fmt.Errorf("block:%d has no chain config", cfgHeader.Height)
+	}
+	this.peers = peersOfConfig(cfgInfo.NewChainConfig)
+	return this, nil
+}
+
+//NewLightClientFromState restores light client from a state saved before
+func NewLightClientFromState(source Source, state *State) (*LightClient, error) {
+	hash, err := common.Uint256FromHexString(state.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state hash:%s", state.Hash)
+	}
+	if len(state.Peers) == 0 {
+		return NewLightClient(source, state.Height, hash)
+	}
+	header, err := getTrustedHeader(source, state.Height, hash)
+	if err != nil {
+		return nil, err
+	}
+	peers := make(map[string]uint32, len(state.Peers))
+	for id, index := range state.Peers {
+		peers[id] = index
+	}
+	return &LightClient{
+		source: source,
+		header: header,
+		vbft:   true,
+		peers:  peers,
+	}, nil
+}
+
+func getTrustedHeader(source Source, height uint32, hash common.Uint256) (*types.Header, error) {
+	header, err := source.GetHeaderByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("get header:%d error:%s", height, err)
+	}
+	if header.Height != height || header.Hash() != hash {
+		return nil, fmt.Errorf("header:%d unmatch trusted hash:%s", height, hash.ToHexString())
+	}
+	return header, nil
+}
+
+func peersOfConfig(cfg *vconfig.ChainConfig) map[string]uint32 {
+	peers := make(map[string]uint32, len(cfg.Peers))
+	for _, p := range cfg.Peers {
+		peers[p.ID] = p.Index
+	}
+	return peers
+}
+
+//Header returns the latest verified header
+func (this *LightClient) Header() *types.Header {
+	return this.header
+}
+
+func (this *LightClient) State() *State {
+	hash := this.header.Hash()
+	return &State{
+		Height: this.header.Height,
+		Hash:   hash.ToHexString(),
+		Peers:  this.peers,
+	}
+}
+
+//SyncTo verifies headers from current header to height
+func (this *LightClient) SyncTo(height uint32) error {
+	for this.header.Height < height {
+		header, err := this.source.GetHeaderByHeight(this.header.Height + 1)
+		if err != nil {
+			return fmt.Errorf("get header:%d error:%s", this.header.Height+1, err)
+		}
+		err = this.verifyHeader(header)
+		if err != nil {
+			return fmt.Errorf("verify header:%d error:%s", this.header.Height+1, err)
+		}
+		this.header = header
+	}
+	return nil
+}
+
+//verifyHeader checks header against current header, see LedgerStoreImp.verifyHeader
+func (this *LightClient) verifyHeader(header *types.Header) error {
+	prevHeader := this.header
+	if header.PrevBlockHash != prevHeader.Hash() {
+		return fmt.Errorf("prev block hash unmatch")
+	}
+	if prevHeader.Height+1 != header.Height {
+		return fmt.Errorf("block height is incorrect")
+	}
+	if prevHeader.Timestamp >= header.Timestamp {
+		return fmt.Errorf("block timestamp is incorrect")
+	}
+	hash := header.Hash()
+	if !this.vbft {
+		address, err := types.AddressFromBookkeepers(header.Bookkeepers)
+		if err != nil {
+			return err
+		}
+		if prevHeader.NextBookkeeper != address {
+			return fmt.Errorf("bookkeeper address error")
+		}
+		m := len(header.Bookkeepers) - (len(header.Bookkeepers)-1)/3
+		return signature.VerifyMultiSignature(hash[:], header.Bookkeepers, m, header.SigData)
+	}
+
+	m := len(this.peers) - (len(this.peers)*6)/7
+	if len(header.Bookkeepers) < m {
+		return fmt.Errorf("header bookkeepers %d less than %d", len(header.Bookkeepers), m)
+	}
+	for _, bookkeeper := range header.Bookkeepers {
+		pubkey := vconfig.PubkeyID(bookkeeper)
+		if _, present := this.peers[pubkey]; !present {
+			return fmt.Errorf("invalid pubkey :%v", pubkey)
+		}
+	}
+	err := signature.VerifyMultiSignature(hash[:], header.Bookkeepers, m, header.SigData)
+	if err != nil {
+		return err
+	}
+	blkInfo, err := vconfig.VbftBlock(header)
+	if err != nil {
+		return err
+	}
+	if blkInfo.NewChainConfig != nil {
+		this.peers = peersOfConfig(blkInfo.NewChainConfig)
+	}
+	return nil
+}
+
+//VerifyTx checks that tx is in the chain, and returns the height of block containing it.
+//The block merkle proof is checked against the block root of a verified header, and the
+//tx is checked against the transactions root of the proved block
+func (this *LightClient) VerifyTx(txHash common.Uint256) (uint32, error) {
+	proof, err := this.source.GetMerkleProof(txHash)
+	if err != nil {
+		return 0, fmt.Errorf("get merkle proof error:%s", err)
+	}
+	if proof.CurBlockHeight < this.header.Height {
+		return 0, fmt.Errorf("proof height:%d is lower than verified height:%d", proof.CurBlockHeight, this.header.Height)
+	}
+	err = this.SyncTo(proof.CurBlockHeight)
+	if err != nil {
+		return 0, err
+	}
+	if this.header.BlockRoot != proof.CurBlockRoot {
+		return 0, fmt.Errorf("block root of header:%d unmatch", this.header.Height)
+	}
+	err = merkle.NewMerkleVerifier().VerifyLeafHashInclusion(proof.TransactionsRoot, proof.BlockHeight,
+		proof.TargetHashes, proof.CurBlockRoot, proof.CurBlockHeight+1)
+	if err != nil {
+		return 0, fmt.Errorf("verify merkle proof error:%s", err)
+	}
+
+	block, err := this.source.GetBlockByHeight(proof.BlockHeight)
+	if err != nil {
+		return 0, fmt.Errorf("get block:%d error:%s", proof.BlockHeight, err)
+	}
+	hashes := make([]common.Uint256, 0, len(block.Transactions))
+	found := false
+	for _, tx := range block.Transactions {
+		hash := tx.Hash()
+		if hash == txHash {
+			found = true
+		}
+		hashes = append(hashes, hash)
+	}
+	if common.ComputeMerkleRoot(hashes) != proof.TransactionsRoot {
+		return 0, fmt.Errorf("transactions root of block:%d unmatch", proof.BlockHeight)
+	}
+	if !found {
+		return 0, fmt.Errorf("tx not found in block:%d", proof.BlockHeight)
+	}
+	return proof.BlockHeight, nil
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lightclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ontio/dad-go-crypto/keypair"
+	"github.com/ontio/dad-go/account"
+	"github.com/ontio/dad-go/common"
+	vconfig "github.com/ontio/dad-go/consensus/vbft/config"
+	"github.com/ontio/dad-go/core/payload"
+	"github.com/ontio/dad-go/core/signature"
+	"github.com/ontio/dad-go/core/types"
+	"github.com/ontio/dad-go/merkle"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSource struct {
+	blocks []*types.Block
+	tree   *merkle.CompactMerkleTree
+}
+
+func (this *testSource) GetHeaderByHeight(height uint32) (*types.Header, error) {
+	if int(height) >= len(this.blocks) {
+		return nil, fmt.Errorf("unknown block")
+	}
+	return this.blocks[height].Header, nil
+}
+
+func (this *testSource) GetBlockByHeight(height uint32) (*types.Block, error) {
+	if int(height) >= len(this.blocks) {
+		return nil, fmt.Errorf("unknown block")
+	}
+	return this.blocks[height], nil
+}
+
+func (this *testSource) GetMerkleProof(txHash common.Uint256) (*MerkleProof, error) {
+	curHeight := uint32(len(this.blocks) - 1)
+	for _, block := range this.blocks {
+		for _, tx := range block.Transactions {
+			if tx.Hash() != txHash {
+				continue
+			}
+			proof, err := this.tree.InclusionProof(block.Header.Height, curHeight+1)
+			if err != nil {
+				return nil, err
+			}
+			return &MerkleProof{
+				BlockHeight:      block.Header.Height,
+				TransactionsRoot: block.Header.TransactionsRoot,
+				CurBlockHeight:   curHeight,
+				CurBlockRoot:     this.blocks[curHeight].Header.BlockRoot,
+				TargetHashes:     proof,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown tx")
+}
+
+func newPeers(n int) []*account.Account {
+	peers := make([]*account.Account, 0, n)
+	for i := 0; i < n; i++ {
+		peers = append(peers, account.NewAccount(""))
+	}
+	return peers
+}
+
+func chainConfig(peers []*account.Account) *vconfig.ChainConfig {
+	cfg := &vconfig.ChainConfig{N: uint32(len(peers))}
+	for i, peer := range peers {
+		cfg.Peers = append(cfg.Peers, &vconfig.PeerConfig{Index: uint32(i + 1), ID: vconfig.PubkeyID(peer.PublicKey)})
+	}
+	return cfg
+}
+
+//newTestChain builds a vbft chain, whose chain config is changed to the second peer set at cfgHeight
+func newTestChain(t *testing.T, length, cfgHeight uint32, peers, newPeers []*account.Account) *testSource {
+	source := &testSource{tree: merkle.NewTree(0, nil, merkle.NewMemHashStore())}
+	signers := peers
+	prevHash := common.UINT256_EMPTY
+	lastConfig := uint32(0)
+	for height := uint32(0); height < length; height++ {
+		info := &vconfig.VbftBlockInfo{LastConfigBlockNum: lastConfig}
+		switch height {
+		case 0:
+			info.NewChainConfig = chainConfig(peers)
+		case cfgHeight:
+			info.NewChainConfig = chainConfig(newPeers)
+			info.LastConfigBlockNum = height
+			lastConfig = height
+		}
+		consensusPayload, err := json.Marshal(info)
+		assert.Nil(t, err)
+
+		mutable := &types.MutableTransaction{
+			TxType:  types.InvokeNeo,
+			Nonce:   height,
+			Payload: &payload.InvokeCode{Code: []byte{byte(height)}},
+		}
+		tx, err := mutable.IntoImmutable()
+		assert.Nil(t, err)
+		txRoot := common.ComputeMerkleRoot([]common.Uint256{tx.Hash()})
+		source.tree.AppendHash(txRoot)
+
+		header := &types.Header{
+			PrevBlockHash:    prevHash,
+			TransactionsRoot: txRoot,
+			BlockRoot:        source.tree.Root(),
+			Timestamp:        height + 1,
+			Height:           height,
+			ConsensusPayload: consensusPayload,
+		}
+		hash := header.Hash()
+		for _, signer := range signers {
+			sig, err := signature.Sign(signer, hash[:])
+			assert.Nil(t, err)
+			header.Bookkeepers = append(header.Bookkeepers, signer.PublicKey)
+			header.SigData = append(header.SigData, sig)
+		}
+		source.blocks = append(source.blocks, &types.Block{Header: header, Transactions: []*types.Transaction{tx}})
+		prevHash = hash
+		if height == cfgHeight {
+			signers = newPeers
+		}
+	}
+	return source
+}
+
+func TestLightClientVerifyTx(t *testing.T) {
+	source := newTestChain(t, 12, 5, newPeers(4), newPeers(4))
+	genesis := source.blocks[0].Header
+	client, err := NewLightClient(source, 0, genesis.Hash())
+	assert.Nil(t, err)
+
+	txHash := source.blocks[3].Transactions[0].Hash()
+	height, err := client.VerifyTx(txHash)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(3), height)
+	assert.Equal(t, uint32(11), client.Header().Height)
+
+	//restore from state, and checkpoint after config change
+	restored, err := NewLightClientFromState(source, client.State())
+	assert.Nil(t, err)
+	assert.Equal(t, client.State(), restored.State())
+	checkpoint := source.blocks[8].Header
+	client, err = NewLightClient(source, 8, checkpoint.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, restored.State().Peers, client.State().Peers)
+
+	_, err = NewLightClient(source, 8, genesis.Hash())
+	assert.NotNil(t, err)
+}
+
+func TestLightClientInvalidHeader(t *testing.T) {
+	peers := newPeers(4)
+	//config block 5 is signed by the new peers instead of the old ones
+	source := newTestChain(t, 8, 5, peers, newPeers(4))
+	source.blocks[5].Header.Bookkeepers = []keypair.PublicKey{}
+	source.blocks[5].Header.SigData = [][]byte{}
+	client, err := NewLightClient(source, 0, source.blocks[0].Header.Hash())
+	assert.Nil(t, err)
+	assert.NotNil(t, client.SyncTo(7))
+	assert.Equal(t, uint32(4), client.Header().Height)
+
+	source = newTestChain(t, 8, 5, peers, peers)
+	client, err = NewLightClient(source, 0, source.blocks[0].Header.Hash())
+	assert.Nil(t, err)
+	proofSource := &tamperedSource{testSource: source}
+	client.source = proofSource
+	_, err = client.VerifyTx(source.blocks[2].Transactions[0].Hash())
+	assert.NotNil(t, err)
+}
+
+type tamperedSource struct {
+	*testSource
+}
+
+func (this *tamperedSource) GetMerkleProof(txHash common.Uint256) (*MerkleProof, error) {
+	proof, err := this.testSource.GetMerkleProof(txHash)
+	if err != nil {
+		return nil, err
+	}
+	proof.TransactionsRoot = common.Uint256{1}
+	return proof, nil
+}
@@ -47,6 +47,7 @@ import (
 	hserver "github.com/ontio/ontology/http/base/actor"
 	"github.com/ontio/ontology/http/jsonrpc"
 	"github.com/ontio/ontology/http/localrpc"
+	"github.com/ontio/ontology/http/metrics"
 	"github.com/ontio/ontology/http/nodeinfo"
 	"github.com/ontio/ontology/http/restful"
 	"github.com/ontio/ontology/http/websocket"
@@ -69,6 +70,7 @@ func setupAPP() *cli.App {
 	app.Copyright = "Copyright in 2018 The dad-go Authors"
 	app.Commands = []cli.Command{
 		cmd.AccountCommand,
+		cmd.WalletCommand,
 		cmd.InfoCommand,
 		cmd.AssetCommand,
 		cmd.ContractCommand,
@@ -78,8 +80,14 @@ func setupAPP() *cli.App {
 		cmd.SigTxCommand,
 		cmd.MultiSigAddrCommand,
 		cmd.MultiSigTxCommand,
+		cmd.PartialSignedTxCommand,
 		cmd.SendTxCommand,
 		cmd.ShowTxCommand,
+		cmd.VerifyCommand,
+		cmd.OracleCommand,
+		cmd.DataCommand,
+		cmd.GovernanceCommand,
+		cmd.SandboxCommand,
 	}
 	app.Flags = []cli.Flag{
 		//common setting
@@ -87,8 +95,15 @@ func setupAPP() *cli.App {
 		utils.LogLevelFlag,
 		utils.DisableLogFileFlag,
 		utils.DisableEventLogFlag,
+		utils.EnableAddressIndexFlag,
+		utils.MetricsPortFlag,
 		utils.DataDirFlag,
+		utils.PruneBlocksFlag,
 		utils.WasmVerifyMethodFlag,
+		//snapshot setting
+		utils.SnapshotIntervalFlag,
+		utils.SnapshotHeightFlag,
+		utils.SnapshotHashFlag,
 		//account setting
 		utils.WalletFileFlag,
 		utils.AccountAddressFlag,
@@ -102,6 +117,10 @@ func setupAPP() *cli.App {
 		utils.TxpoolPreExecDisableFlag,
 		utils.DisableSyncVerifyTxFlag,
 		utils.DisableBroadcastNetTxFlag,
+		utils.TxpoolPriceBumpFlag,
+		utils.TxpoolAccountSlotsFlag,
+		utils.TxpoolAccountQueueFlag,
+		utils.TxpoolGlobalSlotsFlag,
 		//p2p setting
 		utils.ReservedPeersOnlyFlag,
 		utils.ReservedPeersFileFlag,
@@ -119,6 +138,7 @@ func setupAPP() *cli.App {
 		utils.RPCPortFlag,
 		utils.RPCLocalEnableFlag,
 		utils.RPCLocalProtFlag,
+		utils.RPCTraceEnableFlag,
 		//rest setting
 		utils.RestfulEnableFlag,
 		utils.RestfulPortFlag,
@@ -192,6 +212,7 @@ func startdad-go(ctx *cli.Context) {
 	initRestful(ctx)
 	initWs(ctx)
 	initNodeInfo(ctx, p2pSvr)
+	initMetrics(ctx)
 
 	go logCurrBlockHeight()
 	waitToExit(ldg)
@@ -416,6 +437,20 @@ func initNodeInfo(ctx *cli.Context, p2pSvr *p2pserver.P2PServer) {
 	log.Infof("Nodeinfo init success")
 }
 
+func initMetrics(ctx *cli.Context) {
+	if config.DefConfig.Common.MetricsPort == 0 {
+		return
+	}
+	go func() {
+		err := metrics.StartServer()
+		if err != nil {
+			log.Errorf("Metrics server error: %s", err)
+		}
+	}()
+
+	log.Infof("Metrics init success")
+}
+
 func logCurrBlockHeight() {
 	ticker := time.NewTicker(config.DEFAULT_GEN_BLOCK_TIME * time.Second)
 	defer ticker.Stop()
@@ -92,6 +92,8 @@ func (this *P2PActor) Receive(ctx actor.Context) {
 		this.server.OnHeaderReceive(msg.FromID, msg.Headers)
 	case *common.AppendBlock:
 		this.server.OnBlockReceive(msg.FromID, msg.BlockSize, msg.Block, msg.CCMsg, msg.MerkleRoot)
+	case *common.AppendSnapshot:
+		this.server.OnSnapshotReceive(msg.FromID, msg.Height, msg.Index, msg.Data)
 	default:
 		err := this.server.Xmit(ctx.Message())
 		if nil != err {
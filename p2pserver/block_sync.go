@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
 	"github.com/ontio/ontology/common/log"
 	"github.com/ontio/ontology/core/ledger"
 	"github.com/ontio/ontology/core/types"
@@ -234,11 +235,12 @@ type BlockSyncMgr struct {
 	ledger         *ledger.Ledger                       //ledger
 	lock           sync.RWMutex                         //lock
 	nodeWeights    map[uint64]*NodeWeight               //Map NodeID => NodeStatus, using for getNextNode
+	snapshotSync   *SnapshotSyncMgr                     //State snapshot sync, nil if the ledger is not bootstrapped from snapshot
 }
 
 //NewBlockSyncMgr return a BlockSyncMgr instance
 func NewBlockSyncMgr(server *P2PServer) *BlockSyncMgr {
-	return &BlockSyncMgr{
+	syncMgr := &BlockSyncMgr{
 		flightBlocks:  make(map[common.Uint256][]*SyncFlightInfo, 0),
 		flightHeaders: make(map[uint32]*SyncFlightInfo, 0),
 		blocksCache:   NewBlockCache(),
@@ -247,6 +249,15 @@ func NewBlockSyncMgr(server *P2PServer) *BlockSyncMgr {
 		exitCh:        make(chan interface{}, 1),
 		nodeWeights:   make(map[uint64]*NodeWeight, 0),
 	}
+	if height := config.DefConfig.Common.SnapshotHeight; height != 0 {
+		manifestHash, err := common.Uint256FromHexString(config.DefConfig.Common.SnapshotHash)
+		if err != nil {
+			log.Errorf("[p2p]invalid snapshot hash:%s", err)
+		} else {
+			syncMgr.snapshotSync = NewSnapshotSyncMgr(syncMgr, height, manifestHash)
+		}
+	}
+	return syncMgr
 }
 
 type BlockCache struct {
@@ -342,6 +353,9 @@ func (this *BlockSyncMgr) Start() {
 }
 
 func (this *BlockSyncMgr) checkTimeout() {
+	if this.isSnapshotSyncing() {
+		this.snapshotSync.checkTimeout()
+	}
 	now := time.Now()
 	headerTimeoutFlights := make(map[uint32]*SyncFlightInfo, 0)
 	blockTimeoutFlights := make(map[common.Uint256][]*SyncFlightInfo, 0)
@@ -417,9 +431,18 @@ func (this *BlockSyncMgr) checkTimeout() {
 
 func (this *BlockSyncMgr) sync() {
 	this.syncHeader()
+	if this.isSnapshotSyncing() {
+		this.snapshotSync.sync()
+		return
+	}
 	this.syncBlock()
 }
 
+//isSnapshotSyncing return whether blocks are waiting for the state snapshot to be installed
+func (this *BlockSyncMgr) isSnapshotSyncing() bool {
+	return this.snapshotSync != nil && this.ledger.IsSnapshotSyncing()
+}
+
 func (this *BlockSyncMgr) syncHeader() {
 	if !this.server.reachMinConnection() {
 		return
@@ -435,8 +458,12 @@ func (this *BlockSyncMgr) syncHeader() {
 	curBlockHeight := this.ledger.GetCurrentBlockHeight()
 
 	curHeaderHeight := this.ledger.GetCurrentHeaderHeight()
+	//Blocks below the snapshot height are never synced, headers only need to catch up the snapshot
+	if this.isSnapshotSyncing() {
+		curBlockHeight = this.snapshotSync.height
+	}
 	//Waiting for block catch up header
-	if curHeaderHeight-curBlockHeight >= SYNC_MAX_HEADER_FORWARD_SIZE {
+	if curHeaderHeight >= curBlockHeight && curHeaderHeight-curBlockHeight >= SYNC_MAX_HEADER_FORWARD_SIZE {
 		return
 	}
 	NextHeaderId := curHeaderHeight + 1
@@ -556,6 +583,10 @@ func (this *BlockSyncMgr) OnHeaderReceive(fromID uint64, headers []*types.Header
 		log.Warnf("[p2p]OnHeaderReceive AddHeaders error:%s", err)
 		return
 	}
+	if this.isSnapshotSyncing() {
+		this.syncHeader()
+		return
+	}
 	sort.Slice(headers, func(i, j int) bool {
 		return headers[i].Height < headers[j].Height
 	})
@@ -588,6 +619,9 @@ func (this *BlockSyncMgr) OnHeaderReceive(fromID uint64, headers []*types.Header
 // OnBlockReceive receive block from net
 func (this *BlockSyncMgr) OnBlockReceive(fromID uint64, blockSize uint32, block *types.Block, ccMsg *types.CrossChainMsg,
 	merkleRoot common.Uint256) {
+	if this.isSnapshotSyncing() {
+		return
+	}
 	height := block.Header.Height
 	blockHash := block.Hash()
 	log.Tracef("[p2p]OnBlockReceive Height:%d", height)
@@ -614,6 +648,14 @@ func (this *BlockSyncMgr) OnBlockReceive(fromID uint64, blockSize uint32, block
 	this.syncBlock()
 }
 
+//OnSnapshotReceive receive snapshot manifest or chunk from net
+func (this *BlockSyncMgr) OnSnapshotReceive(fromID uint64, height, index uint32, data []byte) {
+	if !this.isSnapshotSyncing() {
+		return
+	}
+	this.snapshotSync.OnSnapshotReceive(fromID, height, index, data)
+}
+
 //OnAddNode to node list when a new node added
 func (this *BlockSyncMgr) OnAddNode(nodeId uint64) {
 	log.Debugf("[p2p]OnAddNode:%d", nodeId)
@@ -714,6 +756,9 @@ func (this *BlockSyncMgr) saveBlock() {
 		return
 	}
 	defer this.releaseSaveBlockLock()
+	if this.isSnapshotSyncing() {
+		return
+	}
 	curBlockHeight := this.ledger.GetCurrentBlockHeight()
 	nextBlockHeight := curBlockHeight + 1
 	this.clearBlocks(curBlockHeight)
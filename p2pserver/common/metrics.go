@@ -0,0 +1,46 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"github.com/ontio/dad-go/common/metrics"
+)
+
+const (
+	MSG_DIRECTION_IN  = "in"
+	MSG_DIRECTION_OUT = "out"
+)
+
+var (
+	msgCount = metrics.NewCounterVec("ontology_p2p_messages_total",
+		"Count of p2p messages by message type and direction", "type", "direction")
+	msgBytes = metrics.NewCounterVec("ontology_p2p_message_bytes_total",
+		"Bytes of p2p messages by message type and direction", "type", "direction")
+)
+
+func init() {
+	metrics.Register(msgCount)
+	metrics.Register(msgBytes)
+}
+
+//RecordMsgTraffic records a p2p message with its size in byte
+func RecordMsgTraffic(msgType string, direction string, size int) {
+	msgCount.With(msgType, direction).Inc()
+	msgBytes.With(msgType, direction).Add(float64(size))
+}
@@ -116,22 +116,24 @@ type PeerAddr struct {
 
 //const channel msg id and type
 const (
-	VERSION_TYPE     = "version"    //peer`s information
-	VERACK_TYPE      = "verack"     //ack msg after version recv
-	GetADDR_TYPE     = "getaddr"    //req nbr address from peer
-	ADDR_TYPE        = "addr"       //nbr address
-	PING_TYPE        = "ping"       //ping  sync height
-	PONG_TYPE        = "pong"       //pong  recv nbr height
-	GET_HEADERS_TYPE = "getheaders" //req blk hdr
-	HEADERS_TYPE     = "headers"    //blk hdr
-	INV_TYPE         = "inv"        //inv payload
-	GET_DATA_TYPE    = "getdata"    //req data from peer
-	BLOCK_TYPE       = "block"      //blk payload
-	TX_TYPE          = "tx"         //transaction
-	CONSENSUS_TYPE   = "consensus"  //consensus payload
-	GET_BLOCKS_TYPE  = "getblocks"  //req blks from peer
-	NOT_FOUND_TYPE   = "notfound"   //peer can`t find blk according to the hash
-	DISCONNECT_TYPE  = "disconnect" //peer disconnect info raise by link
+	VERSION_TYPE      = "version"     //peer`s information
+	VERACK_TYPE       = "verack"      //ack msg after version recv
+	GetADDR_TYPE      = "getaddr"     //req nbr address from peer
+	ADDR_TYPE         = "addr"        //nbr address
+	PING_TYPE         = "ping"        //ping  sync height
+	PONG_TYPE         = "pong"        //pong  recv nbr height
+	GET_HEADERS_TYPE  = "getheaders"  //req blk hdr
+	HEADERS_TYPE      = "headers"     //blk hdr
+	INV_TYPE          = "inv"         //inv payload
+	GET_DATA_TYPE     = "getdata"     //req data from peer
+	BLOCK_TYPE        = "block"       //blk payload
+	TX_TYPE           = "tx"          //transaction
+	CONSENSUS_TYPE    = "consensus"   //consensus payload
+	GET_BLOCKS_TYPE   = "getblocks"   //req blks from peer
+	NOT_FOUND_TYPE    = "notfound"    //peer can`t find blk according to the hash
+	DISCONNECT_TYPE   = "disconnect"  //peer disconnect info raise by link
+	GET_SNAPSHOT_TYPE = "getsnapshot" //req snapshot manifest or chunk
+	SNAPSHOT_TYPE     = "snapshot"    //snapshot manifest or chunk payload
 )
 
 type AppendPeerID struct {
@@ -155,6 +157,13 @@ type AppendBlock struct {
 	MerkleRoot com.Uint256          // MerkleRoot
 }
 
+type AppendSnapshot struct {
+	FromID uint64 // The peer id
+	Height uint32 // Height of the snapshot
+	Index  uint32 // Chunk index, or SNAPSHOT_MANIFEST_INDEX for the manifest
+	Data   []byte // Serialized manifest or chunk, empty if the peer does not have it
+}
+
 //ParseIPAddr return ip address
 func ParseIPAddr(s string) (string, error) {
 	i := strings.Index(s, ":")
@@ -126,6 +126,7 @@ func (this *Link) Rx() {
 
 		t := time.Now()
 		this.UpdateRXTime(t)
+		common.RecordMsgTraffic(msg.CmdType(), common.MSG_DIRECTION_IN, int(payloadSize)+common.MSG_HDR_LEN)
 
 		if !this.needSendMsg(msg) {
 			log.Debugf("skip handle msgType:%s from:%d", msg.CmdType(), this.id)
@@ -206,3 +206,24 @@ func NewConsensusDataReq(hash common.Uint256) mt.Message {
 
 	return &dataReq
 }
+
+//snapshot request package
+func NewSnapshotReq(height, index uint32) mt.Message {
+	log.Trace()
+	var req mt.SnapshotReq
+	req.Height = height
+	req.Index = index
+
+	return &req
+}
+
+//snapshot package
+func NewSnapshot(height, index uint32, data []byte) mt.Message {
+	log.Trace()
+	var snapshot mt.Snapshot
+	snapshot.Height = height
+	snapshot.Index = index
+	snapshot.Data = data
+
+	return &snapshot
+}
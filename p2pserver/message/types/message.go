@@ -179,6 +179,10 @@ func MakeEmptyMessage(cmdType string) (Message, error) {
 		return &Disconnected{}, nil
 	case common.GET_BLOCKS_TYPE:
 		return &BlocksReq{}, nil
+	case common.GET_SNAPSHOT_TYPE:
+		return &SnapshotReq{}, nil
+	case common.SNAPSHOT_TYPE:
+		return &Snapshot{}, nil
 	default:
 		return nil, errors.New("unsupported cmd type:" + cmdType)
 	}
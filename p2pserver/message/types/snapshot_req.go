@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import (
+	"io"
+
+	comm "github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/p2pserver/common"
+)
+
+//SnapshotReq request the manifest or a chunk of the state snapshot at height
+type SnapshotReq struct {
+	Height uint32
+	Index  uint32
+}
+
+//Serialize message payload
+func (this *SnapshotReq) Serialization(sink *comm.ZeroCopySink) {
+	sink.WriteUint32(this.Height)
+	sink.WriteUint32(this.Index)
+}
+
+func (this *SnapshotReq) CmdType() string {
+	return common.GET_SNAPSHOT_TYPE
+}
+
+//Deserialize message payload
+func (this *SnapshotReq) Deserialization(source *comm.ZeroCopySource) error {
+	var eof bool
+	this.Height, eof = source.NextUint32()
+	this.Index, eof = source.NextUint32()
+
+	if eof {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
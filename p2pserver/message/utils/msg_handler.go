@@ -32,6 +32,7 @@ import (
 	"github.com/ontio/ontology/common/config"
 	"github.com/ontio/ontology/common/log"
 	"github.com/ontio/ontology/core/ledger"
+	"github.com/ontio/ontology/core/store"
 	"github.com/ontio/ontology/core/types"
 	actor "github.com/ontio/ontology/p2pserver/actor/req"
 	msgCommon "github.com/ontio/ontology/p2pserver/common"
@@ -198,6 +199,59 @@ func BlockHandle(data *msgTypes.MsgPayload, p2p p2p.P2P, pid *evtActor.PID, args
 	}
 }
 
+// SnapshotReqHandle handles the snapshot manifest or chunk request from peer
+func SnapshotReqHandle(data *msgTypes.MsgPayload, p2p p2p.P2P, pid *evtActor.PID, args ...interface{}) {
+	log.Trace("[p2p]receive snapshot request message", data.Addr, data.Id)
+
+	req := data.Payload.(*msgTypes.SnapshotReq)
+	remotePeer := p2p.GetPeer(data.Id)
+	if remotePeer == nil {
+		log.Debugf("[p2p]remotePeer invalid in SnapshotReqHandle, peer id: %d", data.Id)
+		return
+	}
+
+	var buf []byte
+	if req.Index == store.SNAPSHOT_MANIFEST_INDEX {
+		manifest, err := ledger.DefLedger.GetSnapshotManifest(req.Height)
+		if err == nil {
+			sink := common.NewZeroCopySink(nil)
+			manifest.Serialization(sink)
+			buf = sink.Bytes()
+		} else {
+			log.Debugf("[p2p]get snapshot manifest of height:%d error:%s", req.Height, err)
+		}
+	} else {
+		chunk, err := ledger.DefLedger.GetSnapshotChunk(req.Height, req.Index)
+		if err == nil {
+			buf = chunk
+		} else {
+			log.Debugf("[p2p]get snapshot chunk %d of height:%d error:%s", req.Index, req.Height, err)
+		}
+	}
+	//empty data tells the peer to ask someone else
+	msg := msgpack.NewSnapshot(req.Height, req.Index, buf)
+	err := p2p.Send(remotePeer, msg)
+	if err != nil {
+		log.Warn(err)
+	}
+}
+
+// SnapshotHandle handles the snapshot manifest or chunk from peer
+func SnapshotHandle(data *msgTypes.MsgPayload, p2p p2p.P2P, pid *evtActor.PID, args ...interface{}) {
+	log.Trace("[p2p]receive snapshot message from ", data.Addr, data.Id)
+
+	if pid != nil {
+		var snapshot = data.Payload.(*msgTypes.Snapshot)
+		input := &msgCommon.AppendSnapshot{
+			FromID: data.Id,
+			Height: snapshot.Height,
+			Index:  snapshot.Index,
+			Data:   snapshot.Data,
+		}
+		pid.Tell(input)
+	}
+}
+
 // ConsensusHandle handles the consensus message from peer
 func ConsensusHandle(data *msgTypes.MsgPayload, p2p p2p.P2P, pid *evtActor.PID, args ...interface{}) {
 	log.Debugf("[p2p]receive consensus message:%v,%d", data.Addr, data.Id)
@@ -69,6 +69,8 @@ func (this *MessageRouter) init(p2p p2p.P2P) {
 	this.RegisterMsgHandler(msgCommon.NOT_FOUND_TYPE, NotFoundHandle)
 	this.RegisterMsgHandler(msgCommon.TX_TYPE, TransactionHandle)
 	this.RegisterMsgHandler(msgCommon.DISCONNECT_TYPE, DisconnectHandle)
+	this.RegisterMsgHandler(msgCommon.GET_SNAPSHOT_TYPE, SnapshotReqHandle)
+	this.RegisterMsgHandler(msgCommon.SNAPSHOT_TYPE, SnapshotHandle)
 }
 
 // RegisterMsgHandler registers msg handler with the msg type
@@ -28,6 +28,7 @@ import (
 
 	"github.com/ontio/dad-go/common/config"
 	"github.com/ontio/dad-go/common/log"
+	"github.com/ontio/dad-go/common/metrics"
 	"github.com/ontio/dad-go/core/ledger"
 	"github.com/ontio/dad-go/p2pserver/common"
 	"github.com/ontio/dad-go/p2pserver/common/set"
@@ -46,6 +47,7 @@ func NewNetServer() p2p.P2P {
 	n.PeerAddrMap.PeerAddress = make(map[string]*peer.Peer)
 
 	n.init()
+	n.registerMetrics()
 	return n
 }
 
@@ -122,6 +124,18 @@ func (this *NetServer) init() error {
 	return nil
 }
 
+//registerMetrics exports the peer counts of the net server
+func (this *NetServer) registerMetrics() {
+	metrics.Register(metrics.NewGaugeVecFunc("ontology_p2p_peers",
+		"Count of p2p peers by connection state", "state", func() map[string]float64 {
+			return map[string]float64{
+				"established": float64(this.GetConnectionCnt()),
+				"inbound":     float64(this.GetInConnRecordLen()),
+				"outbound":    float64(this.GetOutConnRecordLen()),
+			}
+		}))
+}
+
 //InitListen start listening on the config port
 func (this *NetServer) Start() {
 	this.startListening()
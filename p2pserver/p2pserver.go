@@ -210,6 +210,11 @@ func (this *P2PServer) OnBlockReceive(fromID uint64, blockSize uint32,
 	this.blockSync.OnBlockReceive(fromID, blockSize, block, ccMsg, merkleRoot)
 }
 
+// OnSnapshotReceive adds the snapshot manifest or chunk from network
+func (this *P2PServer) OnSnapshotReceive(fromID uint64, height, index uint32, data []byte) {
+	this.blockSync.OnSnapshotReceive(fromID, height, index, data)
+}
+
 // Todo: remove it if no use
 func (this *P2PServer) GetConnectionState() uint32 {
 	return common.INIT
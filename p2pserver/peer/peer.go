@@ -199,7 +199,11 @@ func (this *Peer) GetPort() uint16 {
 //SendTo call sync link to send buffer
 func (this *Peer) SendRaw(msgType string, msgPayload []byte) error {
 	if this.Link != nil && this.Link.Valid() {
-		return this.Link.SendRaw(msgPayload)
+		err := this.Link.SendRaw(msgPayload)
+		if err == nil {
+			common.RecordMsgTraffic(msgType, common.MSG_DIRECTION_OUT, len(msgPayload))
+		}
+		return err
 	}
 	return errors.New("[p2p]sync link invalid")
 }
@@ -0,0 +1,297 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2pserver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/log"
+	"github.com/ontio/ontology/core/store"
+	"github.com/ontio/ontology/p2pserver/message/msg_pack"
+)
+
+const (
+	SYNC_MAX_FLIGHT_CHUNK_SIZE    = 4  //Number of snapshot chunks on flight
+	SYNC_SNAPSHOT_REQUEST_TIMEOUT = 10 //s, Request snapshot manifest or chunk timeout time, retry another node after it
+)
+
+//SnapshotSyncMgr downloads the state snapshot the ledger is bootstrapped from. The manifest is trusted by
+//its hash set in config, and every chunk is checked against the manifest before it is saved.
+type SnapshotSyncMgr struct {
+	height         uint32                     //Height of the snapshot
+	manifestHash   common.Uint256             //Trusted manifest hash
+	manifest       *store.SnapshotManifest    //Verified manifest, nil before it is received
+	manifestFlight *SyncFlightInfo            //Flight of the manifest request
+	flightChunks   map[uint32]*SyncFlightInfo //Map ChunkIndex => SyncFlightInfo, the Height of flight is the chunk index
+	received       map[uint32]bool            //Chunks already saved
+	nextChunk      uint32                     //Next chunk index to request
+	installing     bool                       //Help to avoid installing the snapshot concurrently
+	syncer         *BlockSyncMgr
+	lock           sync.Mutex
+}
+
+//NewSnapshotSyncMgr return a SnapshotSyncMgr instance
+func NewSnapshotSyncMgr(syncer *BlockSyncMgr, height uint32, manifestHash common.Uint256) *SnapshotSyncMgr {
+	return &SnapshotSyncMgr{
+		height:       height,
+		manifestHash: manifestHash,
+		flightChunks: make(map[uint32]*SyncFlightInfo),
+		received:     make(map[uint32]bool),
+		syncer:       syncer,
+	}
+}
+
+type snapshotReq struct {
+	nodeId uint64
+	index  uint32
+}
+
+//sync request the manifest, or the next chunks when the manifest is verified
+func (this *SnapshotSyncMgr) sync() {
+	//the manifest can only be verified with the header of snapshot block
+	if this.syncer.ledger.GetCurrentHeaderHeight() < this.height {
+		return
+	}
+	reqs := make([]snapshotReq, 0, SYNC_MAX_FLIGHT_CHUNK_SIZE)
+	this.lock.Lock()
+	if this.installing {
+		this.lock.Unlock()
+		return
+	}
+	if this.manifest == nil {
+		if this.manifestFlight == nil {
+			if reqNode := this.syncer.getNextNode(this.height); reqNode != nil {
+				this.manifestFlight = NewSyncFlightInfo(store.SNAPSHOT_MANIFEST_INDEX, reqNode.GetID())
+				reqs = append(reqs, snapshotReq{nodeId: reqNode.GetID(), index: store.SNAPSHOT_MANIFEST_INDEX})
+			}
+		}
+	} else {
+		for len(this.flightChunks) < SYNC_MAX_FLIGHT_CHUNK_SIZE && this.nextChunk < uint32(len(this.manifest.ChunkHashes)) {
+			index := this.nextChunk
+			if this.received[index] {
+				this.nextChunk++
+				continue
+			}
+			reqNode := this.syncer.getNextNode(this.height)
+			if reqNode == nil {
+				break
+			}
+			this.flightChunks[index] = NewSyncFlightInfo(index, reqNode.GetID())
+			reqs = append(reqs, snapshotReq{nodeId: reqNode.GetID(), index: index})
+			this.nextChunk++
+		}
+	}
+	this.lock.Unlock()
+
+	for _, req := range reqs {
+		this.sendReq(req.nodeId, req.index)
+	}
+}
+
+func (this *SnapshotSyncMgr) sendReq(nodeId uint64, index uint32) {
+	reqNode := this.syncer.server.getNode(nodeId)
+	if reqNode == nil {
+		return
+	}
+	msg := msgpack.NewSnapshotReq(this.height, index)
+	err := this.syncer.server.Send(reqNode, msg, false)
+	if err != nil {
+		log.Warnf("[p2p]snapshot sync height:%d index:%d send error:%s", this.height, index, err)
+	} else {
+		this.syncer.appendReqTime(nodeId)
+	}
+}
+
+//OnSnapshotReceive handle the manifest or chunk received from net
+func (this *SnapshotSyncMgr) OnSnapshotReceive(fromID uint64, height, index uint32, data []byte) {
+	if height != this.height {
+		return
+	}
+	if index == store.SNAPSHOT_MANIFEST_INDEX {
+		this.onManifestReceive(fromID, data)
+		return
+	}
+
+	this.lock.Lock()
+	flightInfo, ok := this.flightChunks[index]
+	if !ok || this.manifest == nil {
+		this.lock.Unlock()
+		return
+	}
+	manifest := this.manifest
+	this.lock.Unlock()
+
+	if len(data) == 0 {
+		log.Debugf("[p2p]snapshot chunk %d not found in node:%d", index, fromID)
+		this.retry(flightInfo, index)
+		return
+	}
+	if err := this.syncer.ledger.AddSnapshotChunk(manifest, index, data); err != nil {
+		log.Warnf("[p2p]OnSnapshotReceive AddSnapshotChunk %d from node:%d error:%s", index, fromID, err)
+		this.syncer.addErrorRespCnt(fromID)
+		this.retry(flightInfo, index)
+		return
+	}
+
+	this.lock.Lock()
+	delete(this.flightChunks, index)
+	this.received[index] = true
+	log.Infof("Snapshot chunk receive height:%d, %d/%d", this.height, len(this.received), len(manifest.ChunkHashes))
+	this.lock.Unlock()
+
+	this.tryInstall()
+}
+
+func (this *SnapshotSyncMgr) onManifestReceive(fromID uint64, data []byte) {
+	this.lock.Lock()
+	flightInfo := this.manifestFlight
+	this.lock.Unlock()
+	if flightInfo == nil {
+		return
+	}
+	if len(data) == 0 {
+		log.Debugf("[p2p]snapshot manifest of height:%d not found in node:%d", this.height, fromID)
+		this.retry(flightInfo, store.SNAPSHOT_MANIFEST_INDEX)
+		return
+	}
+	manifest, err := this.verifyManifest(data)
+	if err != nil {
+		log.Warnf("[p2p]OnSnapshotReceive invalid manifest from node:%d error:%s", fromID, err)
+		this.syncer.addErrorRespCnt(fromID)
+		this.retry(flightInfo, store.SNAPSHOT_MANIFEST_INDEX)
+		return
+	}
+
+	this.lock.Lock()
+	if this.manifest != nil {
+		this.lock.Unlock()
+		return
+	}
+	this.manifest = manifest
+	this.manifestFlight = nil
+	//chunks saved before restart are not downloaded again
+	for index, hash := range manifest.ChunkHashes {
+		chunk, err := this.syncer.ledger.GetSnapshotChunk(this.height, uint32(index))
+		if err == nil && store.SnapshotChunkHash(chunk) == hash {
+			this.received[uint32(index)] = true
+		}
+	}
+	log.Infof("Snapshot manifest receive height:%d, chunks:%d, saved:%d", this.height, len(manifest.ChunkHashes),
+		len(this.received))
+	this.lock.Unlock()
+
+	this.tryInstall()
+}
+
+func (this *SnapshotSyncMgr) verifyManifest(data []byte) (*store.SnapshotManifest, error) {
+	manifest := &store.SnapshotManifest{}
+	if err := manifest.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, err
+	}
+	if hash := manifest.Hash(); hash != this.manifestHash {
+		return nil, fmt.Errorf("manifest hash mismatch, expected:%s, got:%s", this.manifestHash.ToHexString(),
+			hash.ToHexString())
+	}
+	header, err := this.syncer.ledger.GetHeaderByHeight(this.height)
+	if err != nil {
+		return nil, err
+	}
+	if err := manifest.Verify(header); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+//tryInstall install the snapshot to ledger once all the chunks are received
+func (this *SnapshotSyncMgr) tryInstall() {
+	this.lock.Lock()
+	if this.installing || this.manifest == nil || len(this.received) < len(this.manifest.ChunkHashes) {
+		this.lock.Unlock()
+		return
+	}
+	this.installing = true
+	manifest := this.manifest
+	this.lock.Unlock()
+
+	log.Infof("Snapshot install height:%d", this.height)
+	err := this.syncer.ledger.InstallSnapshot(manifest)
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.installing = false
+	if err != nil {
+		//start over, the chunks are verified again when the manifest is received
+		log.Errorf("[p2p]InstallSnapshot height:%d error:%s", this.height, err)
+		this.manifest = nil
+		this.flightChunks = make(map[uint32]*SyncFlightInfo)
+		this.received = make(map[uint32]bool)
+		this.nextChunk = 0
+		return
+	}
+	log.Infof("Snapshot install height:%d done", this.height)
+}
+
+//retry request the manifest or chunk from another node
+func (this *SnapshotSyncMgr) retry(flightInfo *SyncFlightInfo, index uint32) {
+	this.syncer.addTimeoutCnt(flightInfo.GetNodeId())
+	flightInfo.ResetStartTime()
+	flightInfo.MarkFailedNode()
+	reqNode := this.syncer.getNodeWithMinFailedTimes(flightInfo, this.height-1)
+	if reqNode == nil {
+		//request again in next round of sync
+		this.lock.Lock()
+		if index == store.SNAPSHOT_MANIFEST_INDEX {
+			this.manifestFlight = nil
+		} else if this.flightChunks[index] == flightInfo {
+			delete(this.flightChunks, index)
+			if index < this.nextChunk {
+				this.nextChunk = index
+			}
+		}
+		this.lock.Unlock()
+		return
+	}
+	flightInfo.SetNodeId(reqNode.GetID())
+	this.sendReq(reqNode.GetID(), index)
+}
+
+func (this *SnapshotSyncMgr) checkTimeout() {
+	now := time.Now()
+	timeoutFlights := make(map[uint32]*SyncFlightInfo)
+	this.lock.Lock()
+	if this.manifestFlight != nil {
+		timeoutFlights[store.SNAPSHOT_MANIFEST_INDEX] = this.manifestFlight
+	}
+	for index, flightInfo := range this.flightChunks {
+		timeoutFlights[index] = flightInfo
+	}
+	this.lock.Unlock()
+
+	for index, flightInfo := range timeoutFlights {
+		if int(now.Sub(flightInfo.GetStartTime()).Seconds()) < SYNC_SNAPSHOT_REQUEST_TIMEOUT {
+			continue
+		}
+		log.Tracef("[p2p]checkTimeout snapshot height:%d index:%d timeout from id:%d times:%d", this.height, index,
+			flightInfo.GetNodeId(), flightInfo.GetTotalFailedTimes())
+		this.retry(flightInfo, index)
+	}
+}
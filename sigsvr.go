@@ -18,14 +18,18 @@
 package main
 
 import (
+	"fmt"
+	"github.com/ontio/dad-go/account"
 	"github.com/ontio/dad-go/cmd"
 	"github.com/ontio/dad-go/cmd/abi"
 	cmdsvr "github.com/ontio/dad-go/cmd/sigsvr"
 	clisvrcom "github.com/ontio/dad-go/cmd/sigsvr/common"
+	"github.com/ontio/dad-go/cmd/sigsvr/policy"
 	"github.com/ontio/dad-go/cmd/sigsvr/store"
 	"github.com/ontio/dad-go/cmd/utils"
 	"github.com/ontio/dad-go/common/config"
 	"github.com/ontio/dad-go/common/log"
+	"github.com/ontio/dad-go/common/password"
 	"github.com/urfave/cli"
 	"os"
 	"os/signal"
@@ -46,6 +50,7 @@ func setupSigSvr() *cli.App {
 		utils.CliAddressFlag,
 		utils.CliRpcPortFlag,
 		utils.CliABIPathFlag,
+		utils.CliPolicyFileFlag,
 	}
 	app.Commands = []cli.Command{
 		cmdsvr.ImportWalletCommand,
@@ -81,6 +86,17 @@ func startSigSvr(ctx *cli.Context) {
 	}
 	log.Infof("Load wallet data success. Account number:%d", accountNum)
 
+	policyFile := ctx.String(utils.GetFlagName(utils.CliPolicyFileFlag))
+	if policyFile != "" {
+		err = initPolicy(policyFile, walletStore)
+		if err != nil {
+			log.Errorf("Init policy error:%s", err)
+			return
+		}
+		defer clisvrcom.DefAuditLogger.Close()
+		log.Infof("Load policy file:%s success", policyFile)
+	}
+
 	rpcAddress := ctx.String(utils.GetFlagName(utils.CliAddressFlag))
 	rpcPort := ctx.Uint(utils.GetFlagName(utils.CliRpcPortFlag))
 	if rpcPort == 0 {
@@ -108,6 +124,38 @@ func startSigSvr(ctx *cli.Context) {
 	<-exit
 }
 
+func initPolicy(policyFile string, walletStore *store.WalletStore) error {
+	p, err := policy.LoadPolicy(policyFile)
+	if err != nil {
+		return err
+	}
+	clisvrcom.DefPolicy = p
+	if p.Config.AuditLog == "" {
+		return nil
+	}
+	var signer *account.Account
+	if p.Config.AuditAccount != "" {
+		fmt.Printf("Please input password of audit account:%s\n", p.Config.AuditAccount)
+		pwd, err := password.GetPassword()
+		if err != nil {
+			return fmt.Errorf("input password error:%s", err)
+		}
+		signer, err = walletStore.GetAccountByAddress(p.Config.AuditAccount, pwd)
+		if err != nil {
+			return fmt.Errorf("get audit account error:%s", err)
+		}
+		if signer == nil {
+			return fmt.Errorf("cannot find audit account:%s", p.Config.AuditAccount)
+		}
+	}
+	auditLogger, err := policy.NewAuditLogger(p.Config.AuditLog, signer)
+	if err != nil {
+		return err
+	}
+	clisvrcom.DefAuditLogger = auditLogger
+	return nil
+}
+
 func main() {
 	if err := setupSigSvr().Run(os.Args); err != nil {
 		cmd.PrintErrorMsg(err.Error())
@@ -0,0 +1,89 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"errors"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ontio/dad-go-crypto/keypair"
+	"github.com/ontio/dad-go-crypto/sm3"
+	"github.com/ontio/dad-go/core/signature"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	RECOVER_HASH_LEN      = 32 // the length of message hash for public key recovery
+	RECOVER_SIGNATURE_LEN = 65 // the length of recoverable secp256k1 signature, r || s || v
+)
+
+// SM3 return the sm3 digest of data
+func SM3(data []byte) []byte {
+	hash := sm3.Sum(data)
+	return hash[:]
+}
+
+// Keccak256 return the keccak256 digest of data, as used by ethereum
+func Keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// Ripemd160 return the ripemd160 digest of data
+func Ripemd160(data []byte) []byte {
+	h := ripemd160.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// Blake2b256 return the 256 bits blake2b digest of data
+func Blake2b256(data []byte) []byte {
+	hash := blake2b.Sum256(data)
+	return hash[:]
+}
+
+// VerifySignature check the signature of data. The public key and signature are in the serialized
+// format of ontology-crypto, the signature scheme follows the key type: ECDSA (e.g. P-256), SM2 or Ed25519.
+func VerifySignature(pubKey, data, sig []byte) bool {
+	pk, err := keypair.DeserializePublicKey(pubKey)
+	if err != nil {
+		return false
+	}
+	switch keypair.GetKeyType(pk) {
+	case keypair.PK_ECDSA, keypair.PK_SM2, keypair.PK_EDDSA:
+	default:
+		return false
+	}
+	return signature.Verify(pk, data, sig) == nil
+}
+
+// EcRecover return the uncompressed secp256k1 public key which signed the hash. Only secp256k1 is supported,
+// as the 65 bytes r || s || v signature carries no curve, signatures of other curves recover a wrong key or fail
+func EcRecover(hash, sig []byte) ([]byte, error) {
+	if len(hash) != RECOVER_HASH_LEN {
+		return nil, errors.New("invalid hash length")
+	}
+	if len(sig) != RECOVER_SIGNATURE_LEN {
+		return nil, errors.New("invalid signature length")
+	}
+	return ethcrypto.Ecrecover(hash, sig)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ontio/dad-go-crypto/keypair"
+	s "github.com/ontio/dad-go-crypto/signature"
+	"github.com/ontio/dad-go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashes(t *testing.T) {
+	assert.Equal(t, "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0", common.ToHexString(SM3([]byte("abc"))))
+	assert.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", common.ToHexString(Keccak256(nil)))
+	assert.Equal(t, "9c1185a5c5e9fc54612808977ee8f548b2258d31", common.ToHexString(Ripemd160(nil)))
+	assert.Equal(t, "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8", common.ToHexString(Blake2b256(nil)))
+}
+
+func TestVerifySignature(t *testing.T) {
+	data := []byte("impression receipt")
+	cases := []struct {
+		keyType keypair.KeyType
+		curve   byte
+		scheme  s.SignatureScheme
+	}{
+		{keypair.PK_ECDSA, keypair.P256, s.SHA256withECDSA},
+		{keypair.PK_SM2, keypair.SM2P256V1, s.SM3withSM2},
+		{keypair.PK_EDDSA, keypair.ED25519, s.SHA512withEDDSA},
+	}
+	for _, c := range cases {
+		pri, pub, err := keypair.GenerateKeyPair(c.keyType, c.curve)
+		assert.Nil(t, err)
+		sig, err := s.Sign(c.scheme, pri, data, nil)
+		assert.Nil(t, err)
+		raw, err := s.Serialize(sig)
+		assert.Nil(t, err)
+
+		pubKey := keypair.SerializePublicKey(pub)
+		assert.True(t, VerifySignature(pubKey, data, raw))
+		assert.False(t, VerifySignature(pubKey, []byte("forged receipt"), raw))
+		assert.False(t, VerifySignature(pubKey[1:], data, raw))
+	}
+}
+
+func TestEcRecover(t *testing.T) {
+	key, err := ethcrypto.GenerateKey()
+	assert.Nil(t, err)
+	hash := Keccak256([]byte("impression receipt"))
+	sig, err := ethcrypto.Sign(hash, key)
+	assert.Nil(t, err)
+
+	pubKey, err := EcRecover(hash, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, ethcrypto.FromECDSAPub(&key.PublicKey), pubKey)
+
+	_, err = EcRecover(hash[1:], sig)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package abi_registry
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ontio/ontology/common"
+)
+
+const (
+	PARAM_TYPE_BOOL       = "boolean"
+	PARAM_TYPE_STRING     = "string"
+	PARAM_TYPE_INTEGER    = "integer"
+	PARAM_TYPE_ARRAY      = "array"
+	PARAM_TYPE_BYTE_ARRAY = "bytearray"
+	PARAM_TYPE_ADDRESS    = "address"
+	PARAM_TYPE_VOID       = "void"
+	PARAM_TYPE_ANY        = "any"
+)
+
+// ContractAbi is the abi of a contract, compatible with the neovm abi json loaded by cmd/abi
+type ContractAbi struct {
+	Address    string              `json:"hash"`
+	EntryPoint string              `json:"entrypoint"`
+	Functions  []*ContractFuncAbi  `json:"functions"`
+	Events     []*ContractEventAbi `json:"events"`
+}
+
+type ContractFuncAbi struct {
+	Name       string              `json:"name"`
+	Parameters []*ContractParamAbi `json:"parameters"`
+	ReturnType string              `json:"returntype"`
+}
+
+type ContractEventAbi struct {
+	Name       string              `json:"name"`
+	Parameters []*ContractParamAbi `json:"parameters"`
+	ReturnType string              `json:"returntype"`
+}
+
+type ContractParamAbi struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ParseContractAbi parses and validates the json abi
+func ParseContractAbi(data []byte) (*ContractAbi, error) {
+	abi := &ContractAbi{}
+	if err := json.Unmarshal(data, abi); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal error:%s", err)
+	}
+	for _, fn := range abi.Functions {
+		if fn == nil || fn.Name == "" {
+			return nil, fmt.Errorf("function without name")
+		}
+		if err := checkParamsAbi(fn.Parameters); err != nil {
+			return nil, fmt.Errorf("function %s:%s", fn.Name, err)
+		}
+	}
+	for _, evt := range abi.Events {
+		if evt == nil || evt.Name == "" {
+			return nil, fmt.Errorf("event without name")
+		}
+		if err := checkParamsAbi(evt.Parameters); err != nil {
+			return nil, fmt.Errorf("event %s:%s", evt.Name, err)
+		}
+	}
+	return abi, nil
+}
+
+func checkParamsAbi(params []*ContractParamAbi) error {
+	for _, param := range params {
+		if param == nil {
+			return fmt.Errorf("nil parameter")
+		}
+		switch strings.ToLower(param.Type) {
+		case PARAM_TYPE_BOOL, PARAM_TYPE_STRING, PARAM_TYPE_INTEGER, PARAM_TYPE_ARRAY, PARAM_TYPE_BYTE_ARRAY,
+			PARAM_TYPE_ADDRESS, PARAM_TYPE_ANY:
+		default:
+			return fmt.Errorf("parameter %s has unsupported type %s", param.Name, param.Type)
+		}
+	}
+	return nil
+}
+
+func (this *ContractAbi) GetEvent(name string) *ContractEventAbi {
+	name = strings.ToLower(name)
+	for _, evt := range this.Events {
+		if strings.ToLower(evt.Name) == name {
+			return evt
+		}
+	}
+	return nil
+}
+
+// DecodedParam is a typed parameter of a decoded notification
+type DecodedParam struct {
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// DecodedNotify is a notification decoded by the event abi
+type DecodedNotify struct {
+	EventName string
+	Params    []*DecodedParam
+}
+
+// DecodeNotify decodes the states of a notification, which should be a list led by the event name.
+// Items of neovm notifications are hex strings, those of wasm ones are already readable.
+func (this *ContractAbi) DecodeNotify(states interface{}, isNeovm bool) (*DecodedNotify, error) {
+	list, ok := states.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("notification is not an event list")
+	}
+	name, ok := list[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("event name is not a string")
+	}
+	if isNeovm {
+		buf, err := hex.DecodeString(name)
+		if err != nil {
+			return nil, fmt.Errorf("decode event name error:%s", err)
+		}
+		name = string(buf)
+	}
+	evt := this.GetEvent(name)
+	if evt == nil {
+		return nil, fmt.Errorf("event %s is not in abi", name)
+	}
+	values := list[1:]
+	if len(values) != len(evt.Parameters) {
+		return nil, fmt.Errorf("event %s expects %d parameters, got %d", evt.Name, len(evt.Parameters), len(values))
+	}
+	notify := &DecodedNotify{EventName: evt.Name, Params: make([]*DecodedParam, 0, len(values))}
+	for i, param := range evt.Parameters {
+		value := values[i]
+		if isNeovm {
+			val, err := decodeNeovmValue(strings.ToLower(param.Type), value)
+			if err != nil {
+				return nil, fmt.Errorf("decode parameter %s error:%s", param.Name, err)
+			}
+			value = val
+		}
+		notify.Params = append(notify.Params, &DecodedParam{Name: param.Name, Type: param.Type, Value: value})
+	}
+	return notify, nil
+}
+
+func decodeNeovmValue(typ string, value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok {
+		// array and struct are kept as they are
+		return value, nil
+	}
+	switch typ {
+	case PARAM_TYPE_STRING, PARAM_TYPE_INTEGER, PARAM_TYPE_BOOL, PARAM_TYPE_ADDRESS:
+	default:
+		return str, nil
+	}
+	buf, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case PARAM_TYPE_STRING:
+		return string(buf), nil
+	case PARAM_TYPE_INTEGER:
+		return common.BigIntFromNeoBytes(buf).String(), nil
+	case PARAM_TYPE_BOOL:
+		for _, b := range buf {
+			if b != 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		addr, err := common.AddressParseFromBytes(buf)
+		if err != nil {
+			return nil, err
+		}
+		return addr.ToBase58(), nil
+	}
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package abi_registry
+
+import (
+	"fmt"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/smartcontract/event"
+	"github.com/ontio/ontology/smartcontract/service/native"
+	"github.com/ontio/ontology/smartcontract/service/native/global_params"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+const (
+	REGISTER_ABI_NAME  = "registerAbi"
+	SET_ABI_OWNER_NAME = "setAbiOwner"
+	GET_ABI_NAME       = "getAbi"
+
+	ABI_META_PREFIX   = "abimeta"
+	ABI_RECORD_PREFIX = "abirecord"
+
+	MAX_ABI_LENGTH = 64 * 1024
+)
+
+func InitAbiRegistry() {
+	native.Contracts[utils.AbiRegistryContractAddress] = RegisterAbiRegistryContract
+	native.ContractsEnableHeight[utils.AbiRegistryContractAddress] = config.GetAbiRegistryHeight
+}
+
+func RegisterAbiRegistryContract(native *native.NativeService) {
+	native.Register(REGISTER_ABI_NAME, RegisterAbi)
+	native.Register(SET_ABI_OWNER_NAME, SetAbiOwner)
+	native.Register(GET_ABI_NAME, GetAbi)
+}
+
+// RegisterAbi stores a new version of the abi of a deployed contract. The first version must be registered
+// by the contract itself or the governance admin, which also appoints the owner allowed to register the later
+// versions. The owner defaults to the contract itself if it is empty.
+func RegisterAbi(native *native.NativeService) ([]byte, error) {
+	contract := native.ContextRef.CurrentContext().ContractAddress
+	source := common.NewZeroCopySource(native.Input)
+	var param RegisterAbiParam
+	if err := param.Deserialization(source); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterAbi] Deserialization RegisterAbiParam error:%s", err)
+	}
+	if len(param.Abi) == 0 || len(param.Abi) > MAX_ABI_LENGTH {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterAbi] invalid abi length:%d", len(param.Abi))
+	}
+	if _, err := ParseContractAbi([]byte(param.Abi)); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterAbi] invalid abi:%s", err)
+	}
+	dep, err := native.CacheDB.GetContract(param.Contract)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterAbi] GetContract error:%s", err)
+	}
+	if dep == nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterAbi] contract %s is not deployed", param.Contract.ToHexString())
+	}
+	meta, err := getAbiMeta(native, contract, param.Contract)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterAbi] getAbiMeta error:%s", err)
+	}
+	if meta == nil {
+		//check witness
+		if err := validateFirstRegister(native, param.Contract); err != nil {
+			return utils.BYTE_FALSE, fmt.Errorf("[RegisterAbi] the first abi must be registered by contract itself or admin:%s", err)
+		}
+		owner := param.Owner
+		if owner == common.ADDRESS_EMPTY {
+			owner = param.Contract
+		}
+		meta = &AbiMeta{Owner: owner}
+	} else if err := utils.ValidateOwner(native, meta.Owner); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterAbi] checkWitness error:%s", err)
+	}
+	meta.Version++
+	record := &AbiRecord{
+		Contract: param.Contract,
+		Version:  meta.Version,
+		Abi:      param.Abi,
+		Height:   native.Height,
+	}
+	putAbiMeta(native, contract, param.Contract, meta)
+	putAbiRecord(native, contract, record)
+	if config.DefConfig.Common.EnableEventLog {
+		native.Notifications = append(native.Notifications,
+			&event.NotifyEventInfo{
+				ContractAddress: contract,
+				States:          []interface{}{REGISTER_ABI_NAME, param.Contract.ToHexString(), meta.Version},
+			})
+	}
+	return utils.BYTE_TRUE, nil
+}
+
+// SetAbiOwner transfers the right to register abi of a contract to a new owner
+func SetAbiOwner(native *native.NativeService) ([]byte, error) {
+	contract := native.ContextRef.CurrentContext().ContractAddress
+	source := common.NewZeroCopySource(native.Input)
+	var param SetAbiOwnerParam
+	if err := param.Deserialization(source); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[SetAbiOwner] Deserialization SetAbiOwnerParam error:%s", err)
+	}
+	meta, err := getAbiMeta(native, contract, param.Contract)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[SetAbiOwner] getAbiMeta error:%s", err)
+	}
+	if meta == nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[SetAbiOwner] abi of contract %s is not registered", param.Contract.ToHexString())
+	}
+	if param.Owner == common.ADDRESS_EMPTY {
+		return utils.BYTE_FALSE, fmt.Errorf("[SetAbiOwner] owner cannot be empty")
+	}
+	//check witness
+	if err := utils.ValidateOwner(native, meta.Owner); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[SetAbiOwner] checkWitness error:%s", err)
+	}
+	meta.Owner = param.Owner
+	putAbiMeta(native, contract, param.Contract, meta)
+	if config.DefConfig.Common.EnableEventLog {
+		native.Notifications = append(native.Notifications,
+			&event.NotifyEventInfo{
+				ContractAddress: contract,
+				States:          []interface{}{SET_ABI_OWNER_NAME, param.Contract.ToHexString(), param.Owner.ToBase58()},
+			})
+	}
+	return utils.BYTE_TRUE, nil
+}
+
+// GetAbi returns the serialized abi record of the version, the latest one if version is 0,
+// or empty bytes if not registered
+func GetAbi(native *native.NativeService) ([]byte, error) {
+	contract := native.ContextRef.CurrentContext().ContractAddress
+	source := common.NewZeroCopySource(native.Input)
+	var param GetAbiParam
+	if err := param.Deserialization(source); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[GetAbi] Deserialization GetAbiParam error:%s", err)
+	}
+	version := param.Version
+	if version == 0 {
+		meta, err := getAbiMeta(native, contract, param.Contract)
+		if err != nil {
+			return utils.BYTE_FALSE, fmt.Errorf("[GetAbi] getAbiMeta error:%s", err)
+		}
+		if meta == nil {
+			return []byte{}, nil
+		}
+		version = meta.Version
+	}
+	data, err := utils.GetStorageVarBytes(native, GenAbiRecordKey(contract, param.Contract, version))
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[GetAbi] get abi record error:%s", err)
+	}
+	return data, nil
+}
+
+//validateFirstRegister checks the witness of the contract, or of the admin of global params as the governance
+//admin, since the deployer of a contract is not recorded on chain
+func validateFirstRegister(native *native.NativeService, contract common.Address) error {
+	if err := utils.ValidateOwner(native, contract); err == nil {
+		return nil
+	}
+	admin, err := global_params.GetStorageRole(native, global_params.GenerateOperatorKey(utils.ParamContractAddress))
+	if err != nil {
+		return fmt.Errorf("get admin error:%s", err)
+	}
+	if admin == common.ADDRESS_EMPTY {
+		return fmt.Errorf("admin is not set")
+	}
+	return utils.ValidateOwner(native, admin)
+}
+
+func GenAbiMetaKey(registry, contract common.Address) []byte {
+	temp := append(registry[:], []byte(ABI_META_PREFIX)...)
+	return append(temp, contract[:]...)
+}
+
+func GenAbiRecordKey(registry, contract common.Address, version uint32) []byte {
+	temp := append(registry[:], []byte(ABI_RECORD_PREFIX)...)
+	temp = append(temp, contract[:]...)
+	sink := common.NewZeroCopySink(nil)
+	sink.WriteUint32(version)
+	return append(temp, sink.Bytes()...)
+}
+
+func getAbiMeta(native *native.NativeService, registry, contract common.Address) (*AbiMeta, error) {
+	data, err := utils.GetStorageVarBytes(native, GenAbiMetaKey(registry, contract))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	meta := new(AbiMeta)
+	if err := meta.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func putAbiMeta(native *native.NativeService, registry, contract common.Address, meta *AbiMeta) {
+	sink := common.NewZeroCopySink(nil)
+	meta.Serialization(sink)
+	native.CacheDB.Put(GenAbiMetaKey(registry, contract), utils.GenVarBytesStorageItem(sink.Bytes()).ToArray())
+}
+
+func putAbiRecord(native *native.NativeService, registry common.Address, record *AbiRecord) {
+	sink := common.NewZeroCopySink(nil)
+	record.Serialization(sink)
+	native.CacheDB.Put(GenAbiRecordKey(registry, record.Contract, record.Version), utils.GenVarBytesStorageItem(sink.Bytes()).ToArray())
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package abi_registry_test
+
+import (
+	"testing"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/payload"
+	cstates "github.com/ontio/ontology/core/states"
+	"github.com/ontio/ontology/smartcontract/service/native"
+	"github.com/ontio/ontology/smartcontract/service/native/abi_registry"
+	"github.com/ontio/ontology/smartcontract/service/native/global_params"
+	"github.com/ontio/ontology/smartcontract/service/native/testsuite"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRegistryAbi = `{"entrypoint": "Main", "functions": [{"name": "Main", "parameters": [], "returntype": "Void"}]}`
+
+func registerAbi(n *native.NativeService, signer, contract, owner common.Address) error {
+	n.Input = common.SerializeToBytes(&abi_registry.RegisterAbiParam{Contract: contract, Owner: owner, Abi: testRegistryAbi})
+	n.Tx.SignedAddr = []common.Address{signer}
+	_, err := abi_registry.RegisterAbi(n)
+	return err
+}
+
+func setAbiOwner(n *native.NativeService, signer, contract, owner common.Address) error {
+	n.Input = common.SerializeToBytes(&abi_registry.SetAbiOwnerParam{Contract: contract, Owner: owner})
+	n.Tx.SignedAddr = []common.Address{signer}
+	_, err := abi_registry.SetAbiOwner(n)
+	return err
+}
+
+func TestRegisterAbi(t *testing.T) {
+	testsuite.InvokeNativeContract(t, testsuite.RandomAddress(), func(n *native.NativeService) ([]byte, error) {
+		dep, err := payload.NewDeployCode([]byte{0x51, 0x66}, payload.NEOVM_TYPE, "test", "1", "", "", "")
+		assert.Nil(t, err)
+		n.CacheDB.PutContract(dep)
+		contract := dep.Address()
+		admin := testsuite.RandomAddress()
+		other := testsuite.RandomAddress()
+
+		// no admin set yet
+		assert.NotNil(t, registerAbi(n, admin, contract, common.ADDRESS_EMPTY))
+
+		sink := common.NewZeroCopySink(nil)
+		utils.EncodeAddress(sink, admin)
+		n.CacheDB.Put(global_params.GenerateOperatorKey(utils.ParamContractAddress), cstates.GenRawStorageItem(sink.Bytes()))
+
+		// neither contract nor admin
+		assert.NotNil(t, registerAbi(n, other, contract, other))
+		// the first registration by admin, with owner defaults to the contract
+		assert.Nil(t, registerAbi(n, admin, contract, common.ADDRESS_EMPTY))
+		assert.NotNil(t, registerAbi(n, admin, contract, common.ADDRESS_EMPTY))
+		assert.Nil(t, registerAbi(n, contract, contract, common.ADDRESS_EMPTY))
+
+		assert.NotNil(t, setAbiOwner(n, contract, contract, common.ADDRESS_EMPTY))
+		assert.Nil(t, setAbiOwner(n, contract, contract, other))
+		assert.NotNil(t, registerAbi(n, contract, contract, common.ADDRESS_EMPTY))
+		assert.Nil(t, registerAbi(n, other, contract, common.ADDRESS_EMPTY))
+
+		n.Input = common.SerializeToBytes(&abi_registry.GetAbiParam{Contract: contract})
+		data, err := abi_registry.GetAbi(n)
+		assert.Nil(t, err)
+		record := new(abi_registry.AbiRecord)
+		assert.Nil(t, record.Deserialization(common.NewZeroCopySource(data)))
+		assert.Equal(t, uint32(3), record.Version)
+		return nil, nil
+	})
+}
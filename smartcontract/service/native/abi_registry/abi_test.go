@@ -0,0 +1,89 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package abi_registry
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ontio/ontology/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAbi = `{
+	"hash": "0x36bb5c053b6b839c8f6b923fe852f91239b9fccc",
+	"entrypoint": "Main",
+	"functions": [
+		{"name": "transfer", "parameters": [{"name": "from", "type": "ByteArray"}], "returntype": "Boolean"}
+	],
+	"events": [
+		{"name": "transfer", "parameters": [
+			{"name": "from", "type": "Address"},
+			{"name": "amount", "type": "Integer"},
+			{"name": "memo", "type": "String"}
+		], "returntype": "Void"}
+	]
+}`
+
+func TestParseContractAbi(t *testing.T) {
+	abi, err := ParseContractAbi([]byte(testAbi))
+	assert.Nil(t, err)
+	assert.Equal(t, "Main", abi.EntryPoint)
+	assert.NotNil(t, abi.GetEvent("Transfer"))
+	assert.Nil(t, abi.GetEvent("approve"))
+
+	_, err = ParseContractAbi([]byte(`{"events":[{"name":"evt","parameters":[{"name":"a","type":"float"}]}]}`))
+	assert.NotNil(t, err)
+	_, err = ParseContractAbi([]byte(`{"functions":[{"parameters":[]}]}`))
+	assert.NotNil(t, err)
+}
+
+func TestDecodeNeovmNotify(t *testing.T) {
+	abi, err := ParseContractAbi([]byte(testAbi))
+	assert.Nil(t, err)
+	from, _ := common.AddressFromBase58("AMAx993nE6NEqZjwBssUfopxnnvTdob9ij")
+	states := []interface{}{
+		hex.EncodeToString([]byte("transfer")),
+		hex.EncodeToString(from[:]),
+		hex.EncodeToString(common.BigIntToNeoBytes(big.NewInt(1000))),
+		hex.EncodeToString([]byte("hello")),
+	}
+	notify, err := abi.DecodeNotify(states, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "transfer", notify.EventName)
+	assert.Equal(t, from.ToBase58(), notify.Params[0].Value)
+	assert.Equal(t, "1000", notify.Params[1].Value)
+	assert.Equal(t, "hello", notify.Params[2].Value)
+
+	_, err = abi.DecodeNotify(states[:3], true)
+	assert.NotNil(t, err)
+	_, err = abi.DecodeNotify(hex.EncodeToString([]byte("transfer")), true)
+	assert.NotNil(t, err)
+}
+
+func TestDecodeWasmNotify(t *testing.T) {
+	abi, err := ParseContractAbi([]byte(testAbi))
+	assert.Nil(t, err)
+	states := []interface{}{"transfer", "AMAx993nE6NEqZjwBssUfopxnnvTdob9ij", "1000", "hello"}
+	notify, err := abi.DecodeNotify(states, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "amount", notify.Params[1].Name)
+	assert.Equal(t, "1000", notify.Params[1].Value)
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package abi_registry
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+// RegisterAbiParam registers a new abi version of a deployed contract
+type RegisterAbiParam struct {
+	Contract common.Address
+	Owner    common.Address // the owner appointed by the first registration, ignored later
+	Abi      string         // the json abi, in the format of cmd/abi
+}
+
+func (this *RegisterAbiParam) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeAddress(sink, this.Contract)
+	utils.EncodeAddress(sink, this.Owner)
+	utils.EncodeString(sink, this.Abi)
+}
+
+func (this *RegisterAbiParam) Deserialization(source *common.ZeroCopySource) error {
+	var err error
+	if this.Contract, err = utils.DecodeAddress(source); err != nil {
+		return fmt.Errorf("RegisterAbiParam.Deserialization DecodeAddress Contract error:%s", err)
+	}
+	if this.Owner, err = utils.DecodeAddress(source); err != nil {
+		return fmt.Errorf("RegisterAbiParam.Deserialization DecodeAddress Owner error:%s", err)
+	}
+	if this.Abi, err = utils.DecodeString(source); err != nil {
+		return fmt.Errorf("RegisterAbiParam.Deserialization DecodeString Abi error:%s", err)
+	}
+	return nil
+}
+
+// SetAbiOwnerParam appoints a new abi owner of a contract
+type SetAbiOwnerParam struct {
+	Contract common.Address
+	Owner    common.Address
+}
+
+func (this *SetAbiOwnerParam) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeAddress(sink, this.Contract)
+	utils.EncodeAddress(sink, this.Owner)
+}
+
+func (this *SetAbiOwnerParam) Deserialization(source *common.ZeroCopySource) error {
+	var err error
+	if this.Contract, err = utils.DecodeAddress(source); err != nil {
+		return fmt.Errorf("SetAbiOwnerParam.Deserialization DecodeAddress Contract error:%s", err)
+	}
+	if this.Owner, err = utils.DecodeAddress(source); err != nil {
+		return fmt.Errorf("SetAbiOwnerParam.Deserialization DecodeAddress Owner error:%s", err)
+	}
+	return nil
+}
+
+// GetAbiParam queries an abi version of a contract, 0 means the latest one
+type GetAbiParam struct {
+	Contract common.Address
+	Version  uint32
+}
+
+func (this *GetAbiParam) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeAddress(sink, this.Contract)
+	utils.EncodeVarUint(sink, uint64(this.Version))
+}
+
+func (this *GetAbiParam) Deserialization(source *common.ZeroCopySource) error {
+	var err error
+	if this.Contract, err = utils.DecodeAddress(source); err != nil {
+		return fmt.Errorf("GetAbiParam.Deserialization DecodeAddress Contract error:%s", err)
+	}
+	version, err := utils.DecodeVarUint(source)
+	if err != nil {
+		return fmt.Errorf("GetAbiParam.Deserialization DecodeVarUint Version error:%s", err)
+	}
+	if version > math.MaxUint32 {
+		return fmt.Errorf("GetAbiParam.Deserialization Version %d overflow", version)
+	}
+	this.Version = uint32(version)
+	return nil
+}
+
+// AbiMeta is the owner and latest abi version of a contract
+type AbiMeta struct {
+	Owner   common.Address
+	Version uint32
+}
+
+func (this *AbiMeta) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeAddress(sink, this.Owner)
+	sink.WriteUint32(this.Version)
+}
+
+func (this *AbiMeta) Deserialization(source *common.ZeroCopySource) error {
+	var err error
+	if this.Owner, err = utils.DecodeAddress(source); err != nil {
+		return fmt.Errorf("AbiMeta.Deserialization DecodeAddress Owner error:%s", err)
+	}
+	if this.Version, err = utils.DecodeUint32(source); err != nil {
+		return fmt.Errorf("AbiMeta.Deserialization DecodeUint32 Version error:%s", err)
+	}
+	return nil
+}
+
+// AbiRecord is the on chain record of an abi version
+type AbiRecord struct {
+	Contract common.Address
+	Version  uint32
+	Abi      string
+	Height   uint32
+}
+
+func (this *AbiRecord) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeAddress(sink, this.Contract)
+	sink.WriteUint32(this.Version)
+	utils.EncodeString(sink, this.Abi)
+	sink.WriteUint32(this.Height)
+}
+
+func (this *AbiRecord) Deserialization(source *common.ZeroCopySource) error {
+	var err error
+	if this.Contract, err = utils.DecodeAddress(source); err != nil {
+		return fmt.Errorf("AbiRecord.Deserialization DecodeAddress Contract error:%s", err)
+	}
+	if this.Version, err = utils.DecodeUint32(source); err != nil {
+		return fmt.Errorf("AbiRecord.Deserialization DecodeUint32 Version error:%s", err)
+	}
+	if this.Abi, err = utils.DecodeString(source); err != nil {
+		return fmt.Errorf("AbiRecord.Deserialization DecodeString Abi error:%s", err)
+	}
+	if this.Height, err = utils.DecodeUint32(source); err != nil {
+		return fmt.Errorf("AbiRecord.Deserialization DecodeUint32 Height error:%s", err)
+	}
+	return nil
+}
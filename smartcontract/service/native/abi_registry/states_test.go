@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package abi_registry
+
+import (
+	"testing"
+
+	"github.com/ontio/ontology/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterAbiParam_Serialize(t *testing.T) {
+	owner, _ := common.AddressFromBase58("AMAx993nE6NEqZjwBssUfopxnnvTdob9ij")
+	param := RegisterAbiParam{
+		Contract: common.AddressFromVmCode([]byte("contract")),
+		Owner:    owner,
+		Abi:      `{"hash":"","functions":[],"events":[]}`,
+	}
+	sink := common.NewZeroCopySink(nil)
+	param.Serialization(sink)
+
+	param2 := RegisterAbiParam{}
+	source := common.NewZeroCopySource(sink.Bytes())
+	if err := param2.Deserialization(source); err != nil {
+		t.Fatal("RegisterAbiParam deserialize fail!")
+	}
+	assert.Equal(t, param, param2)
+}
+
+func TestGetAbiParam_Serialize(t *testing.T) {
+	param := GetAbiParam{
+		Contract: common.AddressFromVmCode([]byte("contract")),
+		Version:  3,
+	}
+	sink := common.NewZeroCopySink(nil)
+	param.Serialization(sink)
+
+	param2 := GetAbiParam{}
+	source := common.NewZeroCopySource(sink.Bytes())
+	if err := param2.Deserialization(source); err != nil {
+		t.Fatal("GetAbiParam deserialize fail!")
+	}
+	assert.Equal(t, param, param2)
+}
+
+func TestAbiRecord_Serialize(t *testing.T) {
+	record := AbiRecord{
+		Contract: common.AddressFromVmCode([]byte("contract")),
+		Version:  1,
+		Abi:      `{"hash":"","functions":[],"events":[]}`,
+		Height:   100,
+	}
+	sink := common.NewZeroCopySink(nil)
+	record.Serialization(sink)
+
+	record2 := AbiRecord{}
+	source := common.NewZeroCopySource(sink.Bytes())
+	if err := record2.Deserialization(source); err != nil {
+		t.Fatal("AbiRecord deserialize fail!")
+	}
+	assert.Equal(t, record, record2)
+}
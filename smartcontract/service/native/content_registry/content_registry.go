@@ -0,0 +1,173 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package content_registry
+
+import (
+	"fmt"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/smartcontract/event"
+	"github.com/ontio/ontology/smartcontract/service/native"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+const (
+	REGISTER_CONTENT_NAME = "registerContent"
+	SET_PIN_STATUS_NAME   = "setPinStatus"
+	GET_CONTENT_NAME      = "getContent"
+
+	CONTENT_PREFIX = "content"
+
+	MAX_CID_LENGTH  = 128
+	MAX_NOTE_LENGTH = 1024
+)
+
+func InitContentRegistry() {
+	native.Contracts[utils.ContentRegistryContractAddress] = RegisterContentRegistryContract
+	native.ContractsEnableHeight[utils.ContentRegistryContractAddress] = config.GetContentRegistryHeight
+}
+
+func RegisterContentRegistryContract(native *native.NativeService) {
+	native.Register(REGISTER_CONTENT_NAME, RegisterContent)
+	native.Register(SET_PIN_STATUS_NAME, SetPinStatus)
+	native.Register(GET_CONTENT_NAME, GetContent)
+}
+
+// RegisterContent records the cid of a file uploaded to ipfs, a cid can only be registered once
+func RegisterContent(native *native.NativeService) ([]byte, error) {
+	contract := native.ContextRef.CurrentContext().ContractAddress
+	source := common.NewZeroCopySource(native.Input)
+	var param RegisterContentParam
+	if err := param.Deserialization(source); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterContent] Deserialization RegisterContentParam error:%s", err)
+	}
+	if len(param.Cid) == 0 || len(param.Cid) > MAX_CID_LENGTH {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterContent] invalid cid length:%d", len(param.Cid))
+	}
+	if len(param.FileName)+len(param.Note) > MAX_NOTE_LENGTH {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterContent] file name and note exceed %d bytes", MAX_NOTE_LENGTH)
+	}
+	//check witness
+	if err := utils.ValidateOwner(native, param.Uploader); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterContent] checkWitness error:%s", err)
+	}
+	record, err := getContentRecord(native, contract, param.Cid)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterContent] getContentRecord error:%s", err)
+	}
+	if record != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[RegisterContent] cid:%s has been registered by %s", param.Cid, record.Uploader.ToBase58())
+	}
+	record = &ContentRecord{
+		Cid:      param.Cid,
+		Size:     param.Size,
+		Uploader: param.Uploader,
+		FileName: param.FileName,
+		Note:     param.Note,
+		Pinned:   param.Pinned,
+		Height:   native.Height,
+	}
+	putContentRecord(native, contract, record)
+	if config.DefConfig.Common.EnableEventLog {
+		native.Notifications = append(native.Notifications,
+			&event.NotifyEventInfo{
+				ContractAddress: contract,
+				States:          []interface{}{REGISTER_CONTENT_NAME, param.Cid, param.Uploader.ToBase58(), param.Size, param.Pinned},
+			})
+	}
+	return utils.BYTE_TRUE, nil
+}
+
+// SetPinStatus updates whether the content is pinned by ipfs cluster, only the uploader can update it
+func SetPinStatus(native *native.NativeService) ([]byte, error) {
+	contract := native.ContextRef.CurrentContext().ContractAddress
+	source := common.NewZeroCopySource(native.Input)
+	var param SetPinStatusParam
+	if err := param.Deserialization(source); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[SetPinStatus] Deserialization SetPinStatusParam error:%s", err)
+	}
+	record, err := getContentRecord(native, contract, param.Cid)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[SetPinStatus] getContentRecord error:%s", err)
+	}
+	if record == nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[SetPinStatus] cid:%s is not registered", param.Cid)
+	}
+	//check witness
+	if err := utils.ValidateOwner(native, record.Uploader); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[SetPinStatus] checkWitness error:%s", err)
+	}
+	record.Pinned = param.Pinned
+	putContentRecord(native, contract, record)
+	if config.DefConfig.Common.EnableEventLog {
+		native.Notifications = append(native.Notifications,
+			&event.NotifyEventInfo{
+				ContractAddress: contract,
+				States:          []interface{}{SET_PIN_STATUS_NAME, param.Cid, param.Pinned},
+			})
+	}
+	return utils.BYTE_TRUE, nil
+}
+
+// GetContent returns the serialized record of cid, or empty bytes if cid is not registered
+func GetContent(native *native.NativeService) ([]byte, error) {
+	contract := native.ContextRef.CurrentContext().ContractAddress
+	source := common.NewZeroCopySource(native.Input)
+	cid, err := utils.DecodeString(source)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[GetContent] DecodeString cid error:%s", err)
+	}
+	record, err := getContentRecord(native, contract, cid)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("[GetContent] getContentRecord error:%s", err)
+	}
+	if record == nil {
+		return []byte{}, nil
+	}
+	sink := common.NewZeroCopySink(nil)
+	record.Serialization(sink)
+	return sink.Bytes(), nil
+}
+
+func GenContentKey(contract common.Address, cid string) []byte {
+	temp := append(contract[:], []byte(CONTENT_PREFIX)...)
+	return append(temp, []byte(cid)...)
+}
+
+func getContentRecord(native *native.NativeService, contract common.Address, cid string) (*ContentRecord, error) {
+	data, err := utils.GetStorageVarBytes(native, GenContentKey(contract, cid))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	record := new(ContentRecord)
+	if err := record.Deserialization(common.NewZeroCopySource(data)); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func putContentRecord(native *native.NativeService, contract common.Address, record *ContentRecord) {
+	sink := common.NewZeroCopySink(nil)
+	record.Serialization(sink)
+	native.CacheDB.Put(GenContentKey(contract, record.Cid), utils.GenVarBytesStorageItem(sink.Bytes()).ToArray())
+}
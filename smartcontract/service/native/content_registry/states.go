@@ -0,0 +1,137 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package content_registry
+
+import (
+	"fmt"
+
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+// RegisterContentParam anchors a file stored in ipfs on chain
+type RegisterContentParam struct {
+	Uploader common.Address
+	Cid      string
+	Size     uint64
+	FileName string
+	Note     string
+	Pinned   bool
+}
+
+func (this *RegisterContentParam) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeAddress(sink, this.Uploader)
+	utils.EncodeString(sink, this.Cid)
+	utils.EncodeVarUint(sink, this.Size)
+	utils.EncodeString(sink, this.FileName)
+	utils.EncodeString(sink, this.Note)
+	sink.WriteBool(this.Pinned)
+}
+
+func (this *RegisterContentParam) Deserialization(source *common.ZeroCopySource) error {
+	var err error
+	if this.Uploader, err = utils.DecodeAddress(source); err != nil {
+		return fmt.Errorf("RegisterContentParam.Deserialization DecodeAddress Uploader error:%s", err)
+	}
+	if this.Cid, err = utils.DecodeString(source); err != nil {
+		return fmt.Errorf("RegisterContentParam.Deserialization DecodeString Cid error:%s", err)
+	}
+	if this.Size, err = utils.DecodeVarUint(source); err != nil {
+		return fmt.Errorf("RegisterContentParam.Deserialization DecodeVarUint Size error:%s", err)
+	}
+	if this.FileName, err = utils.DecodeString(source); err != nil {
+		return fmt.Errorf("RegisterContentParam.Deserialization DecodeString FileName error:%s", err)
+	}
+	if this.Note, err = utils.DecodeString(source); err != nil {
+		return fmt.Errorf("RegisterContentParam.Deserialization DecodeString Note error:%s", err)
+	}
+	if this.Pinned, err = utils.DecodeBool(source); err != nil {
+		return fmt.Errorf("RegisterContentParam.Deserialization DecodeBool Pinned error:%s", err)
+	}
+	return nil
+}
+
+// SetPinStatusParam updates the cluster pin status of a registered content
+type SetPinStatusParam struct {
+	Cid    string
+	Pinned bool
+}
+
+func (this *SetPinStatusParam) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeString(sink, this.Cid)
+	sink.WriteBool(this.Pinned)
+}
+
+func (this *SetPinStatusParam) Deserialization(source *common.ZeroCopySource) error {
+	var err error
+	if this.Cid, err = utils.DecodeString(source); err != nil {
+		return fmt.Errorf("SetPinStatusParam.Deserialization DecodeString Cid error:%s", err)
+	}
+	if this.Pinned, err = utils.DecodeBool(source); err != nil {
+		return fmt.Errorf("SetPinStatusParam.Deserialization DecodeBool Pinned error:%s", err)
+	}
+	return nil
+}
+
+// ContentRecord is the on chain record of a registered content
+type ContentRecord struct {
+	Cid      string
+	Size     uint64
+	Uploader common.Address
+	FileName string
+	Note     string
+	Pinned   bool
+	Height   uint32
+}
+
+func (this *ContentRecord) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeString(sink, this.Cid)
+	utils.EncodeVarUint(sink, this.Size)
+	utils.EncodeAddress(sink, this.Uploader)
+	utils.EncodeString(sink, this.FileName)
+	utils.EncodeString(sink, this.Note)
+	sink.WriteBool(this.Pinned)
+	sink.WriteUint32(this.Height)
+}
+
+func (this *ContentRecord) Deserialization(source *common.ZeroCopySource) error {
+	var err error
+	if this.Cid, err = utils.DecodeString(source); err != nil {
+		return fmt.Errorf("ContentRecord.Deserialization DecodeString Cid error:%s", err)
+	}
+	if this.Size, err = utils.DecodeVarUint(source); err != nil {
+		return fmt.Errorf("ContentRecord.Deserialization DecodeVarUint Size error:%s", err)
+	}
+	if this.Uploader, err = utils.DecodeAddress(source); err != nil {
+		return fmt.Errorf("ContentRecord.Deserialization DecodeAddress Uploader error:%s", err)
+	}
+	if this.FileName, err = utils.DecodeString(source); err != nil {
+		return fmt.Errorf("ContentRecord.Deserialization DecodeString FileName error:%s", err)
+	}
+	if this.Note, err = utils.DecodeString(source); err != nil {
+		return fmt.Errorf("ContentRecord.Deserialization DecodeString Note error:%s", err)
+	}
+	if this.Pinned, err = utils.DecodeBool(source); err != nil {
+		return fmt.Errorf("ContentRecord.Deserialization DecodeBool Pinned error:%s", err)
+	}
+	if this.Height, err = utils.DecodeUint32(source); err != nil {
+		return fmt.Errorf("ContentRecord.Deserialization DecodeUint32 Height error:%s", err)
+	}
+	return nil
+}
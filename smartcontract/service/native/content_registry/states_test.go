@@ -0,0 +1,86 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package content_registry
+
+import (
+	"testing"
+
+	"github.com/ontio/ontology/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterContentParam_Serialize(t *testing.T) {
+	uploader, _ := common.AddressFromBase58("AMAx993nE6NEqZjwBssUfopxnnvTdob9ij")
+	param := RegisterContentParam{
+		Uploader: uploader,
+		Cid:      "QmXoypizjW3WknFiJnKLwHCnL72vedxjQkDDP1mXWo6uco",
+		Size:     1024,
+		FileName: "readme.md",
+		Note:     "project readme",
+		Pinned:   true,
+	}
+	sink := common.NewZeroCopySink(nil)
+	param.Serialization(sink)
+
+	param2 := RegisterContentParam{}
+	source := common.NewZeroCopySource(sink.Bytes())
+	if err := param2.Deserialization(source); err != nil {
+		t.Fatal("RegisterContentParam deserialize fail!")
+	}
+	assert.Equal(t, param, param2)
+}
+
+func TestSetPinStatusParam_Serialize(t *testing.T) {
+	param := SetPinStatusParam{
+		Cid:    "QmXoypizjW3WknFiJnKLwHCnL72vedxjQkDDP1mXWo6uco",
+		Pinned: true,
+	}
+	sink := common.NewZeroCopySink(nil)
+	param.Serialization(sink)
+
+	param2 := SetPinStatusParam{}
+	source := common.NewZeroCopySource(sink.Bytes())
+	if err := param2.Deserialization(source); err != nil {
+		t.Fatal("SetPinStatusParam deserialize fail!")
+	}
+	assert.Equal(t, param, param2)
+}
+
+func TestContentRecord_Serialize(t *testing.T) {
+	uploader, _ := common.AddressFromBase58("AMAx993nE6NEqZjwBssUfopxnnvTdob9ij")
+	record := ContentRecord{
+		Cid:      "QmXoypizjW3WknFiJnKLwHCnL72vedxjQkDDP1mXWo6uco",
+		Size:     0,
+		Uploader: uploader,
+		Pinned:   true,
+		Height:   100,
+	}
+	sink := common.NewZeroCopySink(nil)
+	record.Serialization(sink)
+
+	record2 := ContentRecord{}
+	source := common.NewZeroCopySource(sink.Bytes())
+	if err := record2.Deserialization(source); err != nil {
+		t.Fatal("ContentRecord deserialize fail!")
+	}
+	assert.Equal(t, record, record2)
+
+	source = common.NewZeroCopySource(sink.Bytes()[:sink.Size()-1])
+	assert.NotNil(t, record2.Deserialization(source))
+}
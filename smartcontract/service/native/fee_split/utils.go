@@ -45,10 +45,3 @@ func AppCallApproveOng(native *native.NativeService, from common.Address, to com
 	}
 	return nil
 }
-
-func splitCurve(pos uint64, avg uint64) uint64 {
-	xi := PRECISE * YITA * 2 * pos / (avg * 10)
-	index := xi / (PRECISE / 10)
-	s := ((Yi[index+1]-Yi[index])*xi + Yi[index]*Xi[index+1] - Yi[index+1]*Xi[index]) / (Xi[index+1] - Xi[index])
-	return s
-}
@@ -67,6 +67,8 @@ const (
 	UPDATE_GLOBAL_PARAM              = "updateGlobalParam"
 	UPDATE_GLOBAL_PARAM2             = "updateGlobalParam2"
 	UPDATE_SPLIT_CURVE               = "updateSplitCurve"
+	UPDATE_FEE_SPLIT_CURVE           = "updateFeeSplitCurve"
+	GET_FEE_SPLIT_CURVE              = "getFeeSplitCurve"
 	TRANSFER_PENALTY                 = "transferPenalty"
 	CHANGE_MAX_AUTHORIZATION         = "changeMaxAuthorization"
 	SET_PEER_COST                    = "setPeerCost"
@@ -74,6 +76,7 @@ const (
 	REDUCE_INIT_POS                  = "reduceInitPos"
 	SET_PROMISE_POS                  = "setPromisePos"
 	SET_GAS_ADDRESS                  = "setGasAddress"
+	REPORT_FAULTY_NODE               = "reportFaultyNode"
 
 	//key prefix
 	GLOBAL_PARAM      = "globalParam"
@@ -93,12 +96,18 @@ const (
 	PROMISE_POS       = "promisePos"
 	PRE_CONFIG        = "preConfig"
 	GAS_ADDRESS       = "gasAddress"
+	FEE_SPLIT_CURVE   = "feeSplitCurve"
+	FAULTY_EVIDENCE   = "faultyEvidence"
 
 	//global
 	PRECISE            = 1000000
 	NEW_VERSION_VIEW   = 6
 	NEW_VERSION_BLOCK  = 414100
 	NEW_WITHDRAW_BLOCK = 2800000
+
+	//fee split curve
+	MAX_SPLIT_CURVE_POINTS = 1000
+	MAX_SPLIT_CURVE_VALUE  = 1000000000
 )
 
 // candidate fee must >= 1 ONG
@@ -136,6 +145,7 @@ func RegisterGovernanceContract(native *native.NativeService) {
 	native.Register(WITHDRAW_FEE, WithdrawFee)
 	native.Register(ADD_INIT_POS, AddInitPos)
 	native.Register(REDUCE_INIT_POS, ReduceInitPos)
+	native.Register(REPORT_FAULTY_NODE, ReportFaultyNode)
 
 	native.Register(INIT_CONFIG, InitConfig)
 	native.Register(APPROVE_CANDIDATE, ApproveCandidate)
@@ -147,6 +157,8 @@ func RegisterGovernanceContract(native *native.NativeService) {
 	native.Register(UPDATE_GLOBAL_PARAM, UpdateGlobalParam)
 	native.Register(UPDATE_GLOBAL_PARAM2, UpdateGlobalParam2)
 	native.Register(UPDATE_SPLIT_CURVE, UpdateSplitCurve)
+	native.Register(UPDATE_FEE_SPLIT_CURVE, UpdateFeeSplitCurve)
+	native.Register(GET_FEE_SPLIT_CURVE, GetFeeSplitCurve)
 	native.Register(TRANSFER_PENALTY, TransferPenalty)
 	native.Register(SET_PROMISE_POS, SetPromisePos)
 	native.Register(SET_GAS_ADDRESS, SetGasAddress)
@@ -619,33 +631,92 @@ func BlackNode(native *native.NativeService) ([]byte, error) {
 	}
 	commit := false
 	for _, peerPubkey := range params.PeerPubkeyList {
-		peerPubkeyPrefix, err := hex.DecodeString(peerPubkey)
+		isConsensus, err := blackPeer(native, contract, peerPoolMap, peerPubkey)
 		if err != nil {
-			return utils.BYTE_FALSE, fmt.Errorf("hex.DecodeString, peerPubkey format error: %v", err)
-		}
-		peerPoolItem, ok := peerPoolMap.PeerPoolMap[peerPubkey]
-		if !ok {
-			return utils.BYTE_FALSE, fmt.Errorf("blackNode, peerPubkey is not in peerPoolMap")
+			return utils.BYTE_FALSE, fmt.Errorf("blackPeer, black peer error: %v", err)
 		}
+		commit = commit || isConsensus
+	}
+	err = putPeerPoolMap(native, contract, view, peerPoolMap)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("putPeerPoolMap, put peerPoolMap error: %v", err)
+	}
 
-		blackListItem := &BlackListItem{
-			PeerPubkey: peerPoolItem.PeerPubkey,
-			Address:    peerPoolItem.Address,
-			InitPos:    peerPoolItem.InitPos,
-		}
-		//put peer into black list
-		native.CacheDB.Put(utils.ConcatKey(contract, []byte(BLACK_LIST), peerPubkeyPrefix), cstates.GenRawStorageItem(common.SerializeToBytes(blackListItem)))
-		//change peerPool status
-		if peerPoolItem.Status == ConsensusStatus {
-			commit = true
+	//commitDpos
+	if commit {
+		err = executeCommitDpos(native, contract)
+		if err != nil {
+			return utils.BYTE_FALSE, fmt.Errorf("executeCommitDpos, executeCommitDpos error: %v", err)
 		}
-		peerPoolItem.Status = BlackStatus
-		peerPoolMap.PeerPoolMap[peerPubkey] = peerPoolItem
+	}
+	return utils.BYTE_TRUE, nil
+}
+
+//Report a peer which signed conflicting blocks of the same round.
+//The evidence is verified on chain, then the peer is put into black list like blackNode, its stake is punished
+//in the next commitDpos and can be transferred by transferPenalty.
+func ReportFaultyNode(native *native.NativeService) ([]byte, error) {
+	if native.Height < config.GetFaultyNodeReportHeight(config.DefConfig.P2PNode.NetworkId) {
+		return utils.BYTE_FALSE, fmt.Errorf("reportFaultyNode, block height is not reached for this func")
+	}
+	params := new(ReportFaultyNodeParam)
+	if err := params.Deserialization(common.NewZeroCopySource(native.Input)); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("deserialize, contract params deserialize error: %v", err)
+	}
+
+	//check witness
+	err := utils.ValidateOwner(native, params.Reporter)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("reportFaultyNode, checkWitness error: %v", err)
+	}
+	contract := native.ContextRef.CurrentContext().ContractAddress
+
+	height, err := VerifyFaultyEvidence(params.PeerPubkey, &params.Evidence)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("reportFaultyNode, verify evidence error: %v", err)
+	}
+	if height > native.Height {
+		return utils.BYTE_FALSE, fmt.Errorf("reportFaultyNode, evidence height %d is higher than current height", height)
+	}
+
+	//one evidence of a height is enough to punish a peer
+	reported, err := isFaultyEvidenceReported(native, contract, params.PeerPubkey, height)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("isFaultyEvidenceReported, get faulty evidence error: %v", err)
+	}
+	if reported {
+		return utils.BYTE_FALSE, fmt.Errorf("reportFaultyNode, faulty evidence of height %d has been reported", height)
+	}
+
+	//get current view
+	view, err := GetView(native, contract)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("getView, get view error: %v", err)
+	}
+	//get peerPoolMap
+	peerPoolMap, err := GetPeerPoolMap(native, contract, view)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("getPeerPoolMap, get peerPoolMap error: %v", err)
+	}
+	peerPoolItem, ok := peerPoolMap.PeerPoolMap[params.PeerPubkey]
+	if !ok {
+		return utils.BYTE_FALSE, fmt.Errorf("reportFaultyNode, peerPubkey is not in peerPoolMap")
+	}
+	if peerPoolItem.Status == BlackStatus {
+		return utils.BYTE_FALSE, fmt.Errorf("reportFaultyNode, peer is already in black list")
+	}
+	commit, err := blackPeer(native, contract, peerPoolMap, params.PeerPubkey)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("blackPeer, black peer error: %v", err)
 	}
 	err = putPeerPoolMap(native, contract, view, peerPoolMap)
 	if err != nil {
 		return utils.BYTE_FALSE, fmt.Errorf("putPeerPoolMap, put peerPoolMap error: %v", err)
 	}
+	err = putFaultyEvidence(native, contract, params.PeerPubkey, height, &params.Evidence)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("putFaultyEvidence, put faulty evidence error: %v", err)
+	}
 
 	//commitDpos
 	if commit {
@@ -1144,6 +1215,23 @@ func UpdateGlobalParam(native *native.NativeService) ([]byte, error) {
 	if globalParam.MinInitStake < 1 {
 		return utils.BYTE_FALSE, fmt.Errorf("updateGlobalParam. MinInitStake must >= 1")
 	}
+	//A, B and Yita are managed by updateFeeSplitCurve once it is called
+	if feeSplitCurveEnabled(native) {
+		updated, err := hasFeeSplitCurveInfo(native, contract)
+		if err != nil {
+			return utils.BYTE_FALSE, fmt.Errorf("hasFeeSplitCurveInfo, check feeSplitCurve error: %v", err)
+		}
+		if updated {
+			oldParam, err := getGlobalParam(native, contract)
+			if err != nil {
+				return utils.BYTE_FALSE, fmt.Errorf("getGlobalParam, getGlobalParam error: %v", err)
+			}
+			if globalParam.A != oldParam.A || globalParam.B != oldParam.B || globalParam.Yita != oldParam.Yita {
+				return utils.BYTE_FALSE, fmt.Errorf("updateGlobalParam. A, B and Yita can only be updated by %s",
+					UPDATE_FEE_SPLIT_CURVE)
+			}
+		}
+	}
 	err = putGlobalParam(native, contract, globalParam)
 	if err != nil {
 		return utils.BYTE_FALSE, fmt.Errorf("putGlobalParam, put globalParam error: %v", err)
@@ -1214,6 +1302,18 @@ func UpdateSplitCurve(native *native.NativeService) ([]byte, error) {
 	}
 	contract := native.ContextRef.CurrentContext().ContractAddress
 
+	//split curve is managed by updateFeeSplitCurve once it is called
+	if feeSplitCurveEnabled(native) {
+		updated, err := hasFeeSplitCurveInfo(native, contract)
+		if err != nil {
+			return utils.BYTE_FALSE, fmt.Errorf("hasFeeSplitCurveInfo, check feeSplitCurve error: %v", err)
+		}
+		if updated {
+			return utils.BYTE_FALSE, fmt.Errorf("updateSplitCurve. split curve can only be updated by %s",
+				UPDATE_FEE_SPLIT_CURVE)
+		}
+	}
+
 	err = putSplitCurve(native, contract, splitCurve)
 	if err != nil {
 		return utils.BYTE_FALSE, fmt.Errorf("putSplitCurve, put splitCurve error: %v", err)
@@ -1222,6 +1322,70 @@ func UpdateSplitCurve(native *native.NativeService) ([]byte, error) {
 	return utils.BYTE_TRUE, nil
 }
 
+//Update the curve points and weights of fee split, the new curve takes effect from ActiveHeight
+func UpdateFeeSplitCurve(native *native.NativeService) ([]byte, error) {
+	if !feeSplitCurveEnabled(native) {
+		return utils.BYTE_FALSE, fmt.Errorf("updateFeeSplitCurve, block height is not reached for this func")
+	}
+	// get admin from database
+	adminAddress, err := global_params.GetStorageRole(native,
+		global_params.GenerateOperatorKey(utils.ParamContractAddress))
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("getAdmin, get admin error: %v", err)
+	}
+
+	//check witness
+	err = utils.ValidateOwner(native, adminAddress)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("updateFeeSplitCurve, checkWitness error: %v", err)
+	}
+
+	param := new(UpdateFeeSplitCurveParam)
+	if err := param.Deserialization(common.NewZeroCopySource(native.Input)); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("deserialize, deserialize updateFeeSplitCurveParam error: %v", err)
+	}
+	if param.ActiveHeight <= native.Height {
+		return utils.BYTE_FALSE, fmt.Errorf("updateFeeSplitCurve. ActiveHeight must > current height %d", native.Height)
+	}
+	if err := param.Curve.Validate(); err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("updateFeeSplitCurve. %v", err)
+	}
+	contract := native.ContextRef.CurrentContext().ContractAddress
+
+	// the curve in effect now keeps working until ActiveHeight, only one curve can be pending
+	previous, err := getFeeSplitCurveInfo(native, contract)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("getFeeSplitCurveInfo, get feeSplitCurveInfo error: %v", err)
+	}
+	if previous.ActiveHeight > native.Height {
+		return utils.BYTE_FALSE, fmt.Errorf("updateFeeSplitCurve. curve pending at height %d has not taken effect",
+			previous.ActiveHeight)
+	}
+	info := &FeeSplitCurveInfo{
+		ActiveHeight: param.ActiveHeight,
+		Curve:        param.Curve,
+		Previous:     *previous.Effective(native.Height),
+	}
+	putFeeSplitCurveInfo(native, contract, info)
+
+	return utils.BYTE_TRUE, nil
+}
+
+//Get the fee split curve in effect and the one scheduled
+func GetFeeSplitCurve(native *native.NativeService) ([]byte, error) {
+	if !feeSplitCurveEnabled(native) {
+		return utils.BYTE_FALSE, fmt.Errorf("getFeeSplitCurve, block height is not reached for this func")
+	}
+	contract := native.ContextRef.CurrentContext().ContractAddress
+	info, err := getFeeSplitCurveInfo(native, contract)
+	if err != nil {
+		return utils.BYTE_FALSE, fmt.Errorf("getFeeSplitCurveInfo, get feeSplitCurveInfo error: %v", err)
+	}
+	sink := common.NewZeroCopySink(nil)
+	info.Serialization(sink)
+	return sink.Bytes(), nil
+}
+
 //Transfer all punished ONT of a black node to a certain address
 func TransferPenalty(native *native.NativeService) ([]byte, error) {
 	// get admin from database
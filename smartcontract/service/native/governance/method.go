@@ -318,6 +318,31 @@ func normalQuit(native *native.NativeService, contract common.Address, peerPoolI
 	return nil
 }
 
+//blackPeer put peer into black list and change its status in peerPoolMap, return whether it is a consensus peer
+func blackPeer(native *native.NativeService, contract common.Address, peerPoolMap *PeerPoolMap, peerPubkey string) (bool, error) {
+	peerPubkeyPrefix, err := hex.DecodeString(peerPubkey)
+	if err != nil {
+		return false, fmt.Errorf("hex.DecodeString, peerPubkey format error: %v", err)
+	}
+	peerPoolItem, ok := peerPoolMap.PeerPoolMap[peerPubkey]
+	if !ok {
+		return false, fmt.Errorf("blackNode, peerPubkey is not in peerPoolMap")
+	}
+
+	blackListItem := &BlackListItem{
+		PeerPubkey: peerPoolItem.PeerPubkey,
+		Address:    peerPoolItem.Address,
+		InitPos:    peerPoolItem.InitPos,
+	}
+	//put peer into black list
+	native.CacheDB.Put(utils.ConcatKey(contract, []byte(BLACK_LIST), peerPubkeyPrefix), cstates.GenRawStorageItem(common.SerializeToBytes(blackListItem)))
+	//change peerPool status
+	isConsensus := peerPoolItem.Status == ConsensusStatus
+	peerPoolItem.Status = BlackStatus
+	peerPoolMap.PeerPoolMap[peerPubkey] = peerPoolItem
+	return isConsensus, nil
+}
+
 func blackQuit(native *native.NativeService, contract common.Address, peerPoolItem *PeerPoolItem) error {
 	// ont transfer to trigger unboundong
 	err := appCallTransferOnt(native, utils.GovernanceContractAddress, utils.GovernanceContractAddress, peerPoolItem.InitPos)
@@ -731,10 +756,10 @@ func executeSplit(native *native.NativeService, contract common.Address, view ui
 	if err != nil {
 		return fmt.Errorf("executeSplit, getOngBalance error: %v", err)
 	}
-	//get globalParam
-	globalParam, err := getGlobalParam(native, contract)
+	//get fee split curve
+	curve, err := getFeeSplitCurve(native, contract)
 	if err != nil {
-		return fmt.Errorf("getGlobalParam, getGlobalParam error: %v", err)
+		return fmt.Errorf("getFeeSplitCurve, get feeSplitCurve error: %v", err)
 	}
 
 	peersCandidate := []*CandidateSplitInfo{}
@@ -773,7 +798,7 @@ func executeSplit(native *native.NativeService, contract common.Address, view ui
 	avg := sum / uint64(config.K)
 	var sumS uint64
 	for i := 0; i < int(config.K); i++ {
-		peersCandidate[i].S, err = splitCurve(native, contract, peersCandidate[i].Stake, avg, uint64(globalParam.Yita))
+		peersCandidate[i].S, err = splitCurve(curve, peersCandidate[i].Stake, avg)
 		if err != nil {
 			return fmt.Errorf("splitCurve, calculate splitCurve error: %v", err)
 		}
@@ -785,7 +810,7 @@ func executeSplit(native *native.NativeService, contract common.Address, view ui
 
 	//fee split of consensus peer
 	for i := 0; i < int(config.K); i++ {
-		nodeAmount := balance * uint64(globalParam.A) / 100 * peersCandidate[i].S / sumS
+		nodeAmount := balance * uint64(curve.A) / 100 * peersCandidate[i].S / sumS
 		address := peersCandidate[i].Address
 		err = appCallTransferOng(native, utils.GovernanceContractAddress, address, nodeAmount)
 		if err != nil {
@@ -803,7 +828,7 @@ func executeSplit(native *native.NativeService, contract common.Address, view ui
 		return nil
 	}
 	for i := int(config.K); i < len(peersCandidate); i++ {
-		nodeAmount := balance * uint64(globalParam.B) / 100 * peersCandidate[i].Stake / sum
+		nodeAmount := balance * uint64(curve.B) / 100 * peersCandidate[i].Stake / sum
 		address := peersCandidate[i].Address
 		err = appCallTransferOng(native, utils.GovernanceContractAddress, address, nodeAmount)
 		if err != nil {
@@ -874,10 +899,10 @@ func executeSplit2(native *native.NativeService, contract common.Address, view u
 		panic("income less than dappIncome!")
 	}
 	nodeIncome := new(big.Int).Sub(new(big.Int).SetUint64(income), dappIncome)
-	//get globalParam
-	globalParam, err := getGlobalParam(native, contract)
+	//get fee split curve
+	curve, err := getFeeSplitCurve(native, contract)
 	if err != nil {
-		return splitSum, fmt.Errorf("getGlobalParam, getGlobalParam error: %v", err)
+		return splitSum, fmt.Errorf("getFeeSplitCurve, get feeSplitCurve error: %v", err)
 	}
 
 	peersCandidate := []*CandidateSplitInfo{}
@@ -916,7 +941,7 @@ func executeSplit2(native *native.NativeService, contract common.Address, view u
 	avg := sum / uint64(config.K)
 	var sumS uint64
 	for i := 0; i < int(config.K); i++ {
-		peersCandidate[i].S, err = splitCurve(native, contract, peersCandidate[i].Stake, avg, uint64(globalParam.Yita))
+		peersCandidate[i].S, err = splitCurve(curve, peersCandidate[i].Stake, avg)
 		if err != nil {
 			return splitSum, fmt.Errorf("splitCurve, calculate splitCurve error: %v", err)
 		}
@@ -928,12 +953,12 @@ func executeSplit2(native *native.NativeService, contract common.Address, view u
 
 	//fee split of consensus peer
 	for i := 0; i < int(config.K); i++ {
-		//nodeAmount := nodeIncome * uint64(globalParam.A) / 100 * peersCandidate[i].S / sumS
-		//consensusWeight := nodeIncome * uint64(globalParam.A)
+		//nodeAmount := nodeIncome * uint64(curve.A) / 100 * peersCandidate[i].S / sumS
+		//consensusWeight := nodeIncome * uint64(curve.A)
 		//consensusAmount := consensusWeight / 100
 		//nodeWeight := consensusAmount * peersCandidate[i].S
 		//nodeAmount := nodeWeight / sumS
-		consensusWeight := new(big.Int).Mul(nodeIncome, new(big.Int).SetUint64(uint64(globalParam.A)))
+		consensusWeight := new(big.Int).Mul(nodeIncome, new(big.Int).SetUint64(uint64(curve.A)))
 		consensusAmount := new(big.Int).Div(consensusWeight, new(big.Int).SetUint64(100))
 		nodeWeight := new(big.Int).Mul(consensusAmount, new(big.Int).SetUint64(peersCandidate[i].S))
 		nodeAmount := new(big.Int).Div(nodeWeight, new(big.Int).SetUint64(sumS))
@@ -964,12 +989,12 @@ func executeSplit2(native *native.NativeService, contract common.Address, view u
 		return splitSum, nil
 	}
 	for i := int(config.K); i < length; i++ {
-		//nodeAmount := nodeIncome * uint64(globalParam.B) / 100 * peersCandidate[i].Stake / sum
-		//candidateWeight := nodeIncome * uint64(globalParam.B)
+		//nodeAmount := nodeIncome * uint64(curve.B) / 100 * peersCandidate[i].Stake / sum
+		//candidateWeight := nodeIncome * uint64(curve.B)
 		//candidateAmount := candidateWeight / 100
 		//nodeWeight := candidateAmount * peersCandidate[i].Stake
 		//nodeAmount := nodeWeight / sum
-		candidateWeight := new(big.Int).Mul(nodeIncome, new(big.Int).SetUint64(uint64(globalParam.B)))
+		candidateWeight := new(big.Int).Mul(nodeIncome, new(big.Int).SetUint64(uint64(curve.B)))
 		candidateAmount := new(big.Int).Div(candidateWeight, new(big.Int).SetUint64(100))
 		nodeWeight := new(big.Int).Mul(candidateAmount, new(big.Int).SetUint64(peersCandidate[i].Stake))
 		nodeAmount := new(big.Int).Div(nodeWeight, new(big.Int).SetUint64(sum))
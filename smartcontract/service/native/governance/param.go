@@ -669,6 +669,183 @@ func (this *SplitCurve) Deserialization(source *common.ZeroCopySource) error {
 	return nil
 }
 
+//FeeSplitCurve is the curve and weights of fee split, it replaces the fixed Xi, the split curve and A, B, Yita of
+//global param once updated
+type FeeSplitCurve struct {
+	Xi   []uint32 //x of curve points, start from 0 and strictly increasing
+	Yi   []uint32 //y of curve points
+	Yita uint32   //split curve coefficient
+	A    uint32   //fee split to all consensus node
+	B    uint32   //fee split to all candidate node
+}
+
+func (this *FeeSplitCurve) Validate() error {
+	if len(this.Xi) < 2 || len(this.Xi) > MAX_SPLIT_CURVE_POINTS {
+		return fmt.Errorf("length of Xi must be in [2, %d]", MAX_SPLIT_CURVE_POINTS)
+	}
+	if len(this.Xi) != len(this.Yi) {
+		return fmt.Errorf("length of Xi and Yi must be equal")
+	}
+	if this.Xi[0] != 0 {
+		return fmt.Errorf("Xi must start from 0")
+	}
+	for i := 1; i < len(this.Xi); i++ {
+		if this.Xi[i] <= this.Xi[i-1] {
+			return fmt.Errorf("Xi must be strictly increasing, Xi[%d]:%d <= Xi[%d]:%d", i, this.Xi[i], i-1, this.Xi[i-1])
+		}
+	}
+	for i := 0; i < len(this.Xi); i++ {
+		if this.Xi[i] > MAX_SPLIT_CURVE_VALUE || this.Yi[i] > MAX_SPLIT_CURVE_VALUE {
+			return fmt.Errorf("curve point %d larger than %d", i, MAX_SPLIT_CURVE_VALUE)
+		}
+	}
+	if this.Yita == 0 {
+		return fmt.Errorf("Yita must > 0")
+	}
+	if this.A+this.B != 100 {
+		return fmt.Errorf("A + B must equal to 100")
+	}
+	return nil
+}
+
+func (this *FeeSplitCurve) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeVarUint(sink, uint64(len(this.Xi)))
+	for _, v := range this.Xi {
+		utils.EncodeVarUint(sink, uint64(v))
+	}
+	utils.EncodeVarUint(sink, uint64(len(this.Yi)))
+	for _, v := range this.Yi {
+		utils.EncodeVarUint(sink, uint64(v))
+	}
+	utils.EncodeVarUint(sink, uint64(this.Yita))
+	utils.EncodeVarUint(sink, uint64(this.A))
+	utils.EncodeVarUint(sink, uint64(this.B))
+}
+
+func (this *FeeSplitCurve) Deserialization(source *common.ZeroCopySource) error {
+	xi, err := decodeUint32Slice(source)
+	if err != nil {
+		return fmt.Errorf("decodeUint32Slice, deserialize Xi error: %v", err)
+	}
+	yi, err := decodeUint32Slice(source)
+	if err != nil {
+		return fmt.Errorf("decodeUint32Slice, deserialize Yi error: %v", err)
+	}
+	yita, err := utils.DecodeVarUint(source)
+	if err != nil {
+		return fmt.Errorf("utils.ReadVarUint, deserialize yita error: %v", err)
+	}
+	a, err := utils.DecodeVarUint(source)
+	if err != nil {
+		return fmt.Errorf("utils.ReadVarUint, deserialize a error: %v", err)
+	}
+	b, err := utils.DecodeVarUint(source)
+	if err != nil {
+		return fmt.Errorf("utils.ReadVarUint, deserialize b error: %v", err)
+	}
+	if yita > math.MaxUint32 {
+		return fmt.Errorf("yita larger than max of uint32")
+	}
+	if a > math.MaxUint32 {
+		return fmt.Errorf("a larger than max of uint32")
+	}
+	if b > math.MaxUint32 {
+		return fmt.Errorf("b larger than max of uint32")
+	}
+	this.Xi = xi
+	this.Yi = yi
+	this.Yita = uint32(yita)
+	this.A = uint32(a)
+	this.B = uint32(b)
+	return nil
+}
+
+func decodeUint32Slice(source *common.ZeroCopySource) ([]uint32, error) {
+	n, err := utils.DecodeVarUint(source)
+	if err != nil {
+		return nil, err
+	}
+	if n > MAX_SPLIT_CURVE_POINTS {
+		return nil, fmt.Errorf("length %d larger than %d", n, MAX_SPLIT_CURVE_POINTS)
+	}
+	result := make([]uint32, 0, n)
+	for i := uint64(0); i < n; i++ {
+		k, err := utils.DecodeVarUint(source)
+		if err != nil {
+			return nil, err
+		}
+		if k > math.MaxUint32 {
+			return nil, fmt.Errorf("value larger than max of uint32")
+		}
+		result = append(result, uint32(k))
+	}
+	return result, nil
+}
+
+type UpdateFeeSplitCurveParam struct {
+	ActiveHeight uint32 //block height from which the curve takes effect
+	Curve        FeeSplitCurve
+}
+
+func (this *UpdateFeeSplitCurveParam) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeVarUint(sink, uint64(this.ActiveHeight))
+	this.Curve.Serialization(sink)
+}
+
+func (this *UpdateFeeSplitCurveParam) Deserialization(source *common.ZeroCopySource) error {
+	activeHeight, err := utils.DecodeVarUint(source)
+	if err != nil {
+		return fmt.Errorf("utils.ReadVarUint, deserialize activeHeight error: %v", err)
+	}
+	if activeHeight > math.MaxUint32 {
+		return fmt.Errorf("activeHeight larger than max of uint32")
+	}
+	if err := this.Curve.Deserialization(source); err != nil {
+		return fmt.Errorf("deserialize, deserialize curve error: %v", err)
+	}
+	this.ActiveHeight = uint32(activeHeight)
+	return nil
+}
+
+//FeeSplitCurveInfo is the stored fee split curve, Previous takes effect before ActiveHeight
+type FeeSplitCurveInfo struct {
+	ActiveHeight uint32
+	Curve        FeeSplitCurve
+	Previous     FeeSplitCurve
+}
+
+func (this *FeeSplitCurveInfo) Serialization(sink *common.ZeroCopySink) {
+	utils.EncodeVarUint(sink, uint64(this.ActiveHeight))
+	this.Curve.Serialization(sink)
+	this.Previous.Serialization(sink)
+}
+
+func (this *FeeSplitCurveInfo) Deserialization(source *common.ZeroCopySource) error {
+	activeHeight, err := utils.DecodeVarUint(source)
+	if err != nil {
+		return fmt.Errorf("utils.ReadVarUint, deserialize activeHeight error: %v", err)
+	}
+	if activeHeight > math.MaxUint32 {
+		return fmt.Errorf("activeHeight larger than max of uint32")
+	}
+	if err := this.Curve.Deserialization(source); err != nil {
+		return fmt.Errorf("deserialize, deserialize curve error: %v", err)
+	}
+	if err := this.Previous.Deserialization(source); err != nil {
+		return fmt.Errorf("deserialize, deserialize previous curve error: %v", err)
+	}
+	this.ActiveHeight = uint32(activeHeight)
+	return nil
+}
+
+//Effective return the curve takes effect at height
+func (this *FeeSplitCurveInfo) Effective(height uint32) *FeeSplitCurve {
+	if height >= this.ActiveHeight {
+		return &this.Curve
+	}
+	return &this.Previous
+}
+
 type TransferPenaltyParam struct {
 	PeerPubkey string
 	Address    common.Address
@@ -693,6 +870,75 @@ func (this *TransferPenaltyParam) Deserialization(source *common.ZeroCopySource)
 	return nil
 }
 
+//FaultyEvidence is two conflicting block hashes of the same round signed by one peer.
+//Headers are raw block headers, sigs are signatures of the faulty peer on the header hashes.
+type FaultyEvidence struct {
+	Header1 []byte
+	Sig1    []byte
+	Header2 []byte
+	Sig2    []byte
+}
+
+func (this *FaultyEvidence) Serialization(sink *common.ZeroCopySink) {
+	sink.WriteVarBytes(this.Header1)
+	sink.WriteVarBytes(this.Sig1)
+	sink.WriteVarBytes(this.Header2)
+	sink.WriteVarBytes(this.Sig2)
+}
+
+func (this *FaultyEvidence) Deserialization(source *common.ZeroCopySource) error {
+	header1, err := utils.DecodeVarBytes(source)
+	if err != nil {
+		return fmt.Errorf("utils.DecodeVarBytes, deserialize header1 error: %v", err)
+	}
+	sig1, err := utils.DecodeVarBytes(source)
+	if err != nil {
+		return fmt.Errorf("utils.DecodeVarBytes, deserialize sig1 error: %v", err)
+	}
+	header2, err := utils.DecodeVarBytes(source)
+	if err != nil {
+		return fmt.Errorf("utils.DecodeVarBytes, deserialize header2 error: %v", err)
+	}
+	sig2, err := utils.DecodeVarBytes(source)
+	if err != nil {
+		return fmt.Errorf("utils.DecodeVarBytes, deserialize sig2 error: %v", err)
+	}
+	this.Header1 = header1
+	this.Sig1 = sig1
+	this.Header2 = header2
+	this.Sig2 = sig2
+	return nil
+}
+
+type ReportFaultyNodeParam struct {
+	Reporter   common.Address
+	PeerPubkey string
+	Evidence   FaultyEvidence
+}
+
+func (this *ReportFaultyNodeParam) Serialization(sink *common.ZeroCopySink) {
+	sink.WriteVarBytes(this.Reporter[:])
+	sink.WriteString(this.PeerPubkey)
+	this.Evidence.Serialization(sink)
+}
+
+func (this *ReportFaultyNodeParam) Deserialization(source *common.ZeroCopySource) error {
+	reporter, err := utils.DecodeAddress(source)
+	if err != nil {
+		return fmt.Errorf("utils.DecodeAddress, deserialize reporter error: %v", err)
+	}
+	peerPubkey, err := utils.DecodeString(source)
+	if err != nil {
+		return fmt.Errorf("utils.DecodeString, deserialize peerPubkey error: %v", err)
+	}
+	if err := this.Evidence.Deserialization(source); err != nil {
+		return fmt.Errorf("deserialize evidence error: %v", err)
+	}
+	this.Reporter = reporter
+	this.PeerPubkey = peerPubkey
+	return nil
+}
+
 type WithdrawOngParam struct {
 	Address common.Address
 }
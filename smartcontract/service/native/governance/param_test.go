@@ -0,0 +1,233 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ontio/dad-go-crypto/keypair"
+	"github.com/ontio/dad-go/account"
+	"github.com/ontio/dad-go/common"
+	vconfig "github.com/ontio/dad-go/consensus/vbft/config"
+	"github.com/ontio/dad-go/core/signature"
+	"github.com/ontio/dad-go/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testYi = []uint32{
+	0, 95123, 180968, 258213, 327493, 389401, 444491, 493282, 536257, 573866, 606531, 634645, 658574, 678660, 695220, 708550,
+	718927, 726606, 731826, 734808, 735759, 734870, 732317, 728265, 722867, 716262, 708583, 699949, 690472, 680254, 669391,
+	657969, 646069, 633765, 621124, 608209, 595076, 581778, 568361, 554869, 541342, 527814, 514317, 500882, 487534, 474297,
+	461191, 448236, 435447, 422839, 410425, 398217, 386223, 374452, 362910, 351604, 340537, 329713, 319135, 308805, 298723,
+	288890, 279306, 269969, 260879, 252033, 243429, 235066, 226939, 219045, 211382, 203945, 196731, 189736, 182955, 176384,
+	170018, 163854, 157887, 152113, 146526, 141122, 135896, 130845, 125963, 121246, 116690, 112290, 108041, 103940, 99981,
+	96162, 92477, 88923, 85496, 82192, 79006, 75936, 72977, 70126, 67380,
+}
+
+func TestFeeSplitCurve_Serialization(t *testing.T) {
+	param := &UpdateFeeSplitCurveParam{
+		ActiveHeight: 100,
+		Curve: FeeSplitCurve{
+			Xi:   []uint32{0, 1000000, 5000000},
+			Yi:   []uint32{0, 600000, 100000},
+			Yita: 5,
+			A:    50,
+			B:    50,
+		},
+	}
+	param2 := new(UpdateFeeSplitCurveParam)
+	assert.Nil(t, param2.Deserialization(common.NewZeroCopySource(common.SerializeToBytes(param))))
+	assert.Equal(t, param, param2)
+
+	info := &FeeSplitCurveInfo{
+		ActiveHeight: 100,
+		Curve:        param.Curve,
+		Previous:     FeeSplitCurve{Xi: Xi, Yi: testYi, Yita: 5, A: 50, B: 50},
+	}
+	info2 := new(FeeSplitCurveInfo)
+	assert.Nil(t, info2.Deserialization(common.NewZeroCopySource(common.SerializeToBytes(info))))
+	assert.Equal(t, info, info2)
+	assert.Equal(t, &info.Previous, info.Effective(99))
+	assert.Equal(t, &info.Curve, info.Effective(100))
+}
+
+func TestFeeSplitCurve_Validate(t *testing.T) {
+	valid := func() *FeeSplitCurve {
+		return &FeeSplitCurve{Xi: []uint32{0, 10, 20}, Yi: []uint32{0, 5, 1}, Yita: 5, A: 50, B: 50}
+	}
+	assert.Nil(t, valid().Validate())
+	assert.Nil(t, (&FeeSplitCurve{Xi: Xi, Yi: testYi, Yita: 5, A: 50, B: 50}).Validate())
+
+	curve := valid()
+	curve.Xi = []uint32{0, 20, 10}
+	assert.NotNil(t, curve.Validate())
+	curve.Xi = []uint32{0, 10, 10}
+	assert.NotNil(t, curve.Validate())
+	curve.Xi = []uint32{1, 10, 20}
+	assert.NotNil(t, curve.Validate())
+	curve = valid()
+	curve.Yi = []uint32{0, 5}
+	assert.NotNil(t, curve.Validate())
+	curve = valid()
+	curve.Xi, curve.Yi = []uint32{0}, []uint32{0}
+	assert.NotNil(t, curve.Validate())
+	curve = valid()
+	curve.Yi[1] = MAX_SPLIT_CURVE_VALUE + 1
+	assert.NotNil(t, curve.Validate())
+	curve = valid()
+	curve.A = 60
+	assert.NotNil(t, curve.Validate())
+	curve = valid()
+	curve.Yita = 0
+	assert.NotNil(t, curve.Validate())
+}
+
+func TestSplitCurve(t *testing.T) {
+	// the result with fixed Xi must be the same as the interpolation of uniform grid before
+	legacy := func(pos, avg, yita uint64) uint64 {
+		xi := PRECISE * yita * 2 * pos / (avg * 10)
+		index := xi / (PRECISE / 10)
+		if index > uint64(len(Xi)-2) {
+			index = uint64(len(Xi) - 2)
+			xi = uint64(Xi[len(Xi)-1])
+		}
+		return (uint64(testYi[index+1])*xi + uint64(testYi[index])*uint64(Xi[index+1]) - uint64(testYi[index])*xi -
+			uint64(testYi[index+1])*uint64(Xi[index])) / (uint64(Xi[index+1]) - uint64(Xi[index]))
+	}
+	curve := &FeeSplitCurve{Xi: Xi, Yi: testYi, Yita: 5, A: 50, B: 50}
+	assert.True(t, isUniformXi(Xi))
+	for _, pos := range []uint64{0, 1, 999, 10000, 12345, 20000, 99999, 100000, 100001, 1000000} {
+		s, err := splitCurve(curve, pos, 10000)
+		assert.Nil(t, err)
+		assert.Equal(t, legacy(pos, 10000, 5), s, "pos %d", pos)
+	}
+	// every point of the grid and its neighbours, xi = 100 * pos here
+	for k := uint64(0); k <= uint64(len(Xi)); k++ {
+		for _, pos := range []uint64{k*1000 - 1, k * 1000, k*1000 + 1} {
+			if pos > k*1000+1 {
+				continue
+			}
+			s, err := splitCurve(curve, pos, 10000)
+			assert.Nil(t, err)
+			assert.Equal(t, legacy(pos, 10000, 5), s, "pos %d", pos)
+		}
+	}
+
+	curve = &FeeSplitCurve{Xi: []uint32{0, 1000000, 5000000}, Yi: []uint32{0, 600000, 200000}, Yita: 5, A: 50, B: 50}
+	assert.False(t, isUniformXi(curve.Xi))
+	s, err := splitCurve(curve, 500, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(300000), s)
+	s, err = splitCurve(curve, 3000, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(400000), s)
+	s, err = splitCurve(curve, 100000, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(200000), s)
+	_, err = splitCurve(curve, 100, 0)
+	assert.NotNil(t, err)
+}
+
+func TestReportFaultyNodeParam_Serialization(t *testing.T) {
+	param := &ReportFaultyNodeParam{
+		Reporter:   common.Address{1, 2, 3},
+		PeerPubkey: "0253ccfd439b29eca0fe90ca7c6eaa1f98572a054aa2d1d56e72ad96c466107a85",
+		Evidence: FaultyEvidence{
+			Header1: []byte{1, 2},
+			Sig1:    []byte{3},
+			Header2: []byte{4, 5, 6},
+			Sig2:    []byte{7},
+		},
+	}
+	param2 := new(ReportFaultyNodeParam)
+	err := param2.Deserialization(common.NewZeroCopySource(common.SerializeToBytes(param)))
+	assert.Nil(t, err)
+	assert.Equal(t, param, param2)
+}
+
+func newFaultyTestHeader(t *testing.T, acc *account.Account, height, timestamp uint32, proposer uint32) ([]byte, []byte) {
+	return newFaultyTestRoundHeader(t, acc, height, timestamp, common.Uint256{}, &vconfig.VbftBlockInfo{Proposer: proposer})
+}
+
+func newFaultyTestRoundHeader(t *testing.T, acc *account.Account, height, timestamp uint32, prevHash common.Uint256,
+	info *vconfig.VbftBlockInfo) ([]byte, []byte) {
+	payload, err := json.Marshal(info)
+	assert.Nil(t, err)
+	header := &types.Header{
+		PrevBlockHash:    prevHash,
+		Height:           height,
+		Timestamp:        timestamp,
+		ConsensusData:    common.GetNonce(),
+		ConsensusPayload: payload,
+	}
+	hash := header.Hash()
+	sig, err := signature.Sign(acc, hash[:])
+	assert.Nil(t, err)
+	header.Bookkeepers = []keypair.PublicKey{acc.PublicKey}
+	header.SigData = [][]byte{sig}
+	sink := common.NewZeroCopySink(nil)
+	header.Serialization(sink)
+	return sink.Bytes(), sig
+}
+
+func TestVerifyFaultyEvidence(t *testing.T) {
+	acc := account.NewAccount("")
+	other := account.NewAccount("")
+	peerPubkey := vconfig.PubkeyID(acc.PublicKey)
+
+	header1, sig1 := newFaultyTestHeader(t, acc, 100, 1000, 1)
+	header2, sig2 := newFaultyTestHeader(t, acc, 100, 1001, 1)
+	height, err := VerifyFaultyEvidence(peerPubkey, &FaultyEvidence{header1, sig1, header2, sig2})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(100), height)
+
+	// same header
+	_, err = VerifyFaultyEvidence(peerPubkey, &FaultyEvidence{header1, sig1, header1, sig1})
+	assert.NotNil(t, err)
+	// block and empty block of one proposal
+	header3, sig3 := newFaultyTestHeader(t, acc, 100, 1000, 1)
+	_, err = VerifyFaultyEvidence(peerPubkey, &FaultyEvidence{header1, sig1, header3, sig3})
+	assert.NotNil(t, err)
+	// different height
+	header4, sig4 := newFaultyTestHeader(t, acc, 101, 1001, 1)
+	_, err = VerifyFaultyEvidence(peerPubkey, &FaultyEvidence{header1, sig1, header4, sig4})
+	assert.NotNil(t, err)
+	// different proposer
+	header5, sig5 := newFaultyTestHeader(t, acc, 100, 1001, 2)
+	_, err = VerifyFaultyEvidence(peerPubkey, &FaultyEvidence{header1, sig1, header5, sig5})
+	assert.NotNil(t, err)
+	// signed by another peer
+	header6, sig6 := newFaultyTestHeader(t, other, 100, 1001, 1)
+	_, err = VerifyFaultyEvidence(peerPubkey, &FaultyEvidence{header1, sig1, header6, sig6})
+	assert.NotNil(t, err)
+	// different round of the same height
+	header7, sig7 := newFaultyTestRoundHeader(t, acc, 100, 1001, common.Uint256{1}, &vconfig.VbftBlockInfo{Proposer: 1})
+	_, err = VerifyFaultyEvidence(peerPubkey, &FaultyEvidence{header1, sig1, header7, sig7})
+	assert.NotNil(t, err)
+	header8, sig8 := newFaultyTestRoundHeader(t, acc, 100, 1001, common.Uint256{},
+		&vconfig.VbftBlockInfo{Proposer: 1, VrfValue: []byte{1}})
+	_, err = VerifyFaultyEvidence(peerPubkey, &FaultyEvidence{header1, sig1, header8, sig8})
+	assert.NotNil(t, err)
+	// different config view
+	header9, sig9 := newFaultyTestRoundHeader(t, acc, 100, 1001, common.Uint256{},
+		&vconfig.VbftBlockInfo{Proposer: 1, LastConfigBlockNum: 10})
+	_, err = VerifyFaultyEvidence(peerPubkey, &FaultyEvidence{header1, sig1, header9, sig9})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ontio/dad-go-crypto/keypair"
+	"github.com/ontio/dad-go/account"
+	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/common/config"
+	vconfig "github.com/ontio/dad-go/consensus/vbft/config"
+	"github.com/ontio/dad-go/core/signature"
+	cstates "github.com/ontio/dad-go/core/states"
+	"github.com/ontio/dad-go/core/types"
+	"github.com/ontio/dad-go/smartcontract/service/native"
+	"github.com/ontio/dad-go/smartcontract/service/native/governance"
+	"github.com/ontio/dad-go/smartcontract/service/native/testsuite"
+	"github.com/ontio/dad-go/smartcontract/service/native/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReportTestHeader(t *testing.T, acc *account.Account, height, timestamp uint32) ([]byte, []byte) {
+	payload, err := json.Marshal(&vconfig.VbftBlockInfo{Proposer: 1})
+	assert.Nil(t, err)
+	header := &types.Header{
+		Height:           height,
+		Timestamp:        timestamp,
+		ConsensusData:    common.GetNonce(),
+		ConsensusPayload: payload,
+	}
+	hash := header.Hash()
+	sig, err := signature.Sign(acc, hash[:])
+	assert.Nil(t, err)
+	header.Bookkeepers = []keypair.PublicKey{acc.PublicKey}
+	header.SigData = [][]byte{sig}
+	sink := common.NewZeroCopySink(nil)
+	header.Serialization(sink)
+	return sink.Bytes(), sig
+}
+
+func putReportTestPeer(t *testing.T, n *native.NativeService, peerPubkey string, status governance.Status) {
+	contract := utils.GovernanceContractAddress
+	bf := new(bytes.Buffer)
+	assert.Nil(t, (&governance.GovernanceView{View: 1}).Serialize(bf))
+	n.CacheDB.Put(utils.ConcatKey(contract, []byte(governance.GOVERNANCE_VIEW)), cstates.GenRawStorageItem(bf.Bytes()))
+
+	peerPoolMap := &governance.PeerPoolMap{
+		PeerPoolMap: map[string]*governance.PeerPoolItem{
+			peerPubkey: {
+				Index:      1,
+				PeerPubkey: peerPubkey,
+				Address:    testsuite.RandomAddress(),
+				Status:     status,
+				InitPos:    10000,
+			},
+		},
+	}
+	sink := common.NewZeroCopySink(nil)
+	assert.Nil(t, peerPoolMap.Serialization(sink))
+	viewBytes, err := governance.GetUint32Bytes(1)
+	assert.Nil(t, err)
+	n.CacheDB.Put(utils.ConcatKey(contract, []byte(governance.PEER_POOL), viewBytes), cstates.GenRawStorageItem(sink.Bytes()))
+}
+
+func reportFaultyNode(n *native.NativeService, reporter common.Address, peerPubkey string, evidence governance.FaultyEvidence) error {
+	param := &governance.ReportFaultyNodeParam{
+		Reporter:   reporter,
+		PeerPubkey: peerPubkey,
+		Evidence:   evidence,
+	}
+	n.Input = common.SerializeToBytes(param)
+	n.Tx.SignedAddr = []common.Address{reporter}
+	_, err := governance.ReportFaultyNode(n)
+	return err
+}
+
+func TestReportFaultyNode(t *testing.T) {
+	networkId := config.DefConfig.P2PNode.NetworkId
+	defer func() {
+		config.DefConfig.P2PNode.NetworkId = networkId
+	}()
+
+	acc := account.NewAccount("")
+	peerPubkey := vconfig.PubkeyID(acc.PublicKey)
+	header1, sig1 := newReportTestHeader(t, acc, 100, 1000)
+	header2, sig2 := newReportTestHeader(t, acc, 100, 1001)
+	evidence := governance.FaultyEvidence{Header1: header1, Sig1: sig1, Header2: header2, Sig2: sig2}
+
+	testsuite.InvokeNativeContract(t, utils.GovernanceContractAddress, func(n *native.NativeService) ([]byte, error) {
+		n.Height = 200
+		reporter := testsuite.RandomAddress()
+		putReportTestPeer(t, n, peerPubkey, governance.CandidateStatus)
+
+		// not enabled on main net yet
+		config.DefConfig.P2PNode.NetworkId = config.NETWORK_ID_MAIN_NET
+		assert.NotNil(t, reportFaultyNode(n, reporter, peerPubkey, evidence))
+		config.DefConfig.P2PNode.NetworkId = config.NETWORK_ID_SOLO_NET
+
+		// not signed by reporter
+		n.Input = common.SerializeToBytes(&governance.ReportFaultyNodeParam{
+			Reporter:   reporter,
+			PeerPubkey: peerPubkey,
+			Evidence:   evidence,
+		})
+		n.Tx.SignedAddr = []common.Address{}
+		_, err := governance.ReportFaultyNode(n)
+		assert.NotNil(t, err)
+
+		// evidence higher than current block
+		header3, sig3 := newReportTestHeader(t, acc, 300, 1000)
+		header4, sig4 := newReportTestHeader(t, acc, 300, 1001)
+		assert.NotNil(t, reportFaultyNode(n, reporter, peerPubkey,
+			governance.FaultyEvidence{Header1: header3, Sig1: sig3, Header2: header4, Sig2: sig4}))
+
+		// invalid evidence
+		assert.NotNil(t, reportFaultyNode(n, reporter, peerPubkey,
+			governance.FaultyEvidence{Header1: header1, Sig1: sig1, Header2: header1, Sig2: sig1}))
+
+		assert.Nil(t, reportFaultyNode(n, reporter, peerPubkey, evidence))
+		peerPoolMap, err := governance.GetPeerPoolMap(n, utils.GovernanceContractAddress, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, governance.BlackStatus, peerPoolMap.PeerPoolMap[peerPubkey].Status)
+
+		// one evidence is reported once
+		assert.NotNil(t, reportFaultyNode(n, reporter, peerPubkey, evidence))
+		return nil, nil
+	})
+}
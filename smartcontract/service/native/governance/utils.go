@@ -22,13 +22,16 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"sort"
 
 	"github.com/ontio/ontology-crypto/vrf"
 	"github.com/ontio/ontology/common"
 	"github.com/ontio/ontology/common/config"
 	"github.com/ontio/ontology/common/serialization"
 	vbftconfig "github.com/ontio/ontology/consensus/vbft/config"
+	"github.com/ontio/ontology/core/signature"
 	cstates "github.com/ontio/ontology/core/states"
+	"github.com/ontio/ontology/core/types"
 	"github.com/ontio/ontology/smartcontract/service/native"
 	"github.com/ontio/ontology/smartcontract/service/native/auth"
 	"github.com/ontio/ontology/smartcontract/service/native/ont"
@@ -187,25 +190,43 @@ func getOngBalance(native *native.NativeService, address common.Address) (uint64
 	return balance, nil
 }
 
-func splitCurve(native *native.NativeService, contract common.Address, pos uint64, avg uint64, yita uint64) (uint64, error) {
+func splitCurve(curve *FeeSplitCurve, pos uint64, avg uint64) (uint64, error) {
 	if avg == 0 {
 		return 0, fmt.Errorf("splitCurve, avg stake is 0")
 	}
-	xi := PRECISE * yita * 2 * pos / (avg * 10)
-	index := xi / (PRECISE / 10)
-	if index > uint64(len(Xi)-2) {
+	Xi, Yi := curve.Xi, curve.Yi
+	if len(Xi) < 2 || len(Xi) != len(Yi) {
+		return 0, fmt.Errorf("splitCurve, invalid curve with %d Xi and %d Yi", len(Xi), len(Yi))
+	}
+	xi := PRECISE * uint64(curve.Yita) * 2 * pos / (avg * 10)
+	var index uint64
+	if isUniformXi(Xi) {
+		//the points are evenly spaced as the fixed Xi, keep the calculation before updateFeeSplitCurve
+		index = xi / (PRECISE / 10)
+		if index > uint64(len(Xi)-2) {
+			index = uint64(len(Xi) - 2)
+			xi = uint64(Xi[len(Xi)-1])
+		}
+	} else if xi >= uint64(Xi[len(Xi)-1]) {
 		index = uint64(len(Xi) - 2)
 		xi = uint64(Xi[len(Xi)-1])
+	} else {
+		index = uint64(sort.Search(len(Xi), func(i int) bool { return uint64(Xi[i]) > xi }) - 1)
 	}
-	splitCurve, err := getSplitCurve(native, contract)
-	if err != nil {
-		return 0, fmt.Errorf("getSplitCurve, get splitCurve error: %v", err)
-	}
-	Yi := splitCurve.Yi
 	s := (uint64(Yi[index+1])*xi + uint64(Yi[index])*uint64(Xi[index+1]) - uint64(Yi[index])*xi - uint64(Yi[index+1])*uint64(Xi[index])) / (uint64(Xi[index+1]) - uint64(Xi[index]))
 	return s, nil
 }
 
+//isUniformXi check if the curve points are spaced by PRECISE / 10 from 0
+func isUniformXi(xi []uint32) bool {
+	for i, x := range xi {
+		if uint64(x) != uint64(i)*(PRECISE/10) {
+			return false
+		}
+	}
+	return true
+}
+
 func GetUint32Bytes(num uint32) ([]byte, error) {
 	bf := new(bytes.Buffer)
 	if err := serialization.WriteUint32(bf, num); err != nil {
@@ -309,6 +330,91 @@ func validatePeerPubKeyFormat(pubkey string) error {
 	return nil
 }
 
+//VerifyFaultyEvidence check that the peer signed two different block headers of the same round proposed by one proposer,
+//and the headers are not the block and empty block of one proposal. Headers of one round share the height, prev block,
+//vrf value and config view. Return the height of the evidence.
+func VerifyFaultyEvidence(peerPubkey string, evidence *FaultyEvidence) (uint32, error) {
+	pubkey, err := vbftconfig.Pubkey(peerPubkey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse pubkey, %s", err)
+	}
+	header1, err := types.HeaderFromRawBytes(evidence.Header1)
+	if err != nil {
+		return 0, fmt.Errorf("deserialize header1 error: %v", err)
+	}
+	header2, err := types.HeaderFromRawBytes(evidence.Header2)
+	if err != nil {
+		return 0, fmt.Errorf("deserialize header2 error: %v", err)
+	}
+	if header1.Height != header2.Height {
+		return 0, fmt.Errorf("headers of different height %d and %d", header1.Height, header2.Height)
+	}
+	hash1, hash2 := header1.Hash(), header2.Hash()
+	if hash1 == hash2 {
+		return 0, fmt.Errorf("headers of the same hash %s", hash1.ToHexString())
+	}
+	if err := signature.Verify(pubkey, hash1[:], evidence.Sig1); err != nil {
+		return 0, fmt.Errorf("verify sig1 error: %v", err)
+	}
+	if err := signature.Verify(pubkey, hash2[:], evidence.Sig2); err != nil {
+		return 0, fmt.Errorf("verify sig2 error: %v", err)
+	}
+	blkInfo1, err := vbftconfig.VbftBlock(header1)
+	if err != nil {
+		return 0, fmt.Errorf("header1 consensus payload error: %v", err)
+	}
+	blkInfo2, err := vbftconfig.VbftBlock(header2)
+	if err != nil {
+		return 0, fmt.Errorf("header2 consensus payload error: %v", err)
+	}
+	if blkInfo1.Proposer != blkInfo2.Proposer {
+		return 0, fmt.Errorf("headers of different proposer %d and %d", blkInfo1.Proposer, blkInfo2.Proposer)
+	}
+	if header1.PrevBlockHash != header2.PrevBlockHash {
+		return 0, fmt.Errorf("headers of different prev block %s and %s", header1.PrevBlockHash.ToHexString(),
+			header2.PrevBlockHash.ToHexString())
+	}
+	if !bytes.Equal(blkInfo1.VrfValue, blkInfo2.VrfValue) {
+		return 0, fmt.Errorf("headers of different vrf value")
+	}
+	if blkInfo1.LastConfigBlockNum != blkInfo2.LastConfigBlockNum {
+		return 0, fmt.Errorf("headers of different config view %d and %d", blkInfo1.LastConfigBlockNum,
+			blkInfo2.LastConfigBlockNum)
+	}
+	//block and empty block of one proposal share the same timestamp and consensus payload
+	if header1.Timestamp == header2.Timestamp && bytes.Equal(header1.ConsensusPayload, header2.ConsensusPayload) {
+		return 0, fmt.Errorf("headers are block and empty block of one proposal")
+	}
+	return header1.Height, nil
+}
+
+func genFaultyEvidenceKey(contract common.Address, peerPubkeyPrefix []byte, height uint32) []byte {
+	return utils.ConcatKey(contract, []byte(FAULTY_EVIDENCE), peerPubkeyPrefix, GetUint64Bytes(uint64(height)))
+}
+
+func isFaultyEvidenceReported(native *native.NativeService, contract common.Address, peerPubkey string, height uint32) (bool, error) {
+	peerPubkeyPrefix, err := hex.DecodeString(peerPubkey)
+	if err != nil {
+		return false, fmt.Errorf("hex.DecodeString, peerPubkey format error: %v", err)
+	}
+	evidenceBytes, err := native.CacheDB.Get(genFaultyEvidenceKey(contract, peerPubkeyPrefix, height))
+	if err != nil {
+		return false, fmt.Errorf("native.CacheDB.Get, get faulty evidence error: %v", err)
+	}
+	return evidenceBytes != nil, nil
+}
+
+func putFaultyEvidence(native *native.NativeService, contract common.Address, peerPubkey string, height uint32,
+	evidence *FaultyEvidence) error {
+	peerPubkeyPrefix, err := hex.DecodeString(peerPubkey)
+	if err != nil {
+		return fmt.Errorf("hex.DecodeString, peerPubkey format error: %v", err)
+	}
+	native.CacheDB.Put(genFaultyEvidenceKey(contract, peerPubkeyPrefix, height),
+		cstates.GenRawStorageItem(common.SerializeToBytes(evidence)))
+	return nil
+}
+
 func CheckVBFTConfig(configuration *config.VBFTConfig) error {
 	if configuration.C == 0 {
 		return fmt.Errorf("initConfig. C can not be 0 in config")
@@ -629,6 +735,85 @@ func putSplitCurve(native *native.NativeService, contract common.Address, splitC
 	return nil
 }
 
+//the fee split curve before updateFeeSplitCurve is made up of fixed Xi, split curve and global param
+func getDefaultFeeSplitCurve(native *native.NativeService, contract common.Address) (*FeeSplitCurve, error) {
+	globalParam, err := getGlobalParam(native, contract)
+	if err != nil {
+		return nil, fmt.Errorf("getGlobalParam, getGlobalParam error: %v", err)
+	}
+	splitCurve, err := getSplitCurve(native, contract)
+	if err != nil {
+		return nil, fmt.Errorf("getSplitCurve, get splitCurve error: %v", err)
+	}
+	return &FeeSplitCurve{
+		Xi:   Xi,
+		Yi:   splitCurve.Yi,
+		Yita: globalParam.Yita,
+		A:    globalParam.A,
+		B:    globalParam.B,
+	}, nil
+}
+
+//check if updateFeeSplitCurve is enabled at the current height
+func feeSplitCurveEnabled(native *native.NativeService) bool {
+	return native.Height >= config.GetFeeSplitCurveHeight(config.DefConfig.P2PNode.NetworkId)
+}
+
+//check if the fee split curve has been updated by updateFeeSplitCurve
+func hasFeeSplitCurveInfo(native *native.NativeService, contract common.Address) (bool, error) {
+	feeSplitCurveBytes, err := native.CacheDB.Get(utils.ConcatKey(contract, []byte(FEE_SPLIT_CURVE)))
+	if err != nil {
+		return false, fmt.Errorf("hasFeeSplitCurveInfo, get feeSplitCurveBytes error: %v", err)
+	}
+	return feeSplitCurveBytes != nil, nil
+}
+
+func getFeeSplitCurveInfo(native *native.NativeService, contract common.Address) (*FeeSplitCurveInfo, error) {
+	feeSplitCurveBytes, err := native.CacheDB.Get(utils.ConcatKey(contract, []byte(FEE_SPLIT_CURVE)))
+	if err != nil {
+		return nil, fmt.Errorf("getFeeSplitCurveInfo, get feeSplitCurveBytes error: %v", err)
+	}
+	if feeSplitCurveBytes == nil {
+		curve, err := getDefaultFeeSplitCurve(native, contract)
+		if err != nil {
+			return nil, fmt.Errorf("getDefaultFeeSplitCurve, get default feeSplitCurve error: %v", err)
+		}
+		return &FeeSplitCurveInfo{Curve: *curve, Previous: *curve}, nil
+	}
+	value, err := cstates.GetValueFromRawStorageItem(feeSplitCurveBytes)
+	if err != nil {
+		return nil, fmt.Errorf("getFeeSplitCurveInfo, deserialize from raw storage item err:%v", err)
+	}
+	info := new(FeeSplitCurveInfo)
+	if err := info.Deserialization(common.NewZeroCopySource(value)); err != nil {
+		return nil, fmt.Errorf("deserialize, deserialize feeSplitCurveInfo error: %v", err)
+	}
+	return info, nil
+}
+
+func putFeeSplitCurveInfo(native *native.NativeService, contract common.Address, info *FeeSplitCurveInfo) {
+	native.CacheDB.Put(utils.ConcatKey(contract, []byte(FEE_SPLIT_CURVE)), cstates.GenRawStorageItem(common.SerializeToBytes(info)))
+}
+
+//get the fee split curve in effect at current block height
+func getFeeSplitCurve(native *native.NativeService, contract common.Address) (*FeeSplitCurve, error) {
+	info, err := getFeeSplitCurveInfo(native, contract)
+	if err != nil {
+		return nil, err
+	}
+	return info.Effective(native.Height), nil
+}
+
+//GetFeeSplitCurveInEffect returns the fee split curve and weights in effect at current block height
+func GetFeeSplitCurveInEffect(native *native.NativeService, contract common.Address) (*FeeSplitCurve, error) {
+	return getFeeSplitCurve(native, contract)
+}
+
+//SplitCurve returns the split weight of stake pos on the fee split curve, avg is the average stake of consensus nodes
+func SplitCurve(curve *FeeSplitCurve, pos uint64, avg uint64) (uint64, error) {
+	return splitCurve(curve, pos, avg)
+}
+
 func appCallInitContractAdmin(native *native.NativeService, adminOntID []byte) error {
 	params := &auth.InitContractAdminParam{
 		AdminOntID: adminOntID,
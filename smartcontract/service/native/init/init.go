@@ -23,7 +23,9 @@ import (
 	"math/big"
 
 	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/abi_registry"
 	"github.com/ontio/ontology/smartcontract/service/native/auth"
+	"github.com/ontio/ontology/smartcontract/service/native/content_registry"
 	"github.com/ontio/ontology/smartcontract/service/native/cross_chain/cross_chain_manager"
 	"github.com/ontio/ontology/smartcontract/service/native/cross_chain/header_sync"
 	"github.com/ontio/ontology/smartcontract/service/native/cross_chain/lock_proxy"
@@ -32,6 +34,7 @@ import (
 	"github.com/ontio/ontology/smartcontract/service/native/ong"
 	"github.com/ontio/ontology/smartcontract/service/native/ont"
 	"github.com/ontio/ontology/smartcontract/service/native/ontid"
+	"github.com/ontio/ontology/smartcontract/service/native/oracle"
 	"github.com/ontio/ontology/smartcontract/service/native/utils"
 	"github.com/ontio/ontology/smartcontract/service/neovm"
 	vm "github.com/ontio/ontology/vm/neovm"
@@ -51,6 +54,9 @@ func init() {
 	cross_chain_manager.InitCrossChain()
 	header_sync.InitHeaderSync()
 	lock_proxy.InitLockProxy()
+	content_registry.InitContentRegistry()
+	abi_registry.InitAbiRegistry()
+	oracle.InitOracle()
 }
 
 func InitBytes(addr common.Address, method string) []byte {
@@ -23,6 +23,7 @@ import (
 	"github.com/ontio/ontology/merkle"
 
 	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
 	"github.com/ontio/ontology/core/types"
 	"github.com/ontio/ontology/errors"
 	"github.com/ontio/ontology/smartcontract/context"
@@ -39,6 +40,9 @@ type (
 
 var (
 	Contracts = make(map[common.Address]RegisterService)
+	// ContractsEnableHeight holds the activation height of native contracts added after genesis,
+	// the contract can not be invoked before the height of the network
+	ContractsEnableHeight = make(map[common.Address]func(networkId uint32) uint32)
 )
 
 // Native service struct
@@ -68,6 +72,10 @@ func (this *NativeService) Invoke() ([]byte, error) {
 	if !ok {
 		return BYTE_FALSE, fmt.Errorf("Native contract address %x haven't been registered.", contract.Address)
 	}
+	if enableHeight, ok := ContractsEnableHeight[contract.Address]; ok &&
+		this.Height < enableHeight(config.DefConfig.P2PNode.NetworkId) {
+		return BYTE_FALSE, fmt.Errorf("Native contract address %x haven't been registered.", contract.Address)
+	}
 	services(this)
 	service, ok := this.ServiceMap[contract.Method]
 	if !ok {
@@ -23,15 +23,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-
-	"github.com/ontio/dad-go/common"
-	"github.com/ontio/dad-go/core/genesis"
-	cstates "github.com/ontio/dad-go/core/states"
-	scommon "github.com/ontio/dad-go/core/store/common"
-	"github.com/ontio/dad-go/errors"
-	"github.com/ontio/dad-go/smartcontract/service/native"
-	"github.com/ontio/dad-go/smartcontract/service/native/governance"
-	"github.com/ontio/dad-go/smartcontract/service/native/utils"
+	"sort"
+	"strconv"
+
+	"github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/errors"
+	"github.com/ontio/ontology/smartcontract/service/native"
+	"github.com/ontio/ontology/smartcontract/service/native/global_params"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
 )
 
 const (
@@ -40,6 +39,20 @@ const (
 	OracleNodeStatus
 )
 
+const (
+	//request status
+	REQUEST_STATUS_PENDING   = "pending"
+	REQUEST_STATUS_EXPIRED   = "expired"
+	REQUEST_STATUS_FINALIZED = "finalized"
+	REQUEST_STATUS_REFUNDED  = "refunded"
+)
+
+const (
+	//aggregation mode
+	MajorityMode AggregationMode = iota
+	MedianMode
+)
+
 const (
 	//function name
 	REGISTER_ORACLE_NODE  = "registerOracleNode"
@@ -47,20 +60,32 @@ const (
 	QUIT_ORACLE_NODE      = "quitOracleNode"
 	CREATE_ORACLE_REQUEST = "createOracleRequest"
 	SET_ORACLE_OUTCOME    = "setOracleOutcome"
+	REFUND_ORACLE_REQUEST = "refundOracleRequest"
+
+	//read method name
+	GET_ORACLE_REQUEST           = "getOracleRequest"
+	LIST_PENDING_ORACLE_REQUESTS = "listPendingOracleRequests"
+	GET_ORACLE_OUTCOME           = "getOracleOutcome"
+
+	//event name
+	FINALIZE_ORACLE_REQUEST = "finalizeOracleRequest"
 
 	//keyPrefix
 	ORACLE_NODE    = "OracleNode"
 	UNDO_TXHASH    = "UndoTxHash"
 	REQUEST        = "Request"
 	OUTCOME_RECORD = "OutcomeRecord"
+	FINAL_OUTCOME  = "FinalOutcome"
 
 	//global
 	MIN_GUARANTY    = 1000
 	ORACLE_NODE_FEE = 500
+	DISSENT_PENALTY = 100
 )
 
 func InitOracle() {
-	native.Contracts[genesis.OracleContractAddress] = RegisterOracleContract
+	native.Contracts[utils.OracleContractAddress] = RegisterOracleContract
+	native.ContractsEnableHeight[utils.OracleContractAddress] = config.GetOracleHeight
 }
 
 func RegisterOracleContract(native *native.NativeService) {
@@ -69,6 +94,10 @@ func RegisterOracleContract(native *native.NativeService) {
 	native.Register(QUIT_ORACLE_NODE, QuitOracleNode)
 	native.Register(CREATE_ORACLE_REQUEST, CreateOracleRequest)
 	native.Register(SET_ORACLE_OUTCOME, SetOracleOutcome)
+	native.Register(REFUND_ORACLE_REQUEST, RefundOracleRequest)
+	native.Register(GET_ORACLE_REQUEST, GetOracleRequest)
+	native.Register(LIST_PENDING_ORACLE_REQUESTS, ListPendingOracleRequests)
+	native.Register(GET_ORACLE_OUTCOME, GetOracleOutcome)
 }
 
 func RegisterOracleNode(native *native.NativeService) ([]byte, error) {
@@ -77,8 +106,12 @@ func RegisterOracleNode(native *native.NativeService) ([]byte, error) {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, contract params deserialize error!")
 	}
 
+	address, err := addressFromHex(params.Address)
+	if err != nil {
+		return utils.BYTE_FALSE, err
+	}
 	//check witness
-	err := utils.ValidateOwner(native, params.Address)
+	err = utils.ValidateOwner(native, address)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "validateOwner, checkWitness error!")
 	}
@@ -89,46 +122,35 @@ func RegisterOracleNode(native *native.NativeService) ([]byte, error) {
 		return utils.BYTE_FALSE, errors.NewErr(fmt.Sprintf("registerOracleNode, guaranty must >= %v!", MIN_GUARANTY))
 	}
 
-	addressBytes, err := hex.DecodeString(params.Address)
-	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, address format error!")
-	}
-	address, err := common.AddressParseFromBytes(addressBytes)
+	oracleNode, err := GetOracleNode(native, contract, address[:])
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "common.AddressParseFromBytes, address format error!")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getOracleNode, get oracleNode error!")
 	}
-
-	oracleNodeBytes, err := native.CloneCache.Get(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(ORACLE_NODE), addressBytes))
-	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "native.CloneCache.Get, get oracleNodeBytes error!")
-	}
-	if oracleNodeBytes != nil {
+	if oracleNode != nil {
 		return utils.BYTE_FALSE, errors.NewErr("registerOracleNode, oracleNode is already registered!")
 	}
 
-	oracleNode := &OracleNode{
+	oracleNode = &OracleNode{
 		Address:  params.Address,
 		Guaranty: params.Guaranty,
 		Status:   RegisterOracleNodeStatus,
 	}
-	bf := new(bytes.Buffer)
-	if err := oracleNode.Serialize(bf); err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "serialize, serialize oracleNode error!")
+	if err := putOracleNode(native, contract, address[:], oracleNode); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "putOracleNode, put oracleNode error!")
 	}
-	native.CloneCache.Add(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(ORACLE_NODE), addressBytes), &cstates.StorageItem{Value: bf.Bytes()})
 
 	//ont transfer
-	err = governance.AppCallTransferOnt(native, address, genesis.OracleContractAddress, params.Guaranty)
+	err = appCallTransferOnt(native, address, utils.OracleContractAddress, params.Guaranty)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "appCallTransferOnt, ont transfer error!")
 	}
 	//ong transfer
-	err = governance.AppCallTransferOng(native, address, genesis.FeeSplitContractAddress, ORACLE_NODE_FEE)
+	err = appCallTransferOng(native, address, utils.GovernanceContractAddress, ORACLE_NODE_FEE)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "appCallTransferOng, ong transfer error!")
 	}
 
-	utils.AddCommonEvent(native, contract, REGISTER_ORACLE_NODE, params)
+	addCommonEvent(native, contract, REGISTER_ORACLE_NODE, params)
 
 	return utils.BYTE_TRUE, nil
 }
@@ -139,41 +161,39 @@ func ApproveOracleNode(native *native.NativeService) ([]byte, error) {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, contract params deserialize error!")
 	}
 
-	//TODO: check witness
-	//err = validateOwner(native, params.Address)
-	//if err != nil {
-	//	return errors.NewDetailErr(err, errors.ErrNoCode, "[registerOracleNode] CheckWitness error!")
-	//}
+	// get admin from database
+	adminAddress, err := global_params.GetStorageRole(native,
+		global_params.GenerateOperatorKey(utils.ParamContractAddress))
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getAdmin, get admin error!")
+	}
+	//check witness
+	err = utils.ValidateOwner(native, adminAddress)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "validateOwner, checkWitness error!")
+	}
 	contract := native.ContextRef.CurrentContext().ContractAddress
 
-	oracleNode := new(OracleNode)
 	addressBytes, err := hex.DecodeString(params.Address)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, address format error!")
 	}
-	oracleNodeBytes, err := native.CloneCache.Get(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(ORACLE_NODE), addressBytes))
+	oracleNode, err := GetOracleNode(native, contract, addressBytes)
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "native.CloneCache.Get, get oracleNodeBytes error!")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getOracleNode, get oracleNode error!")
 	}
-	if oracleNodeBytes == nil {
+	if oracleNode == nil {
 		return utils.BYTE_FALSE, errors.NewErr("approveOracleNode, oracleNode is not registered!")
 	}
-	oracleNodeStore, _ := oracleNodeBytes.(*cstates.StorageItem)
-	if err := oracleNode.Deserialize(bytes.NewBuffer(oracleNodeStore.Value)); err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize oracleNode error!")
-	}
 	if oracleNode.Status != RegisterOracleNodeStatus {
 		return utils.BYTE_FALSE, errors.NewErr("approveOracleNode, oracleNode status is not RegisterOracleNodeStatus!")
 	}
 	oracleNode.Status = OracleNodeStatus
-
-	bf := new(bytes.Buffer)
-	if err := oracleNode.Serialize(bf); err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "serialize, serialize oracleNode error!")
+	if err := putOracleNode(native, contract, addressBytes, oracleNode); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "putOracleNode, put oracleNode error!")
 	}
-	native.CloneCache.Add(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(ORACLE_NODE), addressBytes), &cstates.StorageItem{Value: bf.Bytes()})
 
-	utils.AddCommonEvent(native, contract, APPROVE_ORACLE_NODE, params)
+	addCommonEvent(native, contract, APPROVE_ORACLE_NODE, params)
 
 	return utils.BYTE_TRUE, nil
 }
@@ -184,43 +204,34 @@ func QuitOracleNode(native *native.NativeService) ([]byte, error) {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, contract params deserialize error!")
 	}
 
-	//TODO: check witness
-	//err = validateOwner(native, params.Address)
-	//if err != nil {
-	//	return errors.NewDetailErr(err, errors.ErrNoCode, "[registerOracleNode] CheckWitness error!")
-	//}
 	contract := native.ContextRef.CurrentContext().ContractAddress
 
-	oracleNode := new(OracleNode)
-	addressBytes, err := hex.DecodeString(params.Address)
+	address, err := addressFromHex(params.Address)
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, address format error!")
+		return utils.BYTE_FALSE, err
 	}
-	address, err := common.AddressParseFromBytes(addressBytes)
+	//check witness
+	err = utils.ValidateOwner(native, address)
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "common.AddressParseFromBytes, address format error!")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "validateOwner, checkWitness error!")
 	}
-	oracleNodeBytes, err := native.CloneCache.Get(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(ORACLE_NODE), addressBytes))
+	oracleNode, err := GetOracleNode(native, contract, address[:])
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "native.CloneCache.Get, get oracleNodeBytes error!")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getOracleNode, get oracleNode error!")
 	}
-	if oracleNodeBytes == nil {
+	if oracleNode == nil {
 		return utils.BYTE_FALSE, errors.NewErr("quitOracleNode, oracleNode is not registered!")
 	}
-	oracleNodeStore, _ := oracleNodeBytes.(*cstates.StorageItem)
-	if err := oracleNode.Deserialize(bytes.NewBuffer(oracleNodeStore.Value)); err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize oracleNode error!")
-	}
 
 	//ont transfer
-	err = governance.AppCallTransferOnt(native, address, genesis.OracleContractAddress, oracleNode.Guaranty)
+	err = appCallTransferOnt(native, utils.OracleContractAddress, address, oracleNode.Guaranty)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "appCallTransferOnt, ont transfer error!")
 	}
 
-	native.CloneCache.Delete(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(ORACLE_NODE), addressBytes))
+	native.CacheDB.Delete(utils.ConcatKey(contract, []byte(ORACLE_NODE), address[:]))
 
-	utils.AddCommonEvent(native, contract, QUIT_ORACLE_NODE, params)
+	addCommonEvent(native, contract, QUIT_ORACLE_NODE, params)
 
 	return utils.BYTE_TRUE, nil
 }
@@ -231,162 +242,353 @@ func CreateOracleRequest(native *native.NativeService) ([]byte, error) {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, contract params deserialize error!")
 	}
 
+	address, err := addressFromHex(params.Address)
+	if err != nil {
+		return utils.BYTE_FALSE, err
+	}
 	//check witness
-	err := utils.ValidateOwner(native, params.Address)
+	err = utils.ValidateOwner(native, address)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "validateOwner, validateOwner error!")
 	}
 
-	contract := native.ContextRef.CurrentContext().ContractAddress
-	txHash := native.Tx.Hash()
-	txHashBytes := txHash.ToArray()
-	txHashHex := hex.EncodeToString(txHashBytes)
-	undoRequests := &UndoRequests{
-		Requests: make(map[string]struct{}),
+	//check request params
+	if params.Quorum == 0 {
+		return utils.BYTE_FALSE, errors.NewErr("createOracleRequest, quorum must > 0!")
 	}
-
-	undoRequestsBytes, err := native.CloneCache.Get(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(UNDO_TXHASH)))
-	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "native.CloneCache.Get, get UndoRequests error!")
+	if params.AggregationMode != MajorityMode && params.AggregationMode != MedianMode {
+		return utils.BYTE_FALSE, errors.NewErr("createOracleRequest, unknown aggregation mode!")
 	}
-
-	if undoRequestsBytes != nil {
-		item, _ := undoRequestsBytes.(*cstates.StorageItem)
-		err = json.Unmarshal(item.Value, undoRequests)
-		if err != nil {
-			return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "json.Unmarshal, unmarshal UndoRequests error")
-		}
+	if params.Deadline <= native.Height {
+		return utils.BYTE_FALSE, errors.NewErr("createOracleRequest, deadline must > current block height!")
+	}
+	if params.PenalizeDissent && params.AggregationMode != MajorityMode {
+		return utils.BYTE_FALSE, errors.NewErr("createOracleRequest, dissent can only be penalized in majority mode!")
 	}
 
-	undoRequests.Requests[txHashHex] = struct{}{}
-
-	value, err := json.Marshal(undoRequests)
+	contract := native.ContextRef.CurrentContext().ContractAddress
+	//a quorum larger than the approved oracle nodes can never be met
+	nodeCount, err := getApprovedOracleNodeCount(native, contract)
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "json.Marshal, marshal UndoRequests error")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getApprovedOracleNodeCount, count oracleNode error!")
 	}
+	if params.Quorum > nodeCount {
+		return utils.BYTE_FALSE, errors.NewErr(fmt.Sprintf("createOracleRequest, quorum %d exceeds the %d approved oracle nodes!",
+			params.Quorum, nodeCount))
+	}
+	txHash := native.Tx.Hash()
+	txHashBytes := txHash.ToArray()
+	txHashHex := hex.EncodeToString(txHashBytes)
 
-	native.CloneCache.Add(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(UNDO_TXHASH)), &cstates.StorageItem{Value: value})
-	native.CloneCache.Add(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(REQUEST), txHashBytes), &cstates.StorageItem{Value: native.Input})
-
-	addressBytes, err := hex.DecodeString(params.Address)
+	undoRequests, err := GetUndoRequests(native, contract)
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, address format error!")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getUndoRequests, get UndoRequests error!")
 	}
-	address, err := common.AddressParseFromBytes(addressBytes)
-	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "common.AddressParseFromBytes, address format error!")
+	undoRequests.Requests[txHashHex] = struct{}{}
+	if err := putUndoRequests(native, contract, undoRequests); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "putUndoRequests, put UndoRequests error!")
 	}
+	utils.PutBytes(native, utils.ConcatKey(contract, []byte(REQUEST), txHashBytes), native.Input)
+
 	//ong transfer
-	err = governance.AppCallTransferOng(native, address, genesis.OracleContractAddress, params.Fee)
+	err = appCallTransferOng(native, address, utils.OracleContractAddress, params.Fee)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "appCallTransferOng, ong transfer error!")
 	}
 
-	utils.AddCommonEvent(native, contract, CREATE_ORACLE_REQUEST, params)
+	notifyOracleEvent(native, contract, CREATE_ORACLE_REQUEST, txHashHex, map[string]interface{}{
+		"address":         params.Address,
+		"request":         params.Request,
+		"fee":             params.Fee,
+		"quorum":          params.Quorum,
+		"aggregationMode": params.AggregationMode,
+		"deadline":        params.Deadline,
+		"penalizeDissent": params.PenalizeDissent,
+	})
 
 	return utils.BYTE_TRUE, nil
 }
 
+//SetOracleOutcome record the outcome of an approved oracle node, the request is finalized once its quorum is met.
+//The fee is split among the nodes agreeing with the final outcome, dissenting nodes are penalized in majority mode
+//if the request set PenalizeDissent.
 func SetOracleOutcome(native *native.NativeService) ([]byte, error) {
 	params := new(SetOracleOutcomeParam)
 	if err := params.Deserialize(bytes.NewBuffer(native.Input)); err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, contract params deserialize error!")
 	}
 
+	address, err := addressFromHex(params.Address)
+	if err != nil {
+		return utils.BYTE_FALSE, err
+	}
 	//check witness
-	err := utils.ValidateOwner(native, params.Address)
+	err = utils.ValidateOwner(native, address)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "validateOwner, validateOwner error!")
 	}
 	contract := native.ContextRef.CurrentContext().ContractAddress
 
-	oracleNode := new(OracleNode)
-	addressBytes, err := hex.DecodeString(params.Address)
-	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, address format error!")
-	}
-	oracleNodeBytes, err := native.CloneCache.Get(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(ORACLE_NODE), addressBytes))
+	oracleNode, err := GetOracleNode(native, contract, address[:])
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "native.CloneCache.Get, get oracleNodeBytes error!")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getOracleNode, get oracleNode error!")
 	}
-	if oracleNodeBytes == nil {
+	if oracleNode == nil {
 		return utils.BYTE_FALSE, errors.NewErr("setOracleOutcome, oracleNode is not registered!")
 	}
-	oracleNodeStore, _ := oracleNodeBytes.(*cstates.StorageItem)
-	if err := oracleNode.Deserialize(bytes.NewBuffer(oracleNodeStore.Value)); err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize oracleNode error!")
-	}
 	if oracleNode.Status != OracleNodeStatus {
 		return utils.BYTE_FALSE, errors.NewErr("setOracleOutcome, oracleNode is not approved!")
 	}
 
-	txHashHex := params.TxHash
-	txHash, err := hex.DecodeString(txHashHex)
+	txHash, err := hex.DecodeString(params.TxHash)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, decode hex txHash error!")
 	}
 	//get request
-	request := new(CreateOracleRequestParam)
-	requestBytes, err := native.CloneCache.Get(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(REQUEST), txHash))
+	request, err := GetRequest(native, contract, txHash)
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "native.CloneCache.Get, get requestBytes error!")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getRequest, get request error!")
 	}
-	if requestBytes == nil {
+	if request == nil {
 		return utils.BYTE_FALSE, errors.NewErr("setOracleOutcome, request is not exist!")
 	}
-	requestStore, _ := requestBytes.(*cstates.StorageItem)
-	if err := request.Deserialize(bytes.NewBuffer(requestStore.Value)); err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize request error!")
+	undoRequests, err := GetUndoRequests(native, contract)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getUndoRequests, get UndoRequests error!")
+	}
+	if _, ok := undoRequests.Requests[params.TxHash]; !ok {
+		return utils.BYTE_FALSE, errors.NewErr("setOracleOutcome, request is already finalized or refunded!")
 	}
-	if request.OracleNode != params.Address {
-		return utils.BYTE_FALSE, errors.NewErr("setOracleOutcome, request is not assigned to this address!")
+	if request.Deadline != 0 && native.Height > request.Deadline {
+		return utils.BYTE_FALSE, errors.NewErr("setOracleOutcome, request is expired!")
+	}
+	//legacy requests are answered by the oracle node they designated
+	if request.OracleNode != "" && request.OracleNode != params.Address {
+		return utils.BYTE_FALSE, errors.NewErr("setOracleOutcome, request is designated to another oracleNode!")
+	}
+	if request.AggregationMode == MedianMode {
+		if _, err := strconv.ParseInt(params.Outcome, 10, 64); err != nil {
+			return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "setOracleOutcome, outcome of median request must be an integer!")
+		}
 	}
 
-	outcomeRecordBytes, err := native.CloneCache.Get(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(OUTCOME_RECORD), txHash))
+	outcomeRecord, err := GetOutcomeRecord(native, contract, txHash)
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "native.CloneCache.Get, get OutcomeRecord error!")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getOutcomeRecord, get OutcomeRecord error!")
+	}
+	if _, ok := outcomeRecord.OutcomeRecord[params.Address]; ok {
+		return utils.BYTE_FALSE, errors.NewErr("setOracleOutcome, outcome of this oracleNode is already set!")
 	}
-	if outcomeRecordBytes != nil {
-		return utils.BYTE_FALSE, errors.NewErr("setOracleOutcome, OutcomeRecord is already set!")
+	outcomeRecord.OutcomeRecord[params.Address] = params.Outcome
+	if err := putOutcomeRecord(native, contract, txHash, outcomeRecord); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "putOutcomeRecord, put OutcomeRecord error!")
 	}
 
-	native.CloneCache.Add(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(OUTCOME_RECORD), txHash), &cstates.StorageItem{Value: []byte(params.Outcome)})
+	notifyOracleEvent(native, contract, SET_ORACLE_OUTCOME, params.TxHash, map[string]interface{}{
+		"oracleNode": params.Address,
+		"outcome":    params.Outcome,
+		"count":      len(outcomeRecord.OutcomeRecord),
+	})
+
+	final, agreed, ok, err := aggregateOutcome(request, outcomeRecord)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "aggregateOutcome, aggregate outcome error!")
+	}
+	//quorum not met yet
+	if !ok {
+		return utils.BYTE_TRUE, nil
+	}
+
+	utils.PutBytes(native, utils.ConcatKey(contract, []byte(FINAL_OUTCOME), txHash), []byte(final))
 
 	//remove txHash from undoRequests
-	undoRequests := &UndoRequests{
-		Requests: make(map[string]struct{}),
+	delete(undoRequests.Requests, params.TxHash)
+	if err := putUndoRequests(native, contract, undoRequests); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "putUndoRequests, put UndoRequests error!")
 	}
 
-	undoRequestsBytes, err := native.CloneCache.Get(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(UNDO_TXHASH)))
-	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "native.CloneCache.Get, get UndoRequests error!")
+	//ong transfer
+	if err := splitFee(native, request.Fee, agreed); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "splitFee, split request fee error!")
 	}
 
-	if undoRequestsBytes != nil {
-		item, _ := undoRequestsBytes.(*cstates.StorageItem)
-		err = json.Unmarshal(item.Value, undoRequests)
-		if err != nil {
-			return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "json.Unmarshal, unmarshal UndoRequests error")
+	//penalize dissenting oracle nodes only if the request asked for it, every outcome counts in median mode
+	dissent := make([]string, 0)
+	if request.AggregationMode == MajorityMode && request.PenalizeDissent {
+		for address, outcome := range outcomeRecord.OutcomeRecord {
+			if outcome != final {
+				dissent = append(dissent, address)
+			}
+		}
+		sort.Strings(dissent)
+		for _, address := range dissent {
+			if err := penalizeOracleNode(native, contract, address); err != nil {
+				return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "penalizeOracleNode, penalize oracleNode error!")
+			}
 		}
 	}
-	delete(undoRequests.Requests, params.TxHash)
-	value, err := json.Marshal(undoRequests)
+
+	notifyOracleEvent(native, contract, FINALIZE_ORACLE_REQUEST, params.TxHash, map[string]interface{}{
+		"outcome": final,
+		"agreed":  agreed,
+		"dissent": dissent,
+	})
+
+	return utils.BYTE_TRUE, nil
+}
+
+//RefundOracleRequest give the fee back to requester when the deadline of request passed without quorum,
+//it can be called by anyone.
+func RefundOracleRequest(native *native.NativeService) ([]byte, error) {
+	params := new(RefundOracleRequestParam)
+	if err := params.Deserialize(bytes.NewBuffer(native.Input)); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, contract params deserialize error!")
+	}
+	contract := native.ContextRef.CurrentContext().ContractAddress
+
+	txHash, err := hex.DecodeString(params.TxHash)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, decode hex txHash error!")
+	}
+	request, err := GetRequest(native, contract, txHash)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getRequest, get request error!")
+	}
+	if request == nil {
+		return utils.BYTE_FALSE, errors.NewErr("refundOracleRequest, request is not exist!")
+	}
+	undoRequests, err := GetUndoRequests(native, contract)
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "json.Marshal, marshal UndoRequests error")
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getUndoRequests, get UndoRequests error!")
+	}
+	if _, ok := undoRequests.Requests[params.TxHash]; !ok {
+		return utils.BYTE_FALSE, errors.NewErr("refundOracleRequest, request is already finalized or refunded!")
+	}
+	if request.Deadline == 0 {
+		return utils.BYTE_FALSE, errors.NewErr("refundOracleRequest, legacy request without deadline can not be refunded!")
+	}
+	if native.Height <= request.Deadline {
+		return utils.BYTE_FALSE, errors.NewErr("refundOracleRequest, request deadline is not reached!")
+	}
+
+	delete(undoRequests.Requests, params.TxHash)
+	if err := putUndoRequests(native, contract, undoRequests); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "putUndoRequests, put UndoRequests error!")
 	}
-	native.CloneCache.Add(scommon.ST_STORAGE, utils.ConcatKey(contract, []byte(UNDO_TXHASH)), &cstates.StorageItem{Value: value})
 
-	address, err := common.AddressParseFromBytes(addressBytes)
+	address, err := addressFromHex(request.Address)
 	if err != nil {
-		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "common.AddressParseFromBytes, address format error!")
+		return utils.BYTE_FALSE, err
 	}
 	//ong transfer
-	err = governance.AppCallTransferOng(native, genesis.OracleContractAddress, address, request.Fee)
+	err = appCallTransferOng(native, utils.OracleContractAddress, address, request.Fee)
 	if err != nil {
 		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "appCallTransferOng, ong transfer error!")
 	}
 
-	utils.AddCommonEvent(native, contract, SET_ORACLE_OUTCOME, params)
+	notifyOracleEvent(native, contract, REFUND_ORACLE_REQUEST, params.TxHash, map[string]interface{}{
+		"address": request.Address,
+		"fee":     request.Fee,
+	})
 
 	return utils.BYTE_TRUE, nil
 }
+
+func GetOracleRequest(native *native.NativeService) ([]byte, error) {
+	params := new(QueryOracleRequestParam)
+	if err := params.Deserialize(bytes.NewBuffer(native.Input)); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, contract params deserialize error!")
+	}
+	contract := native.ContextRef.CurrentContext().ContractAddress
+
+	txHash, err := hex.DecodeString(params.TxHash)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, decode hex txHash error!")
+	}
+	undoRequests, err := GetUndoRequests(native, contract)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getUndoRequests, get UndoRequests error!")
+	}
+	info, err := getRequestInfo(native, contract, txHash, undoRequests)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getRequestInfo, get request info error!")
+	}
+	value, err := json.Marshal(info)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "json.Marshal, marshal OracleRequestInfo error")
+	}
+	return value, nil
+}
+
+//ListPendingOracleRequests return the requests waiting for outcomes in txHash order, expired ones are included
+//until they are refunded
+func ListPendingOracleRequests(native *native.NativeService) ([]byte, error) {
+	contract := native.ContextRef.CurrentContext().ContractAddress
+
+	undoRequests, err := GetUndoRequests(native, contract)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getUndoRequests, get UndoRequests error!")
+	}
+	txHashes := make([]string, 0, len(undoRequests.Requests))
+	for txHash := range undoRequests.Requests {
+		txHashes = append(txHashes, txHash)
+	}
+	sort.Strings(txHashes)
+
+	infos := make([]*OracleRequestInfo, 0, len(txHashes))
+	for _, txHashHex := range txHashes {
+		txHash, err := hex.DecodeString(txHashHex)
+		if err != nil {
+			return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, decode hex txHash error!")
+		}
+		info, err := getRequestInfo(native, contract, txHash, undoRequests)
+		if err != nil {
+			return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getRequestInfo, get request info error!")
+		}
+		infos = append(infos, info)
+	}
+	value, err := json.Marshal(infos)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "json.Marshal, marshal OracleRequestInfo error")
+	}
+	return value, nil
+}
+
+func GetOracleOutcome(native *native.NativeService) ([]byte, error) {
+	params := new(QueryOracleRequestParam)
+	if err := params.Deserialize(bytes.NewBuffer(native.Input)); err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, contract params deserialize error!")
+	}
+	contract := native.ContextRef.CurrentContext().ContractAddress
+
+	txHash, err := hex.DecodeString(params.TxHash)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, decode hex txHash error!")
+	}
+	undoRequests, err := GetUndoRequests(native, contract)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getUndoRequests, get UndoRequests error!")
+	}
+	info, err := getRequestInfo(native, contract, txHash, undoRequests)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getRequestInfo, get request info error!")
+	}
+	outcomeRecord, err := GetOutcomeRecord(native, contract, txHash)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getOutcomeRecord, get OutcomeRecord error!")
+	}
+	finalOutcome, err := GetFinalOutcome(native, contract, txHash)
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "getFinalOutcome, get FinalOutcome error!")
+	}
+	value, err := json.Marshal(&OracleOutcomeInfo{
+		TxHash:        info.TxHash,
+		Status:        info.Status,
+		FinalOutcome:  string(finalOutcome),
+		OutcomeRecord: outcomeRecord.OutcomeRecord,
+	})
+	if err != nil {
+		return utils.BYTE_FALSE, errors.NewDetailErr(err, errors.ErrNoCode, "json.Marshal, marshal OracleOutcomeInfo error")
+	}
+	return value, nil
+}
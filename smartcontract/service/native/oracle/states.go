@@ -19,9 +19,10 @@
 package oracle
 
 import (
-	"github.com/ontio/dad-go/common/serialization"
-	"github.com/ontio/dad-go/errors"
 	"io"
+
+	"github.com/ontio/ontology/common/serialization"
+	"github.com/ontio/ontology/errors"
 )
 
 type Status uint8
@@ -42,6 +43,24 @@ func (this *Status) Deserialize(r io.Reader) error {
 	return nil
 }
 
+type AggregationMode uint8
+
+func (this *AggregationMode) Serialize(w io.Writer) error {
+	if err := serialization.WriteUint8(w, uint8(*this)); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.WriteUint8, serialize aggregationMode error!")
+	}
+	return nil
+}
+
+func (this *AggregationMode) Deserialize(r io.Reader) error {
+	mode, err := serialization.ReadUint8(r)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.ReadUint8. deserialize aggregationMode error!")
+	}
+	*this = AggregationMode(mode)
+	return nil
+}
+
 type RegisterOracleNodeParam struct {
 	Address  string `json:"address"`
 	Guaranty uint64 `json:"guaranty"`
@@ -153,11 +172,17 @@ func (this *QuitOracleNodeParam) Deserialize(r io.Reader) error {
 	return nil
 }
 
+//CreateOracleRequestParam is also the stored request. Requests created before quorum aggregation end after Fee,
+//they are decoded with quorum 1 in majority mode without deadline, and only OracleNode can set their outcome.
 type CreateOracleRequestParam struct {
-	Request    string `json:"request"`
-	OracleNode string `json:"oracleNode"`
-	Address    string `json:"address"`
-	Fee        uint64 `json:"fee"`
+	Request         string          `json:"request"`
+	OracleNode      string          `json:"oracleNode"`
+	Address         string          `json:"address"`
+	Fee             uint64          `json:"fee"`
+	Quorum          uint32          `json:"quorum"`
+	AggregationMode AggregationMode `json:"aggregationMode"`
+	Deadline        uint32          `json:"deadline"`
+	PenalizeDissent bool            `json:"penalizeDissent"`
 }
 
 func (this *CreateOracleRequestParam) Serialize(w io.Writer) error {
@@ -173,6 +198,18 @@ func (this *CreateOracleRequestParam) Serialize(w io.Writer) error {
 	if err := serialization.WriteUint64(w, this.Fee); err != nil {
 		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.WriteVarUint, serialize fee error!")
 	}
+	if err := serialization.WriteUint32(w, this.Quorum); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.WriteUint32, serialize quorum error!")
+	}
+	if err := this.AggregationMode.Serialize(w); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "this.AggregationMode.Serialize, serialize aggregationMode error!")
+	}
+	if err := serialization.WriteUint32(w, this.Deadline); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.WriteUint32, serialize deadline error!")
+	}
+	if err := serialization.WriteBool(w, this.PenalizeDissent); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.WriteBool, serialize penalizeDissent error!")
+	}
 	return nil
 }
 
@@ -200,13 +237,56 @@ func (this *CreateOracleRequestParam) Deserialize(r io.Reader) error {
 		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.ReadVarUint. deserialize fee error!")
 	}
 	this.Fee = fee
+
+	if isLegacyRequest(r) {
+		this.Quorum = 1
+		this.AggregationMode = MajorityMode
+		this.Deadline = 0
+		this.PenalizeDissent = false
+		return nil
+	}
+
+	quorum, err := serialization.ReadUint32(r)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.ReadUint32. deserialize quorum error!")
+	}
+	this.Quorum = quorum
+
+	mode := new(AggregationMode)
+	if err := mode.Deserialize(r); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "mode.Deserialize. deserialize aggregationMode error!")
+	}
+	this.AggregationMode = *mode
+
+	deadline, err := serialization.ReadUint32(r)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.ReadUint32. deserialize deadline error!")
+	}
+	this.Deadline = deadline
+
+	penalizeDissent, err := serialization.ReadBool(r)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.ReadBool. deserialize penalizeDissent error!")
+	}
+	this.PenalizeDissent = penalizeDissent
 	return nil
 }
 
+//isLegacyRequest return true if nothing is left after the fee of request
+func isLegacyRequest(r io.Reader) bool {
+	buf, ok := r.(interface{ Len() int })
+	return ok && buf.Len() == 0
+}
+
 type UndoRequests struct {
 	Requests map[string]struct{} `json:"requests"`
 }
 
+//OutcomeRecord collects the outcomes of oracle nodes for a request, keyed by oracle node address
+type OutcomeRecord struct {
+	OutcomeRecord map[string]string `json:"outcomeRecord"`
+}
+
 type SetOracleOutcomeParam struct {
 	TxHash  string `json:"txHash"`
 	Address string `json:"address"`
@@ -246,3 +326,64 @@ func (this *SetOracleOutcomeParam) Deserialize(r io.Reader) error {
 	this.Outcome = outcome
 	return nil
 }
+
+type RefundOracleRequestParam struct {
+	TxHash string `json:"txHash"`
+}
+
+func (this *RefundOracleRequestParam) Serialize(w io.Writer) error {
+	if err := serialization.WriteString(w, this.TxHash); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.WriteString, serialize txHash error!")
+	}
+	return nil
+}
+
+func (this *RefundOracleRequestParam) Deserialize(r io.Reader) error {
+	txHash, err := serialization.ReadString(r)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.ReadString, deserialize txHash error!")
+	}
+	this.TxHash = txHash
+	return nil
+}
+
+type QueryOracleRequestParam struct {
+	TxHash string `json:"txHash"`
+}
+
+func (this *QueryOracleRequestParam) Serialize(w io.Writer) error {
+	if err := serialization.WriteString(w, this.TxHash); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.WriteString, serialize txHash error!")
+	}
+	return nil
+}
+
+func (this *QueryOracleRequestParam) Deserialize(r io.Reader) error {
+	txHash, err := serialization.ReadString(r)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialization.ReadString, deserialize txHash error!")
+	}
+	this.TxHash = txHash
+	return nil
+}
+
+//OracleRequestInfo is the json result of getOracleRequest and listPendingOracleRequests
+type OracleRequestInfo struct {
+	TxHash          string          `json:"txHash"`
+	Request         string          `json:"request"`
+	Address         string          `json:"address"`
+	Fee             uint64          `json:"fee"`
+	Quorum          uint32          `json:"quorum"`
+	AggregationMode AggregationMode `json:"aggregationMode"`
+	Deadline        uint32          `json:"deadline"`
+	PenalizeDissent bool            `json:"penalizeDissent"`
+	Status          string          `json:"status"`
+}
+
+//OracleOutcomeInfo is the json result of getOracleOutcome
+type OracleOutcomeInfo struct {
+	TxHash        string            `json:"txHash"`
+	Status        string            `json:"status"`
+	FinalOutcome  string            `json:"finalOutcome"`
+	OutcomeRecord map[string]string `json:"outcomeRecord"`
+}
@@ -3,6 +3,8 @@ package oracle
 import (
 	"bytes"
 	"testing"
+
+	"github.com/ontio/ontology/common/serialization"
 )
 
 func TestSerialization_Status(t *testing.T) {
@@ -66,3 +68,74 @@ func TestSerialization_OracleNode(t *testing.T) {
 		t.Fatalf("TestSerialization_OracleNode failed")
 	}
 }
+
+func TestSerialization_CreateOracleRequestParam(t *testing.T) {
+	param1 := &CreateOracleRequestParam{
+		Request:         "{\"scheduler\":\"runAfter\"}",
+		OracleNode:      "bbbb5e502c2c72eb6edaa9516735d518f09c95c3",
+		Address:         "aaaa5e502c2c72eb6edaa9516735d518f09c95c3",
+		Fee:             1000,
+		Quorum:          3,
+		AggregationMode: MajorityMode,
+		Deadline:        100,
+		PenalizeDissent: true,
+	}
+	bf := new(bytes.Buffer)
+	if err := param1.Serialize(bf); err != nil {
+		t.Fatal(err)
+	}
+	rd := bytes.NewReader(bf.Bytes())
+
+	param2 := new(CreateOracleRequestParam)
+	if err := param2.Deserialize(rd); err != nil {
+		t.Fatal(err)
+	}
+
+	if *param1 != *param2 {
+		t.Fatalf("TestSerialization_CreateOracleRequestParam failed")
+	}
+}
+
+func TestDeserialization_LegacyCreateOracleRequestParam(t *testing.T) {
+	bf := new(bytes.Buffer)
+	serialization.WriteString(bf, "{\"scheduler\":\"runAfter\"}")
+	serialization.WriteString(bf, "bbbb5e502c2c72eb6edaa9516735d518f09c95c3")
+	serialization.WriteString(bf, "aaaa5e502c2c72eb6edaa9516735d518f09c95c3")
+	serialization.WriteUint64(bf, 1000)
+	rd := bytes.NewReader(bf.Bytes())
+
+	param := new(CreateOracleRequestParam)
+	if err := param.Deserialize(rd); err != nil {
+		t.Fatal(err)
+	}
+
+	if param.OracleNode != "bbbb5e502c2c72eb6edaa9516735d518f09c95c3" || param.Fee != 1000 ||
+		param.Quorum != 1 || param.AggregationMode != MajorityMode || param.Deadline != 0 || param.PenalizeDissent {
+		t.Fatalf("TestDeserialization_LegacyCreateOracleRequestParam failed")
+	}
+}
+
+func TestAggregateOutcome(t *testing.T) {
+	record := &OutcomeRecord{
+		OutcomeRecord: map[string]string{"a": "1", "b": "2", "c": "1"},
+	}
+	final, agreed, ok, err := aggregateOutcome(&CreateOracleRequestParam{Quorum: 2, AggregationMode: MajorityMode}, record)
+	if err != nil || !ok || final != "1" || len(agreed) != 2 || agreed[0] != "a" || agreed[1] != "c" {
+		t.Fatalf("TestAggregateOutcome majority failed")
+	}
+	_, _, ok, err = aggregateOutcome(&CreateOracleRequestParam{Quorum: 3, AggregationMode: MajorityMode}, record)
+	if err != nil || ok {
+		t.Fatalf("TestAggregateOutcome majority without quorum failed")
+	}
+
+	record.OutcomeRecord["d"] = "10"
+	final, agreed, ok, err = aggregateOutcome(&CreateOracleRequestParam{Quorum: 4, AggregationMode: MedianMode}, record)
+	if err != nil || !ok || final != "1" || len(agreed) != 4 {
+		t.Fatalf("TestAggregateOutcome median failed")
+	}
+	record.OutcomeRecord["e"] = "x"
+	_, _, _, err = aggregateOutcome(&CreateOracleRequestParam{Quorum: 4, AggregationMode: MedianMode}, record)
+	if err == nil {
+		t.Fatalf("TestAggregateOutcome median with non numeric outcome failed")
+	}
+}
@@ -0,0 +1,366 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package oracle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/ontio/ontology/common"
+	cstates "github.com/ontio/ontology/core/states"
+	"github.com/ontio/ontology/errors"
+	"github.com/ontio/ontology/smartcontract/event"
+	"github.com/ontio/ontology/smartcontract/service/native"
+	"github.com/ontio/ontology/smartcontract/service/native/ont"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//getStorageValue return the value of storage item, nil if the key doesn't exist
+func getStorageValue(native *native.NativeService, key []byte) ([]byte, error) {
+	item, err := utils.GetStorageItem(native, key)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, nil
+	}
+	return item.Value, nil
+}
+
+func GetOracleNode(native *native.NativeService, contract common.Address, addressBytes []byte) (*OracleNode, error) {
+	oracleNodeBytes, err := getStorageValue(native, utils.ConcatKey(contract, []byte(ORACLE_NODE), addressBytes))
+	if err != nil {
+		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "getStorageValue, get oracleNodeBytes error!")
+	}
+	if oracleNodeBytes == nil {
+		return nil, nil
+	}
+	oracleNode := new(OracleNode)
+	if err := oracleNode.Deserialize(bytes.NewBuffer(oracleNodeBytes)); err != nil {
+		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize oracleNode error!")
+	}
+	return oracleNode, nil
+}
+
+func putOracleNode(native *native.NativeService, contract common.Address, addressBytes []byte, oracleNode *OracleNode) error {
+	bf := new(bytes.Buffer)
+	if err := oracleNode.Serialize(bf); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "serialize, serialize oracleNode error!")
+	}
+	utils.PutBytes(native, utils.ConcatKey(contract, []byte(ORACLE_NODE), addressBytes), bf.Bytes())
+	return nil
+}
+
+//getApprovedOracleNodeCount return the number of oracle nodes that can set outcome
+func getApprovedOracleNodeCount(native *native.NativeService, contract common.Address) (uint32, error) {
+	iter := native.CacheDB.NewIterator(utils.ConcatKey(contract, []byte(ORACLE_NODE)))
+	defer iter.Release()
+	count := uint32(0)
+	for has := iter.First(); has; has = iter.Next() {
+		oracleNodeBytes, err := cstates.GetValueFromRawStorageItem(iter.Value())
+		if err != nil {
+			return 0, errors.NewDetailErr(err, errors.ErrNoCode, "GetValueFromRawStorageItem, oracleNode is not available!")
+		}
+		oracleNode := new(OracleNode)
+		if err := oracleNode.Deserialize(bytes.NewBuffer(oracleNodeBytes)); err != nil {
+			return 0, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize oracleNode error!")
+		}
+		if oracleNode.Status == OracleNodeStatus {
+			count += 1
+		}
+	}
+	return count, nil
+}
+
+func GetUndoRequests(native *native.NativeService, contract common.Address) (*UndoRequests, error) {
+	undoRequests := &UndoRequests{
+		Requests: make(map[string]struct{}),
+	}
+	undoRequestsBytes, err := getStorageValue(native, utils.ConcatKey(contract, []byte(UNDO_TXHASH)))
+	if err != nil {
+		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "getStorageValue, get UndoRequests error!")
+	}
+	if undoRequestsBytes != nil {
+		if err := json.Unmarshal(undoRequestsBytes, undoRequests); err != nil {
+			return nil, errors.NewDetailErr(err, errors.ErrNoCode, "json.Unmarshal, unmarshal UndoRequests error")
+		}
+	}
+	return undoRequests, nil
+}
+
+func putUndoRequests(native *native.NativeService, contract common.Address, undoRequests *UndoRequests) error {
+	value, err := json.Marshal(undoRequests)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "json.Marshal, marshal UndoRequests error")
+	}
+	utils.PutBytes(native, utils.ConcatKey(contract, []byte(UNDO_TXHASH)), value)
+	return nil
+}
+
+func GetRequest(native *native.NativeService, contract common.Address, txHash []byte) (*CreateOracleRequestParam, error) {
+	requestBytes, err := getStorageValue(native, utils.ConcatKey(contract, []byte(REQUEST), txHash))
+	if err != nil {
+		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "getStorageValue, get requestBytes error!")
+	}
+	if requestBytes == nil {
+		return nil, nil
+	}
+	request := new(CreateOracleRequestParam)
+	if err := request.Deserialize(bytes.NewBuffer(requestBytes)); err != nil {
+		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize request error!")
+	}
+	return request, nil
+}
+
+func GetOutcomeRecord(native *native.NativeService, contract common.Address, txHash []byte) (*OutcomeRecord, error) {
+	outcomeRecord := &OutcomeRecord{
+		OutcomeRecord: make(map[string]string),
+	}
+	outcomeRecordBytes, err := getStorageValue(native, utils.ConcatKey(contract, []byte(OUTCOME_RECORD), txHash))
+	if err != nil {
+		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "getStorageValue, get OutcomeRecord error!")
+	}
+	if outcomeRecordBytes != nil {
+		if err := json.Unmarshal(outcomeRecordBytes, outcomeRecord); err != nil {
+			return nil, errors.NewDetailErr(err, errors.ErrNoCode, "json.Unmarshal, unmarshal OutcomeRecord error")
+		}
+	}
+	return outcomeRecord, nil
+}
+
+func putOutcomeRecord(native *native.NativeService, contract common.Address, txHash []byte, outcomeRecord *OutcomeRecord) error {
+	value, err := json.Marshal(outcomeRecord)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "json.Marshal, marshal OutcomeRecord error")
+	}
+	utils.PutBytes(native, utils.ConcatKey(contract, []byte(OUTCOME_RECORD), txHash), value)
+	return nil
+}
+
+func GetFinalOutcome(native *native.NativeService, contract common.Address, txHash []byte) ([]byte, error) {
+	finalOutcomeBytes, err := getStorageValue(native, utils.ConcatKey(contract, []byte(FINAL_OUTCOME), txHash))
+	if err != nil {
+		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "getStorageValue, get FinalOutcome error!")
+	}
+	return finalOutcomeBytes, nil
+}
+
+//aggregateOutcome return the final outcome and the oracle nodes agreeing with it, ok is false when quorum is not met
+func aggregateOutcome(request *CreateOracleRequestParam, outcomeRecord *OutcomeRecord) (final string, agreed []string, ok bool, err error) {
+	switch request.AggregationMode {
+	case MajorityMode:
+		votes := make(map[string]uint32)
+		outcomes := make([]string, 0, len(outcomeRecord.OutcomeRecord))
+		for _, outcome := range outcomeRecord.OutcomeRecord {
+			if votes[outcome] == 0 {
+				outcomes = append(outcomes, outcome)
+			}
+			votes[outcome] += 1
+		}
+		sort.Strings(outcomes)
+		for _, outcome := range outcomes {
+			if votes[outcome] >= request.Quorum {
+				final, ok = outcome, true
+				break
+			}
+		}
+		if !ok {
+			return
+		}
+		for address, outcome := range outcomeRecord.OutcomeRecord {
+			if outcome == final {
+				agreed = append(agreed, address)
+			}
+		}
+	case MedianMode:
+		if uint32(len(outcomeRecord.OutcomeRecord)) < request.Quorum {
+			return
+		}
+		values := make([]int64, 0, len(outcomeRecord.OutcomeRecord))
+		for address, outcome := range outcomeRecord.OutcomeRecord {
+			value, e := strconv.ParseInt(outcome, 10, 64)
+			if e != nil {
+				err = errors.NewDetailErr(e, errors.ErrNoCode, "strconv.ParseInt, outcome is not numeric!")
+				return
+			}
+			values = append(values, value)
+			agreed = append(agreed, address)
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		final, ok = strconv.FormatInt(values[(len(values)-1)/2], 10), true
+	default:
+		err = errors.NewErr("aggregateOutcome, unknown aggregation mode!")
+		return
+	}
+	sort.Strings(agreed)
+	return
+}
+
+//splitFee transfer the fee of request equally to the oracle nodes, remainder goes to the first node
+func splitFee(native *native.NativeService, fee uint64, oracleNodes []string) error {
+	if len(oracleNodes) == 0 {
+		return nil
+	}
+	share := fee / uint64(len(oracleNodes))
+	remain := fee - share*uint64(len(oracleNodes))
+	for i, oracleNode := range oracleNodes {
+		amount := share
+		if i == 0 {
+			amount += remain
+		}
+		if amount == 0 {
+			continue
+		}
+		address, err := addressFromHex(oracleNode)
+		if err != nil {
+			return err
+		}
+		err = appCallTransferOng(native, utils.OracleContractAddress, address, amount)
+		if err != nil {
+			return errors.NewDetailErr(err, errors.ErrNoCode, "appCallTransferOng, ong transfer error!")
+		}
+	}
+	return nil
+}
+
+//penalizeOracleNode slash the guaranty of oracle node, the node need to be approved again if its guaranty drops
+//below MIN_GUARANTY. Slashed ont stays in oracle contract.
+func penalizeOracleNode(native *native.NativeService, contract common.Address, oracleNodeAddress string) error {
+	addressBytes, err := hex.DecodeString(oracleNodeAddress)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, address format error!")
+	}
+	oracleNode, err := GetOracleNode(native, contract, addressBytes)
+	if err != nil {
+		return err
+	}
+	//node already quit
+	if oracleNode == nil {
+		return nil
+	}
+	if oracleNode.Guaranty > DISSENT_PENALTY {
+		oracleNode.Guaranty -= DISSENT_PENALTY
+	} else {
+		oracleNode.Guaranty = 0
+	}
+	if oracleNode.Guaranty < MIN_GUARANTY {
+		oracleNode.Status = RegisterOracleNodeStatus
+	}
+	return putOracleNode(native, contract, addressBytes, oracleNode)
+}
+
+func addressFromHex(address string) (common.Address, error) {
+	addressBytes, err := hex.DecodeString(address)
+	if err != nil {
+		return common.Address{}, errors.NewDetailErr(err, errors.ErrNoCode, "hex.DecodeString, address format error!")
+	}
+	addr, err := common.AddressParseFromBytes(addressBytes)
+	if err != nil {
+		return common.Address{}, errors.NewDetailErr(err, errors.ErrNoCode, "common.AddressParseFromBytes, address format error!")
+	}
+	return addr, nil
+}
+
+//GetRequestStatus return the lifecycle status of request, finalized and refunded requests are no longer in undoRequests
+func GetRequestStatus(native *native.NativeService, contract common.Address, txHash []byte,
+	request *CreateOracleRequestParam, undoRequests *UndoRequests) (string, error) {
+	if _, ok := undoRequests.Requests[hex.EncodeToString(txHash)]; ok {
+		if request.Deadline != 0 && native.Height > request.Deadline {
+			return REQUEST_STATUS_EXPIRED, nil
+		}
+		return REQUEST_STATUS_PENDING, nil
+	}
+	finalOutcome, err := GetFinalOutcome(native, contract, txHash)
+	if err != nil {
+		return "", err
+	}
+	if finalOutcome != nil {
+		return REQUEST_STATUS_FINALIZED, nil
+	}
+	return REQUEST_STATUS_REFUNDED, nil
+}
+
+func getRequestInfo(native *native.NativeService, contract common.Address, txHash []byte,
+	undoRequests *UndoRequests) (*OracleRequestInfo, error) {
+	request, err := GetRequest(native, contract, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, errors.NewErr("getRequestInfo, request is not exist!")
+	}
+	status, err := GetRequestStatus(native, contract, txHash, request, undoRequests)
+	if err != nil {
+		return nil, err
+	}
+	return &OracleRequestInfo{
+		TxHash:          hex.EncodeToString(txHash),
+		Request:         request.Request,
+		Address:         request.Address,
+		Fee:             request.Fee,
+		Quorum:          request.Quorum,
+		AggregationMode: request.AggregationMode,
+		Deadline:        request.Deadline,
+		PenalizeDissent: request.PenalizeDissent,
+		Status:          status,
+	}, nil
+}
+
+//notifyOracleEvent push a structured event, so that oracle nodes can follow the request lifecycle from chain
+func notifyOracleEvent(native *native.NativeService, contract common.Address, name string, txHash string, states map[string]interface{}) {
+	if states == nil {
+		states = make(map[string]interface{})
+	}
+	states["event"] = name
+	states["txHash"] = txHash
+	native.Notifications = append(native.Notifications,
+		&event.NotifyEventInfo{
+			ContractAddress: contract,
+			States:          states,
+		})
+}
+
+func addCommonEvent(native *native.NativeService, contract common.Address, name string, params interface{}) {
+	native.Notifications = append(native.Notifications,
+		&event.NotifyEventInfo{
+			ContractAddress: contract,
+			States:          []interface{}{name, params},
+		})
+}
+
+func appCallTransferOnt(native *native.NativeService, from common.Address, to common.Address, amount uint64) error {
+	return appCallTransfer(native, utils.OntContractAddress, from, to, amount)
+}
+
+func appCallTransferOng(native *native.NativeService, from common.Address, to common.Address, amount uint64) error {
+	return appCallTransfer(native, utils.OngContractAddress, from, to, amount)
+}
+
+func appCallTransfer(native *native.NativeService, contract common.Address, from common.Address, to common.Address, amount uint64) error {
+	transfers := ont.Transfers{
+		States: []ont.State{{From: from, To: to, Value: amount}},
+	}
+	if _, err := native.NativeCall(contract, "transfer", common.SerializeToBytes(&transfers)); err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "appCallTransfer, appCall error!")
+	}
+	return nil
+}
@@ -38,6 +38,10 @@ var (
 	STORAGE_GET_GAS               uint64 = 200
 	STORAGE_PUT_GAS               uint64 = 4000
 	STORAGE_DELETE_GAS            uint64 = 100
+	STORAGE_FIND_GAS              uint64 = 200
+	ITERATOR_NEXT_GAS             uint64 = 200
+	ITERATOR_KEY_GAS              uint64 = 100
+	ITERATOR_VALUE_GAS            uint64 = 100
 	RUNTIME_CHECKWITNESS_GAS      uint64 = 200
 	RUNTIME_VERIFYMUTISIG_GAS     uint64 = 400
 	RUNTIME_ADDRESSTOBASE58_GAS   uint64 = 40
@@ -48,12 +52,17 @@ var (
 	SHA256_GAS                    uint64 = 10
 	HASH160_GAS                   uint64 = 20
 	HASH256_GAS                   uint64 = 20
+	CRYPTO_HASH_GAS               uint64 = 20
+	CRYPTO_VERIFYSIGNATURE_GAS    uint64 = 200
+	CRYPTO_ECRECOVER_GAS          uint64 = 200
 	OPCODE_GAS                    uint64 = 1
 
 	PER_UNIT_CODE_LEN    int = 1024
 	METHOD_LENGTH_LIMIT  int = 1024
 	DUPLICATE_STACK_SIZE int = 1024 * 2
 	VM_STEP_LIMIT        int = 400000
+	// max storage iterators opened by a contract invocation
+	MAX_STORAGE_ITERATORS int = 16
 
 	// API Name
 	ATTRIBUTE_GETUSAGE_NAME = "dad-go.Attribute.GetUsage"
@@ -93,6 +102,11 @@ var (
 	STORAGE_DELETE_NAME             = "System.Storage.Delete"
 	STORAGE_GETCONTEXT_NAME         = "System.Storage.GetContext"
 	STORAGE_GETREADONLYCONTEXT_NAME = "System.Storage.GetReadOnlyContext"
+	STORAGE_FIND_NAME               = "System.Storage.Find"
+
+	ITERATOR_NEXT_NAME  = "System.Iterator.Next"
+	ITERATOR_KEY_NAME   = "System.Iterator.Key"
+	ITERATOR_VALUE_NAME = "System.Iterator.Value"
 
 	STORAGECONTEXT_ASREADONLY_NAME = "System.StorageContext.AsReadOnly"
 
@@ -108,6 +122,13 @@ var (
 	RUNTIME_GETCURRENTBLOCKHASH_NAME = "dad-go.Runtime.GetCurrentBlockHash"
 	RUNTIME_VERIFYMUTISIG_NAME       = "dad-go.Runtime.VerifyMutiSig"
 
+	CRYPTO_SM3_NAME             = "dad-go.Crypto.SM3"
+	CRYPTO_KECCAK256_NAME       = "dad-go.Crypto.Keccak256"
+	CRYPTO_RIPEMD160_NAME       = "dad-go.Crypto.Ripemd160"
+	CRYPTO_BLAKE2B_NAME         = "dad-go.Crypto.Blake2b"
+	CRYPTO_VERIFYSIGNATURE_NAME = "dad-go.Crypto.VerifySignature"
+	CRYPTO_ECRECOVER_NAME       = "dad-go.Crypto.EcRecover"
+
 	NATIVE_INVOKE_NAME = "dad-go.Native.Invoke"
 	WASM_INVOKE_NAME   = "dad-go.Wasm.InvokeWasm"
 
@@ -198,6 +219,16 @@ func initGAS_TABLE() *sync.Map {
 
 	m.Store(RUNTIME_BASE58TOADDRESS_NAME, RUNTIME_BASE58TOADDRESS_GAS)
 	m.Store(RUNTIME_ADDRESSTOBASE58_NAME, RUNTIME_ADDRESSTOBASE58_GAS)
+	m.Store(STORAGE_FIND_NAME, STORAGE_FIND_GAS)
+	m.Store(ITERATOR_NEXT_NAME, ITERATOR_NEXT_GAS)
+	m.Store(ITERATOR_KEY_NAME, ITERATOR_KEY_GAS)
+	m.Store(ITERATOR_VALUE_NAME, ITERATOR_VALUE_GAS)
+	m.Store(CRYPTO_SM3_NAME, CRYPTO_HASH_GAS)
+	m.Store(CRYPTO_KECCAK256_NAME, CRYPTO_HASH_GAS)
+	m.Store(CRYPTO_RIPEMD160_NAME, CRYPTO_HASH_GAS)
+	m.Store(CRYPTO_BLAKE2B_NAME, CRYPTO_HASH_GAS)
+	m.Store(CRYPTO_VERIFYSIGNATURE_NAME, CRYPTO_VERIFYSIGNATURE_GAS)
+	m.Store(CRYPTO_ECRECOVER_NAME, CRYPTO_ECRECOVER_GAS)
 
 	m.Store(RUNTIME_VERIFYMUTISIG_NAME, RUNTIME_VERIFYMUTISIG_GAS)
 	m.Store(WASM_INVOKE_NAME, APPCALL_GAS)
@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package neovm
+
+import (
+	scommon "github.com/ontio/dad-go/smartcontract/common"
+	vm "github.com/ontio/dad-go/vm/neovm"
+)
+
+// CryptoSM3 push the sm3 digest of the data on stack top
+func CryptoSM3(service *NeoVmService, engine *vm.Executor) error {
+	return hashStackTop(engine, scommon.SM3)
+}
+
+// CryptoKeccak256 push the keccak256 digest of the data on stack top
+func CryptoKeccak256(service *NeoVmService, engine *vm.Executor) error {
+	return hashStackTop(engine, scommon.Keccak256)
+}
+
+// CryptoRipemd160 push the ripemd160 digest of the data on stack top
+func CryptoRipemd160(service *NeoVmService, engine *vm.Executor) error {
+	return hashStackTop(engine, scommon.Ripemd160)
+}
+
+// CryptoBlake2b push the 256 bits blake2b digest of the data on stack top
+func CryptoBlake2b(service *NeoVmService, engine *vm.Executor) error {
+	return hashStackTop(engine, scommon.Blake2b256)
+}
+
+// CryptoVerifySignature pop data, public key and signature, push whether the signature is valid
+func CryptoVerifySignature(service *NeoVmService, engine *vm.Executor) error {
+	data, err := engine.EvalStack.PopAsBytes()
+	if err != nil {
+		return err
+	}
+	pubKey, err := engine.EvalStack.PopAsBytes()
+	if err != nil {
+		return err
+	}
+	sig, err := engine.EvalStack.PopAsBytes()
+	if err != nil {
+		return err
+	}
+	return engine.EvalStack.PushBool(scommon.VerifySignature(pubKey, data, sig))
+}
+
+// CryptoEcRecover pop message hash and signature, push the recovered public key, or empty bytes if failed.
+// Only secp256k1 signatures in the 65 bytes r || s || v format can be recovered, signatures of other
+// curves such as P-256 or SM2 always fail
+func CryptoEcRecover(service *NeoVmService, engine *vm.Executor) error {
+	hash, err := engine.EvalStack.PopAsBytes()
+	if err != nil {
+		return err
+	}
+	sig, err := engine.EvalStack.PopAsBytes()
+	if err != nil {
+		return err
+	}
+	pubKey, err := scommon.EcRecover(hash, sig)
+	if err != nil {
+		return engine.EvalStack.PushBytes([]byte{})
+	}
+	return engine.EvalStack.PushBytes(pubKey)
+}
+
+func hashStackTop(engine *vm.Executor, hash func([]byte) []byte) error {
+	data, err := engine.EvalStack.PopAsBytes()
+	if err != nil {
+		return err
+	}
+	return engine.EvalStack.PushBytes(hash(data))
+}
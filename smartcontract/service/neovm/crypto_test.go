@@ -0,0 +1,57 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package neovm
+
+import (
+	"testing"
+
+	vm "github.com/ontio/dad-go/vm/neovm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCryptoGasCost(t *testing.T) {
+	gasTable := map[string]uint64{
+		CRYPTO_KECCAK256_NAME:       CRYPTO_HASH_GAS,
+		CRYPTO_VERIFYSIGNATURE_NAME: CRYPTO_VERIFYSIGNATURE_GAS,
+		CRYPTO_ECRECOVER_NAME:       CRYPTO_ECRECOVER_GAS,
+	}
+	engine := vm.NewExecutor(nil, vm.VmFeatureFlag{})
+	assert.Nil(t, engine.EvalStack.PushBytes(make([]byte, 100)))
+	gas, err := GasPrice(gasTable, engine, CRYPTO_KECCAK256_NAME)
+	assert.Nil(t, err)
+	assert.Equal(t, CRYPTO_HASH_GAS, gas)
+
+	assert.Nil(t, engine.EvalStack.PushBytes(make([]byte, 3000)))
+	gas, err = GasPrice(gasTable, engine, CRYPTO_KECCAK256_NAME)
+	assert.Nil(t, err)
+	assert.Equal(t, 3*CRYPTO_HASH_GAS, gas)
+	gas, err = GasPrice(gasTable, engine, CRYPTO_VERIFYSIGNATURE_NAME)
+	assert.Nil(t, err)
+	assert.Equal(t, 3*CRYPTO_VERIFYSIGNATURE_GAS, gas)
+
+	//the hash to recover from has fixed length
+	gas, err = GasPrice(gasTable, engine, CRYPTO_ECRECOVER_NAME)
+	assert.Nil(t, err)
+	assert.Equal(t, CRYPTO_ECRECOVER_GAS, gas)
+
+	//data missing on stack
+	engine = vm.NewExecutor(nil, vm.VmFeatureFlag{})
+	_, err = GasPrice(gasTable, engine, CRYPTO_KECCAK256_NAME)
+	assert.NotNil(t, err)
+}
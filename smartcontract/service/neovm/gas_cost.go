@@ -39,10 +39,58 @@ func StoreGasCost(gasTable map[string]uint64, engine *vm.Executor) (uint64, erro
 	}
 }
 
+//IteratorGasCost charge the key or value read from the storage iterator on top of stack per KB,
+//the base price is charged if there is no item to read
+func IteratorGasCost(gasTable map[string]uint64, engine *vm.Executor, name string) (uint64, error) {
+	price, ok := gasTable[name]
+	if !ok {
+		return uint64(0), errors.NewErr("[IteratorGasCost] get " + name + " gas failed")
+	}
+	top, err := engine.EvalStack.Peek(0)
+	if err != nil {
+		return price, nil
+	}
+	interop, err := top.AsInteropValue()
+	if err != nil {
+		return price, nil
+	}
+	iter, ok := interop.Data.(*StorageIterator)
+	if !ok {
+		return price, nil
+	}
+	var item []byte
+	if name == ITERATOR_KEY_NAME {
+		item, err = iter.Key()
+	} else {
+		item, err = iter.Value()
+	}
+	if err != nil {
+		return price, nil
+	}
+	return uint64((len(item)-1)/1024+1) * price, nil
+}
+
+//CryptoGasCost charge the data on top of stack to be hashed or verified per KB
+func CryptoGasCost(gasTable map[string]uint64, engine *vm.Executor, name string) (uint64, error) {
+	price, ok := gasTable[name]
+	if !ok {
+		return uint64(0), errors.NewErr("[CryptoGasCost] get " + name + " gas failed")
+	}
+	data, err := engine.EvalStack.PeekAsBytes(0)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(data)/1024+1) * price, nil
+}
+
 func GasPrice(gasTable map[string]uint64, engine *vm.Executor, name string) (uint64, error) {
 	switch name {
 	case STORAGE_PUT_NAME:
 		return StoreGasCost(gasTable, engine)
+	case ITERATOR_KEY_NAME, ITERATOR_VALUE_NAME:
+		return IteratorGasCost(gasTable, engine, name)
+	case CRYPTO_SM3_NAME, CRYPTO_KECCAK256_NAME, CRYPTO_RIPEMD160_NAME, CRYPTO_BLAKE2B_NAME, CRYPTO_VERIFYSIGNATURE_NAME:
+		return CryptoGasCost(gasTable, engine, name)
 	default:
 		if value, ok := gasTable[name]; ok {
 			return value, nil
@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"fmt"
 	scommon "github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/common/config"
 	"github.com/ontio/dad-go/common/log"
 	"github.com/ontio/dad-go/core/store"
 	"github.com/ontio/dad-go/core/types"
@@ -77,6 +78,10 @@ var (
 		STORAGE_GET_NAME:                     {Execute: StorageGet},
 		STORAGE_PUT_NAME:                     {Execute: StoragePut},
 		STORAGE_DELETE_NAME:                  {Execute: StorageDelete},
+		STORAGE_FIND_NAME:                    {Execute: StorageFind, EnableHeight: config.GetStorageIteratorHeight},
+		ITERATOR_NEXT_NAME:                   {Execute: IteratorNext, EnableHeight: config.GetStorageIteratorHeight},
+		ITERATOR_KEY_NAME:                    {Execute: IteratorKey, EnableHeight: config.GetStorageIteratorHeight},
+		ITERATOR_VALUE_NAME:                  {Execute: IteratorValue, EnableHeight: config.GetStorageIteratorHeight},
 		STORAGE_GETCONTEXT_NAME:              {Execute: StorageGetContext},
 		STORAGE_GETREADONLYCONTEXT_NAME:      {Execute: StorageGetReadOnlyContext},
 		STORAGECONTEXT_ASREADONLY_NAME:       {Execute: StorageContextAsReadOnly},
@@ -88,6 +93,13 @@ var (
 		RUNTIME_BASE58TOADDRESS_NAME:     {Execute: RuntimeBase58ToAddress},
 		RUNTIME_ADDRESSTOBASE58_NAME:     {Execute: RuntimeAddressToBase58},
 		RUNTIME_GETCURRENTBLOCKHASH_NAME: {Execute: RuntimeGetCurrentBlockHash},
+
+		CRYPTO_SM3_NAME:             {Execute: CryptoSM3, EnableHeight: config.GetCryptoHeight},
+		CRYPTO_KECCAK256_NAME:       {Execute: CryptoKeccak256, EnableHeight: config.GetCryptoHeight},
+		CRYPTO_RIPEMD160_NAME:       {Execute: CryptoRipemd160, EnableHeight: config.GetCryptoHeight},
+		CRYPTO_BLAKE2B_NAME:         {Execute: CryptoBlake2b, EnableHeight: config.GetCryptoHeight},
+		CRYPTO_VERIFYSIGNATURE_NAME: {Execute: CryptoVerifySignature, EnableHeight: config.GetCryptoHeight},
+		CRYPTO_ECRECOVER_NAME:       {Execute: CryptoEcRecover, EnableHeight: config.GetCryptoHeight},
 	}
 )
 
@@ -107,6 +119,8 @@ type (
 
 type Service struct {
 	Execute Execute
+	// EnableHeight return the activation height of service added after genesis, nil if it is always enabled
+	EnableHeight func(networkId uint32) uint32
 }
 
 // NeoVmService is a struct for smart contract provide interop service
@@ -123,6 +137,7 @@ type NeoVmService struct {
 	BlockHash     scommon.Uint256
 	Engine        *vm.Executor
 	PreExec       bool
+	iterators     []*StorageIterator
 }
 
 // Invoke a smart contract
@@ -130,7 +145,13 @@ func (this *NeoVmService) Invoke() (interface{}, error) {
 	if len(this.Code) == 0 {
 		return nil, ERR_EXECUTE_CODE
 	}
-	this.ContextRef.PushContext(&context.Context{ContractAddress: scommon.AddressFromVmCode(this.Code), Code: this.Code})
+	defer this.releaseIterators()
+	contractAddress := scommon.AddressFromVmCode(this.Code)
+	this.ContextRef.PushContext(&context.Context{ContractAddress: contractAddress, Code: this.Code})
+	tracer := this.Engine.Tracer
+	if tracer != nil {
+		tracer.CaptureEnter(contractAddress)
+	}
 	var gasTable [256]uint64
 	for {
 		//check the execution step count
@@ -140,7 +161,8 @@ func (this *NeoVmService) Invoke() (interface{}, error) {
 		if this.Engine.Context == nil {
 			break
 		}
-		if this.Engine.Context.GetInstructionPointer() >= len(this.Engine.Context.Code) {
+		ip := this.Engine.Context.GetInstructionPointer()
+		if ip >= len(this.Engine.Context.Code) {
 			break
 		}
 		opCode, eof := this.Engine.Context.ReadOpCode()
@@ -162,6 +184,9 @@ func (this *NeoVmService) Invoke() (interface{}, error) {
 			gasTable[opCode] = price
 		}
 
+		if tracer != nil {
+			tracer.CaptureState(ip, opCode, price, this.Engine.EvalStack, this.Engine.AltStack)
+		}
 		if !this.ContextRef.CheckUseGas(price) {
 			return nil, ERR_GAS_INSUFFICIENT
 		}
@@ -225,6 +250,9 @@ func (this *NeoVmService) Invoke() (interface{}, error) {
 			}
 		}
 	}
+	if tracer != nil {
+		tracer.CaptureExit()
+	}
 	this.ContextRef.PopContext()
 	this.ContextRef.PushNotifications(this.Notifications)
 	if this.Engine.EvalStack.Count() != 0 {
@@ -244,13 +272,16 @@ func (this *NeoVmService) SystemCall(engine *vm.Executor) error {
 		return err
 	}
 	service, ok := ServiceMap[serviceName]
-	if !ok {
+	if !ok || (service.EnableHeight != nil && this.Height < service.EnableHeight(config.DefConfig.P2PNode.NetworkId)) {
 		return errors.NewErr(fmt.Sprintf("[SystemCall] the given service is not supported: %s", serviceName))
 	}
 	price, err := GasPrice(this.GasTable, engine, serviceName)
 	if err != nil {
 		return err
 	}
+	if engine.Tracer != nil {
+		engine.Tracer.CaptureGas(price)
+	}
 	if !this.ContextRef.CheckUseGas(price) {
 		return ERR_GAS_INSUFFICIENT
 	}
@@ -53,6 +53,9 @@ func StoragePut(service *NeoVmService, engine *vm.Executor) error {
 	}
 
 	service.CacheDB.Put(genStorageKey(context.Address, key), states.GenRawStorageItem(value))
+	if engine.Tracer != nil {
+		engine.Tracer.CaptureStorage(vm.STORAGE_PUT, key, value)
+	}
 	return nil
 }
 
@@ -73,6 +76,9 @@ func StorageDelete(service *NeoVmService, engine *vm.Executor) error {
 		return err
 	}
 	service.CacheDB.Delete(genStorageKey(context.Address, ba))
+	if engine.Tracer != nil {
+		engine.Tracer.CaptureStorage(vm.STORAGE_DELETE, ba, nil)
+	}
 
 	return nil
 }
@@ -95,12 +101,18 @@ func StorageGet(service *NeoVmService, engine *vm.Executor) error {
 	}
 
 	if len(raw) == 0 {
+		if engine.Tracer != nil {
+			engine.Tracer.CaptureStorage(vm.STORAGE_GET, ba, nil)
+		}
 		return engine.EvalStack.PushBytes([]byte{})
 	}
 	value, err := states.GetValueFromRawStorageItem(raw)
 	if err != nil {
 		return err
 	}
+	if engine.Tracer != nil {
+		engine.Tracer.CaptureStorage(vm.STORAGE_GET, ba, value)
+	}
 	return engine.EvalStack.PushBytes(value)
 }
 
@@ -0,0 +1,175 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package neovm
+
+import (
+	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/core/states"
+	scommon "github.com/ontio/dad-go/core/store/common"
+	"github.com/ontio/dad-go/errors"
+	vm "github.com/ontio/dad-go/vm/neovm"
+)
+
+// StorageIterator iterate the storage items of a contract whose key has the given prefix
+type StorageIterator struct {
+	Address common.Address
+	Prefix  []byte
+	iter    scommon.StoreIterator
+	valid   bool
+}
+
+// NewStorageIterator return a storage iterator positioned before the first item
+func NewStorageIterator(address common.Address, prefix []byte, iter scommon.StoreIterator) *StorageIterator {
+	return &StorageIterator{Address: address, Prefix: prefix, iter: iter}
+}
+
+// ToArray return the key prefix of iterator
+func (this *StorageIterator) ToArray() []byte {
+	return this.Prefix
+}
+
+// Next move the iterator to next item, return false if there is no more item
+func (this *StorageIterator) Next() (bool, error) {
+	if this.iter == nil {
+		return false, nil
+	}
+	this.valid = this.iter.Next()
+	if !this.valid {
+		err := this.iter.Error()
+		this.Release()
+		return false, err
+	}
+	return true, nil
+}
+
+// Key return the storage key of current item, without the contract address
+func (this *StorageIterator) Key() ([]byte, error) {
+	if !this.valid {
+		return nil, errors.NewErr("[StorageIterator] iterator is not positioned on an item")
+	}
+	key := this.iter.Key()
+	if len(key) < common.ADDR_LEN {
+		return nil, errors.NewErr("[StorageIterator] invalid storage key")
+	}
+	return key[common.ADDR_LEN:], nil
+}
+
+// Value return the storage value of current item
+func (this *StorageIterator) Value() ([]byte, error) {
+	if !this.valid {
+		return nil, errors.NewErr("[StorageIterator] iterator is not positioned on an item")
+	}
+	return states.GetValueFromRawStorageItem(this.iter.Value())
+}
+
+// Release close the underlying db iterator
+func (this *StorageIterator) Release() {
+	if this.iter != nil {
+		this.iter.Release()
+		this.iter = nil
+	}
+	this.valid = false
+}
+
+// StorageFind push an iterator over the storage items with the given key prefix to vm stack
+func StorageFind(service *NeoVmService, engine *vm.Executor) error {
+	context, err := getContext(engine)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "[StorageFind] get pop context error!")
+	}
+	prefix, err := engine.EvalStack.PopAsBytes()
+	if err != nil {
+		return err
+	}
+	if len(prefix) > 1024 {
+		return errors.NewErr("[StorageFind] Storage key prefix to long")
+	}
+	if len(service.iterators) >= MAX_STORAGE_ITERATORS {
+		return errors.NewErr("[StorageFind] too many storage iterators")
+	}
+	iter := NewStorageIterator(context.Address, prefix, service.CacheDB.NewIterator(genStorageKey(context.Address, prefix)))
+	service.iterators = append(service.iterators, iter)
+	return engine.EvalStack.PushAsInteropValue(iter)
+}
+
+// IteratorNext move the iterator on vm stack to next item and push whether there is one
+func IteratorNext(service *NeoVmService, engine *vm.Executor) error {
+	iter, err := getStorageIterator(engine)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "[IteratorNext] get pop iterator error!")
+	}
+	ok, err := iter.Next()
+	if err != nil {
+		return err
+	}
+	return engine.EvalStack.PushBool(ok)
+}
+
+// IteratorKey push the storage key of current item to vm stack
+func IteratorKey(service *NeoVmService, engine *vm.Executor) error {
+	iter, err := getStorageIterator(engine)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "[IteratorKey] get pop iterator error!")
+	}
+	key, err := iter.Key()
+	if err != nil {
+		return err
+	}
+	return engine.EvalStack.PushBytes(key)
+}
+
+// IteratorValue push the storage value of current item to vm stack
+func IteratorValue(service *NeoVmService, engine *vm.Executor) error {
+	iter, err := getStorageIterator(engine)
+	if err != nil {
+		return errors.NewDetailErr(err, errors.ErrNoCode, "[IteratorValue] get pop iterator error!")
+	}
+	value, err := iter.Value()
+	if err != nil {
+		return err
+	}
+	if engine.Tracer != nil {
+		if key, err := iter.Key(); err == nil {
+			engine.Tracer.CaptureStorage(vm.STORAGE_GET, key, value)
+		}
+	}
+	return engine.EvalStack.PushBytes(value)
+}
+
+func getStorageIterator(engine *vm.Executor) (*StorageIterator, error) {
+	opInterface, err := engine.EvalStack.PopAsInteropValue()
+	if err != nil {
+		return nil, err
+	}
+	if opInterface.Data == nil {
+		return nil, errors.NewErr("[Iterator] Get storage iterator nil")
+	}
+	iter, ok := opInterface.Data.(*StorageIterator)
+	if !ok {
+		return nil, errors.NewErr("[Iterator] Get storage iterator invalid")
+	}
+	return iter, nil
+}
+
+func (this *NeoVmService) releaseIterators() {
+	for _, iter := range this.iterators {
+		iter.Release()
+	}
+	this.iterators = nil
+}
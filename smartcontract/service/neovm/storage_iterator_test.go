@@ -0,0 +1,102 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package neovm
+
+import (
+	"testing"
+
+	"github.com/ontio/dad-go/common"
+	"github.com/ontio/dad-go/core/states"
+	"github.com/ontio/dad-go/core/store/leveldbstore"
+	"github.com/ontio/dad-go/core/store/overlaydb"
+	"github.com/ontio/dad-go/smartcontract/storage"
+	vm "github.com/ontio/dad-go/vm/neovm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageIterator(t *testing.T) {
+	memback, _ := leveldbstore.NewMemLevelDBStore()
+	overlay := overlaydb.NewOverlayDB(memback)
+	cache := storage.NewCacheDB(overlay)
+
+	addr := common.Address{1}
+	other := common.Address{2}
+	cache.Put(genStorageKey(addr, []byte("a1")), states.GenRawStorageItem([]byte("v1")))
+	cache.Put(genStorageKey(addr, []byte("a2")), states.GenRawStorageItem([]byte("v2")))
+	cache.Put(genStorageKey(addr, []byte("a3")), states.GenRawStorageItem([]byte("v3")))
+	cache.Put(genStorageKey(addr, []byte("b1")), states.GenRawStorageItem([]byte("v4")))
+	cache.Put(genStorageKey(other, []byte("a4")), states.GenRawStorageItem([]byte("v5")))
+	cache.Delete(genStorageKey(addr, []byte("a2")))
+
+	iter := NewStorageIterator(addr, []byte("a"), cache.NewIterator(genStorageKey(addr, []byte("a"))))
+	_, err := iter.Key()
+	assert.NotNil(t, err)
+
+	var keys, values []string
+	for {
+		ok, err := iter.Next()
+		assert.Nil(t, err)
+		if !ok {
+			break
+		}
+		key, err := iter.Key()
+		assert.Nil(t, err)
+		value, err := iter.Value()
+		assert.Nil(t, err)
+		keys = append(keys, string(key))
+		values = append(values, string(value))
+	}
+	assert.Equal(t, []string{"a1", "a3"}, keys)
+	assert.Equal(t, []string{"v1", "v3"}, values)
+
+	ok, err := iter.Next()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	_, err = iter.Value()
+	assert.NotNil(t, err)
+}
+
+func TestIteratorGasCost(t *testing.T) {
+	memback, _ := leveldbstore.NewMemLevelDBStore()
+	overlay := overlaydb.NewOverlayDB(memback)
+	cache := storage.NewCacheDB(overlay)
+
+	addr := common.Address{1}
+	cache.Put(genStorageKey(addr, []byte("k")), states.GenRawStorageItem(make([]byte, 2000)))
+	gasTable := map[string]uint64{ITERATOR_KEY_NAME: ITERATOR_KEY_GAS, ITERATOR_VALUE_NAME: ITERATOR_VALUE_GAS}
+
+	iter := NewStorageIterator(addr, nil, cache.NewIterator(genStorageKey(addr, nil)))
+	engine := vm.NewExecutor(nil, vm.VmFeatureFlag{})
+	assert.Nil(t, engine.EvalStack.PushAsInteropValue(iter))
+
+	//base price before the iterator is positioned on an item
+	gas, err := GasPrice(gasTable, engine, ITERATOR_VALUE_NAME)
+	assert.Nil(t, err)
+	assert.Equal(t, ITERATOR_VALUE_GAS, gas)
+
+	ok, err := iter.Next()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	gas, err = GasPrice(gasTable, engine, ITERATOR_KEY_NAME)
+	assert.Nil(t, err)
+	assert.Equal(t, ITERATOR_KEY_GAS, gas)
+	gas, err = GasPrice(gasTable, engine, ITERATOR_VALUE_NAME)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*ITERATOR_VALUE_GAS, gas)
+}
@@ -35,8 +35,20 @@ var (
 	STORAGE_GET_GAS          uint64 = 200
 	STORAGE_PUT_GAS          uint64 = 4000
 	STORAGE_DELETE_GAS       uint64 = 100
+	STORAGE_ITER_NEW_GAS     uint64 = 200
+	STORAGE_ITER_NEXT_GAS    uint64 = 200
+	STORAGE_ITER_READ_GAS    uint64 = 100 //per KB of the key or value read
 	UINT_DEPLOY_CODE_LEN_GAS uint64 = 200000
 	PER_UNIT_CODE_LEN        uint64 = 1024
 
-	SHA256_GAS uint64 = 10
+	SHA256_GAS           uint64 = 10
+	SM3_GAS              uint64 = 10
+	KECCAK256_GAS        uint64 = 10
+	RIPEMD160_GAS        uint64 = 10
+	BLAKE2B_GAS          uint64 = 10
+	VERIFY_SIGNATURE_GAS uint64 = 200
+	ECRECOVER_GAS        uint64 = 200
+
+	//max storage iterators opened by a contract invocation
+	MAX_STORAGE_ITERATORS = 16
 )
@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package wasmvm
+
+import (
+	"errors"
+
+	"github.com/ontio/ontology/common/config"
+	scommon "github.com/ontio/ontology/smartcontract/common"
+	"github.com/ontio/wagon/exec"
+)
+
+func (self *Runtime) checkCryptoEnabled() {
+	if self.Service.Height < config.GetCryptoHeight(config.DefConfig.P2PNode.NetworkId) {
+		panic(errors.New("crypto host function is not supported"))
+	}
+}
+
+func hashMemory(proc *exec.Process, src uint32, slen uint32, dst uint32, unitGas uint64, hash func([]byte) []byte) {
+	self := proc.HostData().(*Runtime)
+	self.checkCryptoEnabled()
+	cost := uint64((slen/1024)+1) * unitGas
+	self.checkGas(cost)
+
+	bs, err := ReadWasmMemory(proc, src, slen)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = proc.WriteAt(hash(bs), int64(dst))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func SM3(proc *exec.Process, src uint32, slen uint32, dst uint32) {
+	hashMemory(proc, src, slen, dst, SM3_GAS, scommon.SM3)
+}
+
+func Keccak256(proc *exec.Process, src uint32, slen uint32, dst uint32) {
+	hashMemory(proc, src, slen, dst, KECCAK256_GAS, scommon.Keccak256)
+}
+
+func Ripemd160(proc *exec.Process, src uint32, slen uint32, dst uint32) {
+	hashMemory(proc, src, slen, dst, RIPEMD160_GAS, scommon.Ripemd160)
+}
+
+func Blake2b(proc *exec.Process, src uint32, slen uint32, dst uint32) {
+	hashMemory(proc, src, slen, dst, BLAKE2B_GAS, scommon.Blake2b256)
+}
+
+//VerifySignature return 1 if sig is a valid signature of data by the serialized public key, otherwise 0
+func VerifySignature(proc *exec.Process, pkPtr uint32, pkLen uint32, dataPtr uint32, dataLen uint32, sigPtr uint32, sigLen uint32) uint32 {
+	self := proc.HostData().(*Runtime)
+	self.checkCryptoEnabled()
+	cost := uint64((dataLen/1024)+1) * VERIFY_SIGNATURE_GAS
+	self.checkGas(cost)
+
+	pubKey, err := ReadWasmMemory(proc, pkPtr, pkLen)
+	if err != nil {
+		panic(err)
+	}
+	data, err := ReadWasmMemory(proc, dataPtr, dataLen)
+	if err != nil {
+		panic(err)
+	}
+	sig, err := ReadWasmMemory(proc, sigPtr, sigLen)
+	if err != nil {
+		panic(err)
+	}
+
+	if scommon.VerifySignature(pubKey, data, sig) {
+		return 1
+	}
+	return 0
+}
+
+//EcRecover write the uncompressed public key recovered from the 32 bytes hash and 65 bytes signature to dst,
+//return 1 if succeed, otherwise 0. Only secp256k1 signatures can be recovered
+func EcRecover(proc *exec.Process, hashPtr uint32, sigPtr uint32, dst uint32) uint32 {
+	self := proc.HostData().(*Runtime)
+	self.checkCryptoEnabled()
+	self.checkGas(ECRECOVER_GAS)
+
+	hash, err := ReadWasmMemory(proc, hashPtr, scommon.RECOVER_HASH_LEN)
+	if err != nil {
+		panic(err)
+	}
+	sig, err := ReadWasmMemory(proc, sigPtr, scommon.RECOVER_SIGNATURE_LEN)
+	if err != nil {
+		panic(err)
+	}
+
+	pubKey, err := scommon.EcRecover(hash, sig)
+	if err != nil {
+		return 0
+	}
+	_, err = proc.WriteAt(pubKey, int64(dst))
+	if err != nil {
+		panic(err)
+	}
+	return 1
+}
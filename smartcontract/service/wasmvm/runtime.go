@@ -341,6 +341,12 @@ func NewHostModule() *wasm.Module {
 				Form:       0, // value for the 'func' type constructor
 				ParamTypes: []wasm.ValueType{wasm.ValueTypeI32, wasm.ValueTypeI32, wasm.ValueTypeI32},
 			},
+			//func(uint32 * 6)uint32   [12]
+			{
+				Form:        0, // value for the 'func' type constructor
+				ParamTypes:  paramTypes[:6],
+				ReturnTypes: []wasm.ValueType{wasm.ValueTypeI32},
+			},
 		},
 	}
 	m.FunctionIndexSpace = []wasm.Function{
@@ -464,6 +470,56 @@ func NewHostModule() *wasm.Module {
 			Host: reflect.ValueOf(Sha256),
 			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
 		},
+		{ //24
+			Sig:  &m.Types.Entries[8],
+			Host: reflect.ValueOf(StorageIterNew),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
+		{ //25
+			Sig:  &m.Types.Entries[3],
+			Host: reflect.ValueOf(StorageIterNext),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
+		{ //26
+			Sig:  &m.Types.Entries[5],
+			Host: reflect.ValueOf(StorageIterKey),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
+		{ //27
+			Sig:  &m.Types.Entries[5],
+			Host: reflect.ValueOf(StorageIterValue),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
+		{ //28
+			Sig:  &m.Types.Entries[11],
+			Host: reflect.ValueOf(SM3),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
+		{ //29
+			Sig:  &m.Types.Entries[11],
+			Host: reflect.ValueOf(Keccak256),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
+		{ //30
+			Sig:  &m.Types.Entries[11],
+			Host: reflect.ValueOf(Ripemd160),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
+		{ //31
+			Sig:  &m.Types.Entries[11],
+			Host: reflect.ValueOf(Blake2b),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
+		{ //32
+			Sig:  &m.Types.Entries[12],
+			Host: reflect.ValueOf(VerifySignature),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
+		{ //33
+			Sig:  &m.Types.Entries[5],
+			Host: reflect.ValueOf(EcRecover),
+			Body: &wasm.FunctionBody{}, // create a dummy wasm body (the actual value will be taken from Host.)
+		},
 	}
 
 	m.Export = &wasm.SectionExports{
@@ -588,6 +644,56 @@ func NewHostModule() *wasm.Module {
 				Kind:     wasm.ExternalFunction,
 				Index:    23,
 			},
+			"ontio_storage_iter_new": {
+				FieldStr: "ontio_storage_iter_new",
+				Kind:     wasm.ExternalFunction,
+				Index:    24,
+			},
+			"ontio_storage_iter_next": {
+				FieldStr: "ontio_storage_iter_next",
+				Kind:     wasm.ExternalFunction,
+				Index:    25,
+			},
+			"ontio_storage_iter_key": {
+				FieldStr: "ontio_storage_iter_key",
+				Kind:     wasm.ExternalFunction,
+				Index:    26,
+			},
+			"ontio_storage_iter_value": {
+				FieldStr: "ontio_storage_iter_value",
+				Kind:     wasm.ExternalFunction,
+				Index:    27,
+			},
+			"ontio_sm3": {
+				FieldStr: "ontio_sm3",
+				Kind:     wasm.ExternalFunction,
+				Index:    28,
+			},
+			"ontio_keccak256": {
+				FieldStr: "ontio_keccak256",
+				Kind:     wasm.ExternalFunction,
+				Index:    29,
+			},
+			"ontio_ripemd160": {
+				FieldStr: "ontio_ripemd160",
+				Kind:     wasm.ExternalFunction,
+				Index:    30,
+			},
+			"ontio_blake2b": {
+				FieldStr: "ontio_blake2b",
+				Kind:     wasm.ExternalFunction,
+				Index:    31,
+			},
+			"ontio_verify_signature": {
+				FieldStr: "ontio_verify_signature",
+				Kind:     wasm.ExternalFunction,
+				Index:    32,
+			},
+			"ontio_ecrecover": {
+				FieldStr: "ontio_ecrecover",
+				Kind:     wasm.ExternalFunction,
+				Index:    33,
+			},
 		},
 	}
 
@@ -21,7 +21,10 @@ import (
 	"errors"
 	"math"
 
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/config"
 	"github.com/ontio/ontology/core/states"
+	scom "github.com/ontio/ontology/core/store/common"
 	"github.com/ontio/wagon/exec"
 )
 
@@ -110,3 +113,159 @@ func StorageDelete(proc *exec.Process, keyPtr uint32, keyLen uint32) {
 
 	self.Service.CacheDB.Delete(key)
 }
+
+//storageIterator iterate the storage items of current contract with a key prefix
+type storageIterator struct {
+	iter  scom.StoreIterator
+	valid bool
+}
+
+func (self *storageIterator) release() {
+	if self.iter != nil {
+		self.iter.Release()
+		self.iter = nil
+	}
+	self.valid = false
+}
+
+func checkStorageIterEnabled(service *WasmVmService) error {
+	if service.Height < config.GetStorageIteratorHeight(config.DefConfig.P2PNode.NetworkId) {
+		return errors.New("storage iterator is not supported")
+	}
+	return nil
+}
+
+func storageIterNew(service *WasmVmService, prefix []byte) (uint32, error) {
+	if err := checkStorageIterEnabled(service); err != nil {
+		return 0, err
+	}
+	if len(service.iterators) >= MAX_STORAGE_ITERATORS {
+		return 0, errors.New("too many storage iterators")
+	}
+	key := serializeStorageKey(service.ContextRef.CurrentContext().ContractAddress, prefix)
+	service.iterators = append(service.iterators, &storageIterator{iter: service.CacheDB.NewIterator(key)})
+	return uint32(len(service.iterators) - 1), nil
+}
+
+func getStorageIterator(service *WasmVmService, handle uint32) (*storageIterator, error) {
+	if err := checkStorageIterEnabled(service); err != nil {
+		return nil, err
+	}
+	if handle >= uint32(len(service.iterators)) {
+		return nil, errors.New("invalid storage iterator")
+	}
+	return service.iterators[handle], nil
+}
+
+func storageIterNext(service *WasmVmService, handle uint32) (bool, error) {
+	it, err := getStorageIterator(service, handle)
+	if err != nil {
+		return false, err
+	}
+	if it.iter == nil {
+		return false, nil
+	}
+	it.valid = it.iter.Next()
+	if !it.valid {
+		err := it.iter.Error()
+		it.release()
+		return false, err
+	}
+	return true, nil
+}
+
+func storageIterKey(service *WasmVmService, handle uint32) ([]byte, error) {
+	it, err := getStorageIterator(service, handle)
+	if err != nil {
+		return nil, err
+	}
+	if !it.valid {
+		return nil, errors.New("storage iterator is not positioned on an item")
+	}
+	key := it.iter.Key()
+	if len(key) < common.ADDR_LEN {
+		return nil, errors.New("invalid storage key")
+	}
+	return key[common.ADDR_LEN:], nil
+}
+
+func storageIterValue(service *WasmVmService, handle uint32) ([]byte, error) {
+	it, err := getStorageIterator(service, handle)
+	if err != nil {
+		return nil, err
+	}
+	if !it.valid {
+		return nil, errors.New("storage iterator is not positioned on an item")
+	}
+	return states.GetValueFromRawStorageItem(it.iter.Value())
+}
+
+func writeIterItem(proc *exec.Process, item []byte, dst uint32, dlen uint32) uint32 {
+	length := dlen
+	if uint32(len(item)) < dlen {
+		length = uint32(len(item))
+	}
+	_, err := proc.WriteAt(item[:length], int64(dst))
+	if err != nil {
+		panic(err)
+	}
+	return uint32(len(item))
+}
+
+//StorageIterNew open an iterator over the storage items of current contract with the given key prefix, and return its handle
+func StorageIterNew(proc *exec.Process, prefixPtr uint32, prefixLen uint32) uint32 {
+	self := proc.HostData().(*Runtime)
+	self.checkGas(STORAGE_ITER_NEW_GAS)
+	prefix, err := ReadWasmMemory(proc, prefixPtr, prefixLen)
+	if err != nil {
+		panic(err)
+	}
+	handle, err := storageIterNew(self.Service, prefix)
+	if err != nil {
+		panic(err)
+	}
+	return handle
+}
+
+//StorageIterNext move the iterator to next item, return 0 if there is no more item
+func StorageIterNext(proc *exec.Process, handle uint32) uint32 {
+	self := proc.HostData().(*Runtime)
+	self.checkGas(STORAGE_ITER_NEXT_GAS)
+	ok, err := storageIterNext(self.Service, handle)
+	if err != nil {
+		panic(err)
+	}
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+//StorageIterKey copy the key of current item into dst, and return the whole length of key
+func StorageIterKey(proc *exec.Process, handle uint32, dst uint32, dlen uint32) uint32 {
+	self := proc.HostData().(*Runtime)
+	key, err := storageIterKey(self.Service, handle)
+	if err != nil {
+		panic(err)
+	}
+	self.checkGas(uint64((len(key)-1)/1024+1) * STORAGE_ITER_READ_GAS)
+	return writeIterItem(proc, key, dst, dlen)
+}
+
+//StorageIterValue copy the value of current item into dst, and return the whole length of value
+func StorageIterValue(proc *exec.Process, handle uint32, dst uint32, dlen uint32) uint32 {
+	self := proc.HostData().(*Runtime)
+	value, err := storageIterValue(self.Service, handle)
+	if err != nil {
+		panic(err)
+	}
+	self.checkGas(uint64((len(value)-1)/1024+1) * STORAGE_ITER_READ_GAS)
+	return writeIterItem(proc, value, dst, dlen)
+}
+
+func (this *WasmVmService) releaseIterators() {
+	for _, it := range this.iterators {
+		it.release()
+	}
+	this.iterators = nil
+}
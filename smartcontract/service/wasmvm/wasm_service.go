@@ -50,6 +50,7 @@ type WasmVmService struct {
 	IsTerminate   bool
 	JitMode       bool
 	ServiceIndex  uint64
+	iterators     []*storageIterator
 	vm            *exec.VM
 }
 
@@ -125,6 +126,7 @@ func (this *WasmVmService) Invoke() (interface{}, error) {
 	if len(this.Code) == 0 {
 		return nil, ERR_EXECUTE_CODE
 	}
+	defer this.releaseIterators()
 
 	contract := &states.WasmContractParam{}
 	sink := common.NewZeroCopySource(this.Code)
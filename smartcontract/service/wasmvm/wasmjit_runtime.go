@@ -227,6 +227,71 @@ func ontio_storage_delete_cgo(service_index C.uint64_t, key_s C.wasmjit_slice_t)
 	service.CacheDB.Delete(key)
 }
 
+//export ontio_storage_iter_new_cgo
+func ontio_storage_iter_new_cgo(serviceIndex C.uint64_t, prefixSlice C.wasmjit_slice_t) C.wasmjit_u32 {
+	service := getWasmVmService(uint64(serviceIndex))
+	prefix := jitSliceToBytes(prefixSlice)
+
+	handle, err := storageIterNew(service, prefix)
+	if err != nil {
+		return C.wasmjit_u32{v: 0, res: jitErr(err)}
+	}
+
+	return C.wasmjit_u32{v: C.uint32_t(handle), res: C.wasmjit_result_t{kind: C.wasmjit_result_kind(wasmjit_result_success)}}
+}
+
+//export ontio_storage_iter_next_cgo
+func ontio_storage_iter_next_cgo(serviceIndex C.uint64_t, handle C.uint32_t) C.wasmjit_u32 {
+	service := getWasmVmService(uint64(serviceIndex))
+
+	ok, err := storageIterNext(service, uint32(handle))
+	if err != nil {
+		return C.wasmjit_u32{v: 0, res: jitErr(err)}
+	}
+	var v uint32
+	if ok {
+		v = 1
+	}
+
+	return C.wasmjit_u32{v: C.uint32_t(v), res: C.wasmjit_result_t{kind: C.wasmjit_result_kind(wasmjit_result_success)}}
+}
+
+//export ontio_storage_iter_key_cgo
+func ontio_storage_iter_key_cgo(serviceIndex C.uint64_t, handle C.uint32_t, dstSlice C.wasmjit_slice_t) C.wasmjit_u32 {
+	service := getWasmVmService(uint64(serviceIndex))
+
+	key, err := storageIterKey(service, uint32(handle))
+	if err != nil {
+		return C.wasmjit_u32{v: 0, res: jitErr(err)}
+	}
+
+	return jitWriteIterItem(key, dstSlice)
+}
+
+//export ontio_storage_iter_value_cgo
+func ontio_storage_iter_value_cgo(serviceIndex C.uint64_t, handle C.uint32_t, dstSlice C.wasmjit_slice_t) C.wasmjit_u32 {
+	service := getWasmVmService(uint64(serviceIndex))
+
+	value, err := storageIterValue(service, uint32(handle))
+	if err != nil {
+		return C.wasmjit_u32{v: 0, res: jitErr(err)}
+	}
+
+	return jitWriteIterItem(value, dstSlice)
+}
+
+func jitWriteIterItem(item []byte, dstSlice C.wasmjit_slice_t) C.wasmjit_u32 {
+	length := uint32(dstSlice.len)
+	if uint32(len(item)) < length {
+		length = uint32(len(item))
+	}
+	if length != 0 {
+		C.memcpy((unsafe.Pointer)(dstSlice.data), ((unsafe.Pointer)(&item[0])), C.ulong(length))
+	}
+
+	return C.wasmjit_u32{v: C.uint32_t(len(item)), res: C.wasmjit_result_t{kind: C.wasmjit_result_kind(wasmjit_result_success)}}
+}
+
 //export ontio_notify_cgo
 func ontio_notify_cgo(service_index C.uint64_t, data C.wasmjit_slice_t) C.wasmjit_result_t {
 	service := getWasmVmService(uint64(service_index))
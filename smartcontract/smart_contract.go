@@ -53,6 +53,7 @@ type SmartContract struct {
 	PreExec       bool
 	internelErr   bool
 	CrossHashes   []common.Uint256
+	Tracer        vm.Tracer // optional, records the neovm execution steps
 }
 
 // Config describe smart contract need parameters configuration
@@ -151,6 +152,8 @@ func (this *SmartContract) NewExecuteEngine(code []byte, txtype ctypes.Transacti
 	switch txtype {
 	case ctypes.InvokeNeo:
 		feature := NewVmFeatureFlag(this.Config.Height)
+		engine := vm.NewExecutor(code, feature)
+		engine.Tracer = this.Tracer
 		service = &neovm.NeoVmService{
 			Store:      this.Store,
 			CacheDB:    this.CacheDB,
@@ -161,7 +164,7 @@ func (this *SmartContract) NewExecuteEngine(code []byte, txtype ctypes.Transacti
 			Time:       this.Config.Time,
 			Height:     this.Config.Height,
 			BlockHash:  this.Config.BlockHash,
-			Engine:     vm.NewExecutor(code, feature),
+			Engine:     engine,
 			PreExec:    this.PreExec,
 		}
 	case ctypes.InvokeWasm:
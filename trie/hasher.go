@@ -19,9 +19,9 @@
 package trie
 
 import (
-	"github.com/dad-go/common"
+	"github.com/ontio/ontology/common"
 	"bytes"
-	"github.com/dad-go/rlp"
+	"github.com/ontio/ontology/rlp"
 	"sync"
 	"crypto/sha256"
 )
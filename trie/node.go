@@ -21,8 +21,8 @@ package trie
 import (
 	"fmt"
 	"io"
-	"github.com/dad-go/rlp"
-	"github.com/dad-go/common"
+	"github.com/ontio/ontology/rlp"
+	"github.com/ontio/ontology/common"
 )
 
 var indices = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "a", "b", "c", "d", "e", "f", "17"}
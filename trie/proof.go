@@ -20,10 +20,10 @@ package trie
 
 import (
 	"bytes"
-	"github.com/dad-go/common/log"
+	"github.com/ontio/ontology/common/log"
 	"fmt"
-	"github.com/dad-go/rlp"
-	"github.com/dad-go/common"
+	"github.com/ontio/ontology/rlp"
+	"github.com/ontio/ontology/common"
 	"errors"
 )
 
@@ -19,8 +19,8 @@
 package trie
 
 import (
-	"github.com/dad-go/common"
-	"github.com/dad-go/common/log"
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/log"
 	"fmt"
 )
 
@@ -20,8 +20,8 @@ package trie
 
 import (
 	"bytes"
-	"github.com/dad-go/common"
-	"github.com/dad-go/common/log"
+	"github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/log"
 	"fmt"
 	"reflect"
 )
@@ -129,6 +129,15 @@ func (t *Trie) TryUpdate(key, value [] byte) error {
 	return nil
 }
 
+// TryUpdateOrDelete is TryUpdate except that an empty value removes the key, so that
+// the root only depends on the live key/value pairs. It is used by the ledger storage trie.
+func (t *Trie) TryUpdateOrDelete(key, value []byte) error {
+	if len(value) == 0 {
+		return t.TryDelete(key)
+	}
+	return t.TryUpdate(key, value)
+}
+
 func (t *Trie) insert(n node, prefix, key []byte, value node) (node, error) {
 	if len(key) == 0 {
 		return value, nil
@@ -296,6 +305,9 @@ func (t *Trie) commitTo(db DatabaseWriter) (common.Uint256, error) {
 
 func (t *Trie) Hash() common.Uint256 {
 	hash, cached, _ := t.hashRoot(nil)
+	if hash == nil {
+		return common.Uint256{}
+	}
 	t.root = cached
 	u, _ := common.Uint256ParseFromBytes(hash.(hashNode))
 	return u
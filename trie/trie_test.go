@@ -21,7 +21,7 @@ package trie
 import (
 	"testing"
 	"bytes"
-	"github.com/dad-go/common"
+	"github.com/ontio/ontology/common"
 )
 
 func newEmpty() *Trie {
@@ -68,7 +68,7 @@ func TestNode(t *testing.T) {
 		t.Errorf("Wrong error: %v", err)
 	}
 	t.Log("trie key:", root)
-	trie.db.ViewDB()
+	trie.db.(*MemDatabase).ViewDB()
 
 	trie, _ = New(root, trie.db)
 
@@ -136,7 +136,7 @@ func TestReplication(t *testing.T) {
 	if err != nil {
 		t.Fatalf("commit error: %v", err)
 	}
-	if hash.CompareTo(exp) != 0 {
+	if hash != exp {
 		t.Errorf("root failure. expected %x got %x", exp, hash)
 	}
 
@@ -155,7 +155,7 @@ func TestReplication(t *testing.T) {
 	for _, val := range vals2 {
 		updateString(trie2, val.k, val.v)
 	}
-	if hash := trie2.Hash(); hash.CompareTo(exp) != 0 {
+	if hash := trie2.Hash(); hash != exp {
 		t.Errorf("root failure. expected %x got %x", exp, hash)
 	}
 }
@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package trie
+
+import (
+	"fmt"
+
+	"github.com/ontio/ontology/common"
+)
+
+// NodeKey returns the database key of the trie node with hash.
+func NodeKey(hash common.Uint256) []byte {
+	return append(common.CopyBytes(secureKeyPrefix), hash[:]...)
+}
+
+// Walk visits the nodes stored in db that are reachable from root. onNode is called with
+// the hash of every stored node before its children, returning false skips the subtree of
+// the node. onValue, if not nil, is called with every value of the visited subtrees.
+func Walk(root common.Uint256, db DatabaseReader, onNode func(hash common.Uint256) bool,
+	onValue func(value []byte) error) error {
+	if root == (common.Uint256{}) {
+		return nil
+	}
+	return walk(hashNode(root[:]), db, onNode, onValue)
+}
+
+func walk(n node, db DatabaseReader, onNode func(hash common.Uint256) bool, onValue func(value []byte) error) error {
+	switch n := n.(type) {
+	case nil:
+		return nil
+	case hashNode:
+		hash, err := common.Uint256ParseFromBytes(n)
+		if err != nil {
+			return err
+		}
+		if !onNode(hash) {
+			return nil
+		}
+		enc, err := db.Get(NodeKey(hash))
+		if err != nil {
+			return err
+		}
+		dec, err := decodeNode(n, enc)
+		if err != nil {
+			return err
+		}
+		return walk(dec, db, onNode, onValue)
+	case *shortNode:
+		return walk(n.Val, db, onNode, onValue)
+	case *fullNode:
+		for _, child := range n.Children {
+			if err := walk(child, db, onNode, onValue); err != nil {
+				return err
+			}
+		}
+		return nil
+	case valueNode:
+		if onValue == nil {
+			return nil
+		}
+		return onValue(n)
+	default:
+		return fmt.Errorf("invalid node type: %v", n)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/ontio/ontology/common"
+)
+
+func TestWalk(t *testing.T) {
+	db := NewMemDatabase()
+	trie, _ := New(common.Uint256{}, db)
+	vals := map[string]string{
+		"do":     "verb",
+		"dog":    "puppy",
+		"doge":   "coin",
+		"horse":  "stallion",
+		"shaman": "somethingveryoddindeedthis is a long value",
+	}
+	for k, v := range vals {
+		trie.TryUpdate([]byte(k), []byte(v))
+	}
+	root, err := trie.Commit()
+	if err != nil {
+		t.Fatalf("commit error: %v", err)
+	}
+
+	nodes := make(map[common.Uint256]bool)
+	values := make(map[string]bool)
+	err = Walk(root, db, func(hash common.Uint256) bool {
+		if nodes[hash] {
+			return false
+		}
+		nodes[hash] = true
+		return true
+	}, func(value []byte) error {
+		values[string(value)] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk error: %v", err)
+	}
+	if len(values) != len(vals) {
+		t.Errorf("walk values: expected %d got %d", len(vals), len(values))
+	}
+	if len(nodes) != len(db.db) {
+		t.Errorf("walk nodes: expected %d got %d", len(db.db), len(nodes))
+	}
+	for hash := range nodes {
+		if ok, _ := db.Has(NodeKey(hash)); !ok {
+			t.Errorf("walk node %x is not in db", hash)
+		}
+	}
+}
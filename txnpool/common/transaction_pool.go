@@ -20,8 +20,11 @@
 package common
 
 import (
+	"container/heap"
+	"math"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/ontio/dad-go/common"
 	"github.com/ontio/dad-go/common/config"
@@ -40,6 +43,9 @@ type TXAttr struct {
 type TXEntry struct {
 	Tx    *types.Transaction // transaction which has been verified
 	Attrs []*TXAttr          // the result from each validator
+	Time  time.Time          // the time when the tx entered the pool
+
+	priceIndex int // the index in the gas price heap of the pool
 }
 
 // TXPool contains all currently valid transactions. Transactions
@@ -48,7 +54,62 @@ type TXEntry struct {
 // in the ledger.
 type TXPool struct {
 	sync.RWMutex
-	txList map[common.Uint256]*TXEntry // Transactions which have been verified
+	txList       map[common.Uint256]*TXEntry    // Transactions which have been verified
+	accounts     map[common.Address]*accountTxs // Transactions of each payer ordered by nonce
+	priced       pricedTxs                      // Transactions ordered by gas price, the cheapest on top
+	priceBump    uint64                         // The percentage of gas price raised by replacement
+	accountSlots int                            // The max number of pending txs of a payer
+	accountQueue int                            // The max number of queued txs of a payer
+	globalSlots  int                            // The max number of txs in the pool
+}
+
+// accountTxs holds the transactions of a payer sorted by nonce. The
+// first accountSlots ones are pending and can be packed into block,
+// the others are queued until the pending ones leave the pool.
+type accountTxs struct {
+	txs []*TXEntry
+}
+
+// search returns the index of the nonce and whether it is found
+func (acc *accountTxs) search(nonce uint32) (int, bool) {
+	i := sort.Search(len(acc.txs), func(i int) bool {
+		return acc.txs[i].Tx.Nonce >= nonce
+	})
+	return i, i < len(acc.txs) && acc.txs[i].Tx.Nonce == nonce
+}
+
+func (acc *accountTxs) get(nonce uint32) *TXEntry {
+	if i, ok := acc.search(nonce); ok {
+		return acc.txs[i]
+	}
+	return nil
+}
+
+func (acc *accountTxs) put(txEntry *TXEntry) {
+	i, ok := acc.search(txEntry.Tx.Nonce)
+	if ok {
+		acc.txs[i] = txEntry
+		return
+	}
+	acc.txs = append(acc.txs, nil)
+	copy(acc.txs[i+1:], acc.txs[i:])
+	acc.txs[i] = txEntry
+}
+
+func (acc *accountTxs) remove(tx *types.Transaction) {
+	i, ok := acc.search(tx.Nonce)
+	if !ok || acc.txs[i].Tx != tx {
+		return
+	}
+	acc.txs = append(acc.txs[:i], acc.txs[i+1:]...)
+}
+
+// pending returns the txs of the payer which can be packed into block
+func (acc *accountTxs) pending(slots int) []*TXEntry {
+	if slots > 0 && len(acc.txs) > slots {
+		return acc.txs[:slots]
+	}
+	return acc.txs
 }
 
 // Init creates a new transaction pool to gather.
@@ -56,24 +117,135 @@ func (tp *TXPool) Init() {
 	tp.Lock()
 	defer tp.Unlock()
 	tp.txList = make(map[common.Uint256]*TXEntry)
+	tp.accounts = make(map[common.Address]*accountTxs)
+	tp.priced = make(pricedTxs, 0)
+
+	cfg := config.DefConfig.TxPool
+	tp.priceBump = cfg.PriceBump
+	tp.accountSlots = int(cfg.AccountSlots)
+	tp.accountQueue = int(cfg.AccountQueue)
+	tp.globalSlots = int(cfg.GlobalSlots)
+	if tp.globalSlots <= 0 {
+		tp.globalSlots = MAX_CAPACITY
+	}
+}
+
+// addTx puts the transaction into the tx list and its payer's queue
+func (tp *TXPool) addTx(txEntry *TXEntry) {
+	if txEntry.Time.IsZero() {
+		txEntry.Time = time.Now()
+	}
+	tp.txList[txEntry.Tx.Hash()] = txEntry
+	acc, ok := tp.accounts[txEntry.Tx.Payer]
+	if !ok {
+		acc = &accountTxs{}
+		tp.accounts[txEntry.Tx.Payer] = acc
+	}
+	acc.put(txEntry)
+	heap.Push(&tp.priced, txEntry)
+}
+
+// removeTx deletes the transaction from the tx list and its payer's queue
+func (tp *TXPool) removeTx(tx *types.Transaction) {
+	txHash := tx.Hash()
+	txEntry, ok := tp.txList[txHash]
+	if !ok {
+		return
+	}
+	delete(tp.txList, txHash)
+	heap.Remove(&tp.priced, txEntry.priceIndex)
+	if acc, ok := tp.accounts[tx.Payer]; ok {
+		acc.remove(txEntry.Tx)
+		if len(acc.txs) == 0 {
+			delete(tp.accounts, tx.Payer)
+		}
+	}
+}
+
+// replacementGasPrice returns the min gas price for a transaction to
+// replace the one with same payer and nonce.
+func (tp *TXPool) replacementGasPrice(gasPrice uint64) uint64 {
+	bump := gasPrice/100*tp.priceBump + gasPrice%100*tp.priceBump/100
+	price, overflow := common.SafeAdd(gasPrice, bump)
+	if overflow {
+		return math.MaxUint64
+	}
+	if price == gasPrice {
+		price++
+	}
+	return price
+}
+
+// cheapestTx returns the transaction with the lowest gas price, for
+// the txs of a payer with the same gas price, the queued one is chosen.
+func (tp *TXPool) cheapestTx() *types.Transaction {
+	if len(tp.priced) == 0 {
+		return nil
+	}
+	return tp.priced[0].Tx
 }
 
-// AddTxList adds a valid transaction to the transaction pool. If the
-// transaction is already in the pool, just return false. Parameter
+// AddTxList adds a valid transaction to the transaction pool. Parameter
 // txEntry includes transaction, fee, and verified information(height,
-// validator, error code).
-func (tp *TXPool) AddTxList(txEntry *TXEntry) bool {
+// validator, error code). If the payer has a transaction with the same
+// nonce in the pool, it is replaced when the gas price is raised by
+// the configured percentage at least. When the pool is full, the cheapest
+// transaction is evicted for the one paying more.
+func (tp *TXPool) AddTxList(txEntry *TXEntry) errors.ErrCode {
 	tp.Lock()
 	defer tp.Unlock()
-	txHash := txEntry.Tx.Hash()
+	tx := txEntry.Tx
+	txHash := tx.Hash()
 	if _, ok := tp.txList[txHash]; ok {
 		log.Infof("AddTxList: transaction %x is already in the pool",
 			txHash)
-		return false
+		return errors.ErrDuplicateInput
 	}
 
-	tp.txList[txHash] = txEntry
-	return true
+	if acc, ok := tp.accounts[tx.Payer]; ok {
+		if old := acc.get(tx.Nonce); old != nil {
+			minGasPrice := tp.replacementGasPrice(old.Tx.GasPrice)
+			if tx.GasPrice < minGasPrice {
+				log.Infof("AddTxList: transaction %x gas price %d is lower than %d to replace %x",
+					txHash, tx.GasPrice, minGasPrice, old.Tx.Hash())
+				return errors.ErrGasPrice
+			}
+			tp.removeTx(old.Tx)
+			tp.addTx(txEntry)
+			log.Infof("AddTxList: transaction %x is replaced by %x", old.Tx.Hash(), txHash)
+			return errors.ErrNoError
+		}
+		if tp.accountSlots > 0 && len(acc.txs) >= tp.accountSlots+tp.accountQueue {
+			log.Infof("AddTxList: payer %s of transaction %x has too many txs in the pool",
+				tx.Payer.ToBase58(), txHash)
+			return errors.ErrTxPoolFull
+		}
+	}
+
+	if len(tp.txList) >= tp.globalSlots {
+		cheapest := tp.cheapestTx()
+		if cheapest == nil || cheapest.GasPrice >= tx.GasPrice {
+			log.Infof("AddTxList: transaction pool is full for transaction %x", txHash)
+			return errors.ErrTxPoolFull
+		}
+		tp.removeTx(cheapest)
+		log.Infof("AddTxList: transaction %x is evicted by %x", cheapest.Hash(), txHash)
+	}
+
+	tp.addTx(txEntry)
+	return errors.ErrNoError
+}
+
+// Underpriced checks whether the pool is full and the transaction pays
+// no more than the cheapest one, which means it will be rejected.
+func (tp *TXPool) Underpriced(tx *types.Transaction) bool {
+	tp.RLock()
+	defer tp.RUnlock()
+	if len(tp.txList) < tp.globalSlots {
+		return false
+	}
+	cheapest := tp.cheapestTx()
+	return cheapest == nil || cheapest.GasPrice >= tx.GasPrice
 }
 
 // CleanTransactionList cleans the transaction list included in the ledger.
@@ -84,7 +256,7 @@ func (tp *TXPool) CleanTransactionList(txs []*types.Transaction) error {
 	defer tp.Unlock()
 	for _, tx := range txs {
 		if _, ok := tp.txList[tx.Hash()]; ok {
-			delete(tp.txList, tx.Hash())
+			tp.removeTx(tx)
 			cleaned++
 		}
 	}
@@ -102,7 +274,7 @@ func (tp *TXPool) DelTxList(tx *types.Transaction) bool {
 	if _, ok := tp.txList[txHash]; !ok {
 		return false
 	}
-	delete(tp.txList, txHash)
+	tp.removeTx(tx)
 	return true
 }
 
@@ -122,16 +294,18 @@ func (tp *TXPool) compareTxHeight(txEntry *TXEntry, height uint32) bool {
 // GetTxPool gets the transaction lists from the pool for the consensus,
 // if the byCount is marked, return the configured number at most; if the
 // the byCount is not marked, return all of the current transaction pool.
+// Only the pending transactions are returned, the ones of different payers
+// are ordered by gas price and the ones of the same payer by nonce.
 func (tp *TXPool) GetTxPool(byCount bool, height uint32) ([]*TXEntry,
 	[]*types.Transaction) {
 	tp.RLock()
 	defer tp.RUnlock()
 
-	orderByFee := make([]*TXEntry, 0, len(tp.txList))
-	for _, txEntry := range tp.txList {
-		orderByFee = append(orderByFee, txEntry)
+	heads := make(payerHeads, 0, len(tp.accounts))
+	for _, acc := range tp.accounts {
+		heads = append(heads, &payerHead{txs: acc.pending(tp.accountSlots)})
 	}
-	sort.Sort(OrderByNetWorkFee(orderByFee))
+	heap.Init(&heads)
 
 	count := int(config.DefConfig.Consensus.MaxTxInBlock)
 	if count <= 0 {
@@ -144,16 +318,22 @@ func (tp *TXPool) GetTxPool(byCount bool, height uint32) ([]*TXEntry,
 	var num int
 	txList := make([]*TXEntry, 0, count)
 	oldTxList := make([]*types.Transaction, 0)
-	for _, txEntry := range orderByFee {
+	for num < count && heads.Len() > 0 {
+		head := heads[0]
+		txEntry := head.txs[head.index]
+		head.index++
+		if head.index < len(head.txs) {
+			heap.Fix(&heads, 0)
+		} else {
+			heap.Pop(&heads)
+		}
+
 		if !tp.compareTxHeight(txEntry, height) {
 			oldTxList = append(oldTxList, txEntry.Tx)
 			continue
 		}
 		txList = append(txList, txEntry)
 		num++
-		if num >= count {
-			break
-		}
 	}
 
 	return txList, oldTxList
@@ -170,6 +350,19 @@ func (tp *TXPool) GetTransaction(hash common.Uint256) *types.Transaction {
 	return tp.txList[hash].Tx
 }
 
+// getTxState returns whether the transaction is pending or queued
+func (tp *TXPool) getTxState(tx *types.Transaction) TxPoolState {
+	acc, ok := tp.accounts[tx.Payer]
+	if !ok {
+		return TxPending
+	}
+	i, _ := acc.search(tx.Nonce)
+	if tp.accountSlots > 0 && i >= tp.accountSlots {
+		return TxQueued
+	}
+	return TxPending
+}
+
 // GetTxStatus returns a transaction status if it is contained in the pool
 // and nil otherwise.
 func (tp *TXPool) GetTxStatus(hash common.Uint256) *TxStatus {
@@ -182,10 +375,63 @@ func (tp *TXPool) GetTxStatus(hash common.Uint256) *TxStatus {
 	ret := &TxStatus{
 		Hash:  hash,
 		Attrs: txEntry.Attrs,
+		State: tp.getTxState(txEntry.Tx),
 	}
 	return ret
 }
 
+// GetContent returns the transactions paid by the payer, or by all payers
+// if it is nil, whose gas price is not lower than minGasPrice.
+func (tp *TXPool) GetContent(payer *common.Address, minGasPrice uint64) []*TxPoolContent {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	accounts := tp.accounts
+	if payer != nil {
+		accounts = make(map[common.Address]*accountTxs, 1)
+		if acc, ok := tp.accounts[*payer]; ok {
+			accounts[*payer] = acc
+		}
+	}
+	contents := make([]*TxPoolContent, 0)
+	for _, acc := range accounts {
+		for i, txEntry := range acc.txs {
+			if txEntry.Tx.GasPrice < minGasPrice {
+				continue
+			}
+			state := TxPending
+			if tp.accountSlots > 0 && i >= tp.accountSlots {
+				state = TxQueued
+			}
+			contents = append(contents, &TxPoolContent{
+				Tx:    txEntry.Tx,
+				Attrs: txEntry.Attrs,
+				State: state,
+				Time:  txEntry.Time,
+			})
+		}
+	}
+	return contents
+}
+
+// GetPendingGasPrices returns the gas prices of the pending transactions
+// in descending order.
+func (tp *TXPool) GetPendingGasPrices() []uint64 {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	gasPrices := make([]uint64, 0, len(tp.txList))
+	for _, acc := range tp.accounts {
+		for _, txEntry := range acc.pending(tp.accountSlots) {
+			gasPrices = append(gasPrices, txEntry.Tx.GasPrice)
+		}
+	}
+	sort.Slice(gasPrices, func(i, j int) bool {
+		return gasPrices[j] < gasPrices[i]
+	})
+	return gasPrices
+}
+
 // GetTransactionCount returns the tx number of the pool.
 func (tp *TXPool) GetTransactionCount() int {
 	tp.RLock()
@@ -214,7 +460,7 @@ func (tp *TXPool) GetUnverifiedTxs(txs []*types.Transaction,
 		}
 
 		if !tp.compareTxHeight(txEntry, height) {
-			delete(tp.txList, tx.Hash())
+			tp.removeTx(txEntry.Tx)
 			res.OldTxs = append(res.OldTxs, txEntry.Tx)
 			continue
 		}
@@ -242,7 +488,7 @@ func (tp *TXPool) RemoveTxsBelowGasPrice(gasPrice uint64) {
 	defer tp.Unlock()
 	for _, txEntry := range tp.txList {
 		if txEntry.Tx.GasPrice < gasPrice {
-			delete(tp.txList, txEntry.Tx.Hash())
+			tp.removeTx(txEntry.Tx)
 		}
 	}
 }
@@ -255,8 +501,72 @@ func (tp *TXPool) Remain() []*types.Transaction {
 	txList := make([]*types.Transaction, 0, len(tp.txList))
 	for _, txEntry := range tp.txList {
 		txList = append(txList, txEntry.Tx)
-		delete(tp.txList, txEntry.Tx.Hash())
 	}
+	tp.txList = make(map[common.Uint256]*TXEntry)
+	tp.accounts = make(map[common.Address]*accountTxs)
+	tp.priced = make(pricedTxs, 0)
 
 	return txList
 }
+
+// payerHead points to the next pending transaction of a payer
+type payerHead struct {
+	txs   []*TXEntry
+	index int
+}
+
+// pricedTxs is a min-heap of the transactions in the pool by gas price,
+// among the ones with the same gas price, the larger nonce is on top.
+type pricedTxs []*TXEntry
+
+func (h pricedTxs) Len() int { return len(h) }
+
+func (h pricedTxs) Less(i, j int) bool {
+	if h[i].Tx.GasPrice != h[j].Tx.GasPrice {
+		return h[i].Tx.GasPrice < h[j].Tx.GasPrice
+	}
+	return h[i].Tx.Nonce > h[j].Tx.Nonce
+}
+
+func (h pricedTxs) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].priceIndex = i
+	h[j].priceIndex = j
+}
+
+func (h *pricedTxs) Push(x interface{}) {
+	txEntry := x.(*TXEntry)
+	txEntry.priceIndex = len(*h)
+	*h = append(*h, txEntry)
+}
+
+func (h *pricedTxs) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return x
+}
+
+// payerHeads implements heap.Interface, ordered by the gas price of the
+// next pending transaction of each payer
+type payerHeads []*payerHead
+
+func (h payerHeads) Len() int { return len(h) }
+
+func (h payerHeads) Less(i, j int) bool {
+	return h[j].txs[h[j].index].Tx.GasPrice < h[i].txs[h[i].index].Tx.GasPrice
+}
+
+func (h payerHeads) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *payerHeads) Push(x interface{}) { *h = append(*h, x.(*payerHead)) }
+
+func (h *payerHeads) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
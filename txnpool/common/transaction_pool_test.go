@@ -19,12 +19,15 @@
 package common
 
 import (
+	"sort"
 	"testing"
 	"time"
 
+	"github.com/ontio/dad-go/common"
 	"github.com/ontio/dad-go/common/log"
 	"github.com/ontio/dad-go/core/payload"
 	"github.com/ontio/dad-go/core/types"
+	"github.com/ontio/dad-go/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -54,13 +57,13 @@ func TestTxPool(t *testing.T) {
 	}
 
 	ret := txPool.AddTxList(txEntry)
-	if ret == false {
+	if ret != errors.ErrNoError {
 		t.Error("Failed to add tx to the pool")
 		return
 	}
 
 	ret = txPool.AddTxList(txEntry)
-	if ret == true {
+	if ret != errors.ErrDuplicateInput {
 		t.Error("Failed to add tx to the pool")
 		return
 	}
@@ -99,3 +102,129 @@ func TestTxPool(t *testing.T) {
 		return
 	}
 }
+
+func newTxEntry(t *testing.T, payer common.Address, nonce uint32, gasPrice uint64) *TXEntry {
+	mutable := &types.MutableTransaction{
+		TxType:   types.InvokeNeo,
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Payer:    payer,
+		Payload:  &payload.InvokeCode{Code: []byte{}},
+	}
+	tx, err := mutable.IntoImmutable()
+	assert.Nil(t, err)
+	return &TXEntry{Tx: tx, Attrs: []*TXAttr{}}
+}
+
+func TestTxPoolReplaceAndEvict(t *testing.T) {
+	txPool := &TXPool{}
+	txPool.Init()
+	txPool.priceBump = 10
+	txPool.accountSlots = 2
+	txPool.accountQueue = 1
+	txPool.globalSlots = 5
+
+	payer1 := common.Address{1}
+	payer2 := common.Address{2}
+
+	// the txs of a payer beyond the slots are queued
+	tx1 := newTxEntry(t, payer1, 3, 500)
+	tx2 := newTxEntry(t, payer1, 1, 500)
+	tx3 := newTxEntry(t, payer1, 2, 500)
+	for _, entry := range []*TXEntry{tx1, tx2, tx3} {
+		assert.Equal(t, errors.ErrNoError, txPool.AddTxList(entry))
+	}
+	assert.Equal(t, TxPending, txPool.GetTxStatus(tx2.Tx.Hash()).State)
+	assert.Equal(t, TxPending, txPool.GetTxStatus(tx3.Tx.Hash()).State)
+	assert.Equal(t, TxQueued, txPool.GetTxStatus(tx1.Tx.Hash()).State)
+	assert.Equal(t, errors.ErrTxPoolFull, txPool.AddTxList(newTxEntry(t, payer1, 4, 1000)))
+
+	// replacement needs the gas price bumped
+	assert.Equal(t, errors.ErrGasPrice, txPool.AddTxList(newTxEntry(t, payer1, 1, 549)))
+	tx4 := newTxEntry(t, payer1, 1, 550)
+	assert.Equal(t, errors.ErrNoError, txPool.AddTxList(tx4))
+	assert.Nil(t, txPool.GetTransaction(tx2.Tx.Hash()))
+	assert.Equal(t, 3, txPool.GetTransactionCount())
+
+	// only pending txs are packed, in nonce order for a payer
+	tx5 := newTxEntry(t, payer2, 1, 520)
+	assert.Equal(t, errors.ErrNoError, txPool.AddTxList(tx5))
+	txList, _ := txPool.GetTxPool(false, 0)
+	assert.Equal(t, 3, len(txList))
+	assert.Equal(t, tx4.Tx.Hash(), txList[0].Tx.Hash())
+	assert.Equal(t, tx5.Tx.Hash(), txList[1].Tx.Hash())
+	assert.Equal(t, tx3.Tx.Hash(), txList[2].Tx.Hash())
+
+	// the cheapest tx is evicted when the pool is full
+	tx6 := newTxEntry(t, payer2, 2, 510)
+	assert.Equal(t, errors.ErrNoError, txPool.AddTxList(tx6))
+	assert.True(t, txPool.Underpriced(newTxEntry(t, payer2, 3, 500).Tx))
+	assert.Equal(t, errors.ErrTxPoolFull, txPool.AddTxList(newTxEntry(t, payer2, 3, 500)))
+	tx7 := newTxEntry(t, payer2, 3, 600)
+	assert.Equal(t, errors.ErrNoError, txPool.AddTxList(tx7))
+	assert.Equal(t, 5, txPool.GetTransactionCount())
+	assert.Nil(t, txPool.GetTransaction(tx1.Tx.Hash()))
+
+	// queued txs become pending when the pending ones are cleaned
+	err := txPool.CleanTransactionList([]*types.Transaction{tx4.Tx})
+	assert.Nil(t, err)
+	assert.Equal(t, TxPending, txPool.GetTxStatus(tx3.Tx.Hash()).State)
+}
+
+func TestTxPoolCheapestTx(t *testing.T) {
+	txPool := &TXPool{}
+	txPool.Init()
+
+	prices := []uint64{700, 500, 900, 500, 800, 600}
+	entries := make([]*TXEntry, 0, len(prices))
+	for i, price := range prices {
+		entry := newTxEntry(t, common.Address{byte(i)}, 1, price)
+		assert.Equal(t, errors.ErrNoError, txPool.AddTxList(entry))
+		entries = append(entries, entry)
+	}
+	assert.Equal(t, uint64(500), txPool.cheapestTx().GasPrice)
+
+	assert.True(t, txPool.DelTxList(entries[1].Tx))
+	assert.Equal(t, entries[3].Tx, txPool.cheapestTx())
+	assert.True(t, txPool.DelTxList(entries[3].Tx))
+	assert.Equal(t, entries[5].Tx, txPool.cheapestTx())
+
+	// replacement updates the price of the payer's tx
+	replaced := newTxEntry(t, common.Address{5}, 1, 1000)
+	assert.Equal(t, errors.ErrNoError, txPool.AddTxList(replaced))
+	assert.Equal(t, entries[0].Tx, txPool.cheapestTx())
+
+	txPool.Remain()
+	assert.Nil(t, txPool.cheapestTx())
+}
+
+func TestTxPoolGetContent(t *testing.T) {
+	txPool := &TXPool{}
+	txPool.Init()
+	txPool.accountSlots = 1
+
+	payer1 := common.Address{1}
+	payer2 := common.Address{2}
+	tx1 := newTxEntry(t, payer1, 1, 500)
+	tx2 := newTxEntry(t, payer1, 2, 800)
+	tx3 := newTxEntry(t, payer2, 1, 600)
+	for _, entry := range []*TXEntry{tx1, tx2, tx3} {
+		assert.Equal(t, errors.ErrNoError, txPool.AddTxList(entry))
+	}
+
+	contents := txPool.GetContent(nil, 0)
+	assert.Equal(t, 3, len(contents))
+	sort.Sort(OrderByPoolContent(contents))
+	assert.Equal(t, tx2.Tx.Hash(), contents[0].Tx.Hash())
+	assert.Equal(t, TxQueued, contents[0].State)
+	assert.Equal(t, tx3.Tx.Hash(), contents[1].Tx.Hash())
+	assert.Equal(t, tx1.Tx.Hash(), contents[2].Tx.Hash())
+	assert.Equal(t, TxPending, contents[2].State)
+	assert.False(t, contents[2].Time.IsZero())
+
+	contents = txPool.GetContent(&payer1, 600)
+	assert.Equal(t, 1, len(contents))
+	assert.Equal(t, tx2.Tx.Hash(), contents[0].Tx.Hash())
+	contents = txPool.GetContent(&common.Address{3}, 0)
+	assert.Equal(t, 0, len(contents))
+}
@@ -19,6 +19,9 @@
 package common
 
 import (
+	"bytes"
+	"time"
+
 	"github.com/ontio/dad-go/common"
 	"github.com/ontio/dad-go/core/types"
 	"github.com/ontio/dad-go/errors"
@@ -55,9 +58,10 @@ const (
 type SenderType uint8
 
 const (
-	NilSender  SenderType = iota
-	NetSender             // Net sends tx req
-	HttpSender            // Http sends tx req
+	NilSender       SenderType = iota
+	NetSender                  // Net sends tx req
+	HttpSender                 // Http sends tx req
+	ConsensusSender            // Consensus sends tx req
 )
 
 func (sender SenderType) Sender() string {
@@ -68,6 +72,8 @@ func (sender SenderType) Sender() string {
 		return "net sender"
 	case HttpSender:
 		return "http sender"
+	case ConsensusSender:
+		return "consensus sender"
 	default:
 		return "unknown sender"
 	}
@@ -88,6 +94,25 @@ const (
 	MaxStats
 )
 
+func (v TxnStatsType) String() string {
+	switch v {
+	case RcvStats:
+		return "received"
+	case SuccessStats:
+		return "success"
+	case FailureStats:
+		return "failure"
+	case DuplicateStats:
+		return "duplicate"
+	case SigErrStats:
+		return "sig_error"
+	case StateErrStats:
+		return "state_error"
+	default:
+		return "unknown"
+	}
+}
+
 // CheckBlkResult contains a verifed tx list,
 // an unverified tx list and an old tx list
 // to be re-verifed
@@ -97,10 +122,33 @@ type CheckBlkResult struct {
 	OldTxs        []*types.Transaction
 }
 
+// TxPoolState enumerates the state of a transaction in the pool
+type TxPoolState uint8
+
+const (
+	TxVerifying TxPoolState = iota // The tx is being verified
+	TxPending                      // The tx can be packed into the next block
+	TxQueued                       // The tx waits for the pending txs of the same payer
+)
+
+func (state TxPoolState) String() string {
+	switch state {
+	case TxVerifying:
+		return "verifying"
+	case TxPending:
+		return "pending"
+	case TxQueued:
+		return "queued"
+	default:
+		return "unknown"
+	}
+}
+
 // TxStatus contains the attributes of a transaction
 type TxStatus struct {
 	Hash  common.Uint256 // transaction hash
 	Attrs []*TXAttr      // transaction's status
+	State TxPoolState    // transaction's state in the pool
 }
 type TxResult struct {
 	Err  errors.ErrCode
@@ -161,6 +209,7 @@ type GetTxnStatusReq struct {
 type GetTxnStatusRsp struct {
 	Hash     common.Uint256
 	TxStatus []*TXAttr
+	State    TxPoolState
 }
 
 // GetTxnStats specifies the api that how to get the tx statistics.
@@ -204,6 +253,43 @@ type GetTxnPoolRsp struct {
 	TxnPool []*TXEntry
 }
 
+// GetTxnPoolContentReq specifies the api that how to page through the
+// transactions in the pool, including the ones being verified.
+// Input: payer filter, nil for all payers, min gas price filter and page
+type GetTxnPoolContentReq struct {
+	Payer       *common.Address
+	MinGasPrice uint64
+	Offset      uint32
+	Limit       uint32
+}
+
+// TxPoolContent contains a transaction in the pool and its status.
+type TxPoolContent struct {
+	Tx    *types.Transaction
+	Attrs []*TXAttr
+	State TxPoolState
+	Time  time.Time // The time when the tx entered the pool
+}
+
+// GetTxnPoolContentRsp returns the number of matched transactions and
+// a page of them for GetTxnPoolContentReq.
+type GetTxnPoolContentRsp struct {
+	Total uint32
+	Txs   []*TxPoolContent
+}
+
+// GetTxnGasPriceReq specifies the api that how to get the gas price
+// distribution of the pool.
+type GetTxnGasPriceReq struct {
+}
+
+// GetTxnGasPriceRsp returns the min gas price accepted by the pool and
+// the gas prices of the pending transactions in descending order.
+type GetTxnGasPriceRsp struct {
+	MinGasPrice uint64
+	GasPrices   []uint64
+}
+
 // VerifyBlockReq specifies that api that how to verify a block from consensus.
 type VerifyBlockReq struct {
 	Height uint32
@@ -252,3 +338,25 @@ func (n OrderByNetWorkFee) Len() int { return len(n) }
 func (n OrderByNetWorkFee) Swap(i, j int) { n[i], n[j] = n[j], n[i] }
 
 func (n OrderByNetWorkFee) Less(i, j int) bool { return n[j].Tx.GasPrice < n[i].Tx.GasPrice }
+
+// OrderByPoolContent orders the pool contents by gas price, and by payer
+// and nonce for the ones with the same gas price.
+type OrderByPoolContent []*TxPoolContent
+
+func (n OrderByPoolContent) Len() int { return len(n) }
+
+func (n OrderByPoolContent) Swap(i, j int) { n[i], n[j] = n[j], n[i] }
+
+func (n OrderByPoolContent) Less(i, j int) bool {
+	if n[i].Tx.GasPrice != n[j].Tx.GasPrice {
+		return n[j].Tx.GasPrice < n[i].Tx.GasPrice
+	}
+	if cmp := bytes.Compare(n[i].Tx.Payer[:], n[j].Tx.Payer[:]); cmp != 0 {
+		return cmp < 0
+	}
+	if n[i].Tx.Nonce != n[j].Tx.Nonce {
+		return n[i].Tx.Nonce < n[j].Tx.Nonce
+	}
+	hi, hj := n[i].Tx.Hash(), n[j].Tx.Hash()
+	return bytes.Compare(hi[:], hj[:]) < 0
+}
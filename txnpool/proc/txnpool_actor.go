@@ -140,7 +140,7 @@ func (ta *TxActor) handleTransaction(sender tc.SenderType, self *actor.PID,
 			replyTxResult(txResultCh, txn.Hash(), errors.ErrDuplicateInput,
 				fmt.Sprintf("transaction %x is already in the tx pool", txn.Hash()))
 		}
-	} else if ta.server.getTransactionCount() >= tc.MAX_CAPACITY {
+	} else if ta.server.isTxUnderpriced(txn) {
 		log.Debugf("handleTransaction: transaction pool is full for tx %x",
 			txn.Hash())
 
@@ -264,7 +264,7 @@ func (ta *TxActor) Receive(context actor.Context) {
 					TxStatus: nil}, context.Self())
 			} else {
 				sender.Request(&tc.GetTxnStatusRsp{Hash: res.Hash,
-					TxStatus: res.Attrs}, context.Self())
+					TxStatus: res.Attrs, State: res.State}, context.Self())
 			}
 		}
 
@@ -279,6 +279,28 @@ func (ta *TxActor) Receive(context actor.Context) {
 				context.Self())
 		}
 
+	case *tc.GetTxnGasPriceReq:
+		sender := context.Sender()
+
+		log.Debugf("txpool-tx actor receives getting tx gas price req from %v", sender)
+
+		if sender != nil {
+			sender.Request(&tc.GetTxnGasPriceRsp{
+				MinGasPrice: ta.server.getGasPrice(),
+				GasPrices:   ta.server.getPendingGasPrices(),
+			}, context.Self())
+		}
+
+	case *tc.GetTxnPoolContentReq:
+		sender := context.Sender()
+
+		log.Debugf("txpool-tx actor receives getting tx pool content req from %v", sender)
+
+		res := ta.server.getTxPoolContent(msg)
+		if sender != nil {
+			sender.Request(res, context.Self())
+		}
+
 	default:
 		log.Debugf("txpool-tx actor: unknown msg %v type %v", msg, reflect.TypeOf(msg))
 	}
@@ -332,6 +354,14 @@ func (tpa *TxPoolActor) Receive(context actor.Context) {
 
 		tpa.server.verifyBlock(msg, sender)
 
+	case *tc.TxReq:
+		log.Debugf("txpool actor receives tx from %v", msg.Sender.Sender())
+
+		// txs are handled by tx actor, forward the req which is sent by the holder of txpool actor pid, e.g. consensus
+		if pid := tpa.server.GetPID(tc.TxActor); pid != nil {
+			pid.Tell(msg)
+		}
+
 	case *message.SaveBlockCompleteMsg:
 		sender := context.Sender()
 
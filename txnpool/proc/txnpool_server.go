@@ -27,6 +27,7 @@ import (
 	"github.com/ontio/dad-go/common"
 	"github.com/ontio/dad-go/common/config"
 	"github.com/ontio/dad-go/common/log"
+	"github.com/ontio/dad-go/common/metrics"
 	"github.com/ontio/dad-go/core/ledger"
 	tx "github.com/ontio/dad-go/core/types"
 	"github.com/ontio/dad-go/errors"
@@ -38,6 +39,7 @@ import (
 	"sort"
 	"strconv"
 	"sync"
+	"time"
 )
 
 type txStats struct {
@@ -49,6 +51,7 @@ type serverPendingTx struct {
 	tx     *tx.Transaction   // Pending tx
 	sender tc.SenderType     // Indicate which sender tx is from
 	ch     chan *tc.TxResult // channel to send tx result
+	time   time.Time         // The time when tx is received
 }
 
 type pendingBlock struct {
@@ -188,6 +191,27 @@ func (s *TXPoolServer) init(num uint8, disablePreExec, disableBroadcastNetTx boo
 		s.workers[i].init(i, s)
 		go s.workers[i].start()
 	}
+	s.registerMetrics()
+}
+
+// registerMetrics exports the pool sizes and the transaction statistics
+func (s *TXPoolServer) registerMetrics() {
+	metrics.Register(metrics.NewGaugeVecFunc("ontology_txpool_size",
+		"Transaction count in the tx pool", "pool", func() map[string]float64 {
+			return map[string]float64{
+				"verified": float64(s.getTransactionCount()),
+				"pending":  float64(s.getPendingListSize()),
+			}
+		}))
+	metrics.Register(metrics.NewCounterVecFunc("ontology_txpool_txs_total",
+		"Transaction statistics of the tx pool", "stats", func() map[string]float64 {
+			stats := s.getStats()
+			ret := make(map[string]float64, len(stats))
+			for i, v := range stats {
+				ret[tc.TxnStatsType(i+1).String()] = float64(v)
+			}
+			return ret
+		}))
 }
 
 // checkPendingBlockOk checks whether a block from consensus is verified.
@@ -265,7 +289,7 @@ func (s *TXPoolServer) removePendingTx(hash common.Uint256,
 		return
 	}
 
-	if err == errors.ErrNoError && ((pt.sender == tc.HttpSender) ||
+	if err == errors.ErrNoError && ((pt.sender == tc.HttpSender) || (pt.sender == tc.ConsensusSender) ||
 		(pt.sender == tc.NetSender && !s.disableBroadcastNetTx)) {
 		pid := s.GetPID(tc.NetActor)
 		if pid != nil {
@@ -311,6 +335,7 @@ func (s *TXPoolServer) setPendingTx(tx *tx.Transaction,
 		tx:     tx,
 		sender: sender,
 		ch:     txResultCh,
+		time:   time.Now(),
 	}
 
 	s.allPendingTxs[tx.Hash()] = pt
@@ -513,6 +538,54 @@ func (s *TXPoolServer) getTxCount() []uint32 {
 	return ret
 }
 
+// getPendingGasPrices returns the gas prices of the pending txs in tx pool
+func (s *TXPoolServer) getPendingGasPrices() []uint64 {
+	return s.txPool.GetPendingGasPrices()
+}
+
+// getTxPoolContent returns a page of the transactions in the tx pool and
+// the ones being verified, filtered by payer and min gas price
+func (s *TXPoolServer) getTxPoolContent(req *tc.GetTxnPoolContentReq) *tc.GetTxnPoolContentRsp {
+	contents := s.txPool.GetContent(req.Payer, req.MinGasPrice)
+	inPool := make(map[common.Uint256]bool, len(contents))
+	for _, content := range contents {
+		inPool[content.Tx.Hash()] = true
+	}
+
+	s.mu.RLock()
+	for hash, pt := range s.allPendingTxs {
+		if inPool[hash] || pt.tx.GasPrice < req.MinGasPrice ||
+			(req.Payer != nil && pt.tx.Payer != *req.Payer) {
+			continue
+		}
+		contents = append(contents, &tc.TxPoolContent{
+			Tx:    pt.tx,
+			State: tc.TxVerifying,
+			Time:  pt.time,
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Sort(tc.OrderByPoolContent(contents))
+	rsp := &tc.GetTxnPoolContentRsp{
+		Total: uint32(len(contents)),
+		Txs:   make([]*tc.TxPoolContent, 0, req.Limit),
+	}
+	for i := uint64(req.Offset); i < uint64(len(contents)) && i < uint64(req.Offset)+uint64(req.Limit); i++ {
+		content := contents[i]
+		if content.State == tc.TxVerifying {
+			for j := 0; j < len(s.workers); j++ {
+				if status := s.workers[j].GetTxStatus(content.Tx.Hash()); status != nil {
+					content.Attrs = status.Attrs
+					break
+				}
+			}
+		}
+		rsp.Txs = append(rsp.Txs, content)
+	}
+	return rsp
+}
+
 // getPendingTxs returns a currently pending tx list
 func (s *TXPoolServer) getPendingTxs(byCount bool) []*tx.Transaction {
 	s.mu.RLock()
@@ -564,14 +637,33 @@ func (s *TXPoolServer) delTransaction(t *tx.Transaction) {
 }
 
 // addTxList adds a valid transaction to the tx pool.
-func (s *TXPoolServer) addTxList(txEntry *tc.TXEntry) bool {
+func (s *TXPoolServer) addTxList(txEntry *tc.TXEntry) errors.ErrCode {
 	ret := s.txPool.AddTxList(txEntry)
-	if !ret {
+	switch ret {
+	case errors.ErrNoError:
+	case errors.ErrDuplicateInput:
 		s.increaseStats(tc.DuplicateStats)
+	default:
+		s.increaseStats(tc.FailureStats)
 	}
 	return ret
 }
 
+// isTxUnderpriced checks whether the tx pool is full and a transaction
+// pays too little to evict any transaction in it.
+func (s *TXPoolServer) isTxUnderpriced(t *tx.Transaction) bool {
+	return s.txPool.Underpriced(t)
+}
+
+// inPendingBlock checks whether the transaction is in the block being
+// verified for consensus.
+func (s *TXPoolServer) inPendingBlock(hash common.Uint256) bool {
+	s.pendingBlock.mu.RLock()
+	defer s.pendingBlock.mu.RUnlock()
+	_, ok := s.pendingBlock.unProcessedTxs[hash]
+	return ok
+}
+
 // increaseStats increases the count with the stats type
 func (s *TXPoolServer) increaseStats(v tc.TxnStatsType) {
 	s.stats.Lock()
@@ -79,6 +79,7 @@ func (worker *txPoolWorker) GetTxStatus(hash common.Uint256) *tc.TxStatus {
 	txStatus := &tc.TxStatus{
 		Hash:  hash,
 		Attrs: pt.ret,
+		State: tc.TxVerifying,
 	}
 	return txStatus
 }
@@ -161,15 +162,21 @@ func (worker *txPoolWorker) handleTimeoutEvent() {
 }
 
 // putTxPool adds a valid transaction to the tx pool and removes it from
-// the pending list.
+// the pending list. The transaction rejected by the pool, because of its
+// payer's slots or an underpriced replacement, is reported to the submitter,
+// except it is in the block being verified, which it is still valid for.
 func (worker *txPoolWorker) putTxPool(pt *pendingTx) bool {
 	txEntry := &tc.TXEntry{
 		Tx:    pt.tx,
 		Attrs: pt.ret,
 	}
-	worker.server.addTxList(txEntry)
-	worker.server.removePendingTx(pt.tx.Hash(), errors.ErrNoError)
-	return true
+	txHash := pt.tx.Hash()
+	errCode := worker.server.addTxList(txEntry)
+	if errCode != errors.ErrNoError && worker.server.inPendingBlock(txHash) {
+		errCode = errors.ErrNoError
+	}
+	worker.server.removePendingTx(txHash, errCode)
+	return errCode == errors.ErrNoError
 }
 
 // verifyTx prepares a check request and sends it to the validators.
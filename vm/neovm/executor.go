@@ -55,6 +55,7 @@ type Executor struct {
 	Features  VmFeatureFlag
 	Callers   []*ExecutionContext
 	Context   *ExecutionContext
+	Tracer    Tracer
 }
 
 func (self *Executor) PopContext() (*ExecutionContext, error) {
@@ -85,10 +86,14 @@ func (self *Executor) Execute() error {
 			break
 		}
 
+		ip := self.Context.GetInstructionPointer()
 		opcode, eof := self.Context.ReadOpCode()
 		if eof {
 			break
 		}
+		if self.Tracer != nil {
+			self.Tracer.CaptureState(ip, opcode, 0, self.EvalStack, self.AltStack)
+		}
 
 		var err error
 		self.State, err = self.ExecuteOp(opcode, self.Context)
@@ -0,0 +1,199 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package neovm
+
+import (
+	"fmt"
+
+	"github.com/ontio/dad-go/common"
+)
+
+const (
+	STORAGE_GET    = "get"
+	STORAGE_PUT    = "put"
+	STORAGE_DELETE = "delete"
+)
+
+const (
+	DEFAULT_TRACE_MAX_STEPS = 100000           // max steps kept by StructLogger
+	DEFAULT_TRACE_MAX_BYTES = 32 * 1024 * 1024 // max bytes of stack and storage dumps kept by StructLogger
+)
+
+//Tracer is notified by the executor before every opcode it runs, so that an
+//execution trace can be recorded. A nil tracer disables tracing.
+type Tracer interface {
+	//CaptureEnter is called when execution enters the code of a contract
+	CaptureEnter(contract common.Address)
+	//CaptureExit is called when the contract entered last returns
+	CaptureExit()
+	//CaptureState is called before the opcode at ip is executed
+	CaptureState(ip int, opcode OpCode, gasCost uint64, evalStack, altStack *ValueStack)
+	//CaptureGas records extra gas charged by the current step, e.g. the price of a syscall
+	CaptureGas(gasCost uint64)
+	//CaptureStorage records a storage access made by the current step
+	CaptureStorage(op string, key, value []byte)
+}
+
+type StorageLog struct {
+	Op    string
+	Key   string
+	Value string
+}
+
+type StepLog struct {
+	Contract  string
+	Depth     int
+	IP        int
+	Op        string
+	GasCost   uint64
+	EvalStack []string
+	AltStack  []string
+	Storage   []*StorageLog
+}
+
+//StructLogger is a Tracer which keeps a log of every executed step. Once maxSteps steps or maxBytes
+//bytes of dumps are kept, the following steps are not logged and Truncated is set
+type StructLogger struct {
+	Steps     []*StepLog
+	Truncated bool
+	contracts []common.Address
+	gasUsed   uint64
+	maxSteps  int
+	maxBytes  int
+	bytes     int
+}
+
+func NewStructLogger() *StructLogger {
+	return NewStructLoggerWithLimit(DEFAULT_TRACE_MAX_STEPS, DEFAULT_TRACE_MAX_BYTES)
+}
+
+func NewStructLoggerWithLimit(maxSteps, maxBytes int) *StructLogger {
+	return &StructLogger{
+		maxSteps: maxSteps,
+		maxBytes: maxBytes,
+	}
+}
+
+func (self *StructLogger) CaptureEnter(contract common.Address) {
+	self.contracts = append(self.contracts, contract)
+}
+
+func (self *StructLogger) CaptureExit() {
+	if len(self.contracts) > 0 {
+		self.contracts = self.contracts[:len(self.contracts)-1]
+	}
+}
+
+func (self *StructLogger) CaptureState(ip int, opcode OpCode, gasCost uint64, evalStack, altStack *ValueStack) {
+	self.gasUsed += gasCost
+	if self.Truncated {
+		return
+	}
+	if len(self.Steps) >= self.maxSteps {
+		self.Truncated = true
+		return
+	}
+	step := &StepLog{
+		Depth:   len(self.contracts),
+		IP:      ip,
+		Op:      opcodeName(opcode),
+		GasCost: gasCost,
+	}
+	var ok bool
+	if step.EvalStack, ok = self.dumpStack(evalStack); !ok {
+		return
+	}
+	if step.AltStack, ok = self.dumpStack(altStack); !ok {
+		return
+	}
+	if len(self.contracts) > 0 {
+		step.Contract = self.contracts[len(self.contracts)-1].ToHexString()
+	}
+	self.Steps = append(self.Steps, step)
+}
+
+func (self *StructLogger) CaptureGas(gasCost uint64) {
+	self.gasUsed += gasCost
+	if step := self.lastStep(); step != nil {
+		step.GasCost += gasCost
+	}
+}
+
+func (self *StructLogger) CaptureStorage(op string, key, value []byte) {
+	step := self.lastStep()
+	if step == nil {
+		return
+	}
+	log := &StorageLog{Op: op, Key: common.ToHexString(key)}
+	if value != nil {
+		log.Value = common.ToHexString(value)
+	}
+	if !self.consume(len(log.Key) + len(log.Value)) {
+		return
+	}
+	step.Storage = append(step.Storage, log)
+}
+
+//GasUsed return the sum of gas charged by all the traced steps, including those not logged
+func (self *StructLogger) GasUsed() uint64 {
+	return self.gasUsed
+}
+
+//lastStep return the step being executed, nil if it is not logged
+func (self *StructLogger) lastStep() *StepLog {
+	if len(self.Steps) == 0 || self.Truncated {
+		return nil
+	}
+	return self.Steps[len(self.Steps)-1]
+}
+
+//consume counts n bytes of dumps, and set Truncated if maxBytes is exceeded
+func (self *StructLogger) consume(n int) bool {
+	if self.bytes+n > self.maxBytes {
+		self.Truncated = true
+		return false
+	}
+	self.bytes += n
+	return true
+}
+
+//dumpStack return a snapshot of the stack items, from bottom to top
+func (self *StructLogger) dumpStack(stack *ValueStack) ([]string, bool) {
+	if stack == nil {
+		return nil, true
+	}
+	items := make([]string, 0, len(stack.data))
+	for i := range stack.data {
+		item := stack.data[i].Dump()
+		if !self.consume(len(item)) {
+			return nil, false
+		}
+		items = append(items, item)
+	}
+	return items, true
+}
+
+func opcodeName(opcode OpCode) string {
+	if opcode >= PUSHBYTES1 && opcode <= PUSHBYTES75 {
+		return fmt.Sprintf("PUSHBYTES%d", opcode)
+	}
+	if name := OpExecList[opcode].Name; name != "" {
+		return name
+	}
+	return fmt.Sprintf("0x%02x", byte(opcode))
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (C) 2018 The dad-go Authors
+ * This file is part of The dad-go library.
+ *
+ * The dad-go is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The dad-go is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The dad-go.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package neovm
+
+import (
+	"testing"
+
+	"github.com/ontio/dad-go/vm/neovm/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructLoggerMaxSteps(t *testing.T) {
+	logger := NewStructLoggerWithLimit(2, 1024)
+	for i := 0; i < 3; i++ {
+		logger.CaptureState(i, NOP, 1, nil, nil)
+		logger.CaptureGas(2)
+	}
+	assert.Equal(t, 2, len(logger.Steps))
+	assert.True(t, logger.Truncated)
+	assert.Equal(t, uint64(3), logger.Steps[1].GasCost)
+	// gas of the steps not logged is still counted
+	assert.Equal(t, uint64(9), logger.GasUsed())
+}
+
+func TestStructLoggerMaxBytes(t *testing.T) {
+	stack := NewValueStack(16)
+	val, err := types.VmValueFromBytes(make([]byte, 32))
+	assert.Nil(t, err)
+	assert.Nil(t, stack.Push(val))
+	size := len(val.Dump())
+
+	logger := NewStructLoggerWithLimit(100, 2*size)
+	logger.CaptureState(0, NOP, 1, stack, nil)
+	logger.CaptureState(1, NOP, 1, stack, nil)
+	assert.Equal(t, 2, len(logger.Steps))
+	assert.False(t, logger.Truncated)
+
+	logger.CaptureState(2, NOP, 1, stack, nil)
+	assert.Equal(t, 2, len(logger.Steps))
+	assert.True(t, logger.Truncated)
+	logger.CaptureStorage(STORAGE_GET, []byte("key"), nil)
+	assert.Nil(t, logger.Steps[1].Storage)
+	assert.Equal(t, uint64(3), logger.GasUsed())
+}